@@ -17,14 +17,18 @@ func AddReg16T1(instr FetchedInstr) DecodedInstr {
 	return AddRegT1{Rd: Rd, Rm: Rm, Rn: Rn, Imm: 0, setflags: NOT_IT}
 }
 
-func (instr AddRegT1) Execute(regs *Registers) {
-	AddRegister(regs, InstrFields(instr), Shift{function: LSL_C, amount: 0})
+func (instr AddRegT1) Execute(cpu *CPU) {
+	AddRegister(cpu, InstrFields(instr), Shift{srtype: SRType_LSL, amount: 0})
 }
 
 func (instr AddRegT1) String() string {
 	return fmt.Sprintf("adds %s, %s, %s", instr.Rd, instr.Rn, instr.Rm)
 }
 
+func (instr AddRegT1) SetsFlags() SetFlags {
+	return instr.setflags
+}
+
 /* ADD (register)
  * ARM ARM A7.7.4
  * Encoding T2 */
@@ -49,8 +53,8 @@ func AddReg16T2(instr FetchedInstr) DecodedInstr {
 	return AddRegT2{Rd: Rdn, Rm: Rm, Rn: Rdn, Imm: 0, setflags: NEVER}
 }
 
-func (instr AddRegT2) Execute(regs *Registers) {
-	if instr.Rd == PC && regs.InITBlock() && !regs.LastInITBlock() {
+func (instr AddRegT2) Execute(cpu *CPU) {
+	if instr.Rd == PC && cpu.InITBlock() && !cpu.LastInITBlock() {
 		// UNPREDICTABLE
 		// Raise exception (UsageFault?)
 		return
@@ -60,7 +64,7 @@ func (instr AddRegT2) Execute(regs *Registers) {
 		return
 	}
 
-	AddRegister(regs, InstrFields(instr), Shift{function: LSL_C, amount: 0})
+	AddRegister(cpu, InstrFields(instr), Shift{srtype: SRType_LSL, amount: 0})
 }
 
 func (instr AddRegT2) String() string {
@@ -83,8 +87,8 @@ func AddRegSP16T1(instr FetchedInstr) DecodedInstr {
 	return AddRegSPT1{Rd: Rdm, Rm: Rdm, Rn: SP, Imm: 0, setflags: NEVER}
 }
 
-func (instr AddRegSPT1) Execute(regs *Registers) {
-	AddRegister(regs, InstrFields(instr), Shift{function: LSL_C, amount: 0})
+func (instr AddRegSPT1) Execute(cpu *CPU) {
+	AddRegister(cpu, InstrFields(instr), Shift{srtype: SRType_LSL, amount: 0})
 }
 
 func (instr AddRegSPT1) String() string {
@@ -108,8 +112,8 @@ func AddRegSP16T2(instr FetchedInstr) DecodedInstr {
 	return AddRegSPT2{Rd: SP, Rm: Rm, Rn: SP, Imm: 0, setflags: NEVER}
 }
 
-func (instr AddRegSPT2) Execute(regs *Registers) {
-	AddRegister(regs, InstrFields(instr), Shift{function: LSL_C, amount: 0})
+func (instr AddRegSPT2) Execute(cpu *CPU) {
+	AddRegister(cpu, InstrFields(instr), Shift{srtype: SRType_LSL, amount: 0})
 }
 
 func (instr AddRegSPT2) String() string {
@@ -131,14 +135,18 @@ func AddImm16T1(instr FetchedInstr) DecodedInstr {
 	return AddImmT1{Rd: Rd, Rm: 0, Rn: Rn, Imm: Imm, setflags: NOT_IT}
 }
 
-func (instr AddImmT1) Execute(regs *Registers) {
-	AddImmediate(regs, InstrFields(instr))
+func (instr AddImmT1) Execute(cpu *CPU) {
+	AddImmediate(cpu, InstrFields(instr))
 }
 
 func (instr AddImmT1) String() string {
 	return fmt.Sprintf("adds %s, %s, #%d", instr.Rd, instr.Rn, instr.Imm)
 }
 
+func (instr AddImmT1) SetsFlags() SetFlags {
+	return instr.setflags
+}
+
 /* ADD (immediate)
  * ARM ARM A7.7.3
  * Encoding T2 */
@@ -153,14 +161,18 @@ func AddImm16T2(instr FetchedInstr) DecodedInstr {
 	return AddImmT2{Rd: Rdn, Rm: 0, Rn: Rdn, Imm: Imm, setflags: NOT_IT}
 }
 
-func (instr AddImmT2) Execute(regs *Registers) {
-	AddImmediate(regs, InstrFields(instr))
+func (instr AddImmT2) Execute(cpu *CPU) {
+	AddImmediate(cpu, InstrFields(instr))
 }
 
 func (instr AddImmT2) String() string {
 	return fmt.Sprintf("adds %s, #%d", instr.Rd, instr.Imm)
 }
 
+func (instr AddImmT2) SetsFlags() SetFlags {
+	return instr.setflags
+}
+
 /* SUB (register)
  * ARM ARM A7.7.172
  * Encoding T1 */
@@ -176,10 +188,800 @@ func SubReg16T1(instr FetchedInstr) DecodedInstr {
 	return SubRegT1{Rd: Rd, Rm: Rm, Rn: Rn, Imm: 0, setflags: NOT_IT}
 }
 
-func (instr SubRegT1) Execute(regs *Registers) {
-	SubRegister(regs, InstrFields(instr), Shift{function: LSL_C, amount: 0})
+func (instr SubRegT1) Execute(cpu *CPU) {
+	SubRegister(cpu, InstrFields(instr), Shift{srtype: SRType_LSL, amount: 0})
 }
 
 func (instr SubRegT1) String() string {
 	return fmt.Sprintf("subs %s, %s, %s", instr.Rd, instr.Rm, instr.Rn)
 }
+
+func (instr SubRegT1) SetsFlags() SetFlags {
+	return instr.setflags
+}
+
+/* SUB (immediate)
+ * ARM ARM A7.7.171
+ * Encoding T1 */
+type SubImmT1 InstrFields
+
+func SubImm16T1(instr FetchedInstr) DecodedInstr {
+	raw_instr := instr.Uint32()
+
+	Rd := RegIndex(raw_instr & 0x7)
+	Rn := RegIndex((raw_instr >> 3) & 0x7)
+	Imm := uint32((raw_instr >> 6) & 0x7)
+
+	return SubImmT1{Rd: Rd, Rm: 0, Rn: Rn, Imm: Imm, setflags: NOT_IT}
+}
+
+func (instr SubImmT1) Execute(cpu *CPU) {
+	SubImmediate(cpu, InstrFields(instr))
+}
+
+func (instr SubImmT1) String() string {
+	return fmt.Sprintf("sub%s %s, %s, #%d", instr.setflags, instr.Rd, instr.Rn, instr.Imm)
+}
+
+func (instr SubImmT1) SetsFlags() SetFlags {
+	return instr.setflags
+}
+
+/* SUB (immediate)
+ * ARM ARM A7.7.171
+ * Encoding T2 */
+type SubImmT2 InstrFields
+
+func SubImm16T2(instr FetchedInstr) DecodedInstr {
+	raw_instr := instr.Uint32()
+
+	Imm := uint32(raw_instr & 0xff)
+	Rdn := RegIndex((raw_instr >> 8) & 0x7)
+
+	return SubImmT2{Rd: Rdn, Rm: 0, Rn: Rdn, Imm: Imm, setflags: NOT_IT}
+}
+
+func (instr SubImmT2) Execute(cpu *CPU) {
+	SubImmediate(cpu, InstrFields(instr))
+}
+
+func (instr SubImmT2) String() string {
+	return fmt.Sprintf("sub%s %s, #%d", instr.setflags, instr.Rd, instr.Imm)
+}
+
+func (instr SubImmT2) SetsFlags() SetFlags {
+	return instr.setflags
+}
+
+/* ADD (SP plus immediate)
+ * ARM ARM A7.7.5
+ * Encoding T1 */
+type AddSPImmT1 InstrFields
+
+func AddSPImm16T1(instr FetchedInstr) DecodedInstr {
+	raw_instr := instr.Uint32()
+
+	Imm := uint32(raw_instr&0xff) << 2
+	Rd := RegIndex((raw_instr >> 8) & 0x7)
+
+	return AddSPImmT1{Rd: Rd, Rm: 0, Rn: SP, Imm: Imm, setflags: NEVER}
+}
+
+func (instr AddSPImmT1) Execute(cpu *CPU) {
+	AddImmediate(cpu, InstrFields(instr))
+}
+
+func (instr AddSPImmT1) String() string {
+	return fmt.Sprintf("add %s, sp, #%d", instr.Rd, instr.Imm)
+}
+
+/* ADD (SP plus immediate)
+ * ARM ARM A7.7.5
+ * Encoding T2 */
+type AddSPImmT2 InstrFields
+
+func AddSPImm16T2(instr FetchedInstr) DecodedInstr {
+	raw_instr := instr.Uint32()
+
+	Imm := uint32(raw_instr&0x7f) << 2
+
+	return AddSPImmT2{Rd: SP, Rm: 0, Rn: SP, Imm: Imm, setflags: NEVER}
+}
+
+func (instr AddSPImmT2) Execute(cpu *CPU) {
+	AddImmediate(cpu, InstrFields(instr))
+}
+
+func (instr AddSPImmT2) String() string {
+	return fmt.Sprintf("add sp, #%d", instr.Imm)
+}
+
+/* SUB (SP minus immediate)
+ * ARM ARM A7.7.173
+ * Encoding T1 */
+type SubSPImmT1 InstrFields
+
+func SubSPImm16T1(instr FetchedInstr) DecodedInstr {
+	raw_instr := instr.Uint32()
+
+	Imm := uint32(raw_instr&0x7f) << 2
+
+	return SubSPImmT1{Rd: SP, Rm: 0, Rn: SP, Imm: Imm, setflags: NEVER}
+}
+
+func (instr SubSPImmT1) Execute(cpu *CPU) {
+	SubImmediate(cpu, InstrFields(instr))
+}
+
+func (instr SubSPImmT1) String() string {
+	return fmt.Sprintf("sub sp, #%d", instr.Imm)
+}
+
+/* ADC (register)
+ * ARM ARM A7.7.2
+ * Encoding T1 */
+type AdcRegT1 InstrFields
+
+func AdcReg16T1(instr FetchedInstr) DecodedInstr {
+	raw_instr := instr.Uint32()
+
+	Rdn := RegIndex(raw_instr & 0x7)
+	Rm := RegIndex((raw_instr >> 3) & 0x7)
+
+	return AdcRegT1{Rd: Rdn, Rm: Rm, Rn: Rdn, Imm: 0, setflags: NOT_IT}
+}
+
+func (instr AdcRegT1) Execute(cpu *CPU) {
+	AdcRegister(cpu, InstrFields(instr), Shift{srtype: SRType_LSL, amount: 0})
+}
+
+func (instr AdcRegT1) String() string {
+	return fmt.Sprintf("adc%s %s, %s", instr.setflags, instr.Rd, instr.Rm)
+}
+
+func (instr AdcRegT1) SetsFlags() SetFlags {
+	return instr.setflags
+}
+
+/* SBC (register)
+ * ARM ARM A7.7.123
+ * Encoding T1 */
+type SbcRegT1 InstrFields
+
+func SbcReg16T1(instr FetchedInstr) DecodedInstr {
+	raw_instr := instr.Uint32()
+
+	Rdn := RegIndex(raw_instr & 0x7)
+	Rm := RegIndex((raw_instr >> 3) & 0x7)
+
+	return SbcRegT1{Rd: Rdn, Rm: Rm, Rn: Rdn, Imm: 0, setflags: NOT_IT}
+}
+
+func (instr SbcRegT1) Execute(cpu *CPU) {
+	SbcRegister(cpu, InstrFields(instr), Shift{srtype: SRType_LSL, amount: 0})
+}
+
+func (instr SbcRegT1) String() string {
+	return fmt.Sprintf("sbc%s %s, %s", instr.setflags, instr.Rd, instr.Rm)
+}
+
+func (instr SbcRegT1) SetsFlags() SetFlags {
+	return instr.setflags
+}
+
+/* RSB (immediate)
+ * ARM ARM A7.7.117
+ * Encoding T1 */
+type RsbImmT1 InstrFields
+
+func RsbImm16T1(instr FetchedInstr) DecodedInstr {
+	raw_instr := instr.Uint32()
+
+	Rd := RegIndex(raw_instr & 0x7)
+	Rn := RegIndex((raw_instr >> 3) & 0x7)
+
+	return RsbImmT1{Rd: Rd, Rm: 0, Rn: Rn, Imm: 0, setflags: NOT_IT}
+}
+
+func (instr RsbImmT1) Execute(cpu *CPU) {
+	RsbImmediate(cpu, InstrFields(instr))
+}
+
+func (instr RsbImmT1) String() string {
+	return fmt.Sprintf("rsb%s %s, %s, #0", instr.setflags, instr.Rd, instr.Rn)
+}
+
+func (instr RsbImmT1) SetsFlags() SetFlags {
+	return instr.setflags
+}
+
+/* CMP (register)
+ * ARM ARM A7.7.28
+ * Encoding T1 */
+type CmpRegT1 InstrFields
+
+func CmpReg16T1(instr FetchedInstr) DecodedInstr {
+	raw_instr := instr.Uint32()
+
+	Rn := RegIndex(raw_instr & 0x7)
+	Rm := RegIndex((raw_instr >> 3) & 0x7)
+
+	return CmpRegT1{Rd: 0, Rm: Rm, Rn: Rn, Imm: 0, setflags: ALWAYS}
+}
+
+func (instr CmpRegT1) Execute(cpu *CPU) {
+	CmpRegister(cpu, InstrFields(instr), Shift{srtype: SRType_LSL, amount: 0})
+}
+
+func (instr CmpRegT1) String() string {
+	return fmt.Sprintf("cmp %s, %s", instr.Rn, instr.Rm)
+}
+
+/* CMP (register)
+ * ARM ARM A7.7.28
+ * Encoding T2 */
+type CmpRegT2 InstrFields
+
+func CmpReg16T2(instr FetchedInstr) DecodedInstr {
+	raw_instr := instr.Uint32()
+
+	rn := uint8(raw_instr & 0x7)
+	N := uint8((raw_instr >> 7) & 0x1)
+	Rn := RegIndex((N << 3) | rn)
+	Rm := RegIndex((raw_instr >> 3) & 0xf)
+
+	if Rn < 8 && Rm < 8 {
+		return UnpredictableInstr{Rd: 0, Rm: Rm, Rn: Rn, Imm: 0, setflags: ALWAYS}
+	} else if Rn == PC || Rm == PC {
+		return UnpredictableInstr{Rd: 0, Rm: Rm, Rn: Rn, Imm: 0, setflags: ALWAYS}
+	}
+
+	return CmpRegT2{Rd: 0, Rm: Rm, Rn: Rn, Imm: 0, setflags: ALWAYS}
+}
+
+func (instr CmpRegT2) Execute(cpu *CPU) {
+	CmpRegister(cpu, InstrFields(instr), Shift{srtype: SRType_LSL, amount: 0})
+}
+
+func (instr CmpRegT2) String() string {
+	return fmt.Sprintf("cmp %s, %s", instr.Rn, instr.Rm)
+}
+
+/* CMP (immediate)
+ * ARM ARM A7.7.27
+ * Encoding T1 */
+type CmpImmT1 InstrFields
+
+func CmpImm16T1(instr FetchedInstr) DecodedInstr {
+	raw_instr := instr.Uint32()
+
+	Imm := uint32(raw_instr & 0xff)
+	Rn := RegIndex((raw_instr >> 8) & 0x7)
+
+	return CmpImmT1{Rd: 0, Rm: 0, Rn: Rn, Imm: Imm, setflags: ALWAYS}
+}
+
+func (instr CmpImmT1) Execute(cpu *CPU) {
+	CmpImmediate(cpu, InstrFields(instr))
+}
+
+func (instr CmpImmT1) String() string {
+	return fmt.Sprintf("cmp %s, #%d", instr.Rn, instr.Imm)
+}
+
+/* CMN (register)
+ * ARM ARM A7.7.26
+ * Encoding T1 */
+type CmnRegT1 InstrFields
+
+func CmnReg16T1(instr FetchedInstr) DecodedInstr {
+	raw_instr := instr.Uint32()
+
+	Rn := RegIndex(raw_instr & 0x7)
+	Rm := RegIndex((raw_instr >> 3) & 0x7)
+
+	return CmnRegT1{Rd: 0, Rm: Rm, Rn: Rn, Imm: 0, setflags: ALWAYS}
+}
+
+func (instr CmnRegT1) Execute(cpu *CPU) {
+	CmnRegister(cpu, InstrFields(instr), Shift{srtype: SRType_LSL, amount: 0})
+}
+
+func (instr CmnRegT1) String() string {
+	return fmt.Sprintf("cmn %s, %s", instr.Rn, instr.Rm)
+}
+
+/* ADD (immediate)
+ * ARM ARM A7.7.3
+ * Encoding T3 */
+type AddImmT3 InstrFields
+
+func AddImm32T3(instr FetchedInstr) DecodedInstr {
+	Rd, Rn, imm12, setflags := decode_modified_imm(instr.Uint32())
+	Imm := ThumbExpandImm(imm12)
+
+	if Rd == PC && setflags == ALWAYS {
+		return CmnImm32T1(instr)
+	}
+
+	if (Rd == SP && Rn != SP) || Rd == PC || Rn == PC {
+		return UnpredictableInstr{Rd: Rd, Rm: 0, Rn: Rn, Imm: Imm, setflags: setflags}
+	}
+
+	return AddImmT3{Rd: Rd, Rm: 0, Rn: Rn, Imm: Imm, setflags: setflags}
+}
+
+func (instr AddImmT3) Execute(cpu *CPU) {
+	AddImmediate(cpu, InstrFields(instr))
+}
+
+func (instr AddImmT3) String() string {
+	return fmt.Sprintf("add%s.w %s, %s, #%d", instr.setflags, instr.Rd, instr.Rn, instr.Imm)
+}
+
+/* CMN (immediate)
+ * ARM ARM A7.7.25
+ * Encoding T1 */
+type CmnImmT1 InstrFields
+
+func CmnImm32T1(instr FetchedInstr) DecodedInstr {
+	_, Rn, imm12, _ := decode_modified_imm(instr.Uint32())
+	Imm := ThumbExpandImm(imm12)
+
+	if Rn == PC {
+		return UnpredictableInstr{Rd: 0, Rm: 0, Rn: Rn, Imm: Imm, setflags: ALWAYS}
+	}
+
+	return CmnImmT1{Rd: 0, Rm: 0, Rn: Rn, Imm: Imm, setflags: ALWAYS}
+}
+
+func (instr CmnImmT1) Execute(cpu *CPU) {
+	CmnImmediate(cpu, InstrFields(instr))
+}
+
+func (instr CmnImmT1) String() string {
+	return fmt.Sprintf("cmn.w %s, #%d", instr.Rn, instr.Imm)
+}
+
+/* ADC (immediate)
+ * ARM ARM A7.7.1
+ * Encoding T1 */
+type AdcImmT1 InstrFields
+
+func AdcImm32T1(instr FetchedInstr) DecodedInstr {
+	Rd, Rn, imm12, setflags := decode_modified_imm(instr.Uint32())
+	Imm := ThumbExpandImm(imm12)
+
+	if BadReg(Rd) || BadReg(Rn) {
+		return UnpredictableInstr{Rd: Rd, Rm: 0, Rn: Rn, Imm: Imm, setflags: setflags}
+	}
+
+	return AdcImmT1{Rd: Rd, Rm: 0, Rn: Rn, Imm: Imm, setflags: setflags}
+}
+
+func (instr AdcImmT1) Execute(cpu *CPU) {
+	AdcImmediate(cpu, InstrFields(instr))
+}
+
+func (instr AdcImmT1) String() string {
+	return fmt.Sprintf("adc%s %s, %s, #%d", instr.setflags, instr.Rd, instr.Rn, instr.Imm)
+}
+
+/* SBC (immediate)
+ * ARM ARM A7.7.122
+ * Encoding T1 */
+type SbcImmT1 InstrFields
+
+func SbcImm32T1(instr FetchedInstr) DecodedInstr {
+	Rd, Rn, imm12, setflags := decode_modified_imm(instr.Uint32())
+	Imm := ThumbExpandImm(imm12)
+
+	if BadReg(Rd) || BadReg(Rn) {
+		return UnpredictableInstr{Rd: Rd, Rm: 0, Rn: Rn, Imm: Imm, setflags: setflags}
+	}
+
+	return SbcImmT1{Rd: Rd, Rm: 0, Rn: Rn, Imm: Imm, setflags: setflags}
+}
+
+func (instr SbcImmT1) Execute(cpu *CPU) {
+	SbcImmediate(cpu, InstrFields(instr))
+}
+
+func (instr SbcImmT1) String() string {
+	return fmt.Sprintf("sbc%s %s, %s, #%d", instr.setflags, instr.Rd, instr.Rn, instr.Imm)
+}
+
+/* SUB (immediate)
+ * ARM ARM A7.7.171
+ * Encoding T3 */
+type SubImmT3 InstrFields
+
+func SubImm32T3(instr FetchedInstr) DecodedInstr {
+	Rd, Rn, imm12, setflags := decode_modified_imm(instr.Uint32())
+	Imm := ThumbExpandImm(imm12)
+
+	if Rd == PC && setflags == ALWAYS {
+		return CmpImm32T2(instr)
+	}
+
+	if (Rd == SP && Rn != SP) || Rd == PC || Rn == PC {
+		return UnpredictableInstr{Rd: Rd, Rm: 0, Rn: Rn, Imm: Imm, setflags: setflags}
+	}
+
+	return SubImmT3{Rd: Rd, Rm: 0, Rn: Rn, Imm: Imm, setflags: setflags}
+}
+
+func (instr SubImmT3) Execute(cpu *CPU) {
+	SubImmediate(cpu, InstrFields(instr))
+}
+
+func (instr SubImmT3) String() string {
+	return fmt.Sprintf("sub%s.w %s, %s, #%d", instr.setflags, instr.Rd, instr.Rn, instr.Imm)
+}
+
+/* CMP (immediate)
+ * ARM ARM A7.7.27
+ * Encoding T2 */
+type CmpImmT2 InstrFields
+
+func CmpImm32T2(instr FetchedInstr) DecodedInstr {
+	_, Rn, imm12, _ := decode_modified_imm(instr.Uint32())
+	Imm := ThumbExpandImm(imm12)
+
+	if Rn == PC {
+		return UnpredictableInstr{Rd: 0, Rm: 0, Rn: Rn, Imm: Imm, setflags: ALWAYS}
+	}
+
+	return CmpImmT2{Rd: 0, Rm: 0, Rn: Rn, Imm: Imm, setflags: ALWAYS}
+}
+
+func (instr CmpImmT2) Execute(cpu *CPU) {
+	CmpImmediate(cpu, InstrFields(instr))
+}
+
+func (instr CmpImmT2) String() string {
+	return fmt.Sprintf("cmp.w %s, #%d", instr.Rn, instr.Imm)
+}
+
+/* RSB (immediate)
+ * ARM ARM A7.7.117
+ * Encoding T2 */
+type RsbImmT2 InstrFields
+
+func RsbImm32T2(instr FetchedInstr) DecodedInstr {
+	Rd, Rn, imm12, setflags := decode_modified_imm(instr.Uint32())
+	Imm := ThumbExpandImm(imm12)
+
+	if BadReg(Rd) || BadReg(Rn) {
+		return UnpredictableInstr{Rd: Rd, Rm: 0, Rn: Rn, Imm: Imm, setflags: setflags}
+	}
+
+	return RsbImmT2{Rd: Rd, Rm: 0, Rn: Rn, Imm: Imm, setflags: setflags}
+}
+
+func (instr RsbImmT2) Execute(cpu *CPU) {
+	RsbImmediate(cpu, InstrFields(instr))
+}
+
+func (instr RsbImmT2) String() string {
+	return fmt.Sprintf("rsb%s.w %s, %s, #%d", instr.setflags, instr.Rd, instr.Rn, instr.Imm)
+}
+
+/* ADD (immediate)
+ * ARM ARM A7.7.3
+ * Encoding T4 */
+type AddImmT4 InstrFields
+
+func AddImm32T4(instr FetchedInstr) DecodedInstr {
+	raw_instr := instr.Uint32()
+
+	Rd := RegIndex((raw_instr >> 8) & 0xf)
+	Rn := RegIndex((raw_instr >> 16) & 0xf)
+	Imm := decode_plain_imm12(raw_instr)
+
+	if Rn == PC {
+		return Adr32T3(instr)
+	}
+
+	/* ADD (SP plus immediate) may write SP */
+	if (Rn == SP && Rd == PC) || (Rn != SP && BadReg(Rd)) {
+		return UnpredictableInstr{Rd: Rd, Rm: 0, Rn: Rn, Imm: Imm, setflags: NEVER}
+	}
+
+	return AddImmT4{Rd: Rd, Rm: 0, Rn: Rn, Imm: Imm, setflags: NEVER}
+}
+
+func (instr AddImmT4) Execute(cpu *CPU) {
+	AddImmediate(cpu, InstrFields(instr))
+}
+
+func (instr AddImmT4) String() string {
+	return fmt.Sprintf("addw %s, %s, #%d", instr.Rd, instr.Rn, instr.Imm)
+}
+
+/* SUB (immediate)
+ * ARM ARM A7.7.171
+ * Encoding T4 */
+type SubImmT4 InstrFields
+
+func SubImm32T4(instr FetchedInstr) DecodedInstr {
+	raw_instr := instr.Uint32()
+
+	Rd := RegIndex((raw_instr >> 8) & 0xf)
+	Rn := RegIndex((raw_instr >> 16) & 0xf)
+	Imm := decode_plain_imm12(raw_instr)
+
+	if Rn == PC {
+		return Adr32T2(instr)
+	}
+
+	/* SUB (SP minus immediate) may write SP */
+	if (Rn == SP && Rd == PC) || (Rn != SP && BadReg(Rd)) {
+		return UnpredictableInstr{Rd: Rd, Rm: 0, Rn: Rn, Imm: Imm, setflags: NEVER}
+	}
+
+	return SubImmT4{Rd: Rd, Rm: 0, Rn: Rn, Imm: Imm, setflags: NEVER}
+}
+
+func (instr SubImmT4) Execute(cpu *CPU) {
+	SubImmediate(cpu, InstrFields(instr))
+}
+
+func (instr SubImmT4) String() string {
+	return fmt.Sprintf("subw %s, %s, #%d", instr.Rd, instr.Rn, instr.Imm)
+}
+
+/* ADR
+ * ARM ARM A7.7.7
+ * Encoding T1 */
+type AdrT1 InstrFields
+
+func Adr16T1(instr FetchedInstr) DecodedInstr {
+	raw_instr := instr.Uint32()
+
+	Imm := uint32(raw_instr&0xff) << 2
+	Rd := RegIndex((raw_instr >> 8) & 0x7)
+
+	return AdrT1{Rd: Rd, Rm: 0, Rn: PC, Imm: Imm, setflags: NEVER}
+}
+
+func (instr AdrT1) Execute(cpu *CPU) {
+	cpu.SetR(instr.Rd, Align(cpu.Pc(), 4)+instr.Imm)
+}
+
+func (instr AdrT1) String() string {
+	return fmt.Sprintf("adr %s, #%d", instr.Rd, instr.Imm)
+}
+
+/* ADR
+ * ARM ARM A7.7.7
+ * Encoding T2 (subtract) */
+type AdrT2 InstrFields
+
+func Adr32T2(instr FetchedInstr) DecodedInstr {
+	raw_instr := instr.Uint32()
+
+	Rd := RegIndex((raw_instr >> 8) & 0xf)
+	Imm := decode_plain_imm12(raw_instr)
+
+	if BadReg(Rd) {
+		return UnpredictableInstr{Rd: Rd, Rm: 0, Rn: PC, Imm: Imm, setflags: NEVER}
+	}
+
+	return AdrT2{Rd: Rd, Rm: 0, Rn: PC, Imm: Imm, setflags: NEVER}
+}
+
+func (instr AdrT2) Execute(cpu *CPU) {
+	cpu.SetR(instr.Rd, Align(cpu.Pc(), 4)-instr.Imm)
+}
+
+func (instr AdrT2) String() string {
+	return fmt.Sprintf("adr.w %s, #-%d", instr.Rd, instr.Imm)
+}
+
+/* ADR
+ * ARM ARM A7.7.7
+ * Encoding T3 (add) */
+type AdrT3 InstrFields
+
+func Adr32T3(instr FetchedInstr) DecodedInstr {
+	raw_instr := instr.Uint32()
+
+	Rd := RegIndex((raw_instr >> 8) & 0xf)
+	Imm := decode_plain_imm12(raw_instr)
+
+	if BadReg(Rd) {
+		return UnpredictableInstr{Rd: Rd, Rm: 0, Rn: PC, Imm: Imm, setflags: NEVER}
+	}
+
+	return AdrT3{Rd: Rd, Rm: 0, Rn: PC, Imm: Imm, setflags: NEVER}
+}
+
+func (instr AdrT3) Execute(cpu *CPU) {
+	cpu.SetR(instr.Rd, Align(cpu.Pc(), 4)+instr.Imm)
+}
+
+func (instr AdrT3) String() string {
+	return fmt.Sprintf("adr.w %s, #%d", instr.Rd, instr.Imm)
+}
+
+/* ADD (register)
+ * ARM ARM A7.7.4
+ * Encoding T3 */
+type AddRegT3 InstrFields
+
+func AddReg32T3(instr FetchedInstr) DecodedInstr {
+	Rd, Rn, Rm, shift, setflags := decode_shifted_reg(instr.Uint32())
+
+	if Rd == PC && setflags == ALWAYS {
+		return CmnReg32T2(instr)
+	}
+
+	if bad_sp_shifted_reg(Rd, Rn, Rm, shift) {
+		return UnpredictableInstr{Rd: Rd, Rm: Rm, Rn: Rn, Imm: 0, setflags: setflags, Shift: shift}
+	}
+
+	return AddRegT3{Rd: Rd, Rm: Rm, Rn: Rn, Imm: 0, setflags: setflags, Shift: shift}
+}
+
+func (instr AddRegT3) Execute(cpu *CPU) {
+	AddRegister(cpu, InstrFields(instr), instr.Shift)
+}
+
+func (instr AddRegT3) String() string {
+	return fmt.Sprintf("add%s.w %s, %s, %s", instr.setflags, instr.Rd, instr.Rn, shifted_operand(instr.Rm, instr.Shift))
+}
+
+/* ADD and SUB (register) also encode ADD (SP plus register) and SUB (SP
+ * minus register) when Rn is SP, which may write SP with a small left shift
+ * ARM ARM A7.7.6, A7.7.174 */
+func bad_sp_shifted_reg(Rd RegIndex, Rn RegIndex, Rm RegIndex, shift Shift) bool {
+	if Rn == SP {
+		small_lsl := shift.srtype == SRType_LSL && shift.amount <= 3
+		return (Rd == SP && !small_lsl) || Rd == PC || BadReg(Rm)
+	}
+
+	return BadReg(Rd) || Rn == PC || BadReg(Rm)
+}
+
+/* CMN (register)
+ * ARM ARM A7.7.26
+ * Encoding T2 */
+type CmnRegT2 InstrFields
+
+func CmnReg32T2(instr FetchedInstr) DecodedInstr {
+	_, Rn, Rm, shift, _ := decode_shifted_reg(instr.Uint32())
+
+	if Rn == PC || BadReg(Rm) {
+		return UnpredictableInstr{Rd: 0, Rm: Rm, Rn: Rn, Imm: 0, setflags: ALWAYS, Shift: shift}
+	}
+
+	return CmnRegT2{Rd: 0, Rm: Rm, Rn: Rn, Imm: 0, setflags: ALWAYS, Shift: shift}
+}
+
+func (instr CmnRegT2) Execute(cpu *CPU) {
+	CmnRegister(cpu, InstrFields(instr), instr.Shift)
+}
+
+func (instr CmnRegT2) String() string {
+	return fmt.Sprintf("cmn.w %s, %s", instr.Rn, shifted_operand(instr.Rm, instr.Shift))
+}
+
+/* ADC (register)
+ * ARM ARM A7.7.2
+ * Encoding T2 */
+type AdcRegT2 InstrFields
+
+func AdcReg32T2(instr FetchedInstr) DecodedInstr {
+	Rd, Rn, Rm, shift, setflags := decode_shifted_reg(instr.Uint32())
+
+	if BadReg(Rd) || BadReg(Rn) || BadReg(Rm) {
+		return UnpredictableInstr{Rd: Rd, Rm: Rm, Rn: Rn, Imm: 0, setflags: setflags, Shift: shift}
+	}
+
+	return AdcRegT2{Rd: Rd, Rm: Rm, Rn: Rn, Imm: 0, setflags: setflags, Shift: shift}
+}
+
+func (instr AdcRegT2) Execute(cpu *CPU) {
+	AdcRegister(cpu, InstrFields(instr), instr.Shift)
+}
+
+func (instr AdcRegT2) String() string {
+	return fmt.Sprintf("adc%s.w %s, %s, %s", instr.setflags, instr.Rd, instr.Rn, shifted_operand(instr.Rm, instr.Shift))
+}
+
+/* SBC (register)
+ * ARM ARM A7.7.123
+ * Encoding T2 */
+type SbcRegT2 InstrFields
+
+func SbcReg32T2(instr FetchedInstr) DecodedInstr {
+	Rd, Rn, Rm, shift, setflags := decode_shifted_reg(instr.Uint32())
+
+	if BadReg(Rd) || BadReg(Rn) || BadReg(Rm) {
+		return UnpredictableInstr{Rd: Rd, Rm: Rm, Rn: Rn, Imm: 0, setflags: setflags, Shift: shift}
+	}
+
+	return SbcRegT2{Rd: Rd, Rm: Rm, Rn: Rn, Imm: 0, setflags: setflags, Shift: shift}
+}
+
+func (instr SbcRegT2) Execute(cpu *CPU) {
+	SbcRegister(cpu, InstrFields(instr), instr.Shift)
+}
+
+func (instr SbcRegT2) String() string {
+	return fmt.Sprintf("sbc%s.w %s, %s, %s", instr.setflags, instr.Rd, instr.Rn, shifted_operand(instr.Rm, instr.Shift))
+}
+
+/* SUB (register)
+ * ARM ARM A7.7.172
+ * Encoding T2 */
+type SubRegT2 InstrFields
+
+func SubReg32T2(instr FetchedInstr) DecodedInstr {
+	Rd, Rn, Rm, shift, setflags := decode_shifted_reg(instr.Uint32())
+
+	if Rd == PC && setflags == ALWAYS {
+		return CmpReg32T3(instr)
+	}
+
+	if bad_sp_shifted_reg(Rd, Rn, Rm, shift) {
+		return UnpredictableInstr{Rd: Rd, Rm: Rm, Rn: Rn, Imm: 0, setflags: setflags, Shift: shift}
+	}
+
+	return SubRegT2{Rd: Rd, Rm: Rm, Rn: Rn, Imm: 0, setflags: setflags, Shift: shift}
+}
+
+func (instr SubRegT2) Execute(cpu *CPU) {
+	SubRegister(cpu, InstrFields(instr), instr.Shift)
+}
+
+func (instr SubRegT2) String() string {
+	return fmt.Sprintf("sub%s.w %s, %s, %s", instr.setflags, instr.Rd, instr.Rn, shifted_operand(instr.Rm, instr.Shift))
+}
+
+/* CMP (register)
+ * ARM ARM A7.7.28
+ * Encoding T3 */
+type CmpRegT3 InstrFields
+
+func CmpReg32T3(instr FetchedInstr) DecodedInstr {
+	_, Rn, Rm, shift, _ := decode_shifted_reg(instr.Uint32())
+
+	if Rn == PC || BadReg(Rm) {
+		return UnpredictableInstr{Rd: 0, Rm: Rm, Rn: Rn, Imm: 0, setflags: ALWAYS, Shift: shift}
+	}
+
+	return CmpRegT3{Rd: 0, Rm: Rm, Rn: Rn, Imm: 0, setflags: ALWAYS, Shift: shift}
+}
+
+func (instr CmpRegT3) Execute(cpu *CPU) {
+	CmpRegister(cpu, InstrFields(instr), instr.Shift)
+}
+
+func (instr CmpRegT3) String() string {
+	return fmt.Sprintf("cmp.w %s, %s", instr.Rn, shifted_operand(instr.Rm, instr.Shift))
+}
+
+/* RSB (register)
+ * ARM ARM A7.7.118
+ * Encoding T1 */
+type RsbRegT1 InstrFields
+
+func RsbReg32T1(instr FetchedInstr) DecodedInstr {
+	Rd, Rn, Rm, shift, setflags := decode_shifted_reg(instr.Uint32())
+
+	if BadReg(Rd) || BadReg(Rn) || BadReg(Rm) {
+		return UnpredictableInstr{Rd: Rd, Rm: Rm, Rn: Rn, Imm: 0, setflags: setflags, Shift: shift}
+	}
+
+	return RsbRegT1{Rd: Rd, Rm: Rm, Rn: Rn, Imm: 0, setflags: setflags, Shift: shift}
+}
+
+func (instr RsbRegT1) Execute(cpu *CPU) {
+	RsbRegister(cpu, InstrFields(instr), instr.Shift)
+}
+
+func (instr RsbRegT1) String() string {
+	return fmt.Sprintf("rsb%s %s, %s, %s", instr.setflags, instr.Rd, instr.Rn, shifted_operand(instr.Rm, instr.Shift))
+}
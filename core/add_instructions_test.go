@@ -107,11 +107,11 @@ func TestExecuteAddRegT2(t *testing.T) {
 		// add pc, r0
 		{instr: AddRegT2{Rd: PC, Rm: 0, Rn: PC, Imm: 0, setflags: NEVER},
 			regs:     Registers{r: GeneralRegs{4, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13}, pc: 1000},
-			expected: Registers{r: GeneralRegs{4, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13}, pc: 1004}},
+			expected: Registers{r: GeneralRegs{4, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13}, pc: 1004, branched: true}},
 		// add pc, lr
 		{instr: AddRegT2{Rd: PC, Rm: LR, Rn: PC, Imm: 0, setflags: NEVER},
 			regs:     Registers{r: GeneralRegs{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13}, pc: 1000, lr: 2000},
-			expected: Registers{r: GeneralRegs{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13}, pc: 3000, lr: 2000}},
+			expected: Registers{r: GeneralRegs{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13}, pc: 3000, lr: 2000, branched: true}},
 	}
 
 	share_t = t
@@ -168,7 +168,7 @@ func TestExecuteAddRegSPT1(t *testing.T) {
 		// add pc, sp, pc
 		{instr: AddRegSPT1{Rd: PC, Rm: PC, Rn: SP, Imm: 0, setflags: NEVER},
 			regs:     Registers{r: GeneralRegs{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13}, pc: 0x80000000, sp: SPRegs{4, 0}, Control: Control{Spsel: MSP}},
-			expected: Registers{r: GeneralRegs{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13}, pc: 0x80000004, sp: SPRegs{4, 0}, Control: Control{Spsel: MSP}}},
+			expected: Registers{r: GeneralRegs{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13}, pc: 0x80000004, sp: SPRegs{4, 0}, Control: Control{Spsel: MSP}, branched: true}},
 		// add sp, sp, sp
 		{instr: AddRegSPT1{Rd: SP, Rm: SP, Rn: SP, Imm: 0, setflags: NEVER},
 			regs:     Registers{r: GeneralRegs{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13}, sp: SPRegs{4, 0}, Control: Control{Spsel: MSP}},
@@ -401,3 +401,941 @@ func TestExecuteSubRegT1(t *testing.T) {
 
 	test_execute(t, cases)
 }
+
+func TestIdentifySubImmT1(t *testing.T) {
+	cases := []IdentifyCase{
+		{instr: FetchedInstr16(0x1ed1), instr_valid: true},  // subs r1, r2, #3
+		{instr: FetchedInstr16(0x1e00), instr_valid: true},  // subs r0, r0, #0
+		{instr: FetchedInstr16(0x1cd1), instr_valid: false}, // adds r1, r2, #3
+		{instr: FetchedInstr16(0x1ad1), instr_valid: false}, // subs r1, r2, r3
+		{instr: FetchedInstr16(0xffff), instr_valid: false},
+	}
+
+	test_identify(t, cases, reflect.TypeOf(SubImmT1{}))
+}
+
+func TestDecodeSubImm16T1(t *testing.T) {
+	cases := []DecodeCase{
+		// subs r1, r2, #3
+		{instr: FetchedInstr16(0x1ed1), decoded: SubImmT1{Rd: 1, Rm: 0, Rn: 2, Imm: 3, setflags: NOT_IT}},
+	}
+
+	test_decode(t, cases, SubImm16T1)
+}
+
+func TestExecuteSubImmT1(t *testing.T) {
+	cases := []ExecuteCase{
+		// subs r1, r2, #3
+		{instr: SubImmT1{Rd: 1, Rm: 0, Rn: 2, Imm: 3, setflags: NOT_IT},
+			regs:     Registers{r: GeneralRegs{0, 1, 5, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12}},
+			expected: Registers{r: GeneralRegs{0, 2, 5, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12}, Apsr: Apsr{C: true}}},
+		// subs r1, r2, #3
+		{instr: SubImmT1{Rd: 1, Rm: 0, Rn: 2, Imm: 3, setflags: NOT_IT},
+			regs:     Registers{r: GeneralRegs{0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12}},
+			expected: Registers{r: GeneralRegs{0, 0xffffffff, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12}, Apsr: Apsr{N: true}}},
+	}
+
+	test_execute(t, cases)
+}
+
+func TestIdentifySubImmT2(t *testing.T) {
+	cases := []IdentifyCase{
+		{instr: FetchedInstr16(0x3cff), instr_valid: true},  // subs r4, #255
+		{instr: FetchedInstr16(0x37ff), instr_valid: false}, // adds r7, #255
+		{instr: FetchedInstr16(0x2bc8), instr_valid: false}, // cmp r3, #200
+		{instr: FetchedInstr16(0xffff), instr_valid: false},
+	}
+
+	test_identify(t, cases, reflect.TypeOf(SubImmT2{}))
+}
+
+func TestDecodeSubImm16T2(t *testing.T) {
+	cases := []DecodeCase{
+		// subs r4, #255
+		{instr: FetchedInstr16(0x3cff), decoded: SubImmT2{Rd: 4, Rm: 0, Rn: 4, Imm: 255, setflags: NOT_IT}},
+	}
+
+	test_decode(t, cases, SubImm16T2)
+}
+
+func TestExecuteSubImmT2(t *testing.T) {
+	cases := []ExecuteCase{
+		// subs r4, #255
+		{instr: SubImmT2{Rd: 4, Rm: 0, Rn: 4, Imm: 255, setflags: NOT_IT},
+			regs:     Registers{r: GeneralRegs{0, 1, 2, 3, 255, 5, 6, 7, 8, 9, 10, 11, 12}},
+			expected: Registers{r: GeneralRegs{0, 1, 2, 3, 0, 5, 6, 7, 8, 9, 10, 11, 12}, Apsr: Apsr{Z: true, C: true}}},
+		// subs r4, #1
+		{instr: SubImmT2{Rd: 4, Rm: 0, Rn: 4, Imm: 1, setflags: NOT_IT},
+			regs:     Registers{r: GeneralRegs{0, 1, 2, 3, 0x80000000, 5, 6, 7, 8, 9, 10, 11, 12}},
+			expected: Registers{r: GeneralRegs{0, 1, 2, 3, 0x7fffffff, 5, 6, 7, 8, 9, 10, 11, 12}, Apsr: Apsr{C: true, V: true}}},
+	}
+
+	test_execute(t, cases)
+}
+
+func TestIdentifyAddSPImmT1(t *testing.T) {
+	cases := []IdentifyCase{
+		{instr: FetchedInstr16(0xaaff), instr_valid: true},  // add r2, sp, #1020
+		{instr: FetchedInstr16(0xb07f), instr_valid: false}, // add sp, #508
+		{instr: FetchedInstr16(0xffff), instr_valid: false},
+	}
+
+	test_identify(t, cases, reflect.TypeOf(AddSPImmT1{}))
+}
+
+func TestDecodeAddSPImm16T1(t *testing.T) {
+	cases := []DecodeCase{
+		// add r2, sp, #1020
+		{instr: FetchedInstr16(0xaaff), decoded: AddSPImmT1{Rd: 2, Rm: 0, Rn: SP, Imm: 1020, setflags: NEVER}},
+	}
+
+	test_decode(t, cases, AddSPImm16T1)
+}
+
+func TestExecuteAddSPImmT1(t *testing.T) {
+	cases := []ExecuteCase{
+		// add r2, sp, #1020
+		{instr: AddSPImmT1{Rd: 2, Rm: 0, Rn: SP, Imm: 1020, setflags: NEVER},
+			regs:     Registers{r: GeneralRegs{0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12}, sp: SPRegs{0x20000000, 0}},
+			expected: Registers{r: GeneralRegs{0, 1, 0x200003fc, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12}, sp: SPRegs{0x20000000, 0}}},
+	}
+
+	test_execute(t, cases)
+}
+
+func TestIdentifyAddSPImmT2(t *testing.T) {
+	cases := []IdentifyCase{
+		{instr: FetchedInstr16(0xb07f), instr_valid: true},  // add sp, #508
+		{instr: FetchedInstr16(0xb084), instr_valid: false}, // sub sp, #16
+		{instr: FetchedInstr16(0xffff), instr_valid: false},
+	}
+
+	test_identify(t, cases, reflect.TypeOf(AddSPImmT2{}))
+}
+
+func TestDecodeAddSPImm16T2(t *testing.T) {
+	cases := []DecodeCase{
+		// add sp, #508
+		{instr: FetchedInstr16(0xb07f), decoded: AddSPImmT2{Rd: SP, Rm: 0, Rn: SP, Imm: 508, setflags: NEVER}},
+	}
+
+	test_decode(t, cases, AddSPImm16T2)
+}
+
+func TestExecuteAddSPImmT2(t *testing.T) {
+	cases := []ExecuteCase{
+		// add sp, #508
+		{instr: AddSPImmT2{Rd: SP, Rm: 0, Rn: SP, Imm: 508, setflags: NEVER},
+			regs:     Registers{sp: SPRegs{0x20000000, 0x20001000}, Control: Control{Spsel: PSP}},
+			expected: Registers{sp: SPRegs{0x20000000, 0x200011fc}, Control: Control{Spsel: PSP}}},
+	}
+
+	test_execute(t, cases)
+}
+
+func TestIdentifySubSPImmT1(t *testing.T) {
+	cases := []IdentifyCase{
+		{instr: FetchedInstr16(0xb084), instr_valid: true},  // sub sp, #16
+		{instr: FetchedInstr16(0xb07f), instr_valid: false}, // add sp, #508
+		{instr: FetchedInstr16(0xffff), instr_valid: false},
+	}
+
+	test_identify(t, cases, reflect.TypeOf(SubSPImmT1{}))
+}
+
+func TestDecodeSubSPImm16T1(t *testing.T) {
+	cases := []DecodeCase{
+		// sub sp, #16
+		{instr: FetchedInstr16(0xb084), decoded: SubSPImmT1{Rd: SP, Rm: 0, Rn: SP, Imm: 16, setflags: NEVER}},
+	}
+
+	test_decode(t, cases, SubSPImm16T1)
+}
+
+func TestExecuteSubSPImmT1(t *testing.T) {
+	cases := []ExecuteCase{
+		// sub sp, #16
+		{instr: SubSPImmT1{Rd: SP, Rm: 0, Rn: SP, Imm: 16, setflags: NEVER},
+			regs:     Registers{sp: SPRegs{0x20000000, 0}},
+			expected: Registers{sp: SPRegs{0x1ffffff0, 0}}},
+	}
+
+	test_execute(t, cases)
+}
+
+func TestIdentifyAdcRegT1(t *testing.T) {
+	cases := []IdentifyCase{
+		{instr: FetchedInstr16(0x4163), instr_valid: true},  // adcs r3, r4
+		{instr: FetchedInstr16(0x41b5), instr_valid: false}, // sbcs r5, r6
+		{instr: FetchedInstr16(0xffff), instr_valid: false},
+	}
+
+	test_identify(t, cases, reflect.TypeOf(AdcRegT1{}))
+}
+
+func TestDecodeAdcReg16T1(t *testing.T) {
+	cases := []DecodeCase{
+		// adcs r3, r4
+		{instr: FetchedInstr16(0x4163), decoded: AdcRegT1{Rd: 3, Rm: 4, Rn: 3, Imm: 0, setflags: NOT_IT}},
+	}
+
+	test_decode(t, cases, AdcReg16T1)
+}
+
+func TestExecuteAdcRegT1(t *testing.T) {
+	cases := []ExecuteCase{
+		// adcs r3, r4
+		{instr: AdcRegT1{Rd: 3, Rm: 4, Rn: 3, Imm: 0, setflags: NOT_IT},
+			regs:     Registers{r: GeneralRegs{0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12}, Apsr: Apsr{C: true}},
+			expected: Registers{r: GeneralRegs{0, 1, 2, 8, 4, 5, 6, 7, 8, 9, 10, 11, 12}}},
+		// adcs r3, r4
+		{instr: AdcRegT1{Rd: 3, Rm: 4, Rn: 3, Imm: 0, setflags: NOT_IT},
+			regs:     Registers{r: GeneralRegs{0, 1, 2, 0xffffffff, 0, 5, 6, 7, 8, 9, 10, 11, 12}, Apsr: Apsr{C: true}},
+			expected: Registers{r: GeneralRegs{0, 1, 2, 0, 0, 5, 6, 7, 8, 9, 10, 11, 12}, Apsr: Apsr{Z: true, C: true}}},
+	}
+
+	test_execute(t, cases)
+}
+
+func TestIdentifySbcRegT1(t *testing.T) {
+	cases := []IdentifyCase{
+		{instr: FetchedInstr16(0x41b5), instr_valid: true},  // sbcs r5, r6
+		{instr: FetchedInstr16(0x4163), instr_valid: false}, // adcs r3, r4
+		{instr: FetchedInstr16(0xffff), instr_valid: false},
+	}
+
+	test_identify(t, cases, reflect.TypeOf(SbcRegT1{}))
+}
+
+func TestDecodeSbcReg16T1(t *testing.T) {
+	cases := []DecodeCase{
+		// sbcs r5, r6
+		{instr: FetchedInstr16(0x41b5), decoded: SbcRegT1{Rd: 5, Rm: 6, Rn: 5, Imm: 0, setflags: NOT_IT}},
+	}
+
+	test_decode(t, cases, SbcReg16T1)
+}
+
+func TestExecuteSbcRegT1(t *testing.T) {
+	cases := []ExecuteCase{
+		// sbcs r5, r6 (no borrow)
+		{instr: SbcRegT1{Rd: 5, Rm: 6, Rn: 5, Imm: 0, setflags: NOT_IT},
+			regs:     Registers{r: GeneralRegs{0, 1, 2, 3, 4, 10, 3, 7, 8, 9, 10, 11, 12}, Apsr: Apsr{C: true}},
+			expected: Registers{r: GeneralRegs{0, 1, 2, 3, 4, 7, 3, 7, 8, 9, 10, 11, 12}, Apsr: Apsr{C: true}}},
+		// sbcs r5, r6 (borrow)
+		{instr: SbcRegT1{Rd: 5, Rm: 6, Rn: 5, Imm: 0, setflags: NOT_IT},
+			regs:     Registers{r: GeneralRegs{0, 1, 2, 3, 4, 10, 3, 7, 8, 9, 10, 11, 12}},
+			expected: Registers{r: GeneralRegs{0, 1, 2, 3, 4, 6, 3, 7, 8, 9, 10, 11, 12}, Apsr: Apsr{C: true}}},
+	}
+
+	test_execute(t, cases)
+}
+
+func TestIdentifyRsbImmT1(t *testing.T) {
+	cases := []IdentifyCase{
+		{instr: FetchedInstr16(0x4263), instr_valid: true},  // rsbs r3, r4, #0
+		{instr: FetchedInstr16(0x4211), instr_valid: false}, // tst r1, r2
+		{instr: FetchedInstr16(0xffff), instr_valid: false},
+	}
+
+	test_identify(t, cases, reflect.TypeOf(RsbImmT1{}))
+}
+
+func TestDecodeRsbImm16T1(t *testing.T) {
+	cases := []DecodeCase{
+		// rsbs r3, r4, #0
+		{instr: FetchedInstr16(0x4263), decoded: RsbImmT1{Rd: 3, Rm: 0, Rn: 4, Imm: 0, setflags: NOT_IT}},
+	}
+
+	test_decode(t, cases, RsbImm16T1)
+}
+
+func TestExecuteRsbImmT1(t *testing.T) {
+	cases := []ExecuteCase{
+		// rsbs r3, r4, #0
+		{instr: RsbImmT1{Rd: 3, Rm: 0, Rn: 4, Imm: 0, setflags: NOT_IT},
+			regs:     Registers{r: GeneralRegs{0, 1, 2, 3, 1, 5, 6, 7, 8, 9, 10, 11, 12}},
+			expected: Registers{r: GeneralRegs{0, 1, 2, 0xffffffff, 1, 5, 6, 7, 8, 9, 10, 11, 12}, Apsr: Apsr{N: true}}},
+		// rsbs r3, r4, #0
+		{instr: RsbImmT1{Rd: 3, Rm: 0, Rn: 4, Imm: 0, setflags: NOT_IT},
+			regs:     Registers{r: GeneralRegs{0, 1, 2, 3, 0, 5, 6, 7, 8, 9, 10, 11, 12}},
+			expected: Registers{r: GeneralRegs{0, 1, 2, 0, 0, 5, 6, 7, 8, 9, 10, 11, 12}, Apsr: Apsr{Z: true, C: true}}},
+	}
+
+	test_execute(t, cases)
+}
+
+func TestIdentifyCmpRegT1(t *testing.T) {
+	cases := []IdentifyCase{
+		{instr: FetchedInstr16(0x42b5), instr_valid: true},  // cmp r5, r6
+		{instr: FetchedInstr16(0x42c7), instr_valid: false}, // cmn r7, r0
+		{instr: FetchedInstr16(0x4588), instr_valid: false}, // cmp r8, r1
+		{instr: FetchedInstr16(0xffff), instr_valid: false},
+	}
+
+	test_identify(t, cases, reflect.TypeOf(CmpRegT1{}))
+}
+
+func TestDecodeCmpReg16T1(t *testing.T) {
+	cases := []DecodeCase{
+		// cmp r5, r6
+		{instr: FetchedInstr16(0x42b5), decoded: CmpRegT1{Rd: 0, Rm: 6, Rn: 5, Imm: 0, setflags: ALWAYS}},
+	}
+
+	test_decode(t, cases, CmpReg16T1)
+}
+
+func TestExecuteCmpRegT1(t *testing.T) {
+	cases := []ExecuteCase{
+		// cmp r5, r6
+		{instr: CmpRegT1{Rd: 0, Rm: 6, Rn: 5, Imm: 0, setflags: ALWAYS},
+			regs:     Registers{r: GeneralRegs{0, 1, 2, 3, 4, 5, 5, 7, 8, 9, 10, 11, 12}},
+			expected: Registers{r: GeneralRegs{0, 1, 2, 3, 4, 5, 5, 7, 8, 9, 10, 11, 12}, Apsr: Apsr{Z: true, C: true}}},
+		// cmp r5, r6
+		{instr: CmpRegT1{Rd: 0, Rm: 6, Rn: 5, Imm: 0, setflags: ALWAYS},
+			regs:     Registers{r: GeneralRegs{0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12}},
+			expected: Registers{r: GeneralRegs{0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12}, Apsr: Apsr{N: true}}},
+		// cmp r5, r6 (in IT block)
+		{instr: CmpRegT1{Rd: 0, Rm: 6, Rn: 5, Imm: 0, setflags: ALWAYS},
+			regs:     Registers{r: GeneralRegs{0, 1, 2, 3, 4, 0x80000000, 1, 7, 8, 9, 10, 11, 12}, Epsr: Epsr{IT: 0x8}},
+			expected: Registers{r: GeneralRegs{0, 1, 2, 3, 4, 0x80000000, 1, 7, 8, 9, 10, 11, 12}, Epsr: Epsr{IT: 0x8}, Apsr: Apsr{C: true, V: true}}},
+	}
+
+	test_execute(t, cases)
+}
+
+func TestIdentifyCmpRegT2(t *testing.T) {
+	cases := []IdentifyCase{
+		{instr: FetchedInstr16(0x4588), instr_valid: true},  // cmp r8, r1
+		{instr: FetchedInstr16(0x4560), instr_valid: true},  // cmp r0, r12
+		{instr: FetchedInstr16(0x4508), instr_valid: false}, // cmp r0, r1 (UNPREDICTABLE)
+		{instr: FetchedInstr16(0x42b5), instr_valid: false}, // cmp r5, r6
+		{instr: FetchedInstr16(0xffff), instr_valid: false},
+	}
+
+	test_identify(t, cases, reflect.TypeOf(CmpRegT2{}))
+}
+
+func TestDecodeCmpReg16T2(t *testing.T) {
+	cases := []DecodeCase{
+		// cmp r8, r1
+		{instr: FetchedInstr16(0x4588), decoded: CmpRegT2{Rd: 0, Rm: 1, Rn: 8, Imm: 0, setflags: ALWAYS}},
+		// cmp r0, r12
+		{instr: FetchedInstr16(0x4560), decoded: CmpRegT2{Rd: 0, Rm: 12, Rn: 0, Imm: 0, setflags: ALWAYS}},
+		// cmp r0, r1
+		{instr: FetchedInstr16(0x4508), decoded: UnpredictableInstr{Rd: 0, Rm: 1, Rn: 0, Imm: 0, setflags: ALWAYS}},
+		// cmp pc, r1
+		{instr: FetchedInstr16(0x458f), decoded: UnpredictableInstr{Rd: 0, Rm: 1, Rn: PC, Imm: 0, setflags: ALWAYS}},
+	}
+
+	test_decode(t, cases, CmpReg16T2)
+}
+
+func TestExecuteCmpRegT2(t *testing.T) {
+	cases := []ExecuteCase{
+		// cmp r8, r1
+		{instr: CmpRegT2{Rd: 0, Rm: 1, Rn: 8, Imm: 0, setflags: ALWAYS},
+			regs:     Registers{r: GeneralRegs{0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12}},
+			expected: Registers{r: GeneralRegs{0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12}, Apsr: Apsr{C: true}}},
+	}
+
+	test_execute(t, cases)
+}
+
+func TestIdentifyCmpImmT1(t *testing.T) {
+	cases := []IdentifyCase{
+		{instr: FetchedInstr16(0x2bc8), instr_valid: true},  // cmp r3, #200
+		{instr: FetchedInstr16(0x2745), instr_valid: false}, // mov r7, #0x45
+		{instr: FetchedInstr16(0x3cff), instr_valid: false}, // subs r4, #255
+		{instr: FetchedInstr16(0xffff), instr_valid: false},
+	}
+
+	test_identify(t, cases, reflect.TypeOf(CmpImmT1{}))
+}
+
+func TestDecodeCmpImm16T1(t *testing.T) {
+	cases := []DecodeCase{
+		// cmp r3, #200
+		{instr: FetchedInstr16(0x2bc8), decoded: CmpImmT1{Rd: 0, Rm: 0, Rn: 3, Imm: 200, setflags: ALWAYS}},
+	}
+
+	test_decode(t, cases, CmpImm16T1)
+}
+
+func TestExecuteCmpImmT1(t *testing.T) {
+	cases := []ExecuteCase{
+		// cmp r3, #200
+		{instr: CmpImmT1{Rd: 0, Rm: 0, Rn: 3, Imm: 200, setflags: ALWAYS},
+			regs:     Registers{r: GeneralRegs{0, 1, 2, 200, 4, 5, 6, 7, 8, 9, 10, 11, 12}},
+			expected: Registers{r: GeneralRegs{0, 1, 2, 200, 4, 5, 6, 7, 8, 9, 10, 11, 12}, Apsr: Apsr{Z: true, C: true}}},
+		// cmp r3, #200
+		{instr: CmpImmT1{Rd: 0, Rm: 0, Rn: 3, Imm: 200, setflags: ALWAYS},
+			regs:     Registers{r: GeneralRegs{0, 1, 2, 100, 4, 5, 6, 7, 8, 9, 10, 11, 12}, Apsr: Apsr{Z: true, C: true}},
+			expected: Registers{r: GeneralRegs{0, 1, 2, 100, 4, 5, 6, 7, 8, 9, 10, 11, 12}, Apsr: Apsr{N: true}}},
+	}
+
+	test_execute(t, cases)
+}
+
+func TestIdentifyCmnRegT1(t *testing.T) {
+	cases := []IdentifyCase{
+		{instr: FetchedInstr16(0x42c7), instr_valid: true},  // cmn r7, r0
+		{instr: FetchedInstr16(0x42b5), instr_valid: false}, // cmp r5, r6
+		{instr: FetchedInstr16(0xffff), instr_valid: false},
+	}
+
+	test_identify(t, cases, reflect.TypeOf(CmnRegT1{}))
+}
+
+func TestDecodeCmnReg16T1(t *testing.T) {
+	cases := []DecodeCase{
+		// cmn r7, r0
+		{instr: FetchedInstr16(0x42c7), decoded: CmnRegT1{Rd: 0, Rm: 0, Rn: 7, Imm: 0, setflags: ALWAYS}},
+	}
+
+	test_decode(t, cases, CmnReg16T1)
+}
+
+func TestExecuteCmnRegT1(t *testing.T) {
+	cases := []ExecuteCase{
+		// cmn r7, r0
+		{instr: CmnRegT1{Rd: 0, Rm: 0, Rn: 7, Imm: 0, setflags: ALWAYS},
+			regs:     Registers{r: GeneralRegs{1, 1, 2, 3, 4, 5, 6, 0xffffffff, 8, 9, 10, 11, 12}},
+			expected: Registers{r: GeneralRegs{1, 1, 2, 3, 4, 5, 6, 0xffffffff, 8, 9, 10, 11, 12}, Apsr: Apsr{Z: true, C: true}}},
+	}
+
+	test_execute(t, cases)
+}
+
+func TestIdentifyAddImmT3(t *testing.T) {
+	cases := []IdentifyCase{
+		{instr: FetchedInstr32(0xf5046380), instr_valid: true},  // add.w r3, r4, #0x400
+		{instr: FetchedInstr32(0xf10d0d10), instr_valid: true},  // add.w sp, sp, #16
+		{instr: FetchedInstr32(0xf1140301), instr_valid: true},  // adds.w r3, r4, #1
+		{instr: FetchedInstr32(0xf1150f01), instr_valid: false}, // cmn.w r5, #1
+		{instr: FetchedInstr32(0xf1040d10), instr_valid: false}, // add.w sp, r4, #16 (UNPREDICTABLE)
+	}
+
+	test_identify(t, cases, reflect.TypeOf(AddImmT3{}))
+}
+
+func TestDecodeAddImm32T3(t *testing.T) {
+	cases := []DecodeCase{
+		// add.w r3, r4, #0x400
+		{instr: FetchedInstr32(0xf5046380), decoded: AddImmT3{Rd: 3, Rm: 0, Rn: 4, Imm: 0x400, setflags: NEVER}},
+		// add.w sp, sp, #16
+		{instr: FetchedInstr32(0xf10d0d10), decoded: AddImmT3{Rd: SP, Rm: 0, Rn: SP, Imm: 16, setflags: NEVER}},
+		// adds.w r3, r4, #1
+		{instr: FetchedInstr32(0xf1140301), decoded: AddImmT3{Rd: 3, Rm: 0, Rn: 4, Imm: 1, setflags: ALWAYS}},
+		// cmn.w r5, #1
+		{instr: FetchedInstr32(0xf1150f01), decoded: CmnImmT1{Rd: 0, Rm: 0, Rn: 5, Imm: 1, setflags: ALWAYS}},
+	}
+
+	test_decode(t, cases, AddImm32T3)
+}
+
+func TestExecuteAddImmT3(t *testing.T) {
+	cases := []ExecuteCase{
+		// adds.w r3, r4, #1
+		{instr: AddImmT3{Rd: 3, Rm: 0, Rn: 4, Imm: 1, setflags: ALWAYS},
+			regs:     Registers{r: GeneralRegs{0, 1, 2, 3, 0xffffffff, 5, 6, 7, 8, 9, 10, 11, 12}},
+			expected: Registers{r: GeneralRegs{0, 1, 2, 0, 0xffffffff, 5, 6, 7, 8, 9, 10, 11, 12}, Apsr: Apsr{Z: true, C: true}}},
+		// add.w r3, r4, #0x400
+		{instr: AddImmT3{Rd: 3, Rm: 0, Rn: 4, Imm: 0x400, setflags: NEVER},
+			regs:     Registers{r: GeneralRegs{0, 1, 2, 3, 0xffffffff, 5, 6, 7, 8, 9, 10, 11, 12}},
+			expected: Registers{r: GeneralRegs{0, 1, 2, 0x3ff, 0xffffffff, 5, 6, 7, 8, 9, 10, 11, 12}}},
+	}
+
+	test_execute(t, cases)
+}
+
+func TestIdentifyCmnImmT1(t *testing.T) {
+	cases := []IdentifyCase{
+		{instr: FetchedInstr32(0xf1150f01), instr_valid: true},  // cmn.w r5, #1
+		{instr: FetchedInstr32(0xf1140301), instr_valid: false}, // adds.w r3, r4, #1
+	}
+
+	test_identify(t, cases, reflect.TypeOf(CmnImmT1{}))
+}
+
+func TestDecodeCmnImm32T1(t *testing.T) {
+	cases := []DecodeCase{
+		// cmn.w r5, #1
+		{instr: FetchedInstr32(0xf1150f01), decoded: CmnImmT1{Rd: 0, Rm: 0, Rn: 5, Imm: 1, setflags: ALWAYS}},
+	}
+
+	test_decode(t, cases, CmnImm32T1)
+}
+
+func TestExecuteCmnImmT1(t *testing.T) {
+	cases := []ExecuteCase{
+		// cmn.w r5, #1
+		{instr: CmnImmT1{Rd: 0, Rm: 0, Rn: 5, Imm: 1, setflags: ALWAYS},
+			regs:     Registers{r: GeneralRegs{0, 1, 2, 3, 4, 0x7fffffff, 6, 7, 8, 9, 10, 11, 12}},
+			expected: Registers{r: GeneralRegs{0, 1, 2, 3, 4, 0x7fffffff, 6, 7, 8, 9, 10, 11, 12}, Apsr: Apsr{N: true, V: true}}},
+	}
+
+	test_execute(t, cases)
+}
+
+func TestIdentifyAdcImmT1(t *testing.T) {
+	cases := []IdentifyCase{
+		{instr: FetchedInstr32(0xf14706ff), instr_valid: true},  // adc r6, r7, #0xff
+		{instr: FetchedInstr32(0xf1790802), instr_valid: false}, // sbcs r8, r9, #2
+	}
+
+	test_identify(t, cases, reflect.TypeOf(AdcImmT1{}))
+}
+
+func TestDecodeAdcImm32T1(t *testing.T) {
+	cases := []DecodeCase{
+		// adc r6, r7, #0xff
+		{instr: FetchedInstr32(0xf14706ff), decoded: AdcImmT1{Rd: 6, Rm: 0, Rn: 7, Imm: 0xff, setflags: NEVER}},
+	}
+
+	test_decode(t, cases, AdcImm32T1)
+}
+
+func TestExecuteAdcImmT1(t *testing.T) {
+	cases := []ExecuteCase{
+		// adc r6, r7, #0xff
+		{instr: AdcImmT1{Rd: 6, Rm: 0, Rn: 7, Imm: 0xff, setflags: NEVER},
+			regs:     Registers{r: GeneralRegs{0, 1, 2, 3, 4, 5, 6, 1, 8, 9, 10, 11, 12}, Apsr: Apsr{C: true}},
+			expected: Registers{r: GeneralRegs{0, 1, 2, 3, 4, 5, 0x101, 1, 8, 9, 10, 11, 12}, Apsr: Apsr{C: true}}},
+	}
+
+	test_execute(t, cases)
+}
+
+func TestIdentifySbcImmT1(t *testing.T) {
+	cases := []IdentifyCase{
+		{instr: FetchedInstr32(0xf1790802), instr_valid: true},  // sbcs r8, r9, #2
+		{instr: FetchedInstr32(0xf14706ff), instr_valid: false}, // adc r6, r7, #0xff
+	}
+
+	test_identify(t, cases, reflect.TypeOf(SbcImmT1{}))
+}
+
+func TestDecodeSbcImm32T1(t *testing.T) {
+	cases := []DecodeCase{
+		// sbcs r8, r9, #2
+		{instr: FetchedInstr32(0xf1790802), decoded: SbcImmT1{Rd: 8, Rm: 0, Rn: 9, Imm: 2, setflags: ALWAYS}},
+	}
+
+	test_decode(t, cases, SbcImm32T1)
+}
+
+func TestExecuteSbcImmT1(t *testing.T) {
+	cases := []ExecuteCase{
+		// sbcs r8, r9, #2
+		{instr: SbcImmT1{Rd: 8, Rm: 0, Rn: 9, Imm: 2, setflags: ALWAYS},
+			regs:     Registers{r: GeneralRegs{0, 1, 2, 3, 4, 5, 6, 7, 8, 3, 10, 11, 12}},
+			expected: Registers{r: GeneralRegs{0, 1, 2, 3, 4, 5, 6, 7, 0, 3, 10, 11, 12}, Apsr: Apsr{Z: true, C: true}}},
+	}
+
+	test_execute(t, cases)
+}
+
+func TestIdentifySubImmT3(t *testing.T) {
+	cases := []IdentifyCase{
+		{instr: FetchedInstr32(0xf5ab7a80), instr_valid: true},  // sub.w r10, r11, #0x100
+		{instr: FetchedInstr32(0xf5bc5f80), instr_valid: false}, // cmp.w r12, #0x1000
+	}
+
+	test_identify(t, cases, reflect.TypeOf(SubImmT3{}))
+}
+
+func TestDecodeSubImm32T3(t *testing.T) {
+	cases := []DecodeCase{
+		// sub.w r10, r11, #0x100
+		{instr: FetchedInstr32(0xf5ab7a80), decoded: SubImmT3{Rd: 10, Rm: 0, Rn: 11, Imm: 0x100, setflags: NEVER}},
+		// cmp.w r12, #0x1000
+		{instr: FetchedInstr32(0xf5bc5f80), decoded: CmpImmT2{Rd: 0, Rm: 0, Rn: 12, Imm: 0x1000, setflags: ALWAYS}},
+	}
+
+	test_decode(t, cases, SubImm32T3)
+}
+
+func TestExecuteSubImmT3(t *testing.T) {
+	cases := []ExecuteCase{
+		// sub.w r10, r11, #0x100
+		{instr: SubImmT3{Rd: 10, Rm: 0, Rn: 11, Imm: 0x100, setflags: NEVER},
+			regs:     Registers{r: GeneralRegs{0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 0x180, 12}},
+			expected: Registers{r: GeneralRegs{0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 0x80, 0x180, 12}}},
+	}
+
+	test_execute(t, cases)
+}
+
+func TestIdentifyCmpImmT2(t *testing.T) {
+	cases := []IdentifyCase{
+		{instr: FetchedInstr32(0xf5bc5f80), instr_valid: true},  // cmp.w r12, #0x1000
+		{instr: FetchedInstr32(0xf5ab7a80), instr_valid: false}, // sub.w r10, r11, #0x100
+	}
+
+	test_identify(t, cases, reflect.TypeOf(CmpImmT2{}))
+}
+
+func TestDecodeCmpImm32T2(t *testing.T) {
+	cases := []DecodeCase{
+		// cmp.w r12, #0x1000
+		{instr: FetchedInstr32(0xf5bc5f80), decoded: CmpImmT2{Rd: 0, Rm: 0, Rn: 12, Imm: 0x1000, setflags: ALWAYS}},
+	}
+
+	test_decode(t, cases, CmpImm32T2)
+}
+
+func TestExecuteCmpImmT2(t *testing.T) {
+	cases := []ExecuteCase{
+		// cmp.w r12, #0x1000
+		{instr: CmpImmT2{Rd: 0, Rm: 0, Rn: 12, Imm: 0x1000, setflags: ALWAYS},
+			regs:     Registers{r: GeneralRegs{0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 0x1000}},
+			expected: Registers{r: GeneralRegs{0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 0x1000}, Apsr: Apsr{Z: true, C: true}}},
+	}
+
+	test_execute(t, cases)
+}
+
+func TestIdentifyRsbImmT2(t *testing.T) {
+	cases := []IdentifyCase{
+		{instr: FetchedInstr32(0xf1c1000a), instr_valid: true},  // rsb.w r0, r1, #10
+		{instr: FetchedInstr32(0xf5ab7a80), instr_valid: false}, // sub.w r10, r11, #0x100
+	}
+
+	test_identify(t, cases, reflect.TypeOf(RsbImmT2{}))
+}
+
+func TestDecodeRsbImm32T2(t *testing.T) {
+	cases := []DecodeCase{
+		// rsb.w r0, r1, #10
+		{instr: FetchedInstr32(0xf1c1000a), decoded: RsbImmT2{Rd: 0, Rm: 0, Rn: 1, Imm: 10, setflags: NEVER}},
+	}
+
+	test_decode(t, cases, RsbImm32T2)
+}
+
+func TestExecuteRsbImmT2(t *testing.T) {
+	cases := []ExecuteCase{
+		// rsb.w r0, r1, #10
+		{instr: RsbImmT2{Rd: 0, Rm: 0, Rn: 1, Imm: 10, setflags: NEVER},
+			regs:     Registers{r: GeneralRegs{0, 3, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12}},
+			expected: Registers{r: GeneralRegs{7, 3, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12}}},
+	}
+
+	test_execute(t, cases)
+}
+
+func TestIdentifyAddImmT4(t *testing.T) {
+	cases := []IdentifyCase{
+		{instr: FetchedInstr32(0xf60271ff), instr_valid: true},  // addw r1, r2, #0xfff
+		{instr: FetchedInstr32(0xf20d1d00), instr_valid: true},  // addw sp, sp, #0x100
+		{instr: FetchedInstr32(0xf20f1500), instr_valid: false}, // adr.w r5, #0x100
+		{instr: FetchedInstr32(0xf2a40301), instr_valid: false}, // subw r3, r4, #1
+	}
+
+	test_identify(t, cases, reflect.TypeOf(AddImmT4{}))
+}
+
+func TestDecodeAddImm32T4(t *testing.T) {
+	cases := []DecodeCase{
+		// addw r1, r2, #0xfff
+		{instr: FetchedInstr32(0xf60271ff), decoded: AddImmT4{Rd: 1, Rm: 0, Rn: 2, Imm: 0xfff, setflags: NEVER}},
+		// addw sp, sp, #0x100
+		{instr: FetchedInstr32(0xf20d1d00), decoded: AddImmT4{Rd: SP, Rm: 0, Rn: SP, Imm: 0x100, setflags: NEVER}},
+		// adr.w r5, #0x100
+		{instr: FetchedInstr32(0xf20f1500), decoded: AdrT3{Rd: 5, Rm: 0, Rn: PC, Imm: 0x100, setflags: NEVER}},
+		// addw sp, r2, #0xfff
+		{instr: FetchedInstr32(0xf6027dff), decoded: UnpredictableInstr{Rd: SP, Rm: 0, Rn: 2, Imm: 0xfff, setflags: NEVER}},
+	}
+
+	test_decode(t, cases, AddImm32T4)
+}
+
+func TestExecuteAddImmT4(t *testing.T) {
+	cases := []ExecuteCase{
+		// addw r1, r2, #0xfff
+		{instr: AddImmT4{Rd: 1, Rm: 0, Rn: 2, Imm: 0xfff, setflags: NEVER},
+			regs:     Registers{r: GeneralRegs{0, 1, 0xffffffff, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12}},
+			expected: Registers{r: GeneralRegs{0, 0xffe, 0xffffffff, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12}}},
+		// addw sp, sp, #0x100
+		{instr: AddImmT4{Rd: SP, Rm: 0, Rn: SP, Imm: 0x100, setflags: NEVER},
+			regs:     Registers{sp: SPRegs{0x20000f00, 0}},
+			expected: Registers{sp: SPRegs{0x20001000, 0}}},
+	}
+
+	test_execute(t, cases)
+}
+
+func TestIdentifySubImmT4(t *testing.T) {
+	cases := []IdentifyCase{
+		{instr: FetchedInstr32(0xf2a40301), instr_valid: true},  // subw r3, r4, #1
+		{instr: FetchedInstr32(0xf2ad0d08), instr_valid: true},  // subw sp, sp, #8
+		{instr: FetchedInstr32(0xf2af0610), instr_valid: false}, // adr.w r6, #-0x10
+		{instr: FetchedInstr32(0xf60271ff), instr_valid: false}, // addw r1, r2, #0xfff
+	}
+
+	test_identify(t, cases, reflect.TypeOf(SubImmT4{}))
+}
+
+func TestDecodeSubImm32T4(t *testing.T) {
+	cases := []DecodeCase{
+		// subw r3, r4, #1
+		{instr: FetchedInstr32(0xf2a40301), decoded: SubImmT4{Rd: 3, Rm: 0, Rn: 4, Imm: 1, setflags: NEVER}},
+		// subw sp, sp, #8
+		{instr: FetchedInstr32(0xf2ad0d08), decoded: SubImmT4{Rd: SP, Rm: 0, Rn: SP, Imm: 8, setflags: NEVER}},
+		// adr.w r6, #-0x10
+		{instr: FetchedInstr32(0xf2af0610), decoded: AdrT2{Rd: 6, Rm: 0, Rn: PC, Imm: 0x10, setflags: NEVER}},
+	}
+
+	test_decode(t, cases, SubImm32T4)
+}
+
+func TestExecuteSubImmT4(t *testing.T) {
+	cases := []ExecuteCase{
+		// subw r3, r4, #1
+		{instr: SubImmT4{Rd: 3, Rm: 0, Rn: 4, Imm: 1, setflags: NEVER},
+			regs:     Registers{r: GeneralRegs{0, 1, 2, 3, 0, 5, 6, 7, 8, 9, 10, 11, 12}},
+			expected: Registers{r: GeneralRegs{0, 1, 2, 0xffffffff, 0, 5, 6, 7, 8, 9, 10, 11, 12}}},
+		// subw sp, sp, #8
+		{instr: SubImmT4{Rd: SP, Rm: 0, Rn: SP, Imm: 8, setflags: NEVER},
+			regs:     Registers{sp: SPRegs{0x20001000, 0}},
+			expected: Registers{sp: SPRegs{0x20000ff8, 0}}},
+	}
+
+	test_execute(t, cases)
+}
+
+func TestIdentifyAdrT1(t *testing.T) {
+	cases := []IdentifyCase{
+		{instr: FetchedInstr16(0xa3ff), instr_valid: true},  // adr r3, #1020
+		{instr: FetchedInstr16(0xabff), instr_valid: false}, // add r3, sp, #1020
+	}
+
+	test_identify(t, cases, reflect.TypeOf(AdrT1{}))
+}
+
+func TestDecodeAdr16T1(t *testing.T) {
+	cases := []DecodeCase{
+		// adr r3, #1020
+		{instr: FetchedInstr16(0xa3ff), decoded: AdrT1{Rd: 3, Rm: 0, Rn: PC, Imm: 1020, setflags: NEVER}},
+	}
+
+	test_decode(t, cases, Adr16T1)
+}
+
+func TestExecuteAdrT1(t *testing.T) {
+	cases := []ExecuteCase{
+		// adr r3, #8
+		{instr: AdrT1{Rd: 3, Rm: 0, Rn: PC, Imm: 8, setflags: NEVER},
+			regs:     Registers{pc: 0x104},
+			expected: Registers{r: GeneralRegs{0, 0, 0, 0x10c, 0, 0, 0, 0, 0, 0, 0, 0, 0}, pc: 0x104}},
+		// adr r3, #8, from a halfword aligned instruction
+		{instr: AdrT1{Rd: 3, Rm: 0, Rn: PC, Imm: 8, setflags: NEVER},
+			regs:     Registers{pc: 0x106},
+			expected: Registers{r: GeneralRegs{0, 0, 0, 0x10c, 0, 0, 0, 0, 0, 0, 0, 0, 0}, pc: 0x106}},
+	}
+
+	test_execute(t, cases)
+}
+
+func TestIdentifyAdrT2(t *testing.T) {
+	cases := []IdentifyCase{
+		{instr: FetchedInstr32(0xf2af0610), instr_valid: true},  // adr.w r6, #-0x10
+		{instr: FetchedInstr32(0xf20f1500), instr_valid: false}, // adr.w r5, #0x100
+	}
+
+	test_identify(t, cases, reflect.TypeOf(AdrT2{}))
+}
+
+func TestExecuteAdrT2(t *testing.T) {
+	cases := []ExecuteCase{
+		// adr.w r6, #-0x10
+		{instr: AdrT2{Rd: 6, Rm: 0, Rn: PC, Imm: 0x10, setflags: NEVER},
+			regs:     Registers{pc: 0x106},
+			expected: Registers{r: GeneralRegs{0, 0, 0, 0, 0, 0, 0xf4, 0, 0, 0, 0, 0, 0}, pc: 0x106}},
+	}
+
+	test_execute(t, cases)
+}
+
+func TestIdentifyAdrT3(t *testing.T) {
+	cases := []IdentifyCase{
+		{instr: FetchedInstr32(0xf20f1500), instr_valid: true},  // adr.w r5, #0x100
+		{instr: FetchedInstr32(0xf2af0610), instr_valid: false}, // adr.w r6, #-0x10
+	}
+
+	test_identify(t, cases, reflect.TypeOf(AdrT3{}))
+}
+
+func TestExecuteAdrT3(t *testing.T) {
+	cases := []ExecuteCase{
+		// adr.w r5, #0x100
+		{instr: AdrT3{Rd: 5, Rm: 0, Rn: PC, Imm: 0x100, setflags: NEVER},
+			regs:     Registers{pc: 0x106},
+			expected: Registers{r: GeneralRegs{0, 0, 0, 0, 0, 0x204, 0, 0, 0, 0, 0, 0, 0}, pc: 0x106}},
+	}
+
+	test_execute(t, cases)
+}
+
+func TestIdentifyAddRegT3(t *testing.T) {
+	cases := []IdentifyCase{
+		{instr: FetchedInstr32(0xeb010082), instr_valid: true},  // add.w r0, r1, r2, lsl #2
+		{instr: FetchedInstr32(0xeb110022), instr_valid: true},  // adds.w r0, r1, r2, asr #32
+		{instr: FetchedInstr32(0xeb0d0d83), instr_valid: true},  // add.w sp, sp, r3, lsl #2
+		{instr: FetchedInstr32(0xeb0d1d03), instr_valid: false}, // add.w sp, sp, r3, lsl #4 (UNPREDICTABLE)
+		{instr: FetchedInstr32(0xeb140f75), instr_valid: false}, // cmn.w r4, r5, ror #1
+	}
+
+	test_identify(t, cases, reflect.TypeOf(AddRegT3{}))
+}
+
+func TestDecodeAddReg32T3(t *testing.T) {
+	cases := []DecodeCase{
+		// add.w r0, r1, r2, lsl #2
+		{instr: FetchedInstr32(0xeb010082), decoded: AddRegT3{Rd: 0, Rm: 2, Rn: 1, Imm: 0, setflags: NEVER, Shift: Shift{srtype: SRType_LSL, amount: 2}}},
+		// adds.w r0, r1, r2, asr #32
+		{instr: FetchedInstr32(0xeb110022), decoded: AddRegT3{Rd: 0, Rm: 2, Rn: 1, Imm: 0, setflags: ALWAYS, Shift: Shift{srtype: SRType_ASR, amount: 32}}},
+		// add.w sp, sp, r3, lsl #2
+		{instr: FetchedInstr32(0xeb0d0d83), decoded: AddRegT3{Rd: SP, Rm: 3, Rn: SP, Imm: 0, setflags: NEVER, Shift: Shift{srtype: SRType_LSL, amount: 2}}},
+		// cmn.w r4, r5, ror #1
+		{instr: FetchedInstr32(0xeb140f75), decoded: CmnRegT2{Rd: 0, Rm: 5, Rn: 4, Imm: 0, setflags: ALWAYS, Shift: Shift{srtype: SRType_ROR, amount: 1}}},
+	}
+
+	test_decode(t, cases, AddReg32T3)
+}
+
+func TestExecuteAddRegT3(t *testing.T) {
+	cases := []ExecuteCase{
+		// add.w r0, r1, r2, lsl #2
+		{instr: AddRegT3{Rd: 0, Rm: 2, Rn: 1, Imm: 0, setflags: NEVER, Shift: Shift{srtype: SRType_LSL, amount: 2}},
+			regs:     Registers{r: GeneralRegs{0, 0x1000, 3, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12}},
+			expected: Registers{r: GeneralRegs{0x100c, 0x1000, 3, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12}}},
+		// adds.w r0, r1, r2, asr #32
+		{instr: AddRegT3{Rd: 0, Rm: 2, Rn: 1, Imm: 0, setflags: ALWAYS, Shift: Shift{srtype: SRType_ASR, amount: 32}},
+			regs:     Registers{r: GeneralRegs{0, 1, 0x80000000, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12}},
+			expected: Registers{r: GeneralRegs{0, 1, 0x80000000, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12}, Apsr: Apsr{Z: true, C: true}}},
+		// add.w sp, sp, r3, lsl #2
+		{instr: AddRegT3{Rd: SP, Rm: 3, Rn: SP, Imm: 0, setflags: NEVER, Shift: Shift{srtype: SRType_LSL, amount: 2}},
+			regs:     Registers{r: GeneralRegs{0, 1, 2, 4, 4, 5, 6, 7, 8, 9, 10, 11, 12}, sp: SPRegs{0x20000ff0, 0}},
+			expected: Registers{r: GeneralRegs{0, 1, 2, 4, 4, 5, 6, 7, 8, 9, 10, 11, 12}, sp: SPRegs{0x20001000, 0}}},
+	}
+
+	test_execute(t, cases)
+}
+
+func TestExecuteCmnRegT2(t *testing.T) {
+	cases := []ExecuteCase{
+		// cmn.w r4, r5, ror #1
+		{instr: CmnRegT2{Rd: 0, Rm: 5, Rn: 4, Imm: 0, setflags: ALWAYS, Shift: Shift{srtype: SRType_ROR, amount: 1}},
+			regs:     Registers{r: GeneralRegs{0, 1, 2, 3, 0x80000000, 0x1, 6, 7, 8, 9, 10, 11, 12}},
+			expected: Registers{r: GeneralRegs{0, 1, 2, 3, 0x80000000, 0x1, 6, 7, 8, 9, 10, 11, 12}, Apsr: Apsr{Z: true, C: true, V: true}}},
+	}
+
+	test_execute(t, cases)
+}
+
+func TestDecodeAdcReg32T2(t *testing.T) {
+	cases := []DecodeCase{
+		// adc.w r6, r7, r8, lsr #1
+		{instr: FetchedInstr32(0xeb470658), decoded: AdcRegT2{Rd: 6, Rm: 8, Rn: 7, Imm: 0, setflags: NEVER, Shift: Shift{srtype: SRType_LSR, amount: 1}}},
+	}
+
+	test_decode(t, cases, AdcReg32T2)
+}
+
+func TestExecuteAdcRegT2(t *testing.T) {
+	cases := []ExecuteCase{
+		// adc.w r6, r7, r8, lsr #1
+		{instr: AdcRegT2{Rd: 6, Rm: 8, Rn: 7, Imm: 0, setflags: NEVER, Shift: Shift{srtype: SRType_LSR, amount: 1}},
+			regs:     Registers{r: GeneralRegs{0, 1, 2, 3, 4, 5, 6, 0x10, 0x21, 9, 10, 11, 12}, Apsr: Apsr{C: true}},
+			expected: Registers{r: GeneralRegs{0, 1, 2, 3, 4, 5, 0x21, 0x10, 0x21, 9, 10, 11, 12}, Apsr: Apsr{C: true}}},
+	}
+
+	test_execute(t, cases)
+}
+
+func TestDecodeSbcReg32T2(t *testing.T) {
+	cases := []DecodeCase{
+		// sbcs.w r9, r10, r11, lsl #1
+		{instr: FetchedInstr32(0xeb7a094b), decoded: SbcRegT2{Rd: 9, Rm: 11, Rn: 10, Imm: 0, setflags: ALWAYS, Shift: Shift{srtype: SRType_LSL, amount: 1}}},
+	}
+
+	test_decode(t, cases, SbcReg32T2)
+}
+
+func TestExecuteSbcRegT2(t *testing.T) {
+	cases := []ExecuteCase{
+		// sbcs.w r9, r10, r11, lsl #1
+		{instr: SbcRegT2{Rd: 9, Rm: 11, Rn: 10, Imm: 0, setflags: ALWAYS, Shift: Shift{srtype: SRType_LSL, amount: 1}},
+			regs:     Registers{r: GeneralRegs{0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 5, 12}},
+			expected: Registers{r: GeneralRegs{0, 1, 2, 3, 4, 5, 6, 7, 8, 0xffffffff, 10, 5, 12}, Apsr: Apsr{N: true}}},
+	}
+
+	test_execute(t, cases)
+}
+
+func TestIdentifySubRegT2(t *testing.T) {
+	cases := []IdentifyCase{
+		{instr: FetchedInstr32(0xeba02c01), instr_valid: true},  // sub.w r12, r0, r1, lsl #8
+		{instr: FetchedInstr32(0xebad0d02), instr_valid: true},  // sub.w sp, sp, r2
+		{instr: FetchedInstr32(0xebb34f14), instr_valid: false}, // cmp.w r3, r4, lsr #16
+	}
+
+	test_identify(t, cases, reflect.TypeOf(SubRegT2{}))
+}
+
+func TestDecodeSubReg32T2(t *testing.T) {
+	cases := []DecodeCase{
+		// sub.w r12, r0, r1, lsl #8
+		{instr: FetchedInstr32(0xeba02c01), decoded: SubRegT2{Rd: 12, Rm: 1, Rn: 0, Imm: 0, setflags: NEVER, Shift: Shift{srtype: SRType_LSL, amount: 8}}},
+		// sub.w sp, sp, r2
+		{instr: FetchedInstr32(0xebad0d02), decoded: SubRegT2{Rd: SP, Rm: 2, Rn: SP, Imm: 0, setflags: NEVER}},
+		// cmp.w r3, r4, lsr #16
+		{instr: FetchedInstr32(0xebb34f14), decoded: CmpRegT3{Rd: 0, Rm: 4, Rn: 3, Imm: 0, setflags: ALWAYS, Shift: Shift{srtype: SRType_LSR, amount: 16}}},
+	}
+
+	test_decode(t, cases, SubReg32T2)
+}
+
+func TestExecuteSubRegT2(t *testing.T) {
+	cases := []ExecuteCase{
+		// sub.w r12, r0, r1, lsl #8
+		{instr: SubRegT2{Rd: 12, Rm: 1, Rn: 0, Imm: 0, setflags: NEVER, Shift: Shift{srtype: SRType_LSL, amount: 8}},
+			regs:     Registers{r: GeneralRegs{0x1000, 0x1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12}},
+			expected: Registers{r: GeneralRegs{0x1000, 0x1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 0xf00}}},
+	}
+
+	test_execute(t, cases)
+}
+
+func TestExecuteCmpRegT3(t *testing.T) {
+	cases := []ExecuteCase{
+		// cmp.w r3, r4, lsr #16
+		{instr: CmpRegT3{Rd: 0, Rm: 4, Rn: 3, Imm: 0, setflags: ALWAYS, Shift: Shift{srtype: SRType_LSR, amount: 16}},
+			regs:     Registers{r: GeneralRegs{0, 1, 2, 0x1234, 0x12340000, 5, 6, 7, 8, 9, 10, 11, 12}},
+			expected: Registers{r: GeneralRegs{0, 1, 2, 0x1234, 0x12340000, 5, 6, 7, 8, 9, 10, 11, 12}, Apsr: Apsr{Z: true, C: true}}},
+	}
+
+	test_execute(t, cases)
+}
+
+func TestDecodeRsbReg32T1(t *testing.T) {
+	cases := []DecodeCase{
+		// rsb r5, r6, r7, lsl #1
+		{instr: FetchedInstr32(0xebc60547), decoded: RsbRegT1{Rd: 5, Rm: 7, Rn: 6, Imm: 0, setflags: NEVER, Shift: Shift{srtype: SRType_LSL, amount: 1}}},
+	}
+
+	test_decode(t, cases, RsbReg32T1)
+}
+
+func TestExecuteRsbRegT1(t *testing.T) {
+	cases := []ExecuteCase{
+		// rsb r5, r6, r7, lsl #1
+		{instr: RsbRegT1{Rd: 5, Rm: 7, Rn: 6, Imm: 0, setflags: NEVER, Shift: Shift{srtype: SRType_LSL, amount: 1}},
+			regs:     Registers{r: GeneralRegs{0, 1, 2, 3, 4, 5, 6, 10, 8, 9, 10, 11, 12}},
+			expected: Registers{r: GeneralRegs{0, 1, 2, 3, 4, 14, 6, 10, 8, 9, 10, 11, 12}}},
+	}
+
+	test_execute(t, cases)
+}
@@ -25,39 +25,130 @@ func AddWithCarry(x uint32, y uint32, carry_in uint8) (uint32, uint8, uint8) {
 }
 
 /* Perform addition instruction (reg), with shift, updating condition codes */
-func AddRegister(regs *Registers, instr InstrFields, shift Shift) {
-	shifted, _ := shift.Evaluate(regs.R(instr.Rm))
-	result, carry, overflow := AddWithCarry(regs.R(instr.Rn), shifted, 0)
+func AddRegister(cpu *CPU, instr InstrFields, shift Shift) {
+	shifted, _ := shift.EvaluateC(cpu.R(instr.Rm), cpu.Apsr.C)
+	result, carry, overflow := AddWithCarry(cpu.R(instr.Rn), shifted, 0)
 
-	add_update_condition_codes(regs, instr, result, carry, overflow)
+	add_update_condition_codes(cpu, instr, result, carry, overflow)
 }
 
 /* Perform addition instruction (imm), updating condition codes */
-func AddImmediate(regs *Registers, instr InstrFields) {
-	result, carry, overflow := AddWithCarry(regs.R(instr.Rn), instr.Imm, 0)
+func AddImmediate(cpu *CPU, instr InstrFields) {
+	result, carry, overflow := AddWithCarry(cpu.R(instr.Rn), instr.Imm, 0)
 
-	add_update_condition_codes(regs, instr, result, carry, overflow)
+	add_update_condition_codes(cpu, instr, result, carry, overflow)
 }
 
 /* Perform subtraction instruction (reg), with shift, updating condition codes */
-func SubRegister(regs *Registers, instr InstrFields, shift Shift) {
-	shifted, _ := shift.Evaluate(regs.R(instr.Rm))
-	result, carry, overflow := AddWithCarry(regs.R(instr.Rn), ^shifted, 1)
+func SubRegister(cpu *CPU, instr InstrFields, shift Shift) {
+	shifted, _ := shift.EvaluateC(cpu.R(instr.Rm), cpu.Apsr.C)
+	result, carry, overflow := AddWithCarry(cpu.R(instr.Rn), ^shifted, 1)
 
-	add_update_condition_codes(regs, instr, result, carry, overflow)
+	add_update_condition_codes(cpu, instr, result, carry, overflow)
+}
+
+/* Perform subtraction instruction (imm), updating condition codes */
+func SubImmediate(cpu *CPU, instr InstrFields) {
+	result, carry, overflow := AddWithCarry(cpu.R(instr.Rn), ^instr.Imm, 1)
+
+	add_update_condition_codes(cpu, instr, result, carry, overflow)
+}
+
+/* Perform add with carry instruction (reg), with shift, updating condition codes */
+func AdcRegister(cpu *CPU, instr InstrFields, shift Shift) {
+	shifted, _ := shift.EvaluateC(cpu.R(instr.Rm), cpu.Apsr.C)
+	result, carry, overflow := AddWithCarry(cpu.R(instr.Rn), shifted, booltou(cpu.Apsr.C))
+
+	add_update_condition_codes(cpu, instr, result, carry, overflow)
+}
+
+/* Perform subtract with carry instruction (reg), with shift, updating condition codes */
+func SbcRegister(cpu *CPU, instr InstrFields, shift Shift) {
+	shifted, _ := shift.EvaluateC(cpu.R(instr.Rm), cpu.Apsr.C)
+	result, carry, overflow := AddWithCarry(cpu.R(instr.Rn), ^shifted, booltou(cpu.Apsr.C))
+
+	add_update_condition_codes(cpu, instr, result, carry, overflow)
+}
+
+/* Perform reverse subtraction instruction (reg), with shift, updating condition codes */
+func RsbRegister(cpu *CPU, instr InstrFields, shift Shift) {
+	shifted, _ := shift.EvaluateC(cpu.R(instr.Rm), cpu.Apsr.C)
+	result, carry, overflow := AddWithCarry(^cpu.R(instr.Rn), shifted, 1)
+
+	add_update_condition_codes(cpu, instr, result, carry, overflow)
+}
+
+/* Perform reverse subtraction instruction (imm), updating condition codes */
+func RsbImmediate(cpu *CPU, instr InstrFields) {
+	result, carry, overflow := AddWithCarry(^cpu.R(instr.Rn), instr.Imm, 1)
+
+	add_update_condition_codes(cpu, instr, result, carry, overflow)
+}
+
+/* Perform add with carry instruction (imm), updating condition codes */
+func AdcImmediate(cpu *CPU, instr InstrFields) {
+	result, carry, overflow := AddWithCarry(cpu.R(instr.Rn), instr.Imm, booltou(cpu.Apsr.C))
+
+	add_update_condition_codes(cpu, instr, result, carry, overflow)
+}
+
+/* Perform subtract with carry instruction (imm), updating condition codes */
+func SbcImmediate(cpu *CPU, instr InstrFields) {
+	result, carry, overflow := AddWithCarry(cpu.R(instr.Rn), ^instr.Imm, booltou(cpu.Apsr.C))
+
+	add_update_condition_codes(cpu, instr, result, carry, overflow)
+}
+
+/* Perform compare instruction (reg), with shift, updating condition codes */
+func CmpRegister(cpu *CPU, instr InstrFields, shift Shift) {
+	shifted, _ := shift.EvaluateC(cpu.R(instr.Rm), cpu.Apsr.C)
+	result, carry, overflow := AddWithCarry(cpu.R(instr.Rn), ^shifted, 1)
+
+	compare_update_condition_codes(cpu, result, carry, overflow)
+}
+
+/* Perform compare instruction (imm), updating condition codes */
+func CmpImmediate(cpu *CPU, instr InstrFields) {
+	result, carry, overflow := AddWithCarry(cpu.R(instr.Rn), ^instr.Imm, 1)
+
+	compare_update_condition_codes(cpu, result, carry, overflow)
+}
+
+/* Perform compare negative instruction (reg), with shift, updating condition codes */
+func CmnRegister(cpu *CPU, instr InstrFields, shift Shift) {
+	shifted, _ := shift.EvaluateC(cpu.R(instr.Rm), cpu.Apsr.C)
+	result, carry, overflow := AddWithCarry(cpu.R(instr.Rn), shifted, 0)
+
+	compare_update_condition_codes(cpu, result, carry, overflow)
+}
+
+/* Perform compare negative instruction (imm), updating condition codes */
+func CmnImmediate(cpu *CPU, instr InstrFields) {
+	result, carry, overflow := AddWithCarry(cpu.R(instr.Rn), instr.Imm, 0)
+
+	compare_update_condition_codes(cpu, result, carry, overflow)
 }
 
 /* Update condition codes for ADD/SUB instruction */
-func add_update_condition_codes(regs *Registers, instr InstrFields, result uint32, carry uint8, overflow uint8) {
+func add_update_condition_codes(cpu *CPU, instr InstrFields, result uint32, carry uint8, overflow uint8) {
 	if instr.Rd == PC {
-		regs.ALUWritePC(result)
+		cpu.ALUWritePC(result)
 	} else {
-		regs.SetR(instr.Rd, result)
-		if instr.setflags.ShouldSetFlags(*regs) {
-			regs.Apsr.N = (result & 0x80000000) != 0
-			regs.Apsr.Z = (result) == 0
-			regs.Apsr.C = utobool(carry)
-			regs.Apsr.V = utobool(overflow)
+		cpu.SetR(instr.Rd, result)
+		if instr.setflags.ShouldSetFlags(cpu.Registers) {
+			cpu.Apsr.N = (result & 0x80000000) != 0
+			cpu.Apsr.Z = (result) == 0
+			cpu.Apsr.C = utobool(carry)
+			cpu.Apsr.V = utobool(overflow)
 		}
 	}
 }
+
+/* Update condition codes for CMP/CMN instruction, which always set flags
+ * and discard the result */
+func compare_update_condition_codes(cpu *CPU, result uint32, carry uint8, overflow uint8) {
+	cpu.Apsr.N = (result & 0x80000000) != 0
+	cpu.Apsr.Z = (result) == 0
+	cpu.Apsr.C = utobool(carry)
+	cpu.Apsr.V = utobool(overflow)
+}
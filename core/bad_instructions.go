@@ -2,16 +2,23 @@ package core
 
 type UnpredictableInstr InstrFields
 
-// Case to execute in the event of UNPREDICTABLE instruction behavior
-func (instr UnpredictableInstr) Execute(regs *Registers) {
-	// Do nothing, for now
-	return
+/* Case to execute in the event of UNPREDICTABLE instruction behavior.
+ * Treating it as UNDEFINED is permitted, and catches the bug rather than
+ * carrying on with whatever the hardware would have done. */
+func (instr UnpredictableInstr) Execute(cpu *CPU) {
+	cpu.raise(UFSR_UNDEFINSTR)
+}
+
+func (instr UnpredictableInstr) String() string {
+	return "<unpredictable>"
 }
 
 type UndefinedInstr InstrFields
 
-// Placeholder UNDEFINED instruction
-func (instr UndefinedInstr) Execute(regs *Registers) {
-	// Do nothing, for now
-	return
+func (instr UndefinedInstr) Execute(cpu *CPU) {
+	cpu.raise(UFSR_UNDEFINSTR)
+}
+
+func (instr UndefinedInstr) String() string {
+	return "udf"
 }
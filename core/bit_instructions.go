@@ -0,0 +1,205 @@
+package core
+
+import (
+	"fmt"
+	"math/bits"
+)
+
+/* Extract the fields of the 32-bit CLZ, RBIT and REV encodings, which
+ * hold Rm twice. Both copies must match.
+ * ARM ARM A5.3.15 */
+func decode_bit_operation(raw_instr uint32) (InstrFields, bool) {
+	Rd := RegIndex((raw_instr >> 8) & 0xf)
+	Rm := RegIndex(raw_instr & 0xf)
+	Rm2 := RegIndex((raw_instr >> 16) & 0xf)
+
+	fields := InstrFields{Rd: Rd, Rm: Rm, setflags: NEVER}
+
+	return fields, Rm == Rm2 && !BadReg(Rd) && !BadReg(Rm)
+}
+
+/* Reverse the bytes in each halfword */
+func reverse_halfwords(x uint32) uint32 {
+	return ((x & 0x00ff00ff) << 8) | ((x >> 8) & 0x00ff00ff)
+}
+
+/* Reverse the bytes of the low halfword, and sign extend */
+func reverse_signed_halfword(x uint32) uint32 {
+	return SignExtend(((x&0xff)<<8)|((x>>8)&0xff), 16)
+}
+
+/* CLZ - Count Leading Zeros
+ * ARM ARM A7.7.24
+ * Encoding T1 */
+type ClzT1 InstrFields
+
+func Clz32T1(instr FetchedInstr) DecodedInstr {
+	fields, ok := decode_bit_operation(instr.Uint32())
+	if !ok {
+		return UnpredictableInstr{}
+	}
+
+	return ClzT1(fields)
+}
+
+func (instr ClzT1) Execute(cpu *CPU) {
+	cpu.SetR(instr.Rd, uint32(bits.LeadingZeros32(cpu.R(instr.Rm))))
+}
+
+func (instr ClzT1) String() string {
+	return fmt.Sprintf("clz %s, %s", instr.Rd, instr.Rm)
+}
+
+/* RBIT - Reverse Bits
+ * ARM ARM A7.7.110
+ * Encoding T1 */
+type RbitT1 InstrFields
+
+func Rbit32T1(instr FetchedInstr) DecodedInstr {
+	fields, ok := decode_bit_operation(instr.Uint32())
+	if !ok {
+		return UnpredictableInstr{}
+	}
+
+	return RbitT1(fields)
+}
+
+func (instr RbitT1) Execute(cpu *CPU) {
+	cpu.SetR(instr.Rd, bits.Reverse32(cpu.R(instr.Rm)))
+}
+
+func (instr RbitT1) String() string {
+	return fmt.Sprintf("rbit %s, %s", instr.Rd, instr.Rm)
+}
+
+/* REV - Byte-Reverse Word
+ * ARM ARM A7.7.111
+ * Encoding T1 */
+type RevT1 InstrFields
+
+func Rev16T1(instr FetchedInstr) DecodedInstr {
+	raw_instr := instr.Uint32()
+
+	Rd := RegIndex(raw_instr & 0x7)
+	Rm := RegIndex((raw_instr >> 3) & 0x7)
+
+	return RevT1{Rd: Rd, Rm: Rm, setflags: NEVER}
+}
+
+func (instr RevT1) Execute(cpu *CPU) {
+	cpu.SetR(instr.Rd, bits.ReverseBytes32(cpu.R(instr.Rm)))
+}
+
+func (instr RevT1) String() string {
+	return fmt.Sprintf("rev %s, %s", instr.Rd, instr.Rm)
+}
+
+/* REV - Byte-Reverse Word
+ * ARM ARM A7.7.111
+ * Encoding T2 */
+type RevT2 InstrFields
+
+func Rev32T2(instr FetchedInstr) DecodedInstr {
+	fields, ok := decode_bit_operation(instr.Uint32())
+	if !ok {
+		return UnpredictableInstr{}
+	}
+
+	return RevT2(fields)
+}
+
+func (instr RevT2) Execute(cpu *CPU) {
+	cpu.SetR(instr.Rd, bits.ReverseBytes32(cpu.R(instr.Rm)))
+}
+
+func (instr RevT2) String() string {
+	return fmt.Sprintf("rev.w %s, %s", instr.Rd, instr.Rm)
+}
+
+/* REV16 - Byte-Reverse Packed Halfword
+ * ARM ARM A7.7.112
+ * Encoding T1 */
+type RevPackedT1 InstrFields
+
+func RevPacked16T1(instr FetchedInstr) DecodedInstr {
+	raw_instr := instr.Uint32()
+
+	Rd := RegIndex(raw_instr & 0x7)
+	Rm := RegIndex((raw_instr >> 3) & 0x7)
+
+	return RevPackedT1{Rd: Rd, Rm: Rm, setflags: NEVER}
+}
+
+func (instr RevPackedT1) Execute(cpu *CPU) {
+	cpu.SetR(instr.Rd, reverse_halfwords(cpu.R(instr.Rm)))
+}
+
+func (instr RevPackedT1) String() string {
+	return fmt.Sprintf("rev16 %s, %s", instr.Rd, instr.Rm)
+}
+
+/* REV16 - Byte-Reverse Packed Halfword
+ * ARM ARM A7.7.112
+ * Encoding T2 */
+type RevPackedT2 InstrFields
+
+func RevPacked32T2(instr FetchedInstr) DecodedInstr {
+	fields, ok := decode_bit_operation(instr.Uint32())
+	if !ok {
+		return UnpredictableInstr{}
+	}
+
+	return RevPackedT2(fields)
+}
+
+func (instr RevPackedT2) Execute(cpu *CPU) {
+	cpu.SetR(instr.Rd, reverse_halfwords(cpu.R(instr.Rm)))
+}
+
+func (instr RevPackedT2) String() string {
+	return fmt.Sprintf("rev16.w %s, %s", instr.Rd, instr.Rm)
+}
+
+/* REVSH - Byte-Reverse Signed Halfword
+ * ARM ARM A7.7.113
+ * Encoding T1 */
+type RevshT1 InstrFields
+
+func Revsh16T1(instr FetchedInstr) DecodedInstr {
+	raw_instr := instr.Uint32()
+
+	Rd := RegIndex(raw_instr & 0x7)
+	Rm := RegIndex((raw_instr >> 3) & 0x7)
+
+	return RevshT1{Rd: Rd, Rm: Rm, setflags: NEVER}
+}
+
+func (instr RevshT1) Execute(cpu *CPU) {
+	cpu.SetR(instr.Rd, reverse_signed_halfword(cpu.R(instr.Rm)))
+}
+
+func (instr RevshT1) String() string {
+	return fmt.Sprintf("revsh %s, %s", instr.Rd, instr.Rm)
+}
+
+/* REVSH - Byte-Reverse Signed Halfword
+ * ARM ARM A7.7.113
+ * Encoding T2 */
+type RevshT2 InstrFields
+
+func Revsh32T2(instr FetchedInstr) DecodedInstr {
+	fields, ok := decode_bit_operation(instr.Uint32())
+	if !ok {
+		return UnpredictableInstr{}
+	}
+
+	return RevshT2(fields)
+}
+
+func (instr RevshT2) Execute(cpu *CPU) {
+	cpu.SetR(instr.Rd, reverse_signed_halfword(cpu.R(instr.Rm)))
+}
+
+func (instr RevshT2) String() string {
+	return fmt.Sprintf("revsh.w %s, %s", instr.Rd, instr.Rm)
+}
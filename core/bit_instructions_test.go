@@ -0,0 +1,103 @@
+package core
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestIdentifyRevT2(t *testing.T) {
+	cases := []IdentifyCase{
+		{instr: FetchedInstr32(0xfa98f088), instr_valid: true},  // rev.w r0, r8
+		{instr: FetchedInstr32(0xfa9af09a), instr_valid: false}, // rev16.w r0, r10
+		{instr: FetchedInstr32(0xfa91f0a1), instr_valid: false}, // rbit r0, r1
+	}
+
+	test_identify(t, cases, reflect.TypeOf(RevT2{}))
+}
+
+func TestDecodeBitOperation16(t *testing.T) {
+	cases := []DecodeCase{
+		// rev r0, r1
+		{instr: FetchedInstr16(0xba08), decoded: RevT1{Rd: 0, Rm: 1, setflags: NEVER}},
+	}
+
+	test_decode(t, cases, Rev16T1)
+
+	cases = []DecodeCase{
+		// rev16 r0, r1
+		{instr: FetchedInstr16(0xba48), decoded: RevPackedT1{Rd: 0, Rm: 1, setflags: NEVER}},
+	}
+
+	test_decode(t, cases, RevPacked16T1)
+
+	cases = []DecodeCase{
+		// revsh r0, r1
+		{instr: FetchedInstr16(0xbac8), decoded: RevshT1{Rd: 0, Rm: 1, setflags: NEVER}},
+	}
+
+	test_decode(t, cases, Revsh16T1)
+}
+
+func TestDecodeBitOperation32(t *testing.T) {
+	cases := []DecodeCase{
+		// clz r0, r1
+		{instr: FetchedInstr32(0xfab1f081), decoded: ClzT1{Rd: 0, Rm: 1, setflags: NEVER}},
+		// clz r0, r1 with mismatched Rm
+		{instr: FetchedInstr32(0xfab2f081), decoded: UnpredictableInstr{}},
+		// clz r0, pc
+		{instr: FetchedInstr32(0xfabff08f), decoded: UnpredictableInstr{}},
+	}
+
+	test_decode(t, cases, Clz32T1)
+
+	cases = []DecodeCase{
+		// rbit r0, r1
+		{instr: FetchedInstr32(0xfa91f0a1), decoded: RbitT1{Rd: 0, Rm: 1, setflags: NEVER}},
+	}
+
+	test_decode(t, cases, Rbit32T1)
+
+	cases = []DecodeCase{
+		// revsh.w r0, r9
+		{instr: FetchedInstr32(0xfa99f0b9), decoded: RevshT2{Rd: 0, Rm: 9, setflags: NEVER}},
+		// revsh.w sp, r9
+		{instr: FetchedInstr32(0xfa99fdb9), decoded: UnpredictableInstr{}},
+	}
+
+	test_decode(t, cases, Revsh32T2)
+}
+
+func TestExecuteBitOperation(t *testing.T) {
+	cases := []ExecuteCase{
+		// clz r0, r1
+		{instr: ClzT1{Rd: 0, Rm: 1, setflags: NEVER},
+			regs:     Registers{r: GeneralRegs{0, 0x00012345}},
+			expected: Registers{r: GeneralRegs{15, 0x00012345}}},
+		// clz r0, r1
+		{instr: ClzT1{Rd: 0, Rm: 1, setflags: NEVER},
+			regs:     Registers{r: GeneralRegs{0, 0}},
+			expected: Registers{r: GeneralRegs{32, 0}}},
+		// rbit r0, r1
+		{instr: RbitT1{Rd: 0, Rm: 1, setflags: NEVER},
+			regs:     Registers{r: GeneralRegs{0, 0x12345678}},
+			expected: Registers{r: GeneralRegs{0x1e6a2c48, 0x12345678}}},
+		// rev r0, r1
+		{instr: RevT1{Rd: 0, Rm: 1, setflags: NEVER},
+			regs:     Registers{r: GeneralRegs{0, 0x12345678}},
+			expected: Registers{r: GeneralRegs{0x78563412, 0x12345678}}},
+		// rev16.w r0, r1
+		{instr: RevPackedT2{Rd: 0, Rm: 1, setflags: NEVER},
+			regs:     Registers{r: GeneralRegs{0, 0x12345678}},
+			expected: Registers{r: GeneralRegs{0x34127856, 0x12345678}}},
+		// revsh r0, r1
+		{instr: RevshT1{Rd: 0, Rm: 1, setflags: NEVER},
+			regs:     Registers{r: GeneralRegs{0, 0x12345680}},
+			expected: Registers{r: GeneralRegs{0xffff8056, 0x12345680}}},
+		// revsh r0, r1
+		{instr: RevshT1{Rd: 0, Rm: 1, setflags: NEVER},
+			regs:     Registers{r: GeneralRegs{0, 0x12344056}},
+			expected: Registers{r: GeneralRegs{0x5640, 0x12344056}}},
+	}
+
+	test_execute(t, cases)
+}
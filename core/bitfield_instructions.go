@@ -0,0 +1,145 @@
+package core
+
+import "fmt"
+
+/* Extract the fields common to the bitfield instructions. msb holds
+ * msbit for BFI and BFC and widthminus1 for SBFX and UBFX.
+ * ARM ARM A5.3.3 */
+func decode_bitfield(raw_instr uint32) (Rd RegIndex, Rn RegIndex, lsb uint8, msb uint8) {
+	Rd = RegIndex((raw_instr >> 8) & 0xf)
+	Rn = RegIndex((raw_instr >> 16) & 0xf)
+	msb = uint8(raw_instr & 0x1f)
+
+	imm3 := uint8((raw_instr >> 12) & 0x7)
+	imm2 := uint8((raw_instr >> 6) & 0x3)
+	lsb = (imm3 << 2) | imm2
+
+	return Rd, Rn, lsb, msb
+}
+
+/* Mask of width bits starting at lsb */
+func bitfield_mask(lsb uint8, width uint8) uint32 {
+	return uint32((uint64(1)<<width)-1) << lsb
+}
+
+/* BFC
+ * ARM ARM A7.7.13
+ * Encoding T1 */
+type BfcT1 BitfieldFields
+
+func Bfc32T1(instr FetchedInstr) DecodedInstr {
+	Rd, _, lsb, msb := decode_bitfield(instr.Uint32())
+
+	if !plain_imm_sbz(instr.Uint32()) {
+		return UnpredictableInstr{}
+	}
+
+	if BadReg(Rd) || msb < lsb {
+		return UnpredictableInstr{Rd: Rd, Rm: 0, Rn: 0, Imm: 0, setflags: NEVER}
+	}
+
+	return BfcT1{Rd: Rd, Rn: 0, Lsb: lsb, Width: msb - lsb + 1}
+}
+
+func (instr BfcT1) Execute(cpu *CPU) {
+	mask := bitfield_mask(instr.Lsb, instr.Width)
+
+	cpu.SetR(instr.Rd, cpu.R(instr.Rd)&^mask)
+}
+
+func (instr BfcT1) String() string {
+	return fmt.Sprintf("bfc %s, #%d, #%d", instr.Rd, instr.Lsb, instr.Width)
+}
+
+/* BFI
+ * ARM ARM A7.7.14
+ * Encoding T1 */
+type BfiT1 BitfieldFields
+
+func Bfi32T1(instr FetchedInstr) DecodedInstr {
+	Rd, Rn, lsb, msb := decode_bitfield(instr.Uint32())
+
+	if !plain_imm_sbz(instr.Uint32()) {
+		return UnpredictableInstr{}
+	}
+
+	if Rn == PC {
+		return Bfc32T1(instr)
+	}
+
+	if BadReg(Rd) || Rn == SP || msb < lsb {
+		return UnpredictableInstr{Rd: Rd, Rm: 0, Rn: Rn, Imm: 0, setflags: NEVER}
+	}
+
+	return BfiT1{Rd: Rd, Rn: Rn, Lsb: lsb, Width: msb - lsb + 1}
+}
+
+func (instr BfiT1) Execute(cpu *CPU) {
+	mask := bitfield_mask(instr.Lsb, instr.Width)
+	field := (cpu.R(instr.Rn) << instr.Lsb) & mask
+
+	cpu.SetR(instr.Rd, (cpu.R(instr.Rd)&^mask)|field)
+}
+
+func (instr BfiT1) String() string {
+	return fmt.Sprintf("bfi %s, %s, #%d, #%d", instr.Rd, instr.Rn, instr.Lsb, instr.Width)
+}
+
+/* SBFX
+ * ARM ARM A7.7.124
+ * Encoding T1 */
+type SbfxT1 BitfieldFields
+
+func Sbfx32T1(instr FetchedInstr) DecodedInstr {
+	Rd, Rn, lsb, widthminus1 := decode_bitfield(instr.Uint32())
+
+	if !plain_imm_sbz(instr.Uint32()) {
+		return UnpredictableInstr{}
+	}
+
+	if BadReg(Rd) || BadReg(Rn) || lsb+widthminus1 > 31 {
+		return UnpredictableInstr{Rd: Rd, Rm: 0, Rn: Rn, Imm: 0, setflags: NEVER}
+	}
+
+	return SbfxT1{Rd: Rd, Rn: Rn, Lsb: lsb, Width: widthminus1 + 1}
+}
+
+func (instr SbfxT1) Execute(cpu *CPU) {
+	/* Move the field to the top of the word, then sign extend it down */
+	field := int32(cpu.R(instr.Rn) << (32 - instr.Lsb - instr.Width))
+
+	cpu.SetR(instr.Rd, uint32(field>>(32-instr.Width)))
+}
+
+func (instr SbfxT1) String() string {
+	return fmt.Sprintf("sbfx %s, %s, #%d, #%d", instr.Rd, instr.Rn, instr.Lsb, instr.Width)
+}
+
+/* UBFX
+ * ARM ARM A7.7.190
+ * Encoding T1 */
+type UbfxT1 BitfieldFields
+
+func Ubfx32T1(instr FetchedInstr) DecodedInstr {
+	Rd, Rn, lsb, widthminus1 := decode_bitfield(instr.Uint32())
+
+	if !plain_imm_sbz(instr.Uint32()) {
+		return UnpredictableInstr{}
+	}
+
+	if BadReg(Rd) || BadReg(Rn) || lsb+widthminus1 > 31 {
+		return UnpredictableInstr{Rd: Rd, Rm: 0, Rn: Rn, Imm: 0, setflags: NEVER}
+	}
+
+	return UbfxT1{Rd: Rd, Rn: Rn, Lsb: lsb, Width: widthminus1 + 1}
+}
+
+func (instr UbfxT1) Execute(cpu *CPU) {
+	mask := bitfield_mask(0, instr.Width)
+
+	cpu.SetR(instr.Rd, (cpu.R(instr.Rn)>>instr.Lsb)&mask)
+}
+
+func (instr UbfxT1) String() string {
+	return fmt.Sprintf("ubfx %s, %s, #%d, #%d", instr.Rd, instr.Rn, instr.Lsb, instr.Width)
+}
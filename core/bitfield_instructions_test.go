@@ -0,0 +1,143 @@
+package core
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestIdentifyBfiT1(t *testing.T) {
+	cases := []IdentifyCase{
+		{instr: FetchedInstr32(0xf361100b), instr_valid: true},  // bfi r0, r1, #4, #8
+		{instr: FetchedInstr32(0xf36f020f), instr_valid: false}, // bfc r2, #0, #16
+		{instr: FetchedInstr32(0xf3c42303), instr_valid: false}, // ubfx r3, r4, #8, #4
+	}
+
+	test_identify(t, cases, reflect.TypeOf(BfiT1{}))
+}
+
+func TestDecodeBfi32T1(t *testing.T) {
+	cases := []DecodeCase{
+		// bfi r0, r1, #4, #8
+		{instr: FetchedInstr32(0xf361100b), decoded: BfiT1{Rd: 0, Rn: 1, Lsb: 4, Width: 8}},
+		// bfc r2, #0, #16
+		{instr: FetchedInstr32(0xf36f020f), decoded: BfcT1{Rd: 2, Rn: 0, Lsb: 0, Width: 16}},
+		// bfi r0, r1, #4, msb < lsb
+		{instr: FetchedInstr32(0xf3611002), decoded: UnpredictableInstr{Rd: 0, Rm: 0, Rn: 1, Imm: 0, setflags: NEVER}},
+		// bfi r0, r1, #4, #8, bit 26 set
+		{instr: FetchedInstr32(0xf761100b), decoded: UnpredictableInstr{}},
+	}
+
+	test_decode(t, cases, Bfi32T1)
+}
+
+func TestExecuteBfiT1(t *testing.T) {
+	cases := []ExecuteCase{
+		// bfi r0, r1, #4, #8
+		{instr: BfiT1{Rd: 0, Rn: 1, Lsb: 4, Width: 8},
+			regs:     Registers{r: GeneralRegs{0xffffffff, 0x12345600, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12}},
+			expected: Registers{r: GeneralRegs{0xfffff00f, 0x12345600, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12}}},
+		// bfi r0, r1, #0, #32
+		{instr: BfiT1{Rd: 0, Rn: 1, Lsb: 0, Width: 32},
+			regs:     Registers{r: GeneralRegs{0xffffffff, 0x12345678, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12}},
+			expected: Registers{r: GeneralRegs{0x12345678, 0x12345678, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12}}},
+	}
+
+	test_execute(t, cases)
+}
+
+func TestIdentifyBfcT1(t *testing.T) {
+	cases := []IdentifyCase{
+		{instr: FetchedInstr32(0xf36f020f), instr_valid: true},  // bfc r2, #0, #16
+		{instr: FetchedInstr32(0xf361100b), instr_valid: false}, // bfi r0, r1, #4, #8
+	}
+
+	test_identify(t, cases, reflect.TypeOf(BfcT1{}))
+}
+
+func TestDecodeBfc32T1(t *testing.T) {
+	cases := []DecodeCase{
+		// bfc r2, #0, #16
+		{instr: FetchedInstr32(0xf36f020f), decoded: BfcT1{Rd: 2, Rn: 0, Lsb: 0, Width: 16}},
+	}
+
+	test_decode(t, cases, Bfc32T1)
+}
+
+func TestExecuteBfcT1(t *testing.T) {
+	cases := []ExecuteCase{
+		// bfc r2, #0, #16
+		{instr: BfcT1{Rd: 2, Rn: 0, Lsb: 0, Width: 16},
+			regs:     Registers{r: GeneralRegs{0, 1, 0x12345678, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12}},
+			expected: Registers{r: GeneralRegs{0, 1, 0x12340000, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12}}},
+	}
+
+	test_execute(t, cases)
+}
+
+func TestIdentifyUbfxT1(t *testing.T) {
+	cases := []IdentifyCase{
+		{instr: FetchedInstr32(0xf3c42303), instr_valid: true},  // ubfx r3, r4, #8, #4
+		{instr: FetchedInstr32(0xf34675c0), instr_valid: false}, // sbfx r5, r6, #31, #1
+	}
+
+	test_identify(t, cases, reflect.TypeOf(UbfxT1{}))
+}
+
+func TestDecodeUbfx32T1(t *testing.T) {
+	cases := []DecodeCase{
+		// ubfx r3, r4, #8, #4
+		{instr: FetchedInstr32(0xf3c42303), decoded: UbfxT1{Rd: 3, Rn: 4, Lsb: 8, Width: 4}},
+		// ubfx r3, r4, #31, #2
+		{instr: FetchedInstr32(0xf3c473c1), decoded: UnpredictableInstr{Rd: 3, Rm: 0, Rn: 4, Imm: 0, setflags: NEVER}},
+	}
+
+	test_decode(t, cases, Ubfx32T1)
+}
+
+func TestExecuteUbfxT1(t *testing.T) {
+	cases := []ExecuteCase{
+		// ubfx r3, r4, #8, #4
+		{instr: UbfxT1{Rd: 3, Rn: 4, Lsb: 8, Width: 4},
+			regs:     Registers{r: GeneralRegs{0, 1, 2, 3, 0xfffffaff, 5, 6, 7, 8, 9, 10, 11, 12}},
+			expected: Registers{r: GeneralRegs{0, 1, 2, 0xa, 0xfffffaff, 5, 6, 7, 8, 9, 10, 11, 12}}},
+	}
+
+	test_execute(t, cases)
+}
+
+func TestIdentifySbfxT1(t *testing.T) {
+	cases := []IdentifyCase{
+		{instr: FetchedInstr32(0xf34675c0), instr_valid: true},  // sbfx r5, r6, #31, #1
+		{instr: FetchedInstr32(0xf3c42303), instr_valid: false}, // ubfx r3, r4, #8, #4
+	}
+
+	test_identify(t, cases, reflect.TypeOf(SbfxT1{}))
+}
+
+func TestDecodeSbfx32T1(t *testing.T) {
+	cases := []DecodeCase{
+		// sbfx r5, r6, #31, #1
+		{instr: FetchedInstr32(0xf34675c0), decoded: SbfxT1{Rd: 5, Rn: 6, Lsb: 31, Width: 1}},
+	}
+
+	test_decode(t, cases, Sbfx32T1)
+}
+
+func TestExecuteSbfxT1(t *testing.T) {
+	cases := []ExecuteCase{
+		// sbfx r5, r6, #31, #1
+		{instr: SbfxT1{Rd: 5, Rn: 6, Lsb: 31, Width: 1},
+			regs:     Registers{r: GeneralRegs{0, 1, 2, 3, 4, 5, 0x80000000, 7, 8, 9, 10, 11, 12}},
+			expected: Registers{r: GeneralRegs{0, 1, 2, 3, 4, 0xffffffff, 0x80000000, 7, 8, 9, 10, 11, 12}}},
+		// sbfx r5, r6, #4, #8
+		{instr: SbfxT1{Rd: 5, Rn: 6, Lsb: 4, Width: 8},
+			regs:     Registers{r: GeneralRegs{0, 1, 2, 3, 4, 5, 0x7f0, 7, 8, 9, 10, 11, 12}},
+			expected: Registers{r: GeneralRegs{0, 1, 2, 3, 4, 0x7f, 0x7f0, 7, 8, 9, 10, 11, 12}}},
+		// sbfx r5, r6, #0, #32
+		{instr: SbfxT1{Rd: 5, Rn: 6, Lsb: 0, Width: 32},
+			regs:     Registers{r: GeneralRegs{0, 1, 2, 3, 4, 5, 0x87654321, 7, 8, 9, 10, 11, 12}},
+			expected: Registers{r: GeneralRegs{0, 1, 2, 3, 4, 0x87654321, 0x87654321, 7, 8, 9, 10, 11, 12}}},
+	}
+
+	test_execute(t, cases)
+}
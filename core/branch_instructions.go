@@ -0,0 +1,327 @@
+package core
+
+import "fmt"
+
+/* B
+ * ARM ARM A7.7.12
+ * Encoding T1 */
+type BT1 BranchFields
+
+func B16T1(instr FetchedInstr) DecodedInstr {
+	raw_instr := instr.Uint32()
+
+	cond := Condition((raw_instr >> 8) & 0xf)
+	imm32 := SignExtend((raw_instr&0xff)<<1, 9)
+
+	return BT1{Cond: cond, Imm: imm32}
+}
+
+func (instr BT1) Execute(cpu *CPU) {
+	if cpu.InITBlock() {
+		// UNPREDICTABLE
+		return
+	}
+
+	BranchImmediate(cpu, BranchFields(instr))
+}
+
+func (instr BT1) Condition() Condition {
+	return instr.Cond
+}
+
+func (instr BT1) String() string {
+	return fmt.Sprintf("b%s #%d", instr.Cond, int32(instr.Imm))
+}
+
+func (instr BT1) Disassemble(addr uint32) string {
+	return fmt.Sprintf("b%s %#x", instr.Cond, branch_target(addr, instr.Imm))
+}
+
+/* B
+ * ARM ARM A7.7.12
+ * Encoding T2 */
+type BT2 BranchFields
+
+func B16T2(instr FetchedInstr) DecodedInstr {
+	imm32 := SignExtend((instr.Uint32()&0x7ff)<<1, 12)
+
+	return BT2{Cond: COND_AL, Imm: imm32}
+}
+
+func (instr BT2) Execute(cpu *CPU) {
+	if branch_in_it_block(cpu) {
+		// UNPREDICTABLE
+		return
+	}
+
+	BranchImmediate(cpu, BranchFields(instr))
+}
+
+func (instr BT2) String() string {
+	return fmt.Sprintf("b #%d", int32(instr.Imm))
+}
+
+func (instr BT2) Disassemble(addr uint32) string {
+	return fmt.Sprintf("b %#x", branch_target(addr, instr.Imm))
+}
+
+/* B
+ * ARM ARM A7.7.12
+ * Encoding T3 */
+type BT3 BranchFields
+
+func B32T3(instr FetchedInstr) DecodedInstr {
+	raw_instr := instr.Uint32()
+
+	cond := Condition((raw_instr >> 22) & 0xf)
+
+	return BT3{Cond: cond, Imm: decode_cond_branch_offset(raw_instr)}
+}
+
+func (instr BT3) Execute(cpu *CPU) {
+	if cpu.InITBlock() {
+		// UNPREDICTABLE
+		return
+	}
+
+	BranchImmediate(cpu, BranchFields(instr))
+}
+
+func (instr BT3) Condition() Condition {
+	return instr.Cond
+}
+
+func (instr BT3) String() string {
+	return fmt.Sprintf("b%s.w #%d", instr.Cond, int32(instr.Imm))
+}
+
+func (instr BT3) Disassemble(addr uint32) string {
+	return fmt.Sprintf("b%s.w %#x", instr.Cond, branch_target(addr, instr.Imm))
+}
+
+/* B
+ * ARM ARM A7.7.12
+ * Encoding T4 */
+type BT4 BranchFields
+
+func B32T4(instr FetchedInstr) DecodedInstr {
+	return BT4{Cond: COND_AL, Imm: decode_branch_offset(instr.Uint32())}
+}
+
+func (instr BT4) Execute(cpu *CPU) {
+	if branch_in_it_block(cpu) {
+		// UNPREDICTABLE
+		return
+	}
+
+	BranchImmediate(cpu, BranchFields(instr))
+}
+
+func (instr BT4) String() string {
+	return fmt.Sprintf("b.w #%d", int32(instr.Imm))
+}
+
+func (instr BT4) Disassemble(addr uint32) string {
+	return fmt.Sprintf("b.w %#x", branch_target(addr, instr.Imm))
+}
+
+/* BL
+ * ARM ARM A7.7.18
+ * Encoding T1 */
+type BlT1 BranchFields
+
+func Bl32T1(instr FetchedInstr) DecodedInstr {
+	return BlT1{Cond: COND_AL, Imm: decode_branch_offset(instr.Uint32())}
+}
+
+func (instr BlT1) Execute(cpu *CPU) {
+	if branch_in_it_block(cpu) {
+		// UNPREDICTABLE
+		return
+	}
+
+	/* PC is already the address of the next instruction */
+	pc := cpu.Pc()
+	cpu.SetR(LR, pc|0x1)
+	cpu.BranchWritePC(pc + instr.Imm)
+}
+
+func (instr BlT1) String() string {
+	return fmt.Sprintf("bl #%d", int32(instr.Imm))
+}
+
+func (instr BlT1) Disassemble(addr uint32) string {
+	return fmt.Sprintf("bl %#x", branch_target(addr, instr.Imm))
+}
+
+/* BX
+ * ARM ARM A7.7.20
+ * Encoding T1 */
+type BxT1 BranchFields
+
+func Bx16T1(instr FetchedInstr) DecodedInstr {
+	Rm := RegIndex((instr.Uint32() >> 3) & 0xf)
+
+	return BxT1{Cond: COND_AL, Rm: Rm}
+}
+
+func (instr BxT1) Execute(cpu *CPU) {
+	if branch_in_it_block(cpu) {
+		// UNPREDICTABLE
+		return
+	}
+
+	/* An even target clears the T bit, faulting on the next instruction */
+	cpu.BXWritePC(cpu.R(instr.Rm))
+}
+
+func (instr BxT1) String() string {
+	return fmt.Sprintf("bx %s", instr.Rm)
+}
+
+/* BLX (register)
+ * ARM ARM A7.7.19
+ * Encoding T1 */
+type BlxRegT1 BranchFields
+
+func BlxReg16T1(instr FetchedInstr) DecodedInstr {
+	Rm := RegIndex((instr.Uint32() >> 3) & 0xf)
+
+	if Rm == PC {
+		return UnpredictableInstr{}
+	}
+
+	return BlxRegT1{Cond: COND_AL, Rm: Rm}
+}
+
+func (instr BlxRegT1) Execute(cpu *CPU) {
+	if branch_in_it_block(cpu) {
+		// UNPREDICTABLE
+		return
+	}
+
+	target := cpu.R(instr.Rm)
+	next_instr_addr := cpu.Pc() - 2
+
+	cpu.SetR(LR, next_instr_addr|0x1)
+	cpu.BXWritePC(target)
+}
+
+func (instr BlxRegT1) String() string {
+	return fmt.Sprintf("blx %s", instr.Rm)
+}
+
+/* CBZ
+ * ARM ARM A7.7.21
+ * Encoding T1 */
+type CbzT1 BranchFields
+
+func decode_cbz(raw_instr uint32) BranchFields {
+	Rn := RegIndex(raw_instr & 0x7)
+	i := (raw_instr >> 9) & 0x1
+	imm5 := (raw_instr >> 3) & 0x1f
+
+	return BranchFields{Cond: COND_AL, Imm: (i << 6) | (imm5 << 1), Rn: Rn}
+}
+
+func Cbz16T1(instr FetchedInstr) DecodedInstr {
+	return CbzT1(decode_cbz(instr.Uint32()))
+}
+
+func (instr CbzT1) Execute(cpu *CPU) {
+	if cpu.InITBlock() {
+		// UNPREDICTABLE
+		return
+	}
+
+	if cpu.R(instr.Rn) == 0 {
+		cpu.BranchWritePC(cpu.Pc() + instr.Imm)
+	}
+}
+
+func (instr CbzT1) String() string {
+	return fmt.Sprintf("cbz %s, #%d", instr.Rn, instr.Imm)
+}
+
+func (instr CbzT1) Disassemble(addr uint32) string {
+	return fmt.Sprintf("cbz %s, %#x", instr.Rn, branch_target(addr, instr.Imm))
+}
+
+/* CBNZ
+ * ARM ARM A7.7.21
+ * Encoding T1 */
+type CbnzT1 BranchFields
+
+func Cbnz16T1(instr FetchedInstr) DecodedInstr {
+	return CbnzT1(decode_cbz(instr.Uint32()))
+}
+
+func (instr CbnzT1) Execute(cpu *CPU) {
+	if cpu.InITBlock() {
+		// UNPREDICTABLE
+		return
+	}
+
+	if cpu.R(instr.Rn) != 0 {
+		cpu.BranchWritePC(cpu.Pc() + instr.Imm)
+	}
+}
+
+func (instr CbnzT1) String() string {
+	return fmt.Sprintf("cbnz %s, #%d", instr.Rn, instr.Imm)
+}
+
+func (instr CbnzT1) Disassemble(addr uint32) string {
+	return fmt.Sprintf("cbnz %s, %#x", instr.Rn, branch_target(addr, instr.Imm))
+}
+
+/* TBB
+ * ARM ARM A7.7.182
+ * Encoding T1 */
+type TbbT1 BranchFields
+
+func decode_table_branch(raw_instr uint32) (BranchFields, bool) {
+	Rn := RegIndex((raw_instr >> 16) & 0xf)
+	Rm := RegIndex(raw_instr & 0xf)
+
+	return BranchFields{Cond: COND_AL, Rn: Rn, Rm: Rm}, Rn == SP || BadReg(Rm)
+}
+
+func Tbb32T1(instr FetchedInstr) DecodedInstr {
+	fields, unpredictable := decode_table_branch(instr.Uint32())
+	if unpredictable {
+		return UnpredictableInstr{}
+	}
+
+	return TbbT1(fields)
+}
+
+func (instr TbbT1) Execute(cpu *CPU) {
+	TableBranch(cpu, BranchFields(instr), 1)
+}
+
+func (instr TbbT1) String() string {
+	return fmt.Sprintf("tbb [%s, %s]", instr.Rn, instr.Rm)
+}
+
+/* TBH
+ * ARM ARM A7.7.182
+ * Encoding T1 */
+type TbhT1 BranchFields
+
+func Tbh32T1(instr FetchedInstr) DecodedInstr {
+	fields, unpredictable := decode_table_branch(instr.Uint32())
+	if unpredictable {
+		return UnpredictableInstr{}
+	}
+
+	return TbhT1(fields)
+}
+
+func (instr TbhT1) Execute(cpu *CPU) {
+	TableBranch(cpu, BranchFields(instr), 2)
+}
+
+func (instr TbhT1) String() string {
+	return fmt.Sprintf("tbh [%s, %s, lsl #1]", instr.Rn, instr.Rm)
+}
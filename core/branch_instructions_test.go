@@ -0,0 +1,242 @@
+package core
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestIdentifyBT1(t *testing.T) {
+	cases := []IdentifyCase{
+		{instr: FetchedInstr16(0xd0fe), instr_valid: true},  // beq #-4
+		{instr: FetchedInstr16(0xdd00), instr_valid: true},  // ble #0
+		{instr: FetchedInstr16(0xde00), instr_valid: false}, // udf #0
+		{instr: FetchedInstr16(0xdf00), instr_valid: false}, // svc #0
+		{instr: FetchedInstr16(0xe032), instr_valid: false}, // b #100
+	}
+
+	test_identify(t, cases, reflect.TypeOf(BT1{}))
+}
+
+func TestIdentifyBT3(t *testing.T) {
+	cases := []IdentifyCase{
+		{instr: FetchedInstr32(0xf47fae0c), instr_valid: true},  // bne.w #-1000
+		{instr: FetchedInstr32(0xf3408000), instr_valid: true},  // ble.w #0
+		{instr: FetchedInstr32(0xf3af8000), instr_valid: false}, // nop.w
+		{instr: FetchedInstr32(0xf0f4b920), instr_valid: false}, // b.w #1000000
+	}
+
+	test_identify(t, cases, reflect.TypeOf(BT3{}))
+}
+
+func TestIdentifyBlT1(t *testing.T) {
+	cases := []IdentifyCase{
+		{instr: FetchedInstr32(0xf70bfee0), instr_valid: true},  // bl #-1000000
+		{instr: FetchedInstr32(0xf0f4b920), instr_valid: false}, // b.w #1000000
+	}
+
+	test_identify(t, cases, reflect.TypeOf(BlT1{}))
+}
+
+func TestDecodeB16(t *testing.T) {
+	cases := []DecodeCase{
+		// beq #-4
+		{instr: FetchedInstr16(0xd0fe), decoded: BT1{Cond: COND_EQ, Imm: 0xfffffffc}},
+		// bgt #254
+		{instr: FetchedInstr16(0xdc7f), decoded: BT1{Cond: COND_GT, Imm: 254}},
+	}
+
+	test_decode(t, cases, B16T1)
+
+	cases = []DecodeCase{
+		// b #100
+		{instr: FetchedInstr16(0xe032), decoded: BT2{Cond: COND_AL, Imm: 100}},
+		// b #-2048
+		{instr: FetchedInstr16(0xe400), decoded: BT2{Cond: COND_AL, Imm: 0xfffff800}},
+	}
+
+	test_decode(t, cases, B16T2)
+}
+
+func TestDecodeB32(t *testing.T) {
+	cases := []DecodeCase{
+		// bne.w #-1000
+		{instr: FetchedInstr32(0xf47fae0c), decoded: BT3{Cond: COND_NE, Imm: 0xfffffc18}},
+	}
+
+	test_decode(t, cases, B32T3)
+
+	cases = []DecodeCase{
+		// b.w #1000000
+		{instr: FetchedInstr32(0xf0f4b920), decoded: BT4{Cond: COND_AL, Imm: 1000000}},
+	}
+
+	test_decode(t, cases, B32T4)
+
+	cases = []DecodeCase{
+		// bl #-1000000
+		{instr: FetchedInstr32(0xf70bfee0), decoded: BlT1{Cond: COND_AL, Imm: 0xfff0bdc0}},
+	}
+
+	test_decode(t, cases, Bl32T1)
+}
+
+func TestDecodeBranchRegister(t *testing.T) {
+	cases := []DecodeCase{
+		// bx lr
+		{instr: FetchedInstr16(0x4770), decoded: BxT1{Cond: COND_AL, Rm: LR}},
+	}
+
+	test_decode(t, cases, Bx16T1)
+
+	cases = []DecodeCase{
+		// blx r3
+		{instr: FetchedInstr16(0x4798), decoded: BlxRegT1{Cond: COND_AL, Rm: 3}},
+		// blx pc
+		{instr: FetchedInstr16(0x47f8), decoded: UnpredictableInstr{}},
+	}
+
+	test_decode(t, cases, BlxReg16T1)
+}
+
+func TestDecodeCbzCbnz(t *testing.T) {
+	cases := []DecodeCase{
+		// cbz r0, #4
+		{instr: FetchedInstr16(0xb110), decoded: CbzT1{Cond: COND_AL, Rn: 0, Imm: 4}},
+	}
+
+	test_decode(t, cases, Cbz16T1)
+
+	cases = []DecodeCase{
+		// cbnz r7, #126
+		{instr: FetchedInstr16(0xbbff), decoded: CbnzT1{Cond: COND_AL, Rn: 7, Imm: 126}},
+	}
+
+	test_decode(t, cases, Cbnz16T1)
+}
+
+func TestDecodeTableBranch(t *testing.T) {
+	cases := []DecodeCase{
+		// tbb [r0, r1]
+		{instr: FetchedInstr32(0xe8d0f001), decoded: TbbT1{Cond: COND_AL, Rn: 0, Rm: 1}},
+		// tbb [sp, r1]
+		{instr: FetchedInstr32(0xe8ddf001), decoded: UnpredictableInstr{}},
+	}
+
+	test_decode(t, cases, Tbb32T1)
+
+	cases = []DecodeCase{
+		// tbh [pc, r1, lsl #1]
+		{instr: FetchedInstr32(0xe8dff011), decoded: TbhT1{Cond: COND_AL, Rn: PC, Rm: 1}},
+		// tbh [r0, pc, lsl #1]
+		{instr: FetchedInstr32(0xe8d0f01f), decoded: UnpredictableInstr{}},
+	}
+
+	test_decode(t, cases, Tbh32T1)
+}
+
+func TestDisassembleBranch(t *testing.T) {
+	cases := []struct {
+		instr    DecodedInstr
+		expected string
+	}{
+		{instr: BT1{Cond: COND_EQ, Imm: 0xfffffffc}, expected: "beq 0x1000"},
+		{instr: BT2{Cond: COND_AL, Imm: 100}, expected: "b 0x1068"},
+		{instr: BT3{Cond: COND_NE, Imm: 0xfffffc18}, expected: "bne.w 0xc1c"},
+		{instr: BlT1{Cond: COND_AL, Imm: 0x100}, expected: "bl 0x1104"},
+		{instr: CbnzT1{Cond: COND_AL, Rn: 7, Imm: 126}, expected: "cbnz r7, 0x1082"},
+		{instr: BxT1{Cond: COND_AL, Rm: LR}, expected: "bx lr"},
+	}
+
+	for _, test := range cases {
+		if actual := Disassemble(test.instr, 0x1000); actual != test.expected {
+			t.Errorf("Disassemble(%#v) = %q, expected %q", test.instr, actual, test.expected)
+		}
+	}
+}
+
+func TestExecuteB(t *testing.T) {
+	cases := []ExecuteCase{
+		// beq #-4 (taken)
+		{instr: BT1{Cond: COND_EQ, Imm: 0xfffffffc},
+			regs:     Registers{pc: 0x1004, Apsr: Apsr{Z: true}},
+			expected: Registers{pc: 0x1000, Apsr: Apsr{Z: true}, branched: true}},
+		// b #100
+		{instr: BT2{Cond: COND_AL, Imm: 100},
+			regs:     Registers{pc: 0x1004},
+			expected: Registers{pc: 0x1068, branched: true}},
+		// bne.w #-1000
+		{instr: BT3{Cond: COND_NE, Imm: 0xfffffc18},
+			regs:     Registers{pc: 0x1004},
+			expected: Registers{pc: 0xc1c, branched: true}},
+		// bl #256
+		{instr: BlT1{Cond: COND_AL, Imm: 0x100},
+			regs:     Registers{pc: 0x1004},
+			expected: Registers{pc: 0x1104, lr: 0x1005, branched: true}},
+	}
+
+	test_execute(t, cases)
+}
+
+func TestExecuteBxBlx(t *testing.T) {
+	cases := []ExecuteCase{
+		// bx lr
+		{instr: BxT1{Cond: COND_AL, Rm: LR},
+			regs:     Registers{pc: 0x1004, lr: 0x2001, Epsr: Epsr{T: true}},
+			expected: Registers{pc: 0x2000, lr: 0x2001, Epsr: Epsr{T: true}, branched: true}},
+		// bx r0 (even target leaves Thumb state)
+		{instr: BxT1{Cond: COND_AL, Rm: 0},
+			regs:     Registers{r: GeneralRegs{0x2000}, pc: 0x1004, Epsr: Epsr{T: true}},
+			expected: Registers{r: GeneralRegs{0x2000}, pc: 0x2000, Epsr: Epsr{T: false}, branched: true}},
+		// blx r3
+		{instr: BlxRegT1{Cond: COND_AL, Rm: 3},
+			regs:     Registers{r: GeneralRegs{0, 1, 2, 0x3001}, pc: 0x1004, Epsr: Epsr{T: true}},
+			expected: Registers{r: GeneralRegs{0, 1, 2, 0x3001}, pc: 0x3000, lr: 0x1003, Epsr: Epsr{T: true}, branched: true}},
+		// blx lr
+		{instr: BlxRegT1{Cond: COND_AL, Rm: LR},
+			regs:     Registers{pc: 0x1004, lr: 0x3001, Epsr: Epsr{T: true}},
+			expected: Registers{pc: 0x3000, lr: 0x1003, Epsr: Epsr{T: true}, branched: true}},
+	}
+
+	test_execute(t, cases)
+}
+
+func TestExecuteCbzCbnz(t *testing.T) {
+	cases := []ExecuteCase{
+		// cbz r0, #4
+		{instr: CbzT1{Cond: COND_AL, Rn: 0, Imm: 4},
+			regs:     Registers{pc: 0x1004},
+			expected: Registers{pc: 0x1008, branched: true}},
+		// cbz r0, #4
+		{instr: CbzT1{Cond: COND_AL, Rn: 0, Imm: 4},
+			regs:     Registers{r: GeneralRegs{1}, pc: 0x1004},
+			expected: Registers{r: GeneralRegs{1}, pc: 0x1004}},
+		// cbnz r7, #126
+		{instr: CbnzT1{Cond: COND_AL, Rn: 7, Imm: 126},
+			regs:     Registers{r: GeneralRegs{7: 1}, pc: 0x1004},
+			expected: Registers{r: GeneralRegs{7: 1}, pc: 0x1082, branched: true}},
+	}
+
+	test_execute(t, cases)
+}
+
+func TestExecuteTableBranch(t *testing.T) {
+	cases := []MemoryCase{
+		// tbb [r0, r1]
+		{instr: TbbT1{Cond: COND_AL, Rn: 0, Rm: 1},
+			regs:     Registers{r: GeneralRegs{0x10, 2}, pc: 0x1004},
+			expected: Registers{r: GeneralRegs{0x10, 2}, pc: 0x1128, branched: true},
+			addr:     0x10, word: 0x93929190},
+		// tbh [r0, r1, lsl #1]
+		{instr: TbhT1{Cond: COND_AL, Rn: 0, Rm: 1},
+			regs:     Registers{r: GeneralRegs{0x10, 1}, pc: 0x1004},
+			expected: Registers{r: GeneralRegs{0x10, 1}, pc: 0x13728, branched: true},
+			addr:     0x10, word: 0x93929190},
+		// tbh [r0, r1, lsl #1] (unmapped)
+		{instr: TbhT1{Cond: COND_AL, Rn: 0, Rm: 1},
+			regs:     Registers{r: GeneralRegs{0x10, 0x100}, pc: 0x1004},
+			expected: Registers{r: GeneralRegs{0x10, 0x100}, pc: 0x1004},
+			addr:     0x10, word: 0x93929190, fault: true},
+	}
+
+	test_execute_memory(t, cases)
+}
@@ -0,0 +1,60 @@
+package core
+
+/* Branches, other than B with a condition, may only be the last
+ * instruction of an IT block */
+func branch_in_it_block(cpu *CPU) bool {
+	return cpu.InITBlock() && !cpu.LastInITBlock()
+}
+
+/* Target of a PC-relative branch at addr */
+func branch_target(addr uint32, offset uint32) uint32 {
+	return addr + 4 + offset
+}
+
+/* Extract the offset of the 32-bit unconditional B and BL encodings
+ * ARM ARM A7.7.12 */
+func decode_branch_offset(raw_instr uint32) uint32 {
+	S := (raw_instr >> 26) & 0x1
+	imm10 := (raw_instr >> 16) & 0x3ff
+	J1 := (raw_instr >> 13) & 0x1
+	J2 := (raw_instr >> 11) & 0x1
+	imm11 := raw_instr & 0x7ff
+
+	I1 := ^(J1 ^ S) & 0x1
+	I2 := ^(J2 ^ S) & 0x1
+
+	return SignExtend((S<<24)|(I1<<23)|(I2<<22)|(imm10<<12)|(imm11<<1), 25)
+}
+
+/* Extract the offset of the 32-bit conditional B encoding
+ * ARM ARM A7.7.12 */
+func decode_cond_branch_offset(raw_instr uint32) uint32 {
+	S := (raw_instr >> 26) & 0x1
+	imm6 := (raw_instr >> 16) & 0x3f
+	J1 := (raw_instr >> 13) & 0x1
+	J2 := (raw_instr >> 11) & 0x1
+	imm11 := raw_instr & 0x7ff
+
+	return SignExtend((S<<20)|(J2<<19)|(J1<<18)|(imm6<<12)|(imm11<<1), 21)
+}
+
+/* Branch by offset from PC */
+func BranchImmediate(cpu *CPU, instr BranchFields) {
+	cpu.BranchWritePC(cpu.Pc() + instr.Imm)
+}
+
+/* Perform table branch, with a table of size byte entries at Rn
+ * indexed by Rm */
+func TableBranch(cpu *CPU, instr BranchFields, size uint32) {
+	if branch_in_it_block(cpu) {
+		// UNPREDICTABLE
+		return
+	}
+
+	entry, ok := cpu.MemU(cpu.R(instr.Rn)+size*cpu.R(instr.Rm), size)
+	if !ok {
+		return
+	}
+
+	cpu.BranchWritePC(cpu.Pc() + 2*entry)
+}
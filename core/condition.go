@@ -0,0 +1,172 @@
+package core
+
+import (
+	"fmt"
+	"strings"
+)
+
+/* Condition field of conditional instructions
+ * ARM ARM A7.3 */
+type Condition uint8
+
+const (
+	COND_EQ Condition = iota // Equal
+	COND_NE                  // Not equal
+	COND_CS                  // Carry set
+	COND_CC                  // Carry clear
+	COND_MI                  // Minus, negative
+	COND_PL                  // Plus, positive or zero
+	COND_VS                  // Overflow
+	COND_VC                  // No overflow
+	COND_HI                  // Unsigned higher
+	COND_LS                  // Unsigned lower or same
+	COND_GE                  // Signed greater than or equal
+	COND_LT                  // Signed less than
+	COND_GT                  // Signed greater than
+	COND_LE                  // Signed less than or equal
+	COND_AL                  // Always
+)
+
+var condition_names = [...]string{"eq", "ne", "cs", "cc", "mi", "pl", "vs", "vc",
+	"hi", "ls", "ge", "lt", "gt", "le", "", ""}
+
+/* Mnemonic suffix, empty for AL */
+func (cond Condition) String() string {
+	return condition_names[cond&0xf]
+}
+
+/* Do the condition flags satisfy cond?
+ * ARM ARM A7.3.1 ConditionHolds() */
+func (regs Registers) ConditionHolds(cond Condition) bool {
+	var result bool
+
+	switch (cond >> 1) & 0x7 {
+	case 0x0:
+		result = regs.Apsr.Z
+	case 0x1:
+		result = regs.Apsr.C
+	case 0x2:
+		result = regs.Apsr.N
+	case 0x3:
+		result = regs.Apsr.V
+	case 0x4:
+		result = regs.Apsr.C && !regs.Apsr.Z
+	case 0x5:
+		result = regs.Apsr.N == regs.Apsr.V
+	case 0x6:
+		result = regs.Apsr.N == regs.Apsr.V && !regs.Apsr.Z
+	case 0x7:
+		result = true
+	}
+
+	/* Odd conditions are the inverse of the even ones, except AL */
+	if cond&0x1 == 1 && cond != 0xf {
+		result = !result
+	}
+
+	return result
+}
+
+/* Instructions with their own condition, rather than the IT block's */
+type ConditionalInstr interface {
+	DecodedInstr
+	Condition() Condition
+}
+
+/* Should instr execute? Unconditional instructions always do.
+ * ARM ARM A7.3.1 ConditionPassed() */
+func (regs Registers) ConditionPassed(instr DecodedInstr) bool {
+	if conditional, ok := instr.(ConditionalInstr); ok {
+		return regs.ConditionHolds(conditional.Condition())
+	}
+
+	return true
+}
+
+/* Execution state of an IT block, the condition in bits 7:4 and the
+ * remaining length and condition LSBs in bits 4:0
+ * ARM ARM A7.3 */
+type ITState uint8
+
+func (state ITState) InBlock() bool {
+	return state&0xf != 0
+}
+
+func (state ITState) Last() bool {
+	return state&0xf == 0x8
+}
+
+/* Condition of the current instruction in the block */
+func (state ITState) Cond() Condition {
+	return Condition(state >> 4)
+}
+
+/* Move on to the next instruction in the block
+ * ARM ARM A7.3 ITAdvance() */
+func (state *ITState) Advance() {
+	if *state&0x7 == 0 {
+		*state = 0
+	} else {
+		*state = (*state & 0xe0) | ((*state << 1) & 0x1f)
+	}
+}
+
+/* Make instr conditional on the current condition of the IT block, if
+ * it is in one. BKPT executes unconditionally even inside a block. */
+func (state ITState) Conditional(instr DecodedInstr) DecodedInstr {
+	if !state.InBlock() {
+		return instr
+	}
+
+	switch instr.(type) {
+	case ItT1, BkptT1:
+		return instr
+	}
+
+	return ITInstr{Instr: instr, Cond: state.Cond()}
+}
+
+/* An instruction inside an IT block */
+type ITInstr struct {
+	Instr DecodedInstr
+	Cond  Condition
+}
+
+func (instr ITInstr) Execute(cpu *CPU) {
+	instr.Instr.Execute(cpu)
+}
+
+func (instr ITInstr) Condition() Condition {
+	return instr.Cond
+}
+
+func (instr ITInstr) String() string {
+	return instr.suffixed(fmt.Sprint(instr.Instr))
+}
+
+func (instr ITInstr) Disassemble(addr uint32) string {
+	return instr.suffixed(Disassemble(instr.Instr, addr))
+}
+
+/* Add the condition suffix to a disassembled instruction, after any S
+ * suffix but before the .W or .N qualifier. Inside an IT block, the 16-bit
+ * encodings that otherwise set the flags don't, so lose their S suffix. */
+func (instr ITInstr) suffixed(disassembly string) string {
+	mnemonic, operands, has_operands := strings.Cut(disassembly, " ")
+
+	qualifier := ""
+	if i := strings.Index(mnemonic, "."); i >= 0 {
+		mnemonic, qualifier = mnemonic[:i], mnemonic[i:]
+	}
+
+	if flags, ok := instr.Instr.(FlagSettingInstr); ok && flags.SetsFlags() == NOT_IT {
+		mnemonic = strings.TrimSuffix(mnemonic, NOT_IT.String())
+	}
+
+	mnemonic += instr.Cond.String() + qualifier
+	if !has_operands {
+		return mnemonic
+	}
+
+	return mnemonic + " " + operands
+}
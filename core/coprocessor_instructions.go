@@ -0,0 +1,490 @@
+package core
+
+import "fmt"
+
+/* Extract the fields of MCR and MRC */
+func decode_coproc(raw_instr uint32) CoprocFields {
+	return CoprocFields{
+		Coproc: uint8((raw_instr >> 8) & 0xf),
+		Opc1:   uint8((raw_instr >> 21) & 0x7),
+		Opc2:   uint8((raw_instr >> 5) & 0x7),
+		CRn:    uint8((raw_instr >> 16) & 0xf),
+		CRm:    uint8(raw_instr & 0xf),
+		Rt:     RegIndex((raw_instr >> 12) & 0xf),
+	}
+}
+
+/* Extract the fields of CDP, which has a wider opc1 and CRd in place of Rt */
+func decode_coproc_data(raw_instr uint32) CoprocFields {
+	fields := decode_coproc(raw_instr)
+	fields.Opc1 = uint8((raw_instr >> 20) & 0xf)
+	fields.CRd = uint8(fields.Rt)
+	fields.Rt = 0
+
+	return fields
+}
+
+/* Extract the fields of MCRR and MRRC */
+func decode_coproc_pair(raw_instr uint32) CoprocFields {
+	return CoprocFields{
+		Coproc: uint8((raw_instr >> 8) & 0xf),
+		Opc1:   uint8((raw_instr >> 4) & 0xf),
+		CRm:    uint8(raw_instr & 0xf),
+		Rt:     RegIndex((raw_instr >> 12) & 0xf),
+		Rt2:    RegIndex((raw_instr >> 16) & 0xf),
+	}
+}
+
+/* Extract the fields of LDC and STC. P, U and W all clear encode MCRR,
+ * MRRC or nothing, so aren't an addressing mode. */
+func decode_coproc_ldst(raw_instr uint32) (CoprocLoadStoreFields, bool) {
+	index := (raw_instr>>24)&0x1 != 0
+	add := (raw_instr>>23)&0x1 != 0
+	wback := (raw_instr>>21)&0x1 != 0
+
+	fields := CoprocLoadStoreFields{
+		Coproc: uint8((raw_instr >> 8) & 0xf),
+		CRd:    uint8((raw_instr >> 12) & 0xf),
+		Long:   (raw_instr>>22)&0x1 != 0,
+		Rn:     RegIndex((raw_instr >> 16) & 0xf),
+		Imm:    (raw_instr & 0xff) << 2,
+		Index:  index,
+		Add:    add,
+		Wback:  wback,
+	}
+
+	/* Unindexed, passing an option rather than an offset */
+	if !index && !wback {
+		fields.Imm = raw_instr & 0xff
+	}
+
+	return fields, index || add || wback
+}
+
+/* Add the suffixes of encoding T2 and of long transfers, e.g. ldc2l */
+func coproc_mnemonic(mnemonic string, encoding2 bool, long bool) string {
+	if encoding2 {
+		mnemonic += "2"
+	}
+	if long {
+		mnemonic += "l"
+	}
+
+	return mnemonic
+}
+
+func coproc_address(instr CoprocLoadStoreFields) string {
+	if !instr.Index && !instr.Wback {
+		return fmt.Sprintf("[%s], {%d}", instr.Rn, instr.Imm)
+	}
+
+	return imm_address(LoadStoreFields{Rn: instr.Rn, Imm: instr.Imm, Index: instr.Index, Add: instr.Add, Wback: instr.Wback})
+}
+
+func coproc_rt(Rt RegIndex) string {
+	if Rt == PC {
+		return "APSR_nzcv"
+	}
+
+	return Rt.String()
+}
+
+/* CDP, CDP2
+ * ARM ARM A7.7.22
+ * Encoding T1 */
+type CdpT1 CoprocFields
+
+func Cdp32T1(instr FetchedInstr) DecodedInstr {
+	fields := decode_coproc_data(instr.Uint32())
+
+	return CdpT1(fields)
+}
+
+func (instr CdpT1) Execute(cpu *CPU) {
+	CoprocessorNotAccepted(cpu, instr.Coproc)
+}
+
+func (instr CdpT1) String() string {
+	return fmt.Sprintf("%s p%d, #%d, c%d, c%d, c%d, #%d", coproc_mnemonic("cdp", false, false),
+		instr.Coproc, instr.Opc1, instr.CRd, instr.CRn, instr.CRm, instr.Opc2)
+}
+
+/* CDP, CDP2
+ * ARM ARM A7.7.22
+ * Encoding T2 */
+type CdpT2 CoprocFields
+
+func Cdp32T2(instr FetchedInstr) DecodedInstr {
+	fields := decode_coproc_data(instr.Uint32())
+
+	return CdpT2(fields)
+}
+
+func (instr CdpT2) Execute(cpu *CPU) {
+	CoprocessorNotAccepted(cpu, instr.Coproc)
+}
+
+func (instr CdpT2) String() string {
+	return fmt.Sprintf("%s p%d, #%d, c%d, c%d, c%d, #%d", coproc_mnemonic("cdp", true, false),
+		instr.Coproc, instr.Opc1, instr.CRd, instr.CRn, instr.CRm, instr.Opc2)
+}
+
+/* LDC, LDC2 (immediate)
+ * ARM ARM A7.7.38
+ * Encoding T1 */
+type LdcImmT1 CoprocLoadStoreFields
+
+func LdcImm32T1(instr FetchedInstr) DecodedInstr {
+	fields, ok := decode_coproc_ldst(instr.Uint32())
+
+	switch {
+	case !ok:
+		return coproc_pair_or_undefined(instr, Mrrc32T1)
+	case fields.Rn == PC:
+		return LdcLit32T1(instr)
+	}
+
+	return LdcImmT1(fields)
+}
+
+func (instr LdcImmT1) Execute(cpu *CPU) {
+	CoprocessorNotAccepted(cpu, instr.Coproc)
+}
+
+func (instr LdcImmT1) String() string {
+	return fmt.Sprintf("%s p%d, c%d, %s", coproc_mnemonic("ldc", false, instr.Long),
+		instr.Coproc, instr.CRd, coproc_address(CoprocLoadStoreFields(instr)))
+}
+
+/* LDC, LDC2 (immediate)
+ * ARM ARM A7.7.38
+ * Encoding T2 */
+type LdcImmT2 CoprocLoadStoreFields
+
+func LdcImm32T2(instr FetchedInstr) DecodedInstr {
+	fields, ok := decode_coproc_ldst(instr.Uint32())
+
+	switch {
+	case !ok:
+		return coproc_pair_or_undefined(instr, Mrrc32T2)
+	case fields.Rn == PC:
+		return LdcLit32T2(instr)
+	}
+
+	return LdcImmT2(fields)
+}
+
+func (instr LdcImmT2) Execute(cpu *CPU) {
+	CoprocessorNotAccepted(cpu, instr.Coproc)
+}
+
+func (instr LdcImmT2) String() string {
+	return fmt.Sprintf("%s p%d, c%d, %s", coproc_mnemonic("ldc", true, instr.Long),
+		instr.Coproc, instr.CRd, coproc_address(CoprocLoadStoreFields(instr)))
+}
+
+/* LDC, LDC2 (literal)
+ * ARM ARM A7.7.39
+ * Encoding T1 */
+type LdcLitT1 CoprocLoadStoreFields
+
+func LdcLit32T1(instr FetchedInstr) DecodedInstr {
+	fields, _ := decode_coproc_ldst(instr.Uint32())
+
+	if fields.Wback || !fields.Index {
+		return UnpredictableInstr{}
+	}
+
+	return LdcLitT1(fields)
+}
+
+func (instr LdcLitT1) Execute(cpu *CPU) {
+	CoprocessorNotAccepted(cpu, instr.Coproc)
+}
+
+func (instr LdcLitT1) String() string {
+	return fmt.Sprintf("%s p%d, c%d, %s", coproc_mnemonic("ldc", false, instr.Long),
+		instr.Coproc, instr.CRd, coproc_address(CoprocLoadStoreFields(instr)))
+}
+
+/* LDC, LDC2 (literal)
+ * ARM ARM A7.7.39
+ * Encoding T2 */
+type LdcLitT2 CoprocLoadStoreFields
+
+func LdcLit32T2(instr FetchedInstr) DecodedInstr {
+	fields, _ := decode_coproc_ldst(instr.Uint32())
+
+	if fields.Wback || !fields.Index {
+		return UnpredictableInstr{}
+	}
+
+	return LdcLitT2(fields)
+}
+
+func (instr LdcLitT2) Execute(cpu *CPU) {
+	CoprocessorNotAccepted(cpu, instr.Coproc)
+}
+
+func (instr LdcLitT2) String() string {
+	return fmt.Sprintf("%s p%d, c%d, %s", coproc_mnemonic("ldc", true, instr.Long),
+		instr.Coproc, instr.CRd, coproc_address(CoprocLoadStoreFields(instr)))
+}
+
+/* MCR, MCR2
+ * ARM ARM A7.7.71
+ * Encoding T1 */
+type McrT1 CoprocFields
+
+func Mcr32T1(instr FetchedInstr) DecodedInstr {
+	fields := decode_coproc(instr.Uint32())
+
+	if BadReg(fields.Rt) {
+		return UnpredictableInstr{}
+	}
+
+	return McrT1(fields)
+}
+
+func (instr McrT1) Execute(cpu *CPU) {
+	CoprocessorNotAccepted(cpu, instr.Coproc)
+}
+
+func (instr McrT1) String() string {
+	return fmt.Sprintf("%s p%d, #%d, %s, c%d, c%d, #%d", coproc_mnemonic("mcr", false, false),
+		instr.Coproc, instr.Opc1, instr.Rt, instr.CRn, instr.CRm, instr.Opc2)
+}
+
+/* MCR, MCR2
+ * ARM ARM A7.7.71
+ * Encoding T2 */
+type McrT2 CoprocFields
+
+func Mcr32T2(instr FetchedInstr) DecodedInstr {
+	fields := decode_coproc(instr.Uint32())
+
+	if BadReg(fields.Rt) {
+		return UnpredictableInstr{}
+	}
+
+	return McrT2(fields)
+}
+
+func (instr McrT2) Execute(cpu *CPU) {
+	CoprocessorNotAccepted(cpu, instr.Coproc)
+}
+
+func (instr McrT2) String() string {
+	return fmt.Sprintf("%s p%d, #%d, %s, c%d, c%d, #%d", coproc_mnemonic("mcr", true, false),
+		instr.Coproc, instr.Opc1, instr.Rt, instr.CRn, instr.CRm, instr.Opc2)
+}
+
+/* MCRR, MCRR2
+ * ARM ARM A7.7.72
+ * Encoding T1 */
+type McrrT1 CoprocFields
+
+func Mcrr32T1(instr FetchedInstr) DecodedInstr {
+	fields := decode_coproc_pair(instr.Uint32())
+
+	if BadReg(fields.Rt) || BadReg(fields.Rt2) {
+		return UnpredictableInstr{}
+	}
+
+	return McrrT1(fields)
+}
+
+func (instr McrrT1) Execute(cpu *CPU) {
+	CoprocessorNotAccepted(cpu, instr.Coproc)
+}
+
+func (instr McrrT1) String() string {
+	return fmt.Sprintf("%s p%d, #%d, %s, %s, c%d", coproc_mnemonic("mcrr", false, false),
+		instr.Coproc, instr.Opc1, instr.Rt, instr.Rt2, instr.CRm)
+}
+
+/* MCRR, MCRR2
+ * ARM ARM A7.7.72
+ * Encoding T2 */
+type McrrT2 CoprocFields
+
+func Mcrr32T2(instr FetchedInstr) DecodedInstr {
+	fields := decode_coproc_pair(instr.Uint32())
+
+	if BadReg(fields.Rt) || BadReg(fields.Rt2) {
+		return UnpredictableInstr{}
+	}
+
+	return McrrT2(fields)
+}
+
+func (instr McrrT2) Execute(cpu *CPU) {
+	CoprocessorNotAccepted(cpu, instr.Coproc)
+}
+
+func (instr McrrT2) String() string {
+	return fmt.Sprintf("%s p%d, #%d, %s, %s, c%d", coproc_mnemonic("mcrr", true, false),
+		instr.Coproc, instr.Opc1, instr.Rt, instr.Rt2, instr.CRm)
+}
+
+/* MRC, MRC2
+ * ARM ARM A7.7.79
+ * Encoding T1 */
+type MrcT1 CoprocFields
+
+func Mrc32T1(instr FetchedInstr) DecodedInstr {
+	fields := decode_coproc(instr.Uint32())
+
+	/* Rt of PC transfers to the APSR flags */
+	if fields.Rt == SP {
+		return UnpredictableInstr{}
+	}
+
+	return MrcT1(fields)
+}
+
+func (instr MrcT1) Execute(cpu *CPU) {
+	CoprocessorNotAccepted(cpu, instr.Coproc)
+}
+
+func (instr MrcT1) String() string {
+	return fmt.Sprintf("%s p%d, #%d, %s, c%d, c%d, #%d", coproc_mnemonic("mrc", false, false),
+		instr.Coproc, instr.Opc1, coproc_rt(instr.Rt), instr.CRn, instr.CRm, instr.Opc2)
+}
+
+/* MRC, MRC2
+ * ARM ARM A7.7.79
+ * Encoding T2 */
+type MrcT2 CoprocFields
+
+func Mrc32T2(instr FetchedInstr) DecodedInstr {
+	fields := decode_coproc(instr.Uint32())
+
+	/* Rt of PC transfers to the APSR flags */
+	if fields.Rt == SP {
+		return UnpredictableInstr{}
+	}
+
+	return MrcT2(fields)
+}
+
+func (instr MrcT2) Execute(cpu *CPU) {
+	CoprocessorNotAccepted(cpu, instr.Coproc)
+}
+
+func (instr MrcT2) String() string {
+	return fmt.Sprintf("%s p%d, #%d, %s, c%d, c%d, #%d", coproc_mnemonic("mrc", true, false),
+		instr.Coproc, instr.Opc1, coproc_rt(instr.Rt), instr.CRn, instr.CRm, instr.Opc2)
+}
+
+/* MRRC, MRRC2
+ * ARM ARM A7.7.80
+ * Encoding T1 */
+type MrrcT1 CoprocFields
+
+func Mrrc32T1(instr FetchedInstr) DecodedInstr {
+	fields := decode_coproc_pair(instr.Uint32())
+
+	if BadReg(fields.Rt) || BadReg(fields.Rt2) || fields.Rt == fields.Rt2 {
+		return UnpredictableInstr{}
+	}
+
+	return MrrcT1(fields)
+}
+
+func (instr MrrcT1) Execute(cpu *CPU) {
+	CoprocessorNotAccepted(cpu, instr.Coproc)
+}
+
+func (instr MrrcT1) String() string {
+	return fmt.Sprintf("%s p%d, #%d, %s, %s, c%d", coproc_mnemonic("mrrc", false, false),
+		instr.Coproc, instr.Opc1, instr.Rt, instr.Rt2, instr.CRm)
+}
+
+/* MRRC, MRRC2
+ * ARM ARM A7.7.80
+ * Encoding T2 */
+type MrrcT2 CoprocFields
+
+func Mrrc32T2(instr FetchedInstr) DecodedInstr {
+	fields := decode_coproc_pair(instr.Uint32())
+
+	if BadReg(fields.Rt) || BadReg(fields.Rt2) || fields.Rt == fields.Rt2 {
+		return UnpredictableInstr{}
+	}
+
+	return MrrcT2(fields)
+}
+
+func (instr MrrcT2) Execute(cpu *CPU) {
+	CoprocessorNotAccepted(cpu, instr.Coproc)
+}
+
+func (instr MrrcT2) String() string {
+	return fmt.Sprintf("%s p%d, #%d, %s, %s, c%d", coproc_mnemonic("mrrc", true, false),
+		instr.Coproc, instr.Opc1, instr.Rt, instr.Rt2, instr.CRm)
+}
+
+/* STC, STC2
+ * ARM ARM A7.7.155
+ * Encoding T1 */
+type StcT1 CoprocLoadStoreFields
+
+func Stc32T1(instr FetchedInstr) DecodedInstr {
+	fields, ok := decode_coproc_ldst(instr.Uint32())
+
+	switch {
+	case !ok:
+		return coproc_pair_or_undefined(instr, Mcrr32T1)
+	case fields.Rn == PC:
+		return UnpredictableInstr{}
+	}
+
+	return StcT1(fields)
+}
+
+func (instr StcT1) Execute(cpu *CPU) {
+	CoprocessorNotAccepted(cpu, instr.Coproc)
+}
+
+func (instr StcT1) String() string {
+	return fmt.Sprintf("%s p%d, c%d, %s", coproc_mnemonic("stc", false, instr.Long),
+		instr.Coproc, instr.CRd, coproc_address(CoprocLoadStoreFields(instr)))
+}
+
+/* STC, STC2
+ * ARM ARM A7.7.155
+ * Encoding T2 */
+type StcT2 CoprocLoadStoreFields
+
+func Stc32T2(instr FetchedInstr) DecodedInstr {
+	fields, ok := decode_coproc_ldst(instr.Uint32())
+
+	switch {
+	case !ok:
+		return coproc_pair_or_undefined(instr, Mcrr32T2)
+	case fields.Rn == PC:
+		return UnpredictableInstr{}
+	}
+
+	return StcT2(fields)
+}
+
+func (instr StcT2) Execute(cpu *CPU) {
+	CoprocessorNotAccepted(cpu, instr.Coproc)
+}
+
+func (instr StcT2) String() string {
+	return fmt.Sprintf("%s p%d, c%d, %s", coproc_mnemonic("stc", true, instr.Long),
+		instr.Coproc, instr.CRd, coproc_address(CoprocLoadStoreFields(instr)))
+}
+
+/* MCRR and MRRC share the encodings of STC and LDC without an addressing
+ * mode, which are UNDEFINED unless the D bit is set */
+func coproc_pair_or_undefined(instr FetchedInstr, decode DecodeFunc) DecodedInstr {
+	if (instr.Uint32()>>22)&0x1 == 0 {
+		return UndefinedInstr{}
+	}
+
+	return decode(instr)
+}
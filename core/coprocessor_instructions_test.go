@@ -0,0 +1,156 @@
+package core
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestIdentifyLdcImmT1(t *testing.T) {
+	cases := []IdentifyCase{
+		{instr: FetchedInstr32(0xed901001), instr_valid: true},  // ldc p0, c1, [r0, #4]
+		{instr: FetchedInstr32(0xecb32102), instr_valid: true},  // ldc p1, c2, [r3], #8
+		{instr: FetchedInstr32(0xed9f2102), instr_valid: false}, // ldc p1, c2, [pc, #8]
+		{instr: FetchedInstr32(0xec5439ff), instr_valid: false}, // mrrc p9, #15, r3, r4, c15
+		{instr: FetchedInstr32(0xed900a01), instr_valid: false}, // vldr s0, [r0, #4]
+	}
+
+	test_identify(t, cases, reflect.TypeOf(LdcImmT1{}))
+}
+
+func TestDecodeCoprocessor(t *testing.T) {
+	cases := []DecodeCase{
+		// cdp p0, #1, c2, c3, c4, #5
+		{instr: FetchedInstr32(0xee1320a4), decoded: CdpT1{Coproc: 0, Opc1: 1, CRd: 2, CRn: 3, CRm: 4, Opc2: 5}},
+	}
+
+	test_decode(t, cases, Cdp32T1)
+
+	cases = []DecodeCase{
+		// mcr2 p1, #7, r12, c1, c2, #3
+		{instr: FetchedInstr32(0xfee1c172), decoded: McrT2{Coproc: 1, Opc1: 7, Rt: 12, CRn: 1, CRm: 2, Opc2: 3}},
+		// mcr2 p1, #7, sp, c1, c2, #3
+		{instr: FetchedInstr32(0xfee1d172), decoded: UnpredictableInstr{}},
+	}
+
+	test_decode(t, cases, Mcr32T2)
+
+	cases = []DecodeCase{
+		// mrrc2 p9, #15, r3, r4, c15
+		{instr: FetchedInstr32(0xfc5439ff), decoded: MrrcT2{Coproc: 9, Opc1: 15, Rt: 3, Rt2: 4, CRm: 15}},
+		// mrrc2 p9, #15, r3, r3, c15
+		{instr: FetchedInstr32(0xfc5339ff), decoded: UnpredictableInstr{}},
+	}
+
+	test_decode(t, cases, LdcImm32T2)
+
+	cases = []DecodeCase{
+		// ldcl p0, c1, [r0, #-4]!
+		{instr: FetchedInstr32(0xed701001),
+			decoded: LdcImmT1{Coproc: 0, CRd: 1, Long: true, Rn: 0, Imm: 4, Index: true, Add: false, Wback: true}},
+		// ldc p1, c2, [r3], {5}
+		{instr: FetchedInstr32(0xec932105),
+			decoded: LdcImmT1{Coproc: 1, CRd: 2, Rn: 3, Imm: 5, Index: false, Add: true, Wback: false}},
+		// ldc p1, c2, [pc, #8]
+		{instr: FetchedInstr32(0xed9f2102),
+			decoded: LdcLitT1{Coproc: 1, CRd: 2, Rn: PC, Imm: 8, Index: true, Add: true}},
+	}
+
+	test_decode(t, cases, LdcImm32T1)
+
+	cases = []DecodeCase{
+		// stc p2, c3, [pc]
+		{instr: FetchedInstr32(0xed8f3200), decoded: UnpredictableInstr{}},
+	}
+
+	test_decode(t, cases, Stc32T1)
+}
+
+func TestCoprocessorString(t *testing.T) {
+	cases := []struct {
+		instr    FetchedInstr
+		expected string
+	}{
+		{instr: FetchedInstr32(0xee1320a4), expected: "cdp p0, #1, c2, c3, c4, #5"},
+		{instr: FetchedInstr32(0xfef0f7e1), expected: "cdp2 p7, #15, c15, c0, c1, #7"},
+		{instr: FetchedInstr32(0xee010f10), expected: "mcr p15, #0, r0, c1, c0, #0"},
+		{instr: FetchedInstr32(0xfee1c172), expected: "mcr2 p1, #7, r12, c1, c2, #3"},
+		{instr: FetchedInstr32(0xee332eb4), expected: "mrc p14, #1, r2, c3, c4, #5"},
+		{instr: FetchedInstr32(0xee33feb4), expected: "mrc p14, #1, APSR_nzcv, c3, c4, #5"},
+		{instr: FetchedInstr32(0xfe332e14), expected: "mrc2 p14, #1, r2, c3, c4, #0"},
+		{instr: FetchedInstr32(0xec410012), expected: "mcrr p0, #1, r0, r1, c2"},
+		{instr: FetchedInstr32(0xfc5439ff), expected: "mrrc2 p9, #15, r3, r4, c15"},
+		{instr: FetchedInstr32(0xed901001), expected: "ldc p0, c1, [r0, #4]"},
+		{instr: FetchedInstr32(0xed701001), expected: "ldcl p0, c1, [r0, #-4]!"},
+		{instr: FetchedInstr32(0xecb32102), expected: "ldc p1, c2, [r3], #8"},
+		{instr: FetchedInstr32(0xec932105), expected: "ldc p1, c2, [r3], {5}"},
+		{instr: FetchedInstr32(0xfd1f2102), expected: "ldc2 p1, c2, [pc, #-8]"},
+		{instr: FetchedInstr32(0xed9f2102), expected: "ldc p1, c2, [pc, #8]"},
+		{instr: FetchedInstr32(0xed8d3200), expected: "stc p2, c3, [sp]"},
+		{instr: FetchedInstr32(0xfc6432ff), expected: "stc2l p2, c3, [r4], #-1020"},
+	}
+
+	for _, test := range cases {
+		decoded, err := test.instr.Decode()
+		if err != nil {
+			t.Errorf("%v: %v", test.instr, err)
+		} else if actual := Disassemble(decoded, 0); actual != test.expected {
+			t.Errorf("%v: %q, expected %q", test.instr, actual, test.expected)
+		}
+	}
+}
+
+func TestStepCoprocessor(t *testing.T) {
+	program := RAM{
+		0x01, 0xee, 0x10, 0x0f, // 0: mcr p15, #0, r0, c1, c0, #0
+		0x30, 0xee, 0x81, 0x0a, // 4: vadd.f32 s0, s1, s2
+		0xe0, 0xee, 0x10, 0x0a, // 8: mcr p10, #7, r0, c0, c0, #0
+	}
+
+	cpu := NewCPU(program)
+	cpu.Profile = PROFILE_ARMV7EM_FP
+
+	/* No coprocessor but the FP extension exists */
+	if err := cpu.Step(); err != UFSR_NOCP || cpu.Pc() != 0 {
+		t.Fatalf("mcr p15: err = %v, pc = %#x", err, cpu.Pc())
+	}
+
+	/* The FP extension is disabled from reset */
+	cpu.SetR(PC, 4)
+	if err := cpu.Step(); err != UFSR_NOCP || cpu.Pc() != 4 {
+		t.Fatalf("vadd disabled: err = %v, pc = %#x", err, cpu.Pc())
+	}
+
+	/* Privileged access denies unprivileged Thread mode */
+	access := uint32(COPROC_ACCESS_PRIVILEGED)
+	cpu.Write32(SCB_CPACR, access<<CPACR_CP10_SHIFT|access<<CPACR_CP11_SHIFT)
+	cpu.Control.Npriv = true
+	if err := cpu.Step(); err != UFSR_NOCP || cpu.Pc() != 4 {
+		t.Fatalf("vadd unprivileged: err = %v, pc = %#x", err, cpu.Pc())
+	}
+
+	cpu.Control.Npriv = false
+	if err := cpu.Step(); err != nil || cpu.Pc() != 8 {
+		t.Fatalf("vadd privileged: err = %v, pc = %#x", err, cpu.Pc())
+	}
+
+	/* Once enabled, other CP10 encodings are undefined */
+	if err := cpu.Step(); err != UFSR_UNDEFINSTR || cpu.Pc() != 8 {
+		t.Fatalf("mcr p10: err = %v, pc = %#x", err, cpu.Pc())
+	}
+}
+
+func TestCPACR(t *testing.T) {
+	cpu := NewCPU(NewDefaultBus())
+
+	/* Reserved without the FP extension */
+	cpu.Write32(SCB_CPACR, 0xffffffff)
+	if cpacr, err := cpu.Read32(SCB_CPACR); err != nil || cpacr != 0 {
+		t.Errorf("cortex-m4: CPACR = %#x, %v", cpacr, err)
+	}
+
+	cpu.Profile = PROFILE_ARMV7EM_FP
+	cpu.Write32(SCB_CPACR, 0xffffffff)
+	if cpacr, _ := cpu.Read32(SCB_CPACR); cpacr != CPACR_FP_MASK {
+		t.Errorf("cortex-m4f: CPACR = %#x, expected %#x", cpacr, CPACR_FP_MASK)
+	}
+}
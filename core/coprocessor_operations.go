@@ -0,0 +1,41 @@
+package core
+
+/* Access to coprocessor coproc granted by CPACR to the current mode */
+func coproc_access_allowed(cpu *CPU, coproc uint8) bool {
+	switch CoprocAccess((cpu.Scb.Cpacr >> (2 * coproc)) & 0x3) {
+	case COPROC_ACCESS_FULL:
+		return true
+	case COPROC_ACCESS_PRIVILEGED:
+		return cpu.CurrentModeIsPrivileged()
+	}
+
+	return false
+}
+
+/* Check that CPACR grants access to the floating-point extension,
+ * raising NOCP if not. CP10 and CP11 must be given the same access, so
+ * only CP10 is checked.
+ * ARMv7-M ARM pseudocode CheckVFPEnabled() */
+func CheckVFPEnabled(cpu *CPU) bool {
+	if !coproc_access_allowed(cpu, 10) {
+		cpu.raise(UFSR_NOCP)
+		return false
+	}
+
+	return true
+}
+
+/* Perform a coprocessor instruction, which no coprocessor accepts. Only
+ * CP10 and CP11, the floating-point extension, can exist, and their
+ * instructions decode separately. Their other encodings are UNDEFINED
+ * once access is granted, anything else raises NOCP as for an absent
+ * coprocessor.
+ * ARM ARM pseudocode Coproc_Accepted(), GenerateCoprocessorException() */
+func CoprocessorNotAccepted(cpu *CPU, coproc uint8) {
+	if cpu.Profile.FPU && (coproc == 10 || coproc == 11) && coproc_access_allowed(cpu, coproc) {
+		cpu.raise(UFSR_UNDEFINSTR)
+		return
+	}
+
+	cpu.raise(UFSR_NOCP)
+}
@@ -0,0 +1,210 @@
+package core
+
+/* Called for each instruction, after it is decoded but before it executes */
+type TraceFunc func(addr uint32, fetched FetchedInstr, instr DecodedInstr)
+
+/* Called by each STREX at pc, to addr, that would otherwise succeed.
+ * Returning true forces it to fail, to exercise retry loops. */
+type ExclusiveFailFunc func(pc uint32, addr uint32) bool
+
+/* Called after each memory barrier (DMB, DSB, ISB) executes. Anything
+ * caching instructions or translated code must be discarded by ISB. */
+type BarrierFunc func(barrier Barrier)
+
+/* Called by BKPT #imm, for a debugger or semihosting. Returning true
+ * resumes execution after the BKPT, otherwise Step halts with DebugHalt. */
+type BreakpointFunc func(imm uint8) bool
+
+/* Called when an instruction faults, with PC left at the instruction.
+ * Returning true takes the fault exception, otherwise Step halts,
+ * returning the fault. */
+type FaultFunc func(fault error) bool
+
+type CPU struct {
+	Registers
+	Scb     SCB
+	Nvic    NVIC
+	Mem     Memory
+	Monitor ExclusiveMonitor
+	Profile Profile
+
+	Event  bool       // Event register, set by SEV and consumed by WFE
+	Sleep  SleepState // WFI or WFE, until woken
+	Cycles uint64     // Virtual time, counting executed instructions
+
+	Trace         TraceFunc
+	ExclusiveFail ExclusiveFailFunc
+	Idle          IdleFunc
+	Barrier       BarrierFunc
+	Breakpoint    BreakpointFunc
+	Fault         FaultFunc
+
+	pending  PendingExceptions
+	active   ActiveExceptions
+	priority ExceptionPriorities
+	fault    error // Raised by the executing instruction
+}
+
+/* The CPU only supports the Thumb instruction set, so starts in Thumb
+ * state. It implements ARMv7E-M unless the Profile is changed, with all
+ * 240 interrupts and 8 priority bits until the Nvic is configured. */
+func NewCPU(mem Memory) *CPU {
+	cpu := &CPU{Mem: mem, Profile: PROFILE_ARMV7EM}
+	cpu.Epsr.T = true
+	cpu.Scb.Ccr = CCR_RESET
+	cpu.Scb.Fpccr = FPCCR_RESET
+	cpu.Nvic.Interrupts = NVIC_MAX_INTERRUPTS
+	cpu.Nvic.PriorityBits = 8
+
+	return cpu
+}
+
+/* Take the reset exception, booting from the vector table at VTOR.
+ * VTOR is left unchanged so the host may relocate the table before reset.
+ * ARMv7-M ARM B1.5.5 */
+func (cpu *CPU) Reset() error {
+	vectors := cpu.Scb.Vtor & VTOR_TBLOFF_MASK
+
+	msp, err := cpu.Read32(vectors)
+	if err != nil {
+		return err
+	}
+
+	handler, err := cpu.Read32(vectors + 4)
+	if err != nil {
+		return err
+	}
+
+	/* General purpose registers are UNKNOWN on reset, clear them anyway */
+	cpu.Registers = Registers{}
+	cpu.pending = PendingExceptions{}
+	cpu.active = ActiveExceptions{}
+	cpu.priority = ExceptionPriorities{}
+	cpu.Nvic.Reset()
+	cpu.ClearExclusiveLocal()
+	cpu.Sleep = AWAKE
+	cpu.Event = false
+
+	cpu.sp[MSP] = msp &^ 0x3
+	cpu.lr = 0xffffffff
+	cpu.Mode = MODE_THREAD
+	cpu.Control = Control{Npriv: false, Spsel: MSP, Fpca: false}
+	cpu.Scb.Aircr = 0
+	cpu.Scb.Ccr = CCR_RESET
+	cpu.Scb.Shcsr = 0
+	cpu.Scb.Cfsr = 0
+	cpu.Scb.Hfsr = 0
+	cpu.Scb.Cpacr = 0
+	cpu.Scb.Fpccr = FPCCR_RESET
+	cpu.Epsr.T = (handler & 0x1) != 0
+	cpu.BranchTo(handler &^ 0x1)
+
+	return nil
+}
+
+/* Fetch the instruction at addr, joining both halfwords of a 32-bit instruction */
+func Fetch(mem Memory, addr uint32) (FetchedInstr, error) {
+	raw, err := mem.Read16(addr)
+	if err != nil {
+		return nil, err
+	}
+
+	upper := FetchedInstr16(raw)
+	if !upper.IsWordInstr() {
+		return upper, nil
+	}
+
+	raw, err = mem.Read16(addr + 2)
+	if err != nil {
+		return nil, err
+	}
+
+	return upper.Extend(FetchedInstr16(raw)), nil
+}
+
+/* Fetch, decode and execute the instruction at PC, or instead take the
+ * pending exception able to preempt. A sleeping core idles, until a
+ * pending exception wakes it. */
+func (cpu *CPU) Step() error {
+	if cpu.Sleep != AWAKE {
+		if !cpu.wakeup_pending() {
+			return cpu.idle()
+		}
+		cpu.Wake()
+	}
+
+	if excp, ok := cpu.PendingException(); ok {
+		return cpu.ExceptionEntry(excp)
+	}
+
+	addr := cpu.Pc()
+
+	/* Executing in ARM state (after interworking to an even address) faults */
+	if !cpu.Epsr.T {
+		return cpu.take_fault(UFSR_INVSTATE, 0)
+	}
+
+	fetched, err := Fetch(cpu.Mem, addr)
+	if err != nil {
+		return cpu.take_fault(err, BFSR_IBUSERR)
+	}
+
+	/* Undefined instructions fault when they execute */
+	instr, err := fetched.DecodeFor(cpu.Profile)
+	if err != nil && err != ErrUndefinedInstruction {
+		return err
+	}
+	instr = cpu.Epsr.IT.Conditional(instr)
+
+	var size uint32 = 2
+	if _, ok := fetched.(FetchedInstr32); ok {
+		size = 4
+	}
+
+	if cpu.Trace != nil {
+		cpu.Trace(addr, fetched, instr)
+	}
+
+	/* While an instruction executes, PC reads as its address plus 4 */
+	cpu.SetR(PC, addr+4)
+	cpu.branched = false
+	cpu.exc_return = false
+
+	if cpu.ConditionPassed(instr) {
+		instr.Execute(cpu)
+	}
+
+	/* A faulting instruction has no effect, and is left to be retried */
+	if cpu.fault != nil {
+		fault := cpu.fault
+		cpu.fault = nil
+		cpu.SetR(PC, addr)
+		return cpu.take_fault(fault, BFSR_PRECISERR)
+	}
+
+	/* IT sets up the block rather than being part of it */
+	if _, ok := instr.(ItT1); !ok {
+		cpu.ITAdvance()
+	}
+
+	if !cpu.branched {
+		cpu.SetR(PC, addr+size)
+	}
+
+	cpu.Cycles++
+
+	if cpu.exc_return {
+		return cpu.ExceptionReturn(cpu.Pc())
+	}
+
+	return nil
+}
+
+/* Execute instructions until one fails to fetch, decode or execute */
+func (cpu *CPU) Run() error {
+	for {
+		if err := cpu.Step(); err != nil {
+			return err
+		}
+	}
+}
@@ -0,0 +1,260 @@
+package core
+
+import "testing"
+
+func TestStep(t *testing.T) {
+	program := RAM{
+		0x01, 0x20, // 0: mov r0, #1
+		0x88, 0x46, // 2: mov r8, r1
+		0x8f, 0x46, // 4: mov pc, r1
+		0x00, 0x00, // 6: movs r0, r0
+		0xf0, 0xf7, 0x00, 0xa0, // 8: udf.w #0
+	}
+
+	cpu := NewCPU(program)
+	cpu.SetR(1, 8)
+
+	pcs := []uint32{2, 4, 8}
+	for _, pc := range pcs {
+		if err := cpu.Step(); err != nil {
+			t.Fatalf("step: %v", err)
+		}
+
+		if cpu.Pc() != pc {
+			t.Errorf("pc = %#x, expected %#x", cpu.Pc(), pc)
+		}
+	}
+
+	if cpu.R(0) != 1 || cpu.R(8) != 8 {
+		t.Errorf("Unexpected register state:\n%s", cpu.Pretty())
+	}
+
+	if err := cpu.Step(); err != UFSR_UNDEFINSTR {
+		t.Errorf("err = %v, expected %v", err, UFSR_UNDEFINSTR)
+	}
+
+	if cpu.Pc() != 8 {
+		t.Errorf("pc = %#x, expected %#x", cpu.Pc(), 8)
+	}
+}
+
+func TestFetch(t *testing.T) {
+	program := RAM{0x01, 0x20, 0x00, 0xf0, 0x00, 0xf8}
+
+	fetched, err := Fetch(program, 0)
+	if err != nil || fetched != FetchedInstr16(0x2001) {
+		t.Errorf("fetched %v, %v", fetched, err)
+	}
+
+	fetched, err = Fetch(program, 2)
+	if err != nil || fetched != FetchedInstr32(0xf000f800) {
+		t.Errorf("fetched %v, %v", fetched, err)
+	}
+
+	if _, err = Fetch(program, 4); err != ErrUnmappedAccess {
+		t.Errorf("err = %v, expected %v", err, ErrUnmappedAccess)
+	}
+}
+
+func TestReset(t *testing.T) {
+	vectors := make(RAM, 0x208)
+	vectors.Write32(0, SRAM_BASE+SRAM_SIZE)
+	vectors.Write32(4, 0x201)
+	vectors.Write32(0x200, SRAM_BASE+0x1002) // misaligned SP
+	vectors.Write32(0x204, 0x401)
+
+	bus := NewDefaultBus()
+	LoadBytes(bus, FLASH_BASE, vectors)
+
+	cpu := NewCPU(bus)
+	cpu.SetR(0, 0xdead)
+	cpu.Mode = MODE_HANDLER
+	cpu.Primask = true
+	cpu.Basepri = 0x40
+	cpu.Control = Control{Npriv: true, Spsel: PSP, Fpca: true}
+	cpu.Epsr.IT = 0x8
+
+	if err := cpu.Reset(); err != nil {
+		t.Fatalf("reset: %v", err)
+	}
+
+	expected := Registers{sp: SPRegs{SRAM_BASE + SRAM_SIZE, 0}, lr: 0xffffffff, pc: 0x200,
+		Epsr: Epsr{T: true}, Mode: MODE_THREAD, branched: true}
+	if cpu.Registers != expected {
+		t.Errorf("After:\n%s", cpu.Pretty())
+		t.Errorf("Expected:\n%s", expected.Pretty())
+	}
+
+	/* Relocated vector table */
+	if err := cpu.Write32(SCB_VTOR, 0x200); err != nil {
+		t.Fatalf("write VTOR: %v", err)
+	}
+
+	if err := cpu.Reset(); err != nil {
+		t.Fatalf("reset: %v", err)
+	}
+
+	if cpu.Pc() != 0x400 || cpu.Sp() != SRAM_BASE+0x1000 {
+		t.Errorf("pc = %#x, sp = %#x, expected 0x400, %#x", cpu.Pc(), cpu.Sp(), SRAM_BASE+0x1000)
+	}
+}
+
+func TestStepFault(t *testing.T) {
+	bus := NewDefaultBus()
+	LoadBytes(bus, FLASH_BASE, []byte{
+		0x56, 0xf8, 0x04, 0x5f, // 0: ldr r5, [r6, #4]!
+		0x48, 0x68, // 4: ldr r0, [r1, #4]
+	})
+
+	cpu := NewCPU(bus)
+	cpu.SetR(6, SRAM_BASE+SRAM_SIZE-4)
+
+	/* Faulting instructions have no effect */
+	expected := MemoryFault{Addr: SRAM_BASE + SRAM_SIZE, Write: false, Err: ErrUnmappedAccess}
+	if err := cpu.Step(); err != expected {
+		t.Errorf("err = %v, expected %v", err, expected)
+	}
+
+	if cpu.Pc() != 0 || cpu.R(6) != SRAM_BASE+SRAM_SIZE-4 {
+		t.Errorf("Unexpected register state:\n%s", cpu.Pretty())
+	}
+
+	/* Unaligned word loads only fault if CCR.UNALIGN_TRP is set */
+	cpu.SetR(PC, 4)
+	cpu.SetR(1, SRAM_BASE+1)
+	if err := cpu.Step(); err != nil {
+		t.Fatalf("step: %v", err)
+	}
+
+	if err := cpu.Write32(SCB_CCR, CCR_UNALIGN_TRP); err != nil {
+		t.Fatalf("write CCR: %v", err)
+	}
+
+	cpu.SetR(PC, 4)
+	expected = MemoryFault{Addr: SRAM_BASE + 5, Write: false, Err: ErrUnalignedAccess}
+	if err := cpu.Step(); err != expected {
+		t.Errorf("err = %v, expected %v", err, expected)
+	}
+
+	if cpu.Pc() != 4 {
+		t.Errorf("pc = %#x, expected %#x", cpu.Pc(), 4)
+	}
+}
+
+func TestStepPushPop(t *testing.T) {
+	bus := NewDefaultBus()
+	LoadBytes(bus, FLASH_BASE, []byte{
+		0x10, 0xb5, // 0: push {r4, lr}
+		0x00, 0x24, // 2: movs r4, #0
+		0x10, 0xbd, // 4: pop {r4, pc}
+	})
+
+	cpu := NewCPU(bus)
+	cpu.Epsr.T = true
+	cpu.SetR(SP, SRAM_BASE+SRAM_SIZE)
+	cpu.SetR(4, 0x44)
+	cpu.SetR(LR, 0x9)
+
+	for i := 0; i < 3; i++ {
+		if err := cpu.Step(); err != nil {
+			t.Fatalf("step: %v", err)
+		}
+	}
+
+	/* Returned to the Thumb address in LR, with r4 restored */
+	if cpu.Pc() != 0x8 || !cpu.Epsr.T || cpu.R(4) != 0x44 || cpu.Sp() != SRAM_BASE+SRAM_SIZE {
+		t.Errorf("Unexpected register state:\n%s", cpu.Pretty())
+	}
+}
+
+func TestStepInterworking(t *testing.T) {
+	bus := NewDefaultBus()
+	LoadBytes(bus, FLASH_BASE, []byte{
+		0x00, 0x47, // 0: bx r0
+		0x00, 0xbf, // 2: nop
+	})
+
+	cpu := NewCPU(bus)
+	cpu.SetR(0, 0x2)
+
+	if err := cpu.Step(); err != nil {
+		t.Fatalf("step: %v", err)
+	}
+
+	/* The branch itself succeeds, but leaves Thumb state */
+	if cpu.Pc() != 0x2 || cpu.Epsr.T {
+		t.Errorf("Unexpected register state:\n%s", cpu.Pretty())
+	}
+
+	if err := cpu.Step(); err != UFSR_INVSTATE {
+		t.Errorf("Expected %v, got %v", UFSR_INVSTATE, err)
+	}
+
+	if cpu.Pc() != 0x2 {
+		t.Errorf("PC changed to %#x after INVSTATE", cpu.Pc())
+	}
+}
+
+func TestStepIT(t *testing.T) {
+	bus := NewDefaultBus()
+	LoadBytes(bus, FLASH_BASE, []byte{
+		0x06, 0xbf, // 0: itte eq
+		0x01, 0x21, // 2: moveq r1, #1
+		0x02, 0x22, // 4: moveq r2, #2
+		0x03, 0x23, // 6: movne r3, #3
+		0x04, 0x24, // 8: movs r4, #4
+		0xfe, 0xd0, // a: beq #-4
+	})
+
+	cpu := NewCPU(bus)
+	cpu.Apsr.Z = true
+
+	for i := 0; i < 6; i++ {
+		if err := cpu.Step(); err != nil {
+			t.Fatalf("step: %v", err)
+		}
+	}
+
+	/* Instructions in the block don't set the flags, so the Then
+	 * instructions both execute and the Else is skipped. The final
+	 * branch isn't taken, after movs clears Z. */
+	if cpu.R(1) != 1 || cpu.R(2) != 2 || cpu.R(3) != 0 || cpu.R(4) != 4 ||
+		cpu.Apsr.Z || cpu.Epsr.IT != 0 || cpu.Pc() != 0xc {
+		t.Errorf("Unexpected register state:\n%s", cpu.Pretty())
+	}
+}
+
+func TestStepSleep(t *testing.T) {
+	program := RAM{
+		0x30, 0xbf, // 0: wfi
+		0x01, 0x20, // 2: movs r0, #1
+	}
+
+	cpu := NewCPU(program)
+
+	if err := cpu.Step(); err != nil || cpu.Sleep != SLEEP_WFI || cpu.Pc() != 2 {
+		t.Fatalf("wfi: err = %v, sleep = %v, pc = %#x", err, cpu.Sleep, cpu.Pc())
+	}
+
+	/* Nothing can wake the core */
+	if err := cpu.Step(); err != ErrSleeping {
+		t.Errorf("err = %v, expected %v", err, ErrSleeping)
+	}
+
+	/* A timer due at cycle 1000 wakes it, skipping the time between */
+	cpu.Idle = func(now uint64) (uint64, bool) {
+		if now != 1 {
+			t.Errorf("idle at %d, expected 1", now)
+		}
+		cpu.Wake()
+		return 1000, true
+	}
+
+	if err := cpu.Step(); err != nil || cpu.Sleep != AWAKE || cpu.Cycles != 1000 {
+		t.Fatalf("idle: err = %v, sleep = %v, cycles = %d", err, cpu.Sleep, cpu.Cycles)
+	}
+
+	if err := cpu.Step(); err != nil || cpu.R(0) != 1 || cpu.Cycles != 1001 {
+		t.Errorf("movs: err = %v, r0 = %d, cycles = %d", err, cpu.R(0), cpu.Cycles)
+	}
+}
@@ -21,6 +21,7 @@ var ErrUndefinedInstruction = errors.New("Instruction not defined.")
 
 type FetchedInstr interface {
 	Decode() (DecodedInstr, error)
+	DecodeFor(profile Profile) (DecodedInstr, error)
 	String() string
 	Uint32() uint32
 }
@@ -29,25 +30,34 @@ type FetchedInstr16 uint16
 type FetchedInstr32 uint32
 
 func (instr FetchedInstr16) Decode() (DecodedInstr, error) {
-	raw_instr := uint16(instr)
-
 	/* Check if this is the beginning of a 32-bit instruction */
-	switch raw_instr & WORD_INSTR_MASK {
-	case WORD_INSTR1, WORD_INSTR2, WORD_INSTR3:
+	if instr.IsWordInstr() {
 		return nil, ErrIncompleteInstruction
 	}
 
 	/* Check for a matching opcode */
-	for opcode, decode := range InstrOpcodes16 {
-		if opcode.Match(instr) {
-			/* Instruction identified, now decode it */
-			return decode(instr), nil
-		}
+	if decoded, ok := decode_opcodes(instr, InstrOpcodes16); ok {
+		return decoded, nil
 	}
 
 	return UndefinedInstr{}, ErrUndefinedInstruction
 }
 
+/* None of the 16-bit instructions are optional */
+func (instr FetchedInstr16) DecodeFor(profile Profile) (DecodedInstr, error) {
+	return instr.Decode()
+}
+
+/* Is this halfword the first half of a 32-bit instruction? */
+func (instr FetchedInstr16) IsWordInstr() bool {
+	switch uint16(instr) & WORD_INSTR_MASK {
+	case WORD_INSTR1, WORD_INSTR2, WORD_INSTR3:
+		return true
+	}
+
+	return false
+}
+
 func (instr FetchedInstr16) Uint32() uint32 {
 	return uint32(instr)
 }
@@ -61,17 +71,50 @@ func (upper FetchedInstr16) Extend(lower FetchedInstr16) FetchedInstr32 {
 	return FetchedInstr32((uint32(upper) << 16) | uint32(lower))
 }
 
+/* Decode as any ARMv7E-M instruction, including the FP extension */
 func (instr FetchedInstr32) Decode() (DecodedInstr, error) {
+	return instr.DecodeFor(PROFILE_ARMV7EM_FP)
+}
+
+/* Decode, treating instructions of extensions missing from profile as
+ * UNDEFINED */
+func (instr FetchedInstr32) DecodeFor(profile Profile) (DecodedInstr, error) {
 	/* Check for a matching opcode */
-	for opcode, decode := range InstrOpcodes32 {
-		if opcode.Match(instr) {
-			return decode(instr), nil
+	if decoded, ok := decode_opcodes(instr, InstrOpcodes32); ok {
+		return decoded, nil
+	}
+
+	if profile.DSP {
+		if decoded, ok := decode_opcodes(instr, DSPOpcodes32); ok {
+			return decoded, nil
 		}
 	}
 
+	if profile.FPU {
+		if decoded, ok := decode_opcodes(instr, FPOpcodes32); ok {
+			return decoded, nil
+		}
+	}
+
+	if decoded, ok := decode_opcodes(instr, CoprocessorOpcodes32); ok {
+		return decoded, nil
+	}
+
 	return UndefinedInstr{}, ErrUndefinedInstruction
 }
 
+/* Decode instr with the first matching opcode in opcodes */
+func decode_opcodes(instr FetchedInstr, opcodes map[Opcode]DecodeFunc) (DecodedInstr, bool) {
+	for opcode, decode := range opcodes {
+		if opcode.Match(instr) {
+			/* Instruction identified, now decode it */
+			return decode(instr), true
+		}
+	}
+
+	return nil, false
+}
+
 func (instr FetchedInstr32) Uint32() uint32 {
 	return uint32(instr)
 }
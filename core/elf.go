@@ -0,0 +1,145 @@
+package core
+
+import (
+	"debug/elf"
+	"errors"
+	"io"
+	"sort"
+	"strings"
+)
+
+var ErrNotARMExecutable = errors.New("Not a 32-bit little-endian ARM ELF executable.")
+
+type Symbol struct {
+	Name string
+	Addr uint32
+	Size uint32
+}
+
+/* Symbols sorted by address */
+type SymbolTable []Symbol
+
+func (table SymbolTable) Len() int           { return len(table) }
+func (table SymbolTable) Less(i, j int) bool { return table[i].Addr < table[j].Addr }
+func (table SymbolTable) Swap(i, j int)      { table[i], table[j] = table[j], table[i] }
+
+/* Find the symbol containing addr, if any. Symbols without a size
+ * contain everything up to the next symbol. */
+func (table SymbolTable) Lookup(addr uint32) (Symbol, bool) {
+	i := sort.Search(len(table), func(i int) bool { return table[i].Addr > addr })
+	if i == 0 {
+		return Symbol{}, false
+	}
+
+	sym := table[i-1]
+	if sym.Size != 0 && addr-sym.Addr >= sym.Size {
+		return Symbol{}, false
+	}
+
+	return sym, true
+}
+
+/* A loaded PT_LOAD segment */
+type Segment struct {
+	Addr       uint32 // Load (physical) address
+	Size       uint32 // Bytes loaded from the file
+	Executable bool
+}
+
+type Image struct {
+	Entry    uint32
+	Segments []Segment
+	Symbols  SymbolTable
+}
+
+/* Load an ELF32 little-endian ARM executable into mem.
+ *
+ * Segments are loaded at their physical address (LMA). Segments such
+ * as .data, whose virtual address is in RAM, are left for the startup
+ * code to copy, just as on hardware. Any zero-initialized tail of a
+ * segment (.bss) is cleared at its virtual address. */
+func LoadELF(r io.ReaderAt, mem Memory) (*Image, error) {
+	file, err := elf.NewFile(r)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	if file.Class != elf.ELFCLASS32 || file.Data != elf.ELFDATA2LSB ||
+		file.Machine != elf.EM_ARM || file.Type != elf.ET_EXEC {
+		return nil, ErrNotARMExecutable
+	}
+
+	image := &Image{Entry: uint32(file.Entry)}
+
+	for _, prog := range file.Progs {
+		if prog.Type != elf.PT_LOAD {
+			continue
+		}
+
+		data := make([]byte, prog.Filesz)
+		if _, err := prog.ReadAt(data, 0); err != nil {
+			return nil, err
+		}
+
+		if err := LoadBytes(mem, uint32(prog.Paddr), data); err != nil {
+			return nil, err
+		}
+
+		if prog.Memsz > prog.Filesz {
+			zeros := make([]byte, prog.Memsz-prog.Filesz)
+			if err := LoadBytes(mem, uint32(prog.Vaddr+prog.Filesz), zeros); err != nil {
+				return nil, err
+			}
+		}
+
+		image.Segments = append(image.Segments, Segment{
+			Addr:       uint32(prog.Paddr),
+			Size:       uint32(prog.Filesz),
+			Executable: prog.Flags&elf.PF_X != 0,
+		})
+	}
+
+	image.Symbols, err = elf_symbols(file)
+	if err != nil {
+		return nil, err
+	}
+
+	return image, nil
+}
+
+/* Collect named function and object symbols, sorted by address */
+func elf_symbols(file *elf.File) (SymbolTable, error) {
+	syms, err := file.Symbols()
+	if err == elf.ErrNoSymbols {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+
+	var table SymbolTable
+
+	for _, sym := range syms {
+		switch elf.ST_TYPE(sym.Info) {
+		case elf.STT_FUNC, elf.STT_OBJECT, elf.STT_NOTYPE:
+		default:
+			continue
+		}
+
+		/* Skip unnamed, undefined and mapping ($t, $d) symbols */
+		if sym.Name == "" || sym.Section == elf.SHN_UNDEF || strings.HasPrefix(sym.Name, "$") {
+			continue
+		}
+
+		addr := uint32(sym.Value)
+		if elf.ST_TYPE(sym.Info) == elf.STT_FUNC {
+			addr &^= 0x1 // Clear the thumb bit
+		}
+
+		table = append(table, Symbol{Name: sym.Name, Addr: addr, Size: uint32(sym.Size)})
+	}
+
+	sort.Stable(table)
+
+	return table, nil
+}
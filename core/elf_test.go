@@ -0,0 +1,186 @@
+package core
+
+import (
+	"bytes"
+	"debug/elf"
+	"encoding/binary"
+	"testing"
+)
+
+type elf_sym struct {
+	name  string
+	value uint32
+	size  uint32
+	typ   elf.SymType
+}
+
+/* Build a minimal ARM executable with a flash text segment and a .data
+ * segment whose LMA (in flash) differs from its VMA (in SRAM) */
+func build_elf(text []byte, data []byte, bss uint32, syms []elf_sym) []byte {
+	const (
+		ehsize  = 52
+		phsize  = 32
+		shsize  = 40
+		symsize = 16
+		lma     = 0x100
+	)
+
+	var strtab bytes.Buffer
+	strtab.WriteByte(0)
+	add_str := func(s string) uint32 {
+		off := uint32(strtab.Len())
+		strtab.WriteString(s)
+		strtab.WriteByte(0)
+		return off
+	}
+	symtab_name := add_str(".symtab")
+	strtab_name := add_str(".strtab")
+
+	var symtab bytes.Buffer
+	binary.Write(&symtab, binary.LittleEndian, elf.Sym32{})
+	for _, sym := range syms {
+		binary.Write(&symtab, binary.LittleEndian, elf.Sym32{
+			Name:  add_str(sym.name),
+			Value: sym.value,
+			Size:  sym.size,
+			Info:  elf.ST_INFO(elf.STB_GLOBAL, sym.typ),
+			Shndx: uint16(elf.SHN_ABS),
+		})
+	}
+
+	text_off := uint32(ehsize + 2*phsize)
+	data_off := text_off + uint32(len(text))
+	strtab_off := data_off + uint32(len(data))
+	symtab_off := (strtab_off + uint32(strtab.Len()) + 3) &^ 3
+	sh_off := symtab_off + uint32(symtab.Len())
+
+	var out bytes.Buffer
+	header := elf.Header32{
+		Type:      uint16(elf.ET_EXEC),
+		Machine:   uint16(elf.EM_ARM),
+		Version:   uint32(elf.EV_CURRENT),
+		Entry:     0x1,
+		Phoff:     ehsize,
+		Shoff:     sh_off,
+		Ehsize:    ehsize,
+		Phentsize: phsize,
+		Phnum:     2,
+		Shentsize: shsize,
+		Shnum:     3,
+		Shstrndx:  2,
+	}
+	copy(header.Ident[:], elf.ELFMAG)
+	header.Ident[elf.EI_CLASS] = byte(elf.ELFCLASS32)
+	header.Ident[elf.EI_DATA] = byte(elf.ELFDATA2LSB)
+	header.Ident[elf.EI_VERSION] = byte(elf.EV_CURRENT)
+	binary.Write(&out, binary.LittleEndian, header)
+
+	binary.Write(&out, binary.LittleEndian, elf.Prog32{
+		Type: uint32(elf.PT_LOAD), Off: text_off, Vaddr: FLASH_BASE, Paddr: FLASH_BASE,
+		Filesz: uint32(len(text)), Memsz: uint32(len(text)), Flags: uint32(elf.PF_R | elf.PF_X), Align: 4,
+	})
+	binary.Write(&out, binary.LittleEndian, elf.Prog32{
+		Type: uint32(elf.PT_LOAD), Off: data_off, Vaddr: SRAM_BASE, Paddr: lma,
+		Filesz: uint32(len(data)), Memsz: uint32(len(data)) + bss, Flags: uint32(elf.PF_R | elf.PF_W), Align: 4,
+	})
+
+	out.Write(text)
+	out.Write(data)
+	out.Write(strtab.Bytes())
+	for uint32(out.Len()) < symtab_off {
+		out.WriteByte(0)
+	}
+	out.Write(symtab.Bytes())
+
+	binary.Write(&out, binary.LittleEndian, elf.Section32{})
+	binary.Write(&out, binary.LittleEndian, elf.Section32{
+		Name: symtab_name, Type: uint32(elf.SHT_SYMTAB), Off: symtab_off,
+		Size: uint32(symtab.Len()), Link: 2, Info: 1, Addralign: 4, Entsize: symsize,
+	})
+	binary.Write(&out, binary.LittleEndian, elf.Section32{
+		Name: strtab_name, Type: uint32(elf.SHT_STRTAB), Off: strtab_off,
+		Size: uint32(strtab.Len()), Addralign: 1,
+	})
+
+	return out.Bytes()
+}
+
+func TestLoadELF(t *testing.T) {
+	text := []byte{0x01, 0x20, 0xfe, 0xe7} // mov r0, #1; b .
+	data := []byte{0xef, 0xbe, 0xad, 0xde}
+	syms := []elf_sym{
+		{name: "main", value: 0x3, size: 2, typ: elf.STT_FUNC},
+		{name: "_start", value: 0x1, size: 2, typ: elf.STT_FUNC},
+		{name: "counter", value: SRAM_BASE, size: 4, typ: elf.STT_OBJECT},
+		{name: "$t", value: 0x0, typ: elf.STT_NOTYPE},
+	}
+
+	bus := NewDefaultBus()
+	bus.Write32(SRAM_BASE+4, 0xffffffff)
+
+	image, err := LoadELF(bytes.NewReader(build_elf(text, data, 4, syms)), bus)
+	if err != nil {
+		t.Fatalf("LoadELF: %v", err)
+	}
+
+	if image.Entry != 0x1 {
+		t.Errorf("entry = %#x, expected 0x1", image.Entry)
+	}
+
+	if w, _ := bus.Read32(FLASH_BASE); w != 0xe7fe2001 {
+		t.Errorf("text = %#x, expected 0xe7fe2001", w)
+	}
+
+	/* .data at its LMA, not its VMA */
+	if w, _ := bus.Read32(0x100); w != 0xdeadbeef {
+		t.Errorf("data LMA = %#x, expected 0xdeadbeef", w)
+	}
+	if w, _ := bus.Read32(SRAM_BASE); w != 0 {
+		t.Errorf("data VMA = %#x, expected 0", w)
+	}
+
+	/* .bss cleared */
+	if w, _ := bus.Read32(SRAM_BASE + 4); w != 0 {
+		t.Errorf("bss = %#x, expected 0", w)
+	}
+
+	expected_segs := []Segment{
+		{Addr: FLASH_BASE, Size: 4, Executable: true},
+		{Addr: 0x100, Size: 4, Executable: false},
+	}
+	if len(image.Segments) != len(expected_segs) {
+		t.Fatalf("segments = %v, expected %v", image.Segments, expected_segs)
+	}
+	for i := range expected_segs {
+		if image.Segments[i] != expected_segs[i] {
+			t.Errorf("segment %d = %v, expected %v", i, image.Segments[i], expected_segs[i])
+		}
+	}
+
+	lookups := []struct {
+		addr  uint32
+		name  string
+		found bool
+	}{
+		{addr: 0x0, name: "_start", found: true},
+		{addr: 0x2, name: "main", found: true},
+		{addr: 0x4, found: false},
+		{addr: SRAM_BASE + 3, name: "counter", found: true},
+		{addr: SRAM_BASE + 4, found: false},
+	}
+	for _, l := range lookups {
+		sym, ok := image.Symbols.Lookup(l.addr)
+		if ok != l.found || sym.Name != l.name {
+			t.Errorf("Lookup(%#x) = %v, %v, expected %q, %v", l.addr, sym, ok, l.name, l.found)
+		}
+	}
+}
+
+func TestLoadELFRejectsOtherMachines(t *testing.T) {
+	image := build_elf([]byte{0, 0}, nil, 0, nil)
+	binary.LittleEndian.PutUint16(image[18:], uint16(elf.EM_X86_64))
+
+	if _, err := LoadELF(bytes.NewReader(image), NewDefaultBus()); err != ErrNotARMExecutable {
+		t.Errorf("err = %v, expected %v", err, ErrNotARMExecutable)
+	}
+}
@@ -0,0 +1,561 @@
+package core
+
+import "errors"
+
+var ErrLockup = errors.New("Locked up, by a fault that HardFault cannot preempt.")
+
+/* Exception numbers
+ * ARMv7-M ARM B1.5.2 */
+const (
+	EXCEPTION_RESET        = 1
+	EXCEPTION_NMI          = 2
+	EXCEPTION_HARDFAULT    = 3
+	EXCEPTION_MEMMANAGE    = 4
+	EXCEPTION_BUSFAULT     = 5
+	EXCEPTION_USAGEFAULT   = 6
+	EXCEPTION_SVCALL       = 11
+	EXCEPTION_DEBUGMONITOR = 12
+	EXCEPTION_PENDSV       = 14
+	EXCEPTION_SYSTICK      = 15
+	EXCEPTION_IRQ0         = 16 // First external interrupt
+)
+
+/* Exception numbers range up to 511, those from 16 being external
+ * interrupts */
+const EXCEPTION_COUNT = 512
+
+/* Exceptions waiting to be taken, indexed by exception number */
+type PendingExceptions [EXCEPTION_COUNT]bool
+
+/* Exceptions whose handlers have been entered and not yet returned from,
+ * indexed by exception number */
+type ActiveExceptions [EXCEPTION_COUNT]bool
+
+/* Configurable priorities, indexed by exception number, lower values
+ * taking precedence */
+type ExceptionPriorities [EXCEPTION_COUNT]uint8
+
+/* Fixed priorities, above any configurable priority, and the priority of
+ * Thread mode, below any exception
+ * ARMv7-M ARM B1.5.4 */
+const (
+	PRIORITY_RESET     = -3
+	PRIORITY_NMI       = -2
+	PRIORITY_HARDFAULT = -1
+	PRIORITY_THREAD    = 256
+)
+
+/* Mark excp as pending, to be taken once its priority allows */
+func (cpu *CPU) SetPending(excp uint16) {
+	cpu.pending[excp] = true
+}
+
+func (cpu *CPU) ClearPending(excp uint16) {
+	cpu.pending[excp] = false
+}
+
+func (cpu *CPU) IsPending(excp uint16) bool {
+	return cpu.pending[excp]
+}
+
+func (cpu *CPU) IsActive(excp uint16) bool {
+	return cpu.active[excp]
+}
+
+/* Number of active exceptions, nested by preemption */
+func (cpu *CPU) active_count() int {
+	count := 0
+	for _, active := range cpu.active {
+		if active {
+			count++
+		}
+	}
+
+	return count
+}
+
+/* The priority of excp, fixed or configured */
+func (cpu *CPU) ExceptionPriority(excp uint16) int {
+	switch excp {
+	case EXCEPTION_RESET:
+		return PRIORITY_RESET
+	case EXCEPTION_NMI:
+		return PRIORITY_NMI
+	case EXCEPTION_HARDFAULT:
+		return PRIORITY_HARDFAULT
+	}
+
+	return int(cpu.priority[excp])
+}
+
+/* The group priority of excp, which decides preemption. The subpriority
+ * bits below AIRCR.PRIGROUP only order exceptions pending together.
+ * ARMv7-M ARM B1.5.4 */
+func (cpu *CPU) group_priority(excp uint16) int {
+	priority := cpu.ExceptionPriority(excp)
+	if priority < 0 {
+		return priority
+	}
+
+	return priority & cpu.group_mask()
+}
+
+/* The bits of a priority giving its group priority */
+func (cpu *CPU) group_mask() int {
+	prigroup := (cpu.Scb.Aircr & AIRCR_PRIGROUP_MASK) >> AIRCR_PRIGROUP_SHIFT
+	return (0xff << (prigroup + 1)) & 0xff
+}
+
+/* Can excp have its priority configured, through SHPR1-3 for the
+ * system handlers? */
+func priority_configurable(excp uint16) bool {
+	switch excp {
+	case EXCEPTION_MEMMANAGE, EXCEPTION_BUSFAULT, EXCEPTION_USAGEFAULT,
+		EXCEPTION_SVCALL, EXCEPTION_DEBUGMONITOR, EXCEPTION_PENDSV, EXCEPTION_SYSTICK:
+		return true
+	}
+
+	return excp >= EXCEPTION_IRQ0
+}
+
+/* The priority below which the current context is preempted: the group
+ * priority of the highest priority active exception, boosted by BASEPRI,
+ * PRIMASK and FAULTMASK
+ * ARMv7-M ARM pseudocode ExecutionPriority() */
+func (cpu *CPU) ExecutionPriority() int {
+	return cpu.execution_priority(cpu.Primask)
+}
+
+func (cpu *CPU) execution_priority(primask bool) int {
+	highest := PRIORITY_THREAD
+	for excp := uint16(EXCEPTION_NMI); excp < EXCEPTION_COUNT; excp++ {
+		if cpu.active[excp] {
+			if priority := cpu.group_priority(excp); priority < highest {
+				highest = priority
+			}
+		}
+	}
+
+	boosted := PRIORITY_THREAD
+	if cpu.Basepri != 0 {
+		boosted = int(cpu.Basepri) & cpu.group_mask()
+	}
+	if primask {
+		boosted = 0
+	}
+	if cpu.Faultmask {
+		boosted = PRIORITY_HARDFAULT
+	}
+
+	if boosted < highest {
+		return boosted
+	}
+
+	return highest
+}
+
+/* The highest priority pending exception, the lowest numbered of those
+ * with equal priority. Disabled interrupts are left pending. */
+func (cpu *CPU) highest_pending() (uint16, bool) {
+	found := false
+	var highest uint16
+
+	for excp := uint16(EXCEPTION_RESET); excp < EXCEPTION_COUNT; excp++ {
+		if !cpu.pending[excp] || !cpu.interrupt_enabled(excp) {
+			continue
+		}
+
+		if !found || cpu.ExceptionPriority(excp) < cpu.ExceptionPriority(highest) {
+			highest = excp
+			found = true
+		}
+	}
+
+	return highest, found
+}
+
+/* The pending exception to take now, if any can preempt */
+func (cpu *CPU) PendingException() (uint16, bool) {
+	excp, ok := cpu.highest_pending()
+	if !ok || cpu.group_priority(excp) >= cpu.ExecutionPriority() {
+		return 0, false
+	}
+
+	return excp, true
+}
+
+/* Is an exception pending that WFI wakes for? Those that could preempt
+ * if PRIMASK were clear do, so that sleeping with interrupts masked
+ * resumes after the WFI.
+ * ARMv7-M ARM B1.5.19 */
+func (cpu *CPU) wakeup_pending() bool {
+	excp, ok := cpu.highest_pending()
+	return ok && cpu.group_priority(excp) < cpu.execution_priority(false)
+}
+
+/* Return excp to inactive, as its handler returns. An interrupt whose
+ * line is still asserted pends again. */
+func (cpu *CPU) deactivate(excp uint16) {
+	cpu.active[excp] = false
+	if excp != EXCEPTION_NMI {
+		cpu.Faultmask = false
+	}
+
+	if irq := int(excp) - EXCEPTION_IRQ0; cpu.Nvic.implemented(irq) && cpu.Nvic.lines[irq] {
+		cpu.SetPending(excp)
+	}
+}
+
+/* EXC_RETURN values, loaded into LR on exception entry. Bit 4 is clear
+ * when an extended frame was pushed.
+ * ARMv7-M ARM B1.5.8 */
+const (
+	EXC_RETURN_HANDLER     = 0xfffffff1 // Handler mode, main stack
+	EXC_RETURN_THREAD_MSP  = 0xfffffff9 // Thread mode, main stack
+	EXC_RETURN_THREAD_PSP  = 0xfffffffd // Thread mode, process stack
+	EXC_RETURN_BASIC_FRAME = 1 << 4
+
+	EXC_RETURN_PREFIX = 0xf0000000 // Branching here in Handler mode returns
+	EXC_RETURN_ONES   = 0x0fffffe0 // Bits 27:5, which must be set
+	EXC_RETURN_MODE   = 0xf        // Bits 3:0, selecting the mode and stack
+)
+
+/* The EXC_RETURN of an exception taken now, returning to the current mode
+ * and stack, and the frame type pushed
+ * ARMv7-M ARM pseudocode PushStack() */
+func (cpu *CPU) ExcReturn() uint32 {
+	var exc_return uint32
+
+	switch {
+	case cpu.Mode == MODE_HANDLER:
+		exc_return = EXC_RETURN_HANDLER
+	case cpu.Control.Spsel == PSP:
+		exc_return = EXC_RETURN_THREAD_PSP
+	default:
+		exc_return = EXC_RETURN_THREAD_MSP
+	}
+
+	if cpu.ExtendedFrame() {
+		exc_return &^= EXC_RETURN_BASIC_FRAME
+	}
+
+	return exc_return
+}
+
+/* Stack the context preempted by an exception on its current stack,
+ * aligned to 8 bytes if CCR.STKALIGN is set, and load LR with
+ * EXC_RETURN. Faults while stacking are pended as derived exceptions,
+ * returning ErrLockup if they cannot be.
+ * ARMv7-M ARM pseudocode PushStack() */
+func (cpu *CPU) PushStack() error {
+	exc_return := cpu.ExcReturn()
+
+	var forcealign uint32
+	if cpu.Scb.Ccr&CCR_STKALIGN != 0 {
+		forcealign = 1
+	}
+
+	sp := cpu.LookupSP()
+	frameptralign := (cpu.sp[sp] >> 2) & forcealign
+	frameptr := (cpu.sp[sp] - cpu.FrameSize()) &^ (forcealign << 2)
+	cpu.sp[sp] = frameptr
+
+	/* Bit 9 of the stacked xPSR records the padding word */
+	xpsr := cpu.Xpsr()&^(1<<9) | frameptralign<<9
+
+	frame := [8]uint32{cpu.R(0), cpu.R(1), cpu.R(2), cpu.R(3), cpu.R(12), cpu.Lr(), cpu.Pc(), xpsr}
+	for i, word := range frame {
+		if !cpu.SetMemA(frameptr+4*uint32(i), 4, word) {
+			break
+		}
+	}
+
+	if cpu.fault == nil && cpu.ExtendedFrame() {
+		cpu.PushFPContext(frameptr)
+	}
+
+	cpu.SetR(LR, exc_return)
+
+	return cpu.derived_fault(BFSR_STKERR)
+}
+
+/* Unstack the context an exception returns to, from frameptr on the
+ * stack now selected. Nothing is restored if any access faults.
+ * ARMv7-M ARM pseudocode PopStack() */
+func (cpu *CPU) PopStack(frameptr uint32, exc_return uint32) bool {
+	var frame [8]uint32
+	for i := range frame {
+		word, ok := cpu.MemA(frameptr+4*uint32(i), 4)
+		if !ok {
+			return false
+		}
+
+		frame[i] = word
+	}
+
+	framesize := uint32(FRAME_SIZE_BASIC)
+	extended := exc_return&EXC_RETURN_BASIC_FRAME == 0
+	if extended {
+		framesize = FRAME_SIZE_EXTENDED
+		if !cpu.PopFPContext(frameptr) {
+			return false
+		}
+	}
+
+	xpsr := frame[7]
+
+	var spmask uint32
+	if cpu.Scb.Ccr&CCR_STKALIGN != 0 {
+		spmask = xpsr & (1 << 9) >> 7
+	}
+	cpu.SetR(SP, (frameptr+framesize)|spmask)
+
+	for i, reg := range []RegIndex{0, 1, 2, 3, 12, LR} {
+		cpu.SetR(reg, frame[i])
+	}
+	cpu.SetXpsr(xpsr)
+	cpu.BranchTo(frame[6])
+
+	if cpu.Profile.FPU {
+		cpu.Control.Fpca = extended
+	}
+
+	return true
+}
+
+/* Enter the handler of excp, its context already stacked. If the vector
+ * cannot be read, HardFault is taken instead.
+ * ARMv7-M ARM pseudocode ExceptionTaken() */
+func (cpu *CPU) ExceptionTaken(excp uint16) error {
+	vectors := cpu.Scb.Vtor & VTOR_TBLOFF_MASK
+
+	vector, err := cpu.Read32(vectors + 4*uint32(excp))
+	if err != nil {
+		if excp == EXCEPTION_HARDFAULT || cpu.ExecutionPriority() <= PRIORITY_HARDFAULT {
+			return ErrLockup
+		}
+
+		cpu.pending[excp] = false
+		cpu.Scb.Hfsr |= HFSR_VECTTBL
+		return cpu.ExceptionTaken(EXCEPTION_HARDFAULT)
+	}
+
+	cpu.Mode = MODE_HANDLER
+	cpu.Ipsr.ExcpNum = excp
+	cpu.Epsr = Epsr{T: (vector & 0x1) != 0}
+	cpu.Control.Spsel = MSP
+	cpu.Control.Fpca = false
+	cpu.BranchTo(vector &^ 0x1)
+
+	cpu.pending[excp] = false
+	cpu.active[excp] = true
+
+	cpu.ClearExclusiveLocal()
+	cpu.Sleep = AWAKE
+
+	return nil
+}
+
+/* Preempt the current context with excp. Should a higher priority
+ * exception become pending while stacking, such as a fault stacking
+ * itself, it arrives late and is taken in place of excp, which remains
+ * pending.
+ * ARMv7-M ARM B1.5.6 */
+func (cpu *CPU) ExceptionEntry(excp uint16) error {
+	priority := cpu.ExecutionPriority()
+
+	if err := cpu.PushStack(); err != nil {
+		return err
+	}
+
+	if late, ok := cpu.highest_pending(); ok && cpu.group_priority(late) < priority {
+		excp = late
+	}
+
+	return cpu.ExceptionTaken(excp)
+}
+
+/* Return from the active exception, as a BX, POP, LDM or LDR writing
+ * exc_return to PC in Handler mode does. An exception pending above the
+ * priority returned to is tail-chained, entered without unstacking and
+ * restacking. Invalid returns raise INVPC.
+ * ARMv7-M ARM pseudocode ExceptionReturn() */
+func (cpu *CPU) ExceptionReturn(exc_return uint32) error {
+	returning := cpu.Ipsr.ExcpNum
+
+	if !cpu.active[returning] || exc_return&EXC_RETURN_ONES != EXC_RETURN_ONES ||
+		(exc_return&EXC_RETURN_BASIC_FRAME == 0 && !cpu.Profile.FPU) {
+		return cpu.invalid_return(exc_return)
+	}
+
+	var mode Mode
+	var stack SPType
+
+	switch exc_return & EXC_RETURN_MODE {
+	case EXC_RETURN_HANDLER & EXC_RETURN_MODE:
+		mode, stack = MODE_HANDLER, MSP
+	case EXC_RETURN_THREAD_MSP & EXC_RETURN_MODE:
+		mode, stack = MODE_THREAD, MSP
+	case EXC_RETURN_THREAD_PSP & EXC_RETURN_MODE:
+		mode, stack = MODE_THREAD, PSP
+	default:
+		return cpu.invalid_return(exc_return)
+	}
+
+	/* Thread mode can't be returned to with other exceptions active,
+	 * unless CCR.NONBASETHRDENA allows it */
+	if mode == MODE_THREAD && cpu.active_count() != 1 && cpu.Scb.Ccr&CCR_NONBASETHRDENA == 0 {
+		return cpu.invalid_return(exc_return)
+	}
+
+	cpu.deactivate(returning)
+
+	/* Tail-chain, the next handler returning as this one would have */
+	if excp, ok := cpu.PendingException(); ok {
+		cpu.SetR(LR, exc_return)
+		return cpu.ExceptionTaken(excp)
+	}
+
+	cpu.Mode = mode
+	cpu.Control.Spsel = stack
+	if !cpu.PopStack(cpu.sp[stack], exc_return) {
+		cpu.Mode = MODE_HANDLER
+		cpu.Control.Spsel = MSP
+
+		return cpu.tail_chain_fault(exc_return, BFSR_UNSTKERR)
+	}
+
+	cpu.ClearExclusiveLocal()
+	cpu.Event = true
+
+	/* The stacked IPSR must agree with the mode returned to */
+	if (mode == MODE_HANDLER) != (cpu.Ipsr.ExcpNum != 0) {
+		if err := cpu.pend_fault(UFSR_INVPC, 0); err != nil {
+			return err
+		}
+
+		excp, _ := cpu.PendingException()
+		return cpu.ExceptionEntry(excp)
+	}
+
+	return nil
+}
+
+/* Fault an exception return, taking UsageFault INVPC from the handler
+ * with its frame still stacked and LR holding the rejected EXC_RETURN */
+func (cpu *CPU) invalid_return(exc_return uint32) error {
+	cpu.SetR(LR, exc_return)
+	cpu.Scb.Cfsr |= uint32(UFSR_INVPC) << CFSR_UFSR_SHIFT
+
+	if err := cpu.pend_escalated(EXCEPTION_USAGEFAULT); err != nil {
+		return err
+	}
+
+	excp, _ := cpu.PendingException()
+	return cpu.ExceptionTaken(excp)
+}
+
+/* Take a fault raised by exception return, with the frame left stacked */
+func (cpu *CPU) tail_chain_fault(exc_return uint32, cause BusFault) error {
+	if err := cpu.derived_fault(cause); err != nil {
+		return err
+	}
+
+	cpu.SetR(LR, exc_return)
+
+	excp, _ := cpu.PendingException()
+	return cpu.ExceptionTaken(excp)
+}
+
+/* Pend the fault raised by the stacking or unstacking accesses, if any.
+ * Bus errors are reported as cause. */
+func (cpu *CPU) derived_fault(cause BusFault) error {
+	if cpu.fault == nil {
+		return nil
+	}
+
+	fault := cpu.fault
+	cpu.fault = nil
+
+	return cpu.pend_fault(fault, cause)
+}
+
+/* Is the configurable fault excp enabled by SHCSR? Disabled faults
+ * escalate to HardFault. */
+func (cpu *CPU) fault_enabled(excp uint16) bool {
+	switch excp {
+	case EXCEPTION_MEMMANAGE:
+		return cpu.Scb.Shcsr&SHCSR_MEMFAULTENA != 0
+	case EXCEPTION_BUSFAULT:
+		return cpu.Scb.Shcsr&SHCSR_BUSFAULTENA != 0
+	case EXCEPTION_USAGEFAULT:
+		return cpu.Scb.Shcsr&SHCSR_USGFAULTENA != 0
+	}
+
+	return true
+}
+
+/* Could the configurable fault excp be pended now, without escalating? */
+func (cpu *CPU) fault_ready(excp uint16) bool {
+	return cpu.fault_enabled(excp) && cpu.group_priority(excp) < cpu.ExecutionPriority()
+}
+
+/* Pend a synchronous exception, which must preempt at once. One that is
+ * disabled or cannot preempt escalates to HardFault, and if HardFault
+ * cannot preempt either the core locks up.
+ * ARMv7-M ARM B1.5.4 */
+func (cpu *CPU) pend_escalated(excp uint16) error {
+	if !cpu.fault_ready(excp) {
+		if cpu.ExecutionPriority() <= PRIORITY_HARDFAULT {
+			return ErrLockup
+		}
+
+		cpu.Scb.Hfsr |= HFSR_FORCED
+		excp = EXCEPTION_HARDFAULT
+	}
+
+	cpu.SetPending(excp)
+
+	return nil
+}
+
+/* Record fault in the fault status registers, and pend its exception.
+ * Unaligned accesses are UsageFaults. Other memory faults, and errors
+ * fetching instructions, are BusFaults reported as cause, with BFAR
+ * holding the address of a precise one. */
+func (cpu *CPU) pend_fault(fault error, cause BusFault) error {
+	switch fault := fault.(type) {
+	case UsageFault:
+		cpu.Scb.Cfsr |= uint32(fault) << CFSR_UFSR_SHIFT
+		return cpu.pend_escalated(EXCEPTION_USAGEFAULT)
+	case MemoryFault:
+		if fault.Err == ErrUnalignedAccess {
+			cpu.Scb.Cfsr |= uint32(UFSR_UNALIGNED) << CFSR_UFSR_SHIFT
+			return cpu.pend_escalated(EXCEPTION_USAGEFAULT)
+		}
+
+		if cause == BFSR_PRECISERR {
+			cpu.Scb.Bfar = fault.Addr
+			cause |= BFSR_BFARVALID
+		}
+	}
+
+	cpu.Scb.Cfsr |= uint32(cause) << CFSR_BFSR_SHIFT
+	return cpu.pend_escalated(EXCEPTION_BUSFAULT)
+}
+
+/* Handle fault, raised by the instruction at PC. If the Fault hook
+ * accepts it, the fault is taken as an exception, otherwise it is
+ * returned. Bus errors are reported as cause. */
+func (cpu *CPU) take_fault(fault error, cause BusFault) error {
+	if fault == ErrLockup || cpu.Fault == nil || !cpu.Fault(fault) {
+		return fault
+	}
+
+	if err := cpu.pend_fault(fault, cause); err != nil {
+		return err
+	}
+
+	excp, _ := cpu.PendingException()
+	return cpu.ExceptionEntry(excp)
+}
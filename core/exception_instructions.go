@@ -0,0 +1,84 @@
+package core
+
+import "fmt"
+
+/* BKPT
+ * ARM ARM A7.7.17
+ * Encoding T1 */
+type BkptT1 struct {
+	Imm uint8
+}
+
+func Bkpt16T1(instr FetchedInstr) DecodedInstr {
+	return BkptT1{Imm: uint8(instr.Uint32() & 0xff)}
+}
+
+func (instr BkptT1) Execute(cpu *CPU) {
+	SoftwareBreakpoint(cpu, instr.Imm)
+}
+
+func (instr BkptT1) String() string {
+	return fmt.Sprintf("bkpt #%d", instr.Imm)
+}
+
+/* SVC
+ * ARM ARM A7.7.175
+ * Encoding T1 */
+type SvcT1 struct {
+	Imm uint8
+}
+
+func Svc16T1(instr FetchedInstr) DecodedInstr {
+	return SvcT1{Imm: uint8(instr.Uint32() & 0xff)}
+}
+
+func (instr SvcT1) Execute(cpu *CPU) {
+	SupervisorCall(cpu)
+}
+
+func (instr SvcT1) String() string {
+	return fmt.Sprintf("svc #%d", instr.Imm)
+}
+
+/* UDF
+ * ARM ARM A7.7.191
+ * Encoding T1 */
+type UdfT1 struct {
+	Imm uint8
+}
+
+func Udf16T1(instr FetchedInstr) DecodedInstr {
+	return UdfT1{Imm: uint8(instr.Uint32() & 0xff)}
+}
+
+func (instr UdfT1) Execute(cpu *CPU) {
+	cpu.raise(UFSR_UNDEFINSTR)
+}
+
+func (instr UdfT1) String() string {
+	return fmt.Sprintf("udf #%d", instr.Imm)
+}
+
+/* UDF
+ * ARM ARM A7.7.191
+ * Encoding T2 */
+type UdfT2 struct {
+	Imm uint16
+}
+
+func Udf32T2(instr FetchedInstr) DecodedInstr {
+	raw_instr := instr.Uint32()
+
+	imm4 := (raw_instr >> 16) & 0xf
+	imm12 := raw_instr & 0xfff
+
+	return UdfT2{Imm: uint16(imm4<<12 | imm12)}
+}
+
+func (instr UdfT2) Execute(cpu *CPU) {
+	cpu.raise(UFSR_UNDEFINSTR)
+}
+
+func (instr UdfT2) String() string {
+	return fmt.Sprintf("udf.w #%d", instr.Imm)
+}
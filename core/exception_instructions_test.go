@@ -0,0 +1,127 @@
+package core
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestIdentifySvc(t *testing.T) {
+	cases := []IdentifyCase{
+		{instr: FetchedInstr16(0xdf00), instr_valid: true},  // svc #0
+		{instr: FetchedInstr16(0xdfff), instr_valid: true},  // svc #255
+		{instr: FetchedInstr16(0xde00), instr_valid: false}, // udf #0
+		{instr: FetchedInstr16(0xdd00), instr_valid: false}, // ble #0
+	}
+
+	test_identify(t, cases, reflect.TypeOf(SvcT1{}))
+}
+
+func TestDecodeExceptionGenerating(t *testing.T) {
+	cases := []DecodeCase{
+		// bkpt #171
+		{instr: FetchedInstr16(0xbeab), decoded: BkptT1{Imm: 171}},
+	}
+
+	test_decode(t, cases, Bkpt16T1)
+
+	cases = []DecodeCase{
+		// udf #3
+		{instr: FetchedInstr16(0xde03), decoded: UdfT1{Imm: 3}},
+	}
+
+	test_decode(t, cases, Udf16T1)
+
+	cases = []DecodeCase{
+		// udf.w #4660
+		{instr: FetchedInstr32(0xf7f1a234), decoded: UdfT2{Imm: 0x1234}},
+	}
+
+	test_decode(t, cases, Udf32T2)
+
+	cases = []DecodeCase{
+		// ldrh.w pc, [r0, #-4] (unallocated memory hint)
+		{instr: FetchedInstr32(0xf830fc04), decoded: NopT2{}},
+		// ldrh pc, [r0, #-4]!
+		{instr: FetchedInstr32(0xf830fd04), decoded: UnpredictableInstr{}},
+	}
+
+	test_decode(t, cases, LdrhImm32T3)
+}
+
+func TestExecuteSvc(t *testing.T) {
+	cpu := CPU{}
+	SvcT1{Imm: 1}.Execute(&cpu)
+
+	if !cpu.IsPending(EXCEPTION_SVCALL) {
+		t.Errorf("SVCall not pending")
+	}
+}
+
+func TestStepBkpt(t *testing.T) {
+	program := RAM{
+		0x08, 0xbf, // 0: it eq
+		0xab, 0xbe, // 2: bkpt #171
+		0x01, 0x20, // 4: movs r0, #1
+	}
+
+	cpu := NewCPU(program)
+
+	if err := cpu.Step(); err != nil {
+		t.Fatalf("it: %v", err)
+	}
+
+	/* Halts without a hook, despite failing its condition */
+	if err := cpu.Step(); err != (DebugHalt{Imm: 171}) || cpu.Pc() != 2 {
+		t.Fatalf("bkpt: err = %v, pc = %#x", err, cpu.Pc())
+	}
+
+	/* Semihosting resumes after the BKPT */
+	var imms []uint8
+	cpu.Breakpoint = func(imm uint8) bool {
+		imms = append(imms, imm)
+		return true
+	}
+
+	if err := cpu.Step(); err != nil || cpu.Pc() != 4 {
+		t.Fatalf("bkpt: err = %v, pc = %#x", err, cpu.Pc())
+	}
+
+	if !reflect.DeepEqual(imms, []uint8{171}) {
+		t.Errorf("breakpoints = %v, expected [171]", imms)
+	}
+
+	if cpu.InITBlock() {
+		t.Errorf("IT block not finished")
+	}
+}
+
+func TestStepUndefined(t *testing.T) {
+	program := RAM{
+		0x00, 0xbf, // 0: nop
+		0xff, 0xf7, 0x00, 0x80, // 2: undefined
+	}
+
+	cpu := NewCPU(program)
+	cpu.Step()
+
+	if err := cpu.Step(); err != UFSR_UNDEFINSTR || cpu.Pc() != 2 {
+		t.Errorf("err = %v, pc = %#x", err, cpu.Pc())
+	}
+}
+
+func TestBadInstrString(t *testing.T) {
+	cases := []struct {
+		instr    FetchedInstr
+		expected string
+	}{
+		{instr: FetchedInstr32(0xf7ff8000), expected: "udf"},
+		{instr: FetchedInstr32(0xf7010007), expected: "<unpredictable>"}, // ssat, bit 26 set
+	}
+
+	for _, test := range cases {
+		decoded, _ := test.instr.Decode()
+		if actual := Disassemble(decoded, 0); actual != test.expected {
+			t.Errorf("%v: %q, expected %q", test.instr, actual, test.expected)
+		}
+	}
+}
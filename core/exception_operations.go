@@ -0,0 +1,31 @@
+package core
+
+import "fmt"
+
+/* Returned by Step when BKPT halts execution, with PC left at the BKPT */
+type DebugHalt struct {
+	Imm uint8
+}
+
+func (halt DebugHalt) Error() string {
+	return fmt.Sprintf("Halted by BKPT #%d.", halt.Imm)
+}
+
+/* Perform SVC, pending SVCall. The immediate is only read by the handler,
+ * from the instruction before the stacked return address. SVCall must
+ * preempt at once, escalating to HardFault if it cannot. */
+func SupervisorCall(cpu *CPU) {
+	if err := cpu.pend_escalated(EXCEPTION_SVCALL); err != nil {
+		cpu.raise(err)
+	}
+}
+
+/* Perform BKPT, handing control to the Breakpoint hook. Without one, or
+ * if it declines, execution halts at the BKPT. */
+func SoftwareBreakpoint(cpu *CPU, imm uint8) {
+	if cpu.Breakpoint != nil && cpu.Breakpoint(imm) {
+		return
+	}
+
+	cpu.raise(DebugHalt{Imm: imm})
+}
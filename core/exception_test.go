@@ -0,0 +1,392 @@
+package core
+
+import "testing"
+
+/* Exception handler of excp, a bx lr */
+func handler(excp uint16) uint32 {
+	return 0x400 + 4*uint32(excp)
+}
+
+/* A core booted into Thread mode at 0x200, a run of NOPs. Every exception
+ * has its own handler, which immediately returns, and the first 32
+ * interrupts are enabled. */
+func exception_cpu(t *testing.T) *CPU {
+	flash := make(RAM, handler(48))
+	flash.Write32(0, SRAM_BASE+SRAM_SIZE)
+	flash.Write32(4, 0x201)
+
+	for excp := uint16(EXCEPTION_NMI); excp < 48; excp++ {
+		flash.Write32(4*uint32(excp), handler(excp)|0x1)
+		flash.Write16(handler(excp), 0x4770) // bx lr
+	}
+	for addr := uint32(0x200); addr < 0x220; addr += 2 {
+		flash.Write16(addr, 0xbf00) // nop
+	}
+
+	bus := NewDefaultBus()
+	LoadBytes(bus, FLASH_BASE, flash)
+
+	cpu := NewCPU(bus)
+	if err := cpu.Reset(); err != nil {
+		t.Fatalf("reset: %v", err)
+	}
+	cpu.Write32(NVIC_ISER, 0xffffffff)
+
+	return cpu
+}
+
+func step(t *testing.T, cpu *CPU) {
+	if err := cpu.Step(); err != nil {
+		t.Fatalf("step at %#x: %v", cpu.Pc(), err)
+	}
+}
+
+func TestExceptionEntryReturn(t *testing.T) {
+	cpu := exception_cpu(t)
+	cpu.Control.Spsel = PSP
+	cpu.sp[PSP] = SRAM_BASE + 0x1004
+	for i := RegIndex(0); i < 13; i++ {
+		cpu.SetR(i, uint32(i))
+	}
+	cpu.SetR(LR, 0x1234)
+	cpu.Apsr.C = true
+
+	cpu.SetPending(EXCEPTION_IRQ0)
+	step(t, cpu)
+
+	/* Stacked on PSP, aligned to 8 bytes by a padding word */
+	frameptr := uint32(SRAM_BASE + 0xfe0)
+	if cpu.Psp() != frameptr {
+		t.Errorf("PSP = %#x, expected %#x", cpu.Psp(), frameptr)
+	}
+
+	expected := []uint32{0, 1, 2, 3, 12, 0x1234, 0x200, XPSR_T | XPSR_C | 1<<9}
+	for i, word := range expected {
+		if stacked, _ := cpu.Read32(frameptr + 4*uint32(i)); stacked != word {
+			t.Errorf("frame[%d] = %#x, expected %#x", i, stacked, word)
+		}
+	}
+
+	if cpu.Mode != MODE_HANDLER || cpu.Ipsr.ExcpNum != EXCEPTION_IRQ0 || cpu.Pc() != handler(EXCEPTION_IRQ0) ||
+		cpu.Lr() != EXC_RETURN_THREAD_PSP || cpu.Sp() != cpu.Msp() || cpu.Control.Spsel != MSP {
+		t.Errorf("Handler entered with:\n%s", cpu.Pretty())
+	}
+
+	if !cpu.IsActive(EXCEPTION_IRQ0) || cpu.IsPending(EXCEPTION_IRQ0) {
+		t.Errorf("IRQ0 not active")
+	}
+
+	/* bx lr */
+	cpu.SetR(0, 0xdead)
+	step(t, cpu)
+
+	if cpu.Mode != MODE_THREAD || cpu.Ipsr.ExcpNum != 0 || cpu.Pc() != 0x200 || cpu.Control.Spsel != PSP ||
+		cpu.Psp() != SRAM_BASE+0x1004 || cpu.R(0) != 0 || cpu.Lr() != 0x1234 || !cpu.Apsr.C {
+		t.Errorf("Returned with:\n%s", cpu.Pretty())
+	}
+
+	if cpu.IsActive(EXCEPTION_IRQ0) {
+		t.Errorf("IRQ0 still active")
+	}
+}
+
+func TestExceptionPriority(t *testing.T) {
+	cpu := exception_cpu(t)
+	cpu.Write32(SCB_SHPR3, 0x40<<16) // PendSV
+
+	/* PRIMASK holds off everything configurable */
+	cpu.Primask = true
+	cpu.SetPending(EXCEPTION_PENDSV)
+	step(t, cpu)
+	if cpu.Mode != MODE_THREAD {
+		t.Fatalf("taken with PRIMASK set")
+	}
+
+	/* BASEPRI only those at or below its priority */
+	cpu.Primask = false
+	cpu.Basepri = 0x40
+	step(t, cpu)
+	if cpu.Mode != MODE_THREAD {
+		t.Fatalf("taken at BASEPRI")
+	}
+
+	cpu.Basepri = 0x80
+	step(t, cpu)
+	if cpu.Ipsr.ExcpNum != EXCEPTION_PENDSV {
+		t.Fatalf("PendSV not taken, IPSR = %d", cpu.Ipsr.ExcpNum)
+	}
+
+	/* Equal priorities don't preempt, higher priorities do */
+	cpu.SetPending(EXCEPTION_IRQ0 + 1)
+	cpu.priority[EXCEPTION_IRQ0+1] = 0x40
+	if _, ok := cpu.PendingException(); ok {
+		t.Errorf("equal priority preempts")
+	}
+
+	cpu.priority[EXCEPTION_IRQ0+1] = 0x20
+	step(t, cpu)
+	if cpu.Ipsr.ExcpNum != EXCEPTION_IRQ0+1 || cpu.Lr() != EXC_RETURN_HANDLER {
+		t.Errorf("IRQ1 not nested, IPSR = %d, LR = %#x", cpu.Ipsr.ExcpNum, cpu.Lr())
+	}
+
+	if priority := cpu.ExecutionPriority(); priority != 0x20 {
+		t.Errorf("execution priority = %#x, expected 0x20", priority)
+	}
+
+	/* Back to PendSV, then Thread mode after the two NOPs executed */
+	step(t, cpu)
+	step(t, cpu)
+	if cpu.Mode != MODE_THREAD || cpu.Pc() != 0x204 || cpu.Sp() != SRAM_BASE+SRAM_SIZE {
+		t.Errorf("Returned with:\n%s", cpu.Pretty())
+	}
+}
+
+func TestTailChaining(t *testing.T) {
+	cpu := exception_cpu(t)
+	cpu.SetPending(EXCEPTION_IRQ0)
+	step(t, cpu)
+
+	/* Pended by the handler, below its priority */
+	cpu.priority[EXCEPTION_IRQ0+2] = 0x10
+	cpu.SetPending(EXCEPTION_IRQ0 + 2)
+	sp := cpu.Sp()
+
+	step(t, cpu)
+	if cpu.Ipsr.ExcpNum != EXCEPTION_IRQ0+2 || cpu.Pc() != handler(EXCEPTION_IRQ0+2) ||
+		cpu.Sp() != sp || cpu.Lr() != EXC_RETURN_THREAD_MSP || cpu.IsActive(EXCEPTION_IRQ0) {
+		t.Errorf("Not tail-chained:\n%s", cpu.Pretty())
+	}
+
+	step(t, cpu)
+	if cpu.Mode != MODE_THREAD || cpu.Pc() != 0x200 || cpu.Sp() != SRAM_BASE+SRAM_SIZE {
+		t.Errorf("Returned with:\n%s", cpu.Pretty())
+	}
+}
+
+func TestTailChainingLR(t *testing.T) {
+	cpu := exception_cpu(t)
+	LoadBytes(cpu.Mem, 4*EXCEPTION_IRQ0, []byte{0x01, 0x03, 0x00, 0x00})
+	LoadBytes(cpu.Mem, 0x300, []byte{
+		0x00, 0xb5, // push {lr}
+		0x41, 0xf2, 0x34, 0x2e, // movw lr, #0x1234
+		0x00, 0xbd, // pop {pc}
+	})
+	cpu.SetPending(EXCEPTION_IRQ0)
+	step(t, cpu)
+
+	cpu.SetPending(EXCEPTION_IRQ0 + 1)
+	for i := 0; i < 3; i++ {
+		step(t, cpu)
+	}
+
+	/* The chained handler returns with EXC_RETURN, not the clobbered LR */
+	if cpu.Ipsr.ExcpNum != EXCEPTION_IRQ0+1 || cpu.Lr() != EXC_RETURN_THREAD_MSP {
+		t.Fatalf("IPSR = %d, LR = %#x", cpu.Ipsr.ExcpNum, cpu.Lr())
+	}
+
+	step(t, cpu)
+	if cpu.Mode != MODE_THREAD || cpu.Pc() != 0x200 {
+		t.Errorf("Returned with:\n%s", cpu.Pretty())
+	}
+}
+
+func TestLateArrival(t *testing.T) {
+	cpu := exception_cpu(t)
+
+	/* Stacking faults, the derived BusFault escalating to HardFault */
+	cpu.SetR(SP, SRAM_BASE+0x10)
+	cpu.SetPending(EXCEPTION_IRQ0)
+	step(t, cpu)
+
+	if cpu.Ipsr.ExcpNum != EXCEPTION_HARDFAULT || !cpu.IsPending(EXCEPTION_IRQ0) {
+		t.Errorf("IPSR = %d, IRQ0 pending = %v", cpu.Ipsr.ExcpNum, cpu.IsPending(EXCEPTION_IRQ0))
+	}
+
+	if cpu.Scb.Hfsr != HFSR_FORCED || cpu.Scb.Cfsr != uint32(BFSR_STKERR)<<CFSR_BFSR_SHIFT {
+		t.Errorf("HFSR = %#x, CFSR = %#x", cpu.Scb.Hfsr, cpu.Scb.Cfsr)
+	}
+
+	/* IRQ0 is tail-chained on return, without unstacking */
+	step(t, cpu)
+	if cpu.Ipsr.ExcpNum != EXCEPTION_IRQ0 {
+		t.Errorf("IPSR = %d, expected %d", cpu.Ipsr.ExcpNum, EXCEPTION_IRQ0)
+	}
+
+	/* Unstacking faults too, tail-chaining the fault */
+	cpu.Write32(SCB_SHCSR, SHCSR_BUSFAULTENA)
+	step(t, cpu)
+
+	if cpu.Ipsr.ExcpNum != EXCEPTION_BUSFAULT || cpu.Lr() != EXC_RETURN_THREAD_MSP ||
+		cpu.Scb.Cfsr&(uint32(BFSR_UNSTKERR)<<CFSR_BFSR_SHIFT) == 0 {
+		t.Errorf("IPSR = %d, LR = %#x, CFSR = %#x", cpu.Ipsr.ExcpNum, cpu.Lr(), cpu.Scb.Cfsr)
+	}
+}
+
+func TestInvalidExceptionReturn(t *testing.T) {
+	cpu := exception_cpu(t)
+	cpu.Write32(SCB_SHCSR, SHCSR_USGFAULTENA)
+	cpu.Write32(SCB_SHPR3, 0x80<<24) // SysTick
+	cpu.SetPending(EXCEPTION_SYSTICK)
+	step(t, cpu)
+
+	/* Thread mode with the process stack, from Handler mode */
+	cpu.SetR(LR, 0xfffffff5)
+	step(t, cpu)
+
+	if cpu.Ipsr.ExcpNum != EXCEPTION_USAGEFAULT || cpu.Lr() != 0xfffffff5 ||
+		cpu.Scb.Cfsr != uint32(UFSR_INVPC)<<CFSR_UFSR_SHIFT || !cpu.IsActive(EXCEPTION_SYSTICK) {
+		t.Errorf("IPSR = %d, LR = %#x, CFSR = %#x", cpu.Ipsr.ExcpNum, cpu.Lr(), cpu.Scb.Cfsr)
+	}
+
+	/* Returning to Thread mode with SysTick still active */
+	cpu.SetR(LR, EXC_RETURN_THREAD_MSP)
+	step(t, cpu)
+
+	if cpu.Ipsr.ExcpNum != EXCEPTION_HARDFAULT || cpu.Scb.Hfsr != HFSR_FORCED {
+		t.Errorf("IPSR = %d, HFSR = %#x", cpu.Ipsr.ExcpNum, cpu.Scb.Hfsr)
+	}
+}
+
+func TestStepFaultException(t *testing.T) {
+	cpu := exception_cpu(t)
+	LoadBytes(cpu.Mem, 0x200, []byte{0x00, 0xde}) // udf #0
+
+	var faults []error
+	cpu.Fault = func(fault error) bool {
+		faults = append(faults, fault)
+		return true
+	}
+
+	/* Escalated while UsageFault is disabled */
+	step(t, cpu)
+	if cpu.Ipsr.ExcpNum != EXCEPTION_HARDFAULT || cpu.Scb.Hfsr != HFSR_FORCED ||
+		cpu.Scb.Cfsr != uint32(UFSR_UNDEFINSTR)<<CFSR_UFSR_SHIFT {
+		t.Errorf("IPSR = %d, HFSR = %#x, CFSR = %#x", cpu.Ipsr.ExcpNum, cpu.Scb.Hfsr, cpu.Scb.Cfsr)
+	}
+
+	/* Returns to retry the instruction */
+	step(t, cpu)
+	if cpu.Pc() != 0x200 || cpu.Mode != MODE_THREAD {
+		t.Fatalf("Returned with:\n%s", cpu.Pretty())
+	}
+
+	cpu.Write32(SCB_SHCSR, SHCSR_USGFAULTENA)
+	step(t, cpu)
+	if cpu.Ipsr.ExcpNum != EXCEPTION_USAGEFAULT || len(faults) != 2 || faults[1] != UFSR_UNDEFINSTR {
+		t.Errorf("IPSR = %d, faults = %v", cpu.Ipsr.ExcpNum, faults)
+	}
+
+	/* A precise BusFault records the address, preempting UsageFault */
+	cpu.Write32(SCB_SHCSR, SHCSR_USGFAULTENA|SHCSR_BUSFAULTENA)
+	cpu.Write8(SCB_SHPR1+2, 0x80)
+	LoadBytes(cpu.Mem, handler(EXCEPTION_USAGEFAULT), []byte{0x08, 0x68}) // ldr r0, [r1]
+	cpu.SetR(1, 0x10000000)
+	step(t, cpu)
+	if cpu.Ipsr.ExcpNum != EXCEPTION_BUSFAULT || cpu.Scb.Bfar != 0x10000000 ||
+		cpu.Scb.Cfsr&(uint32(BFSR_PRECISERR|BFSR_BFARVALID)<<CFSR_BFSR_SHIFT) == 0 {
+		t.Errorf("IPSR = %d, BFAR = %#x, CFSR = %#x", cpu.Ipsr.ExcpNum, cpu.Scb.Bfar, cpu.Scb.Cfsr)
+	}
+}
+
+func TestLockup(t *testing.T) {
+	cpu := exception_cpu(t)
+	LoadBytes(cpu.Mem, handler(EXCEPTION_HARDFAULT), []byte{0x00, 0xde}) // udf #0
+	cpu.Fault = func(fault error) bool { return true }
+
+	cpu.SetPending(EXCEPTION_HARDFAULT)
+	step(t, cpu)
+
+	if err := cpu.Step(); err != ErrLockup {
+		t.Errorf("err = %v, expected %v", err, ErrLockup)
+	}
+}
+
+func TestSystemHandlerRegisters(t *testing.T) {
+	cpu := exception_cpu(t)
+
+	/* Only configurable priorities are writable */
+	cpu.Write32(SCB_SHPR2, 0xffffffff)
+	if shpr2, _ := cpu.Read32(SCB_SHPR2); shpr2 != 0xff000000 {
+		t.Errorf("SHPR2 = %#x, expected 0xff000000", shpr2)
+	}
+	if priority := cpu.ExceptionPriority(EXCEPTION_SVCALL); priority != 0xff {
+		t.Errorf("SVCall priority = %#x", priority)
+	}
+
+	cpu.Write8(SCB_SHPR1+2, 0x20)
+	if shpr1, _ := cpu.Read32(SCB_SHPR1); shpr1 != 0x200000 {
+		t.Errorf("SHPR1 = %#x, expected 0x200000", shpr1)
+	}
+
+	cpu.Write32(SCB_ICSR, ICSR_PENDSVSET)
+	if icsr, _ := cpu.Read32(SCB_ICSR); icsr != ICSR_PENDSVSET|ICSR_RETTOBASE|EXCEPTION_PENDSV<<12 {
+		t.Errorf("ICSR = %#x", icsr)
+	}
+
+	step(t, cpu)
+	cpu.SetPending(EXCEPTION_SVCALL)
+	if shcsr, _ := cpu.Read32(SCB_SHCSR); shcsr != 1<<10|1<<15 {
+		t.Errorf("SHCSR = %#x, expected %#x", shcsr, 1<<10|1<<15)
+	}
+	if icsr, _ := cpu.Read32(SCB_ICSR); icsr != ICSR_RETTOBASE|EXCEPTION_SVCALL<<12|EXCEPTION_PENDSV {
+		t.Errorf("ICSR = %#x", icsr)
+	}
+
+	/* Fault status is cleared by writing ones */
+	cpu.Scb.Cfsr = uint32(UFSR_NOCP|UFSR_INVPC) << CFSR_UFSR_SHIFT
+	cpu.Write16(SCB_CFSR+2, uint16(UFSR_NOCP))
+	if cfsr, _ := cpu.Read32(SCB_CFSR); cfsr != uint32(UFSR_INVPC)<<CFSR_UFSR_SHIFT {
+		t.Errorf("CFSR = %#x", cfsr)
+	}
+}
+
+func TestSupervisorCallEscalation(t *testing.T) {
+	cpu := exception_cpu(t)
+	LoadBytes(cpu.Mem, handler(EXCEPTION_SVCALL), []byte{0x00, 0xdf}) // svc #0
+
+	/* SVC from the SVCall handler can't preempt itself */
+	cpu.SetPending(EXCEPTION_SVCALL)
+	step(t, cpu)
+	step(t, cpu)
+
+	if !cpu.IsPending(EXCEPTION_HARDFAULT) || cpu.Scb.Hfsr != HFSR_FORCED {
+		t.Errorf("HardFault pending = %v, HFSR = %#x", cpu.IsPending(EXCEPTION_HARDFAULT), cpu.Scb.Hfsr)
+	}
+}
+
+func TestStepWakeOnException(t *testing.T) {
+	cpu := exception_cpu(t)
+	LoadBytes(cpu.Mem, 0x200, []byte{0x30, 0xbf}) // wfi
+	cpu.Primask = true
+
+	step(t, cpu)
+	if err := cpu.Step(); err != ErrSleeping {
+		t.Errorf("err = %v, expected %v", err, ErrSleeping)
+	}
+
+	cpu.SetPending(EXCEPTION_NMI)
+	step(t, cpu)
+	if cpu.Sleep != AWAKE || cpu.Ipsr.ExcpNum != EXCEPTION_NMI {
+		t.Errorf("sleep = %v, IPSR = %d", cpu.Sleep, cpu.Ipsr.ExcpNum)
+	}
+}
+
+func TestExceptionExtendedFrame(t *testing.T) {
+	cpu := exception_cpu(t)
+	cpu.Profile = PROFILE_ARMV7EM_FP
+	cpu.Control.Fpca = true
+
+	cpu.SetPending(EXCEPTION_IRQ0)
+	step(t, cpu)
+
+	/* Space reserved for the lazy save */
+	if cpu.Lr() != 0xffffffe9 || cpu.Sp() != SRAM_BASE+SRAM_SIZE-FRAME_SIZE_EXTENDED ||
+		cpu.Control.Fpca || cpu.Scb.Fpccr&FPCCR_LSPACT == 0 {
+		t.Errorf("Handler entered with:\n%s", cpu.Pretty())
+	}
+
+	step(t, cpu)
+	if cpu.Sp() != SRAM_BASE+SRAM_SIZE || !cpu.Control.Fpca || cpu.Scb.Fpccr&FPCCR_LSPACT != 0 {
+		t.Errorf("Returned with:\n%s", cpu.Pretty())
+	}
+}
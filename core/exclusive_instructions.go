@@ -0,0 +1,161 @@
+package core
+
+import "fmt"
+
+/* LDREX
+ * ARM ARM A7.7.51
+ * Encoding T1 */
+type LdrexT1 LoadStoreFields
+
+func Ldrex32T1(instr FetchedInstr) DecodedInstr {
+	fields := decode_ldst_exclusive(instr.Uint32(), true)
+	fields.Rd = 0
+
+	if BadReg(fields.Rt) || fields.Rn == PC {
+		return UnpredictableInstr{}
+	}
+
+	return LdrexT1(fields)
+}
+
+func (instr LdrexT1) Execute(cpu *CPU) {
+	LoadExclusive(cpu, LoadStoreFields(instr), 4)
+}
+
+func (instr LdrexT1) String() string {
+	return fmt.Sprintf("ldrex %s, %s", instr.Rt, imm_address(LoadStoreFields(instr)))
+}
+
+/* LDREXB
+ * ARM ARM A7.7.52
+ * Encoding T1 */
+type LdrexbT1 LoadStoreFields
+
+func Ldrexb32T1(instr FetchedInstr) DecodedInstr {
+	fields := decode_ldst_exclusive(instr.Uint32(), false)
+	fields.Rd = 0
+
+	if BadReg(fields.Rt) || fields.Rn == PC {
+		return UnpredictableInstr{}
+	}
+
+	return LdrexbT1(fields)
+}
+
+func (instr LdrexbT1) Execute(cpu *CPU) {
+	LoadExclusive(cpu, LoadStoreFields(instr), 1)
+}
+
+func (instr LdrexbT1) String() string {
+	return fmt.Sprintf("ldrexb %s, [%s]", instr.Rt, instr.Rn)
+}
+
+/* LDREXH
+ * ARM ARM A7.7.53
+ * Encoding T1 */
+type LdrexhT1 LoadStoreFields
+
+func Ldrexh32T1(instr FetchedInstr) DecodedInstr {
+	fields := decode_ldst_exclusive(instr.Uint32(), false)
+	fields.Rd = 0
+
+	if BadReg(fields.Rt) || fields.Rn == PC {
+		return UnpredictableInstr{}
+	}
+
+	return LdrexhT1(fields)
+}
+
+func (instr LdrexhT1) Execute(cpu *CPU) {
+	LoadExclusive(cpu, LoadStoreFields(instr), 2)
+}
+
+func (instr LdrexhT1) String() string {
+	return fmt.Sprintf("ldrexh %s, [%s]", instr.Rt, instr.Rn)
+}
+
+/* STREX
+ * ARM ARM A7.7.164
+ * Encoding T1 */
+type StrexT1 LoadStoreFields
+
+func Strex32T1(instr FetchedInstr) DecodedInstr {
+	fields := decode_ldst_exclusive(instr.Uint32(), true)
+
+	if bad_store_exclusive(fields) {
+		return UnpredictableInstr{}
+	}
+
+	return StrexT1(fields)
+}
+
+func (instr StrexT1) Execute(cpu *CPU) {
+	StoreExclusive(cpu, LoadStoreFields(instr), 4)
+}
+
+func (instr StrexT1) String() string {
+	return fmt.Sprintf("strex %s, %s, %s", instr.Rd, instr.Rt, imm_address(LoadStoreFields(instr)))
+}
+
+/* STREXB
+ * ARM ARM A7.7.165
+ * Encoding T1 */
+type StrexbT1 LoadStoreFields
+
+func Strexb32T1(instr FetchedInstr) DecodedInstr {
+	fields := decode_ldst_exclusive(instr.Uint32(), false)
+
+	if bad_store_exclusive(fields) {
+		return UnpredictableInstr{}
+	}
+
+	return StrexbT1(fields)
+}
+
+func (instr StrexbT1) Execute(cpu *CPU) {
+	StoreExclusive(cpu, LoadStoreFields(instr), 1)
+}
+
+func (instr StrexbT1) String() string {
+	return fmt.Sprintf("strexb %s, %s, [%s]", instr.Rd, instr.Rt, instr.Rn)
+}
+
+/* STREXH
+ * ARM ARM A7.7.166
+ * Encoding T1 */
+type StrexhT1 LoadStoreFields
+
+func Strexh32T1(instr FetchedInstr) DecodedInstr {
+	fields := decode_ldst_exclusive(instr.Uint32(), false)
+
+	if bad_store_exclusive(fields) {
+		return UnpredictableInstr{}
+	}
+
+	return StrexhT1(fields)
+}
+
+func (instr StrexhT1) Execute(cpu *CPU) {
+	StoreExclusive(cpu, LoadStoreFields(instr), 2)
+}
+
+func (instr StrexhT1) String() string {
+	return fmt.Sprintf("strexh %s, %s, [%s]", instr.Rd, instr.Rt, instr.Rn)
+}
+
+/* CLREX
+ * ARM ARM A7.7.23
+ * Encoding T1 */
+type ClrexT1 struct{}
+
+func Clrex32T1(instr FetchedInstr) DecodedInstr {
+	return ClrexT1{}
+}
+
+func (instr ClrexT1) Execute(cpu *CPU) {
+	cpu.ClearExclusiveLocal()
+}
+
+func (instr ClrexT1) String() string {
+	return "clrex"
+}
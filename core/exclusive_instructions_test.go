@@ -0,0 +1,155 @@
+package core
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+)
+
+func TestIdentifyLdrexT1(t *testing.T) {
+	cases := []IdentifyCase{
+		{instr: FetchedInstr32(0xe8510f00), instr_valid: true},  // ldrex r0, [r1]
+		{instr: FetchedInstr32(0xe8d10f4f), instr_valid: false}, // ldrexb r0, [r1]
+		{instr: FetchedInstr32(0xe8410002), instr_valid: false}, // strex r2, r0, [r1]
+	}
+
+	test_identify(t, cases, reflect.TypeOf(LdrexT1{}))
+
+	cases = []IdentifyCase{
+		{instr: FetchedInstr32(0xe8c10f52), instr_valid: true},  // strexh r2, r0, [r1]
+		{instr: FetchedInstr32(0xe8c10f42), instr_valid: false}, // strexb r2, r0, [r1]
+		{instr: FetchedInstr32(0xe8d1f001), instr_valid: false}, // tbb [r1, r1]
+	}
+
+	test_identify(t, cases, reflect.TypeOf(StrexhT1{}))
+}
+
+func TestDecodeExclusive(t *testing.T) {
+	cases := []DecodeCase{
+		// ldrex r0, [r1, #4]
+		{instr: FetchedInstr32(0xe8510f01), decoded: LdrexT1{Rt: 0, Rn: 1, Imm: 4, Index: true, Add: true}},
+		// ldrex r0, [pc]
+		{instr: FetchedInstr32(0xe85f0f00), decoded: UnpredictableInstr{}},
+	}
+
+	test_decode(t, cases, Ldrex32T1)
+
+	cases = []DecodeCase{
+		// strex r2, r0, [r1, #1020]
+		{instr: FetchedInstr32(0xe84102ff), decoded: StrexT1{Rt: 0, Rd: 2, Rn: 1, Imm: 1020, Index: true, Add: true}},
+		// strex r0, r0, [r1]
+		{instr: FetchedInstr32(0xe8410000), decoded: UnpredictableInstr{}},
+		// strex r1, r0, [r1]
+		{instr: FetchedInstr32(0xe8410001), decoded: UnpredictableInstr{}},
+	}
+
+	test_decode(t, cases, Strex32T1)
+
+	cases = []DecodeCase{
+		// strexb r2, r0, [r1]
+		{instr: FetchedInstr32(0xe8c10f42), decoded: StrexbT1{Rt: 0, Rd: 2, Rn: 1, Index: true, Add: true}},
+	}
+
+	test_decode(t, cases, Strexb32T1)
+}
+
+func TestExclusiveString(t *testing.T) {
+	cases := []struct {
+		instr    FetchedInstr
+		expected string
+	}{
+		{instr: FetchedInstr32(0xe8510f00), expected: "ldrex r0, [r1]"},
+		{instr: FetchedInstr32(0xe8510f01), expected: "ldrex r0, [r1, #4]"},
+		{instr: FetchedInstr32(0xe8d10f5f), expected: "ldrexh r0, [r1]"},
+		{instr: FetchedInstr32(0xe84102ff), expected: "strex r2, r0, [r1, #1020]"},
+		{instr: FetchedInstr32(0xe8c10f42), expected: "strexb r2, r0, [r1]"},
+		{instr: FetchedInstr32(0xf3bf8f2f), expected: "clrex"},
+	}
+
+	for _, test := range cases {
+		decoded, err := test.instr.Decode()
+		if err != nil {
+			t.Errorf("%v: %v", test.instr, err)
+		} else if actual := decoded.(fmt.Stringer).String(); actual != test.expected {
+			t.Errorf("%v: %q, expected %q", test.instr, actual, test.expected)
+		}
+	}
+}
+
+func TestExecuteExclusive(t *testing.T) {
+	ldrex := LdrexT1{Rt: 0, Rn: 1, Index: true, Add: true}
+	ldrexb := LdrexbT1{Rt: 0, Rn: 1, Index: true, Add: true}
+	strex := StrexT1{Rt: 3, Rd: 2, Rn: 1, Index: true, Add: true}
+	strex_next := StrexT1{Rt: 3, Rd: 2, Rn: 1, Imm: 4, Index: true, Add: true}
+
+	cases := []struct {
+		name   string
+		instrs []DecodedInstr
+		status uint32
+		word   uint32
+	}{
+		{"ldrex, strex", []DecodedInstr{ldrex, strex}, 0, 0x12345678},
+		{"strex without ldrex", []DecodedInstr{strex}, 1, 0x93929190},
+		{"strex twice", []DecodedInstr{ldrex, strex, strex}, 1, 0x12345678},
+		{"clrex", []DecodedInstr{ldrex, ClrexT1{}, strex}, 1, 0x93929190},
+		{"different address", []DecodedInstr{ldrex, strex_next, strex}, 1, 0x93929190},
+		{"different size", []DecodedInstr{ldrexb, strex}, 1, 0x93929190},
+	}
+
+	for _, test := range cases {
+		cpu := CPU{Registers: Registers{r: GeneralRegs{0, 0x10, 0xff, 0x12345678}}, Mem: test_memory()}
+		for _, instr := range test.instrs {
+			instr.Execute(&cpu)
+		}
+
+		if status := cpu.R(2); status != test.status {
+			t.Errorf("%s: status = %d, expected %d", test.name, status, test.status)
+		}
+
+		if word, _ := cpu.Mem.Read32(0x10); word != test.word {
+			t.Errorf("%s: mem[0x10] = %#x, expected %#x", test.name, word, test.word)
+		}
+	}
+}
+
+func TestExclusiveFail(t *testing.T) {
+	cpu := CPU{Registers: Registers{r: GeneralRegs{0, 0x10, 0xff, 0x12345678}, pc: 0x1004}, Mem: test_memory()}
+
+	failures := 1
+	cpu.ExclusiveFail = func(pc uint32, addr uint32) bool {
+		if pc != 0x1000 || addr != 0x10 {
+			t.Errorf("ExclusiveFail(%#x, %#x), expected (0x1000, 0x10)", pc, addr)
+		}
+		failures--
+		return failures >= 0
+	}
+
+	/* The retry loop runs twice */
+	for _, status := range []uint32{1, 0} {
+		LdrexT1{Rt: 0, Rn: 1, Index: true, Add: true}.Execute(&cpu)
+		StrexT1{Rt: 3, Rd: 2, Rn: 1, Index: true, Add: true}.Execute(&cpu)
+
+		if cpu.R(2) != status {
+			t.Errorf("status = %d, expected %d", cpu.R(2), status)
+		}
+	}
+
+	if word, _ := cpu.Mem.Read32(0x10); word != 0x12345678 {
+		t.Errorf("mem[0x10] = %#x, expected 0x12345678", word)
+	}
+}
+
+func TestStrexUnaligned(t *testing.T) {
+	cpu := CPU{Registers: Registers{r: GeneralRegs{0, 0x12, 0xff, 0x12345678}}, Mem: test_memory()}
+
+	/* Faults even though the monitor check would fail */
+	StrexT1{Rt: 3, Rd: 2, Rn: 1, Index: true, Add: true}.Execute(&cpu)
+
+	if cpu.fault == nil {
+		t.Errorf("unaligned strex didn't fault")
+	}
+
+	if cpu.R(2) != 0xff {
+		t.Errorf("status = %#x, expected unchanged", cpu.R(2))
+	}
+}
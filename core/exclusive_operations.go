@@ -0,0 +1,100 @@
+package core
+
+/* Local exclusive monitor, tagging the address and size of the last
+ * LDREX. Only a STREX to the same address and size succeeds.
+ * ARM ARM A3.4.1 */
+type ExclusiveMonitor struct {
+	Exclusive bool // Exclusive Access state, rather than Open Access
+	Addr      uint32
+	Size      uint32
+}
+
+/* Tag addr for a following STREX
+ * ARM ARM pseudocode SetExclusiveMonitors() */
+func (cpu *CPU) SetExclusiveMonitors(addr uint32, size uint32) {
+	cpu.Monitor = ExclusiveMonitor{Exclusive: true, Addr: addr, Size: size}
+}
+
+/* Check whether a STREX to addr may store, clearing the monitor either
+ * way. The access must be aligned even if the check fails.
+ * ARM ARM pseudocode ExclusiveMonitorsPass() */
+func (cpu *CPU) ExclusiveMonitorsPass(addr uint32, size uint32) (pass bool, ok bool) {
+	if addr&(size-1) != 0 {
+		cpu.raise(MemoryFault{Addr: addr, Write: true, Err: ErrUnalignedAccess})
+		return false, false
+	}
+
+	pass = cpu.Monitor == ExclusiveMonitor{Exclusive: true, Addr: addr, Size: size}
+	cpu.ClearExclusiveLocal()
+
+	if pass && cpu.ExclusiveFail != nil && cpu.ExclusiveFail(cpu.Pc()-4, addr) {
+		pass = false
+	}
+
+	return pass, true
+}
+
+/* Return the monitor to Open Access. Besides CLREX and STREX, this
+ * happens on reset and on exception entry and return.
+ * ARM ARM pseudocode ClearExclusiveLocal() */
+func (cpu *CPU) ClearExclusiveLocal() {
+	cpu.Monitor = ExclusiveMonitor{}
+}
+
+/* Perform load exclusive instructions (LDREX, LDREXB, LDREXH) */
+func LoadExclusive(cpu *CPU, instr LoadStoreFields, size uint32) {
+	address := cpu.R(instr.Rn) + instr.Imm
+
+	data, ok := cpu.MemA(address, size)
+	if !ok {
+		return
+	}
+
+	cpu.SetExclusiveMonitors(address, size)
+	cpu.SetR(instr.Rt, data)
+}
+
+/* Perform store exclusive instructions (STREX, STREXB, STREXH), writing
+ * 0 to Rd if the store happened, or 1 if not */
+func StoreExclusive(cpu *CPU, instr LoadStoreFields, size uint32) {
+	address := cpu.R(instr.Rn) + instr.Imm
+
+	pass, ok := cpu.ExclusiveMonitorsPass(address, size)
+	if !ok {
+		return
+	}
+
+	if !pass {
+		cpu.SetR(instr.Rd, 1)
+		return
+	}
+
+	if !cpu.SetMemA(address, size, cpu.R(instr.Rt)) {
+		return
+	}
+
+	cpu.SetR(instr.Rd, 0)
+}
+
+/* Extract the fields of the exclusive access encodings. Only LDREX and
+ * STREX have an offset, imm8 scaled to words. */
+func decode_ldst_exclusive(raw_instr uint32, offset bool) LoadStoreFields {
+	Rn := RegIndex((raw_instr >> 16) & 0xf)
+	Rt := RegIndex((raw_instr >> 12) & 0xf)
+
+	if offset {
+		Rd := RegIndex((raw_instr >> 8) & 0xf)
+		Imm := (raw_instr & 0xff) << 2
+		return LoadStoreFields{Rt: Rt, Rd: Rd, Rn: Rn, Imm: Imm, Index: true, Add: true}
+	}
+
+	Rd := RegIndex(raw_instr & 0xf)
+	return LoadStoreFields{Rt: Rt, Rd: Rd, Rn: Rn, Index: true, Add: true}
+}
+
+/* Registers of STREX, STREXB and STREXH. The status register must differ
+ * from the others, so that a faulting store can be retried. */
+func bad_store_exclusive(instr LoadStoreFields) bool {
+	return BadReg(instr.Rd) || BadReg(instr.Rt) || instr.Rn == PC ||
+		instr.Rd == instr.Rn || instr.Rd == instr.Rt
+}
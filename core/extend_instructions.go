@@ -0,0 +1,377 @@
+package core
+
+import "fmt"
+
+/* SXTB
+ * ARM ARM A7.7.179
+ * Encoding T1 */
+type SxtbT1 InstrFields
+
+func Sxtb16T1(instr FetchedInstr) DecodedInstr {
+	return SxtbT1(decode_extend16(instr.Uint32()))
+}
+
+func (instr SxtbT1) Execute(cpu *CPU) {
+	Extend(cpu, InstrFields(instr), 8, true, false)
+}
+
+func (instr SxtbT1) String() string {
+	return fmt.Sprintf("sxtb %s, %s", instr.Rd, instr.Rm)
+}
+
+/* SXTB
+ * ARM ARM A7.7.179
+ * Encoding T2 */
+type SxtbT2 InstrFields
+
+func Sxtb32T2(instr FetchedInstr) DecodedInstr {
+	fields := decode_extend32(instr.Uint32())
+	fields.Rn = 0
+
+	if bad_extend(fields, false) {
+		return UnpredictableInstr{}
+	}
+
+	return SxtbT2(fields)
+}
+
+func (instr SxtbT2) Execute(cpu *CPU) {
+	Extend(cpu, InstrFields(instr), 8, true, false)
+}
+
+func (instr SxtbT2) String() string {
+	return fmt.Sprintf("sxtb.w %s, %s", instr.Rd, shifted_operand(instr.Rm, instr.Shift))
+}
+
+/* SXTH
+ * ARM ARM A7.7.181
+ * Encoding T1 */
+type SxthT1 InstrFields
+
+func Sxth16T1(instr FetchedInstr) DecodedInstr {
+	return SxthT1(decode_extend16(instr.Uint32()))
+}
+
+func (instr SxthT1) Execute(cpu *CPU) {
+	Extend(cpu, InstrFields(instr), 16, true, false)
+}
+
+func (instr SxthT1) String() string {
+	return fmt.Sprintf("sxth %s, %s", instr.Rd, instr.Rm)
+}
+
+/* SXTH
+ * ARM ARM A7.7.181
+ * Encoding T2 */
+type SxthT2 InstrFields
+
+func Sxth32T2(instr FetchedInstr) DecodedInstr {
+	fields := decode_extend32(instr.Uint32())
+	fields.Rn = 0
+
+	if bad_extend(fields, false) {
+		return UnpredictableInstr{}
+	}
+
+	return SxthT2(fields)
+}
+
+func (instr SxthT2) Execute(cpu *CPU) {
+	Extend(cpu, InstrFields(instr), 16, true, false)
+}
+
+func (instr SxthT2) String() string {
+	return fmt.Sprintf("sxth.w %s, %s", instr.Rd, shifted_operand(instr.Rm, instr.Shift))
+}
+
+/* UXTB
+ * ARM ARM A7.7.218
+ * Encoding T1 */
+type UxtbT1 InstrFields
+
+func Uxtb16T1(instr FetchedInstr) DecodedInstr {
+	return UxtbT1(decode_extend16(instr.Uint32()))
+}
+
+func (instr UxtbT1) Execute(cpu *CPU) {
+	Extend(cpu, InstrFields(instr), 8, false, false)
+}
+
+func (instr UxtbT1) String() string {
+	return fmt.Sprintf("uxtb %s, %s", instr.Rd, instr.Rm)
+}
+
+/* UXTB
+ * ARM ARM A7.7.218
+ * Encoding T2 */
+type UxtbT2 InstrFields
+
+func Uxtb32T2(instr FetchedInstr) DecodedInstr {
+	fields := decode_extend32(instr.Uint32())
+	fields.Rn = 0
+
+	if bad_extend(fields, false) {
+		return UnpredictableInstr{}
+	}
+
+	return UxtbT2(fields)
+}
+
+func (instr UxtbT2) Execute(cpu *CPU) {
+	Extend(cpu, InstrFields(instr), 8, false, false)
+}
+
+func (instr UxtbT2) String() string {
+	return fmt.Sprintf("uxtb.w %s, %s", instr.Rd, shifted_operand(instr.Rm, instr.Shift))
+}
+
+/* UXTH
+ * ARM ARM A7.7.220
+ * Encoding T1 */
+type UxthT1 InstrFields
+
+func Uxth16T1(instr FetchedInstr) DecodedInstr {
+	return UxthT1(decode_extend16(instr.Uint32()))
+}
+
+func (instr UxthT1) Execute(cpu *CPU) {
+	Extend(cpu, InstrFields(instr), 16, false, false)
+}
+
+func (instr UxthT1) String() string {
+	return fmt.Sprintf("uxth %s, %s", instr.Rd, instr.Rm)
+}
+
+/* UXTH
+ * ARM ARM A7.7.220
+ * Encoding T2 */
+type UxthT2 InstrFields
+
+func Uxth32T2(instr FetchedInstr) DecodedInstr {
+	fields := decode_extend32(instr.Uint32())
+	fields.Rn = 0
+
+	if bad_extend(fields, false) {
+		return UnpredictableInstr{}
+	}
+
+	return UxthT2(fields)
+}
+
+func (instr UxthT2) Execute(cpu *CPU) {
+	Extend(cpu, InstrFields(instr), 16, false, false)
+}
+
+func (instr UxthT2) String() string {
+	return fmt.Sprintf("uxth.w %s, %s", instr.Rd, shifted_operand(instr.Rm, instr.Shift))
+}
+
+/* SXTB16
+ * ARM ARM A7.7.180
+ * Encoding T1 */
+type SxtbDualT1 InstrFields
+
+func SxtbDual32T1(instr FetchedInstr) DecodedInstr {
+	fields := decode_extend32(instr.Uint32())
+	fields.Rn = 0
+
+	if bad_extend(fields, false) {
+		return UnpredictableInstr{}
+	}
+
+	return SxtbDualT1(fields)
+}
+
+func (instr SxtbDualT1) Execute(cpu *CPU) {
+	ExtendDual(cpu, InstrFields(instr), true, false)
+}
+
+func (instr SxtbDualT1) String() string {
+	return fmt.Sprintf("sxtb16 %s, %s", instr.Rd, shifted_operand(instr.Rm, instr.Shift))
+}
+
+/* UXTB16
+ * ARM ARM A7.7.219
+ * Encoding T1 */
+type UxtbDualT1 InstrFields
+
+func UxtbDual32T1(instr FetchedInstr) DecodedInstr {
+	fields := decode_extend32(instr.Uint32())
+	fields.Rn = 0
+
+	if bad_extend(fields, false) {
+		return UnpredictableInstr{}
+	}
+
+	return UxtbDualT1(fields)
+}
+
+func (instr UxtbDualT1) Execute(cpu *CPU) {
+	ExtendDual(cpu, InstrFields(instr), false, false)
+}
+
+func (instr UxtbDualT1) String() string {
+	return fmt.Sprintf("uxtb16 %s, %s", instr.Rd, shifted_operand(instr.Rm, instr.Shift))
+}
+
+/* SXTAB
+ * ARM ARM A7.7.176
+ * Encoding T1 */
+type SxtabT1 InstrFields
+
+func Sxtab32T1(instr FetchedInstr) DecodedInstr {
+	fields := decode_extend32(instr.Uint32())
+
+	if fields.Rn == PC {
+		return Sxtb32T2(instr)
+	}
+
+	if bad_extend(fields, true) {
+		return UnpredictableInstr{}
+	}
+
+	return SxtabT1(fields)
+}
+
+func (instr SxtabT1) Execute(cpu *CPU) {
+	Extend(cpu, InstrFields(instr), 8, true, true)
+}
+
+func (instr SxtabT1) String() string {
+	return fmt.Sprintf("sxtab %s, %s, %s", instr.Rd, instr.Rn, shifted_operand(instr.Rm, instr.Shift))
+}
+
+/* SXTAH
+ * ARM ARM A7.7.178
+ * Encoding T1 */
+type SxtahT1 InstrFields
+
+func Sxtah32T1(instr FetchedInstr) DecodedInstr {
+	fields := decode_extend32(instr.Uint32())
+
+	if fields.Rn == PC {
+		return Sxth32T2(instr)
+	}
+
+	if bad_extend(fields, true) {
+		return UnpredictableInstr{}
+	}
+
+	return SxtahT1(fields)
+}
+
+func (instr SxtahT1) Execute(cpu *CPU) {
+	Extend(cpu, InstrFields(instr), 16, true, true)
+}
+
+func (instr SxtahT1) String() string {
+	return fmt.Sprintf("sxtah %s, %s, %s", instr.Rd, instr.Rn, shifted_operand(instr.Rm, instr.Shift))
+}
+
+/* UXTAB
+ * ARM ARM A7.7.215
+ * Encoding T1 */
+type UxtabT1 InstrFields
+
+func Uxtab32T1(instr FetchedInstr) DecodedInstr {
+	fields := decode_extend32(instr.Uint32())
+
+	if fields.Rn == PC {
+		return Uxtb32T2(instr)
+	}
+
+	if bad_extend(fields, true) {
+		return UnpredictableInstr{}
+	}
+
+	return UxtabT1(fields)
+}
+
+func (instr UxtabT1) Execute(cpu *CPU) {
+	Extend(cpu, InstrFields(instr), 8, false, true)
+}
+
+func (instr UxtabT1) String() string {
+	return fmt.Sprintf("uxtab %s, %s, %s", instr.Rd, instr.Rn, shifted_operand(instr.Rm, instr.Shift))
+}
+
+/* UXTAH
+ * ARM ARM A7.7.217
+ * Encoding T1 */
+type UxtahT1 InstrFields
+
+func Uxtah32T1(instr FetchedInstr) DecodedInstr {
+	fields := decode_extend32(instr.Uint32())
+
+	if fields.Rn == PC {
+		return Uxth32T2(instr)
+	}
+
+	if bad_extend(fields, true) {
+		return UnpredictableInstr{}
+	}
+
+	return UxtahT1(fields)
+}
+
+func (instr UxtahT1) Execute(cpu *CPU) {
+	Extend(cpu, InstrFields(instr), 16, false, true)
+}
+
+func (instr UxtahT1) String() string {
+	return fmt.Sprintf("uxtah %s, %s, %s", instr.Rd, instr.Rn, shifted_operand(instr.Rm, instr.Shift))
+}
+
+/* SXTAB16
+ * ARM ARM A7.7.177
+ * Encoding T1 */
+type SxtabDualT1 InstrFields
+
+func SxtabDual32T1(instr FetchedInstr) DecodedInstr {
+	fields := decode_extend32(instr.Uint32())
+
+	if fields.Rn == PC {
+		return SxtbDual32T1(instr)
+	}
+
+	if bad_extend(fields, true) {
+		return UnpredictableInstr{}
+	}
+
+	return SxtabDualT1(fields)
+}
+
+func (instr SxtabDualT1) Execute(cpu *CPU) {
+	ExtendDual(cpu, InstrFields(instr), true, true)
+}
+
+func (instr SxtabDualT1) String() string {
+	return fmt.Sprintf("sxtab16 %s, %s, %s", instr.Rd, instr.Rn, shifted_operand(instr.Rm, instr.Shift))
+}
+
+/* UXTAB16
+ * ARM ARM A7.7.216
+ * Encoding T1 */
+type UxtabDualT1 InstrFields
+
+func UxtabDual32T1(instr FetchedInstr) DecodedInstr {
+	fields := decode_extend32(instr.Uint32())
+
+	if fields.Rn == PC {
+		return UxtbDual32T1(instr)
+	}
+
+	if bad_extend(fields, true) {
+		return UnpredictableInstr{}
+	}
+
+	return UxtabDualT1(fields)
+}
+
+func (instr UxtabDualT1) Execute(cpu *CPU) {
+	ExtendDual(cpu, InstrFields(instr), false, true)
+}
+
+func (instr UxtabDualT1) String() string {
+	return fmt.Sprintf("uxtab16 %s, %s, %s", instr.Rd, instr.Rn, shifted_operand(instr.Rm, instr.Shift))
+}
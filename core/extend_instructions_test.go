@@ -0,0 +1,120 @@
+package core
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestIdentifySxtabT1(t *testing.T) {
+	cases := []IdentifyCase{
+		{instr: FetchedInstr32(0xfa41f0a2), instr_valid: true},  // sxtab r0, r1, r2, ror #16
+		{instr: FetchedInstr32(0xfa4ff091), instr_valid: false}, // sxtb.w r0, r1, ror #8
+		{instr: FetchedInstr32(0xfa51f082), instr_valid: false}, // uxtab r0, r1, r2
+	}
+
+	test_identify(t, cases, reflect.TypeOf(SxtabT1{}))
+
+	cases = []IdentifyCase{
+		{instr: FetchedInstr32(0xfa4ff091), instr_valid: true},  // sxtb.w r0, r1, ror #8
+		{instr: FetchedInstr32(0xfa41f0a2), instr_valid: false}, // sxtab r0, r1, r2, ror #16
+	}
+
+	test_identify(t, cases, reflect.TypeOf(SxtbT2{}))
+}
+
+func TestDecodeExtend16(t *testing.T) {
+	cases := []DecodeCase{
+		// sxtb r0, r1
+		{instr: FetchedInstr16(0xb248), decoded: SxtbT1{Rd: 0, Rm: 1, setflags: NEVER}},
+	}
+
+	test_decode(t, cases, Sxtb16T1)
+
+	cases = []DecodeCase{
+		// uxth r2, r7
+		{instr: FetchedInstr16(0xb2ba), decoded: UxthT1{Rd: 2, Rm: 7, setflags: NEVER}},
+	}
+
+	test_decode(t, cases, Uxth16T1)
+}
+
+func TestDecodeExtend32(t *testing.T) {
+	cases := []DecodeCase{
+		// uxth.w r0, r1, ror #24
+		{instr: FetchedInstr32(0xfa1ff0b1), decoded: UxthT2{Rd: 0, Rm: 1,
+			Shift: Shift{srtype: SRType_ROR, amount: 24}, setflags: NEVER}},
+		// uxth.w r0, sp
+		{instr: FetchedInstr32(0xfa1ff08d), decoded: UnpredictableInstr{}},
+	}
+
+	test_decode(t, cases, Uxth32T2)
+
+	cases = []DecodeCase{
+		// sxtab r0, r1, r2, ror #16
+		{instr: FetchedInstr32(0xfa41f0a2), decoded: SxtabT1{Rd: 0, Rn: 1, Rm: 2,
+			Shift: Shift{srtype: SRType_ROR, amount: 16}, setflags: NEVER}},
+		// sxtb.w r0, r1, ror #8
+		{instr: FetchedInstr32(0xfa4ff091), decoded: SxtbT2{Rd: 0, Rm: 1,
+			Shift: Shift{srtype: SRType_ROR, amount: 8}, setflags: NEVER}},
+		// sxtab r0, sp, r2
+		{instr: FetchedInstr32(0xfa4df082), decoded: UnpredictableInstr{}},
+	}
+
+	test_decode(t, cases, Sxtab32T1)
+
+	cases = []DecodeCase{
+		// uxtab16 r0, r1, r2
+		{instr: FetchedInstr32(0xfa31f082), decoded: UxtabDualT1{Rd: 0, Rn: 1, Rm: 2, setflags: NEVER}},
+		// uxtb16 r0, r1
+		{instr: FetchedInstr32(0xfa3ff081), decoded: UxtbDualT1{Rd: 0, Rm: 1, setflags: NEVER}},
+	}
+
+	test_decode(t, cases, UxtabDual32T1)
+}
+
+func TestExecuteExtend(t *testing.T) {
+	cases := []ExecuteCase{
+		// sxtb r0, r1
+		{instr: SxtbT1{Rd: 0, Rm: 1, setflags: NEVER},
+			regs:     Registers{r: GeneralRegs{0, 0x12345680}},
+			expected: Registers{r: GeneralRegs{0xffffff80, 0x12345680}}},
+		// uxth r0, r1
+		{instr: UxthT1{Rd: 0, Rm: 1, setflags: NEVER},
+			regs:     Registers{r: GeneralRegs{0, 0x1234f680}},
+			expected: Registers{r: GeneralRegs{0xf680, 0x1234f680}}},
+		// sxtb.w r0, r1, ror #8
+		{instr: SxtbT2{Rd: 0, Rm: 1, Shift: Shift{srtype: SRType_ROR, amount: 8}, setflags: NEVER},
+			regs:     Registers{r: GeneralRegs{0, 0x1234a680}},
+			expected: Registers{r: GeneralRegs{0xffffffa6, 0x1234a680}}},
+		// uxtb.w r0, r1, ror #24
+		{instr: UxtbT2{Rd: 0, Rm: 1, Shift: Shift{srtype: SRType_ROR, amount: 24}, setflags: NEVER},
+			regs:     Registers{r: GeneralRegs{0, 0x9234a680}},
+			expected: Registers{r: GeneralRegs{0x92, 0x9234a680}}},
+		// sxth.w r0, r1, ror #24
+		{instr: SxthT2{Rd: 0, Rm: 1, Shift: Shift{srtype: SRType_ROR, amount: 24}, setflags: NEVER},
+			regs:     Registers{r: GeneralRegs{0, 0x9234a680}},
+			expected: Registers{r: GeneralRegs{0xffff8092, 0x9234a680}}},
+		// sxtab r0, r1, r2, ror #16
+		{instr: SxtabT1{Rd: 0, Rn: 1, Rm: 2, Shift: Shift{srtype: SRType_ROR, amount: 16}, setflags: NEVER},
+			regs:     Registers{r: GeneralRegs{0, 100, 0x00fe0000}},
+			expected: Registers{r: GeneralRegs{98, 100, 0x00fe0000}}},
+		// uxtah r0, r1, r2
+		{instr: UxtahT1{Rd: 0, Rn: 1, Rm: 2, setflags: NEVER},
+			regs:     Registers{r: GeneralRegs{0, 0xffff0001, 0x1234ffff}},
+			expected: Registers{r: GeneralRegs{0x0, 0xffff0001, 0x1234ffff}}},
+		// sxtb16 r0, r1, ror #8
+		{instr: SxtbDualT1{Rd: 0, Rm: 1, Shift: Shift{srtype: SRType_ROR, amount: 8}, setflags: NEVER},
+			regs:     Registers{r: GeneralRegs{0, 0x80127f34}},
+			expected: Registers{r: GeneralRegs{0xff80007f, 0x80127f34}}},
+		// uxtab16 r0, r1, r2
+		{instr: UxtabDualT1{Rd: 0, Rn: 1, Rm: 2, setflags: NEVER},
+			regs:     Registers{r: GeneralRegs{0, 0x0001ffff, 0x12ff3402}},
+			expected: Registers{r: GeneralRegs{0x01000001, 0x0001ffff, 0x12ff3402}}},
+		// sxtab16 r0, r1, r2
+		{instr: SxtabDualT1{Rd: 0, Rn: 1, Rm: 2, setflags: NEVER},
+			regs:     Registers{r: GeneralRegs{0, 0x00050005, 0x00ff00fe}},
+			expected: Registers{r: GeneralRegs{0x00040003, 0x00050005, 0x00ff00fe}}},
+	}
+
+	test_execute(t, cases)
+}
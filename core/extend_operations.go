@@ -0,0 +1,68 @@
+package core
+
+/* Rotate Rm right by the encoded rotation, then sign or zero extend the
+ * bottom size bits. The extend and add forms add the result to Rn. */
+func Extend(cpu *CPU, instr InstrFields, size uint8, signed bool, add bool) {
+	rotated, _ := instr.Shift.EvaluateC(cpu.R(instr.Rm), cpu.Apsr.C)
+
+	result := rotated & ((1 << size) - 1)
+	if signed {
+		result = SignExtend(result, size)
+	}
+
+	if add {
+		result += cpu.R(instr.Rn)
+	}
+
+	cpu.SetR(instr.Rd, result)
+}
+
+/* Rotate Rm right by the encoded rotation, then sign or zero extend
+ * bytes 0 and 2 to halfwords (SXTB16, UXTB16). The extend and add forms
+ * add each halfword to the matching halfword of Rn. */
+func ExtendDual(cpu *CPU, instr InstrFields, signed bool, add bool) {
+	rotated, _ := instr.Shift.EvaluateC(cpu.R(instr.Rm), cpu.Apsr.C)
+
+	low := rotated & 0xff
+	high := (rotated >> 16) & 0xff
+	if signed {
+		low = SignExtend(low, 8)
+		high = SignExtend(high, 8)
+	}
+
+	if add {
+		low += cpu.R(instr.Rn)
+		high += cpu.R(instr.Rn) >> 16
+	}
+
+	cpu.SetR(instr.Rd, (high<<16)|(low&0xffff))
+}
+
+/* Extract the fields of the 16-bit extend encodings */
+func decode_extend16(raw_instr uint32) InstrFields {
+	Rd := RegIndex(raw_instr & 0x7)
+	Rm := RegIndex((raw_instr >> 3) & 0x7)
+
+	return InstrFields{Rd: Rd, Rm: Rm, setflags: NEVER}
+}
+
+/* Extract the fields of the 32-bit extend encodings, Rm rotated right by
+ * 0, 8, 16 or 24 bits. Rn is 1111 in those that don't add.
+ * ARM ARM A5.3.12 */
+func decode_extend32(raw_instr uint32) InstrFields {
+	Rd := RegIndex((raw_instr >> 8) & 0xf)
+	Rn := RegIndex((raw_instr >> 16) & 0xf)
+	Rm := RegIndex(raw_instr & 0xf)
+
+	var shift Shift
+	if rotate := (raw_instr >> 4) & 0x3; rotate != 0 {
+		shift = Shift{srtype: SRType_ROR, amount: uint8(8 * rotate)}
+	}
+
+	return InstrFields{Rd: Rd, Rn: Rn, Rm: Rm, Shift: shift, setflags: NEVER}
+}
+
+/* Are the registers of a 32-bit extend encoding UNPREDICTABLE? */
+func bad_extend(instr InstrFields, add bool) bool {
+	return BadReg(instr.Rd) || BadReg(instr.Rm) || (add && instr.Rn == SP)
+}
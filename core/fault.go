@@ -0,0 +1,80 @@
+package core
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+var ErrUnalignedAccess = errors.New("Unaligned access.")
+
+/* A memory access that could not complete. Err is ErrUnalignedAccess
+ * for alignment faults, otherwise the error returned by memory. */
+type MemoryFault struct {
+	Addr  uint32
+	Write bool
+	Err   error
+}
+
+func (fault MemoryFault) Error() string {
+	access := "reading"
+	if fault.Write {
+		access = "writing"
+	}
+
+	return fmt.Sprintf("Fault %s %#x: %s", access, fault.Addr, fault.Err)
+}
+
+/* Record a fault raised by the executing instruction. Only the first
+ * fault of an instruction is kept. */
+func (cpu *CPU) raise(fault error) {
+	if cpu.fault == nil {
+		cpu.fault = fault
+	}
+}
+
+/* UsageFault, with the UFSR bit of each cause set
+ * ARMv7-M ARM B3.2.15 */
+type UsageFault uint16
+
+const (
+	UFSR_UNDEFINSTR UsageFault = 1 << 0 // Undefined instruction
+	UFSR_INVSTATE   UsageFault = 1 << 1 // Executed with an invalid EPSR
+	UFSR_INVPC      UsageFault = 1 << 2 // Invalid EXC_RETURN
+	UFSR_NOCP       UsageFault = 1 << 3 // Coprocessor access
+	UFSR_UNALIGNED  UsageFault = 1 << 8 // Unaligned access trap
+	UFSR_DIVBYZERO  UsageFault = 1 << 9 // Divide by zero trap
+)
+
+var usage_fault_names = map[UsageFault]string{
+	UFSR_UNDEFINSTR: "UNDEFINSTR",
+	UFSR_INVSTATE:   "INVSTATE",
+	UFSR_INVPC:      "INVPC",
+	UFSR_NOCP:       "NOCP",
+	UFSR_UNALIGNED:  "UNALIGNED",
+	UFSR_DIVBYZERO:  "DIVBYZERO",
+}
+
+func (fault UsageFault) Error() string {
+	var causes []string
+
+	for bit := UsageFault(1); bit != 0; bit <<= 1 {
+		if fault&bit != 0 {
+			causes = append(causes, usage_fault_names[bit])
+		}
+	}
+
+	return fmt.Sprintf("UsageFault: %s", strings.Join(causes, ", "))
+}
+
+/* BusFault causes, each the BFSR bit set
+ * ARMv7-M ARM B3.2.16 */
+type BusFault uint8
+
+const (
+	BFSR_IBUSERR   BusFault = 1 << 0 // Instruction fetch
+	BFSR_PRECISERR BusFault = 1 << 1 // Data access, with the address in BFAR
+	BFSR_UNSTKERR  BusFault = 1 << 3 // Unstacking on exception return
+	BFSR_STKERR    BusFault = 1 << 4 // Stacking on exception entry
+	BFSR_BFARVALID BusFault = 1 << 7 // BFAR holds the faulting address
+)
@@ -0,0 +1,131 @@
+package core
+
+/* Floating-point context preservation. An exception taken while
+ * CONTROL.FPCA is set pushes an extended frame, with space for S0-S15 and
+ * FPSCR above the basic frame. With FPCCR.LSPEN the registers are not
+ * saved there until the handler executes a floating-point instruction,
+ * and not at all if it returns first.
+ * ARMv7-M ARM B1.5.7 */
+
+/* Exception stack frame sizes */
+const (
+	FRAME_SIZE_BASIC    = 0x20 // R0-R3, R12, LR, return address, xPSR
+	FRAME_SIZE_EXTENDED = 0x68 // Basic frame, S0-S15, FPSCR, reserved word
+	FRAME_FP_OFFSET     = 0x20 // S0 within the extended frame
+)
+
+/* Does an exception taken now push an extended frame? */
+func (cpu *CPU) ExtendedFrame() bool {
+	return cpu.Profile.FPU && cpu.Control.Fpca
+}
+
+/* Size of the frame an exception taken now pushes */
+func (cpu *CPU) FrameSize() uint32 {
+	if cpu.ExtendedFrame() {
+		return FRAME_SIZE_EXTENDED
+	}
+
+	return FRAME_SIZE_BASIC
+}
+
+/* Store S0-S15 and FPSCR from addr */
+func (cpu *CPU) save_fp_context(addr uint32) bool {
+	for i := SRegIndex(0); i < 16; i++ {
+		if !cpu.SetMemA(addr+4*uint32(i), 4, cpu.S(i)) {
+			return false
+		}
+	}
+
+	return cpu.SetMemA(addr+0x40, 4, cpu.Fpscr.Uint32())
+}
+
+/* Load S0-S15 and FPSCR from addr. Nothing is written if any access faults. */
+func (cpu *CPU) restore_fp_context(addr uint32) bool {
+	var values [17]uint32
+	for i := range values {
+		value, ok := cpu.MemA(addr+4*uint32(i), 4)
+		if !ok {
+			return false
+		}
+
+		values[i] = value
+	}
+
+	for i := SRegIndex(0); i < 16; i++ {
+		cpu.SetS(i, values[i])
+	}
+	cpu.Fpscr.SetUint32(values[16])
+
+	return true
+}
+
+/* Save the floating-point context into the extended frame at frameptr,
+ * as exception entry does, or with FPCCR.LSPEN defer the save */
+func (cpu *CPU) PushFPContext(frameptr uint32) bool {
+	if cpu.Scb.Fpccr&FPCCR_LSPEN == 0 {
+		return CheckVFPEnabled(cpu) && cpu.save_fp_context(frameptr+FRAME_FP_OFFSET)
+	}
+
+	cpu.UpdateFPCCR(frameptr)
+
+	return true
+}
+
+/* Restore the floating-point context from the extended frame at frameptr,
+ * as exception return does. A save still deferred is abandoned, the
+ * registers already holding the context.
+ * ARMv7-M ARM B1.5.8 */
+func (cpu *CPU) PopFPContext(frameptr uint32) bool {
+	if cpu.Scb.Fpccr&FPCCR_LSPACT != 0 {
+		cpu.Scb.Fpccr &^= FPCCR_LSPACT
+		return true
+	}
+
+	return CheckVFPEnabled(cpu) && cpu.restore_fp_context(frameptr+FRAME_FP_OFFSET)
+}
+
+/* Defer saving the floating-point context to the extended frame at
+ * frameptr, recording the state of the interrupted context, including
+ * which faults the save could pend from it.
+ * ARMv7-M ARM pseudocode UpdateFPCCR() */
+func (cpu *CPU) UpdateFPCCR(frameptr uint32) {
+	fpccr := cpu.Scb.Fpccr &^ (FPCCR_USER | FPCCR_THREAD | FPCCR_HFRDY | FPCCR_MMRDY | FPCCR_BFRDY | FPCCR_MONRDY)
+	fpccr |= FPCCR_LSPACT
+
+	if !cpu.CurrentModeIsPrivileged() {
+		fpccr |= FPCCR_USER
+	}
+	if cpu.Mode == MODE_THREAD {
+		fpccr |= FPCCR_THREAD
+	}
+	if faultmask_settable(cpu) {
+		fpccr |= FPCCR_HFRDY
+	}
+	if cpu.fault_ready(EXCEPTION_MEMMANAGE) {
+		fpccr |= FPCCR_MMRDY
+	}
+	if cpu.fault_ready(EXCEPTION_BUSFAULT) {
+		fpccr |= FPCCR_BFRDY
+	}
+
+	cpu.Scb.Fpcar = (frameptr + FRAME_FP_OFFSET) & FPCAR_ADDRESS_MASK
+	cpu.Scb.Fpccr = fpccr
+}
+
+/* Complete a deferred save of the floating-point context, before a
+ * floating-point instruction changes it. If the save faults it remains
+ * pending, to be retried with the instruction.
+ * ARMv7-M ARM pseudocode PreserveFPState() */
+func (cpu *CPU) PreserveFPState() bool {
+	if cpu.Scb.Fpccr&FPCCR_LSPACT == 0 {
+		return true
+	}
+
+	if !cpu.save_fp_context(cpu.Scb.Fpcar) {
+		return false
+	}
+
+	cpu.Scb.Fpccr &^= FPCCR_LSPACT
+
+	return true
+}
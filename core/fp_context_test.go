@@ -0,0 +1,171 @@
+package core
+
+import "testing"
+
+func TestFPContextRegisters(t *testing.T) {
+	cpu := NewCPU(NewDefaultBus())
+	cpu.Profile = PROFILE_ARMV7EM_FP
+
+	if fpccr, err := cpu.Read32(SCB_FPCCR); err != nil || fpccr != FPCCR_ASPEN|FPCCR_LSPEN {
+		t.Errorf("FPCCR = %#x, %v, expected %#x", fpccr, err, FPCCR_ASPEN|FPCCR_LSPEN)
+	}
+
+	/* FPCAR is doubleword aligned, FPDSCR only holds the FPSCR defaults */
+	cpu.Write32(SCB_FPCAR, 0x2000010c)
+	cpu.Write32(SCB_FPDSCR, 0xffffffff)
+
+	if fpcar, _ := cpu.Read32(SCB_FPCAR); fpcar != 0x20000108 {
+		t.Errorf("FPCAR = %#x, expected %#x", fpcar, 0x20000108)
+	}
+	if fpdscr, _ := cpu.Read32(SCB_FPDSCR); fpdscr != 0x07c00000 {
+		t.Errorf("FPDSCR = %#x, expected %#x", fpdscr, 0x07c00000)
+	}
+
+	/* Absent without the FP extension */
+	cpu.Profile = PROFILE_ARMV7EM
+	if _, err := cpu.Read32(SCB_FPCCR); err != ErrUnmappedAccess {
+		t.Errorf("FPCCR without FPU: err = %v, expected %v", err, ErrUnmappedAccess)
+	}
+}
+
+func TestExecuteFPCheckNewContext(t *testing.T) {
+	cpu := CPU{Profile: PROFILE_ARMV7EM_FP}
+	cpu.Scb.Cpacr = CPACR_FP_MASK
+	cpu.Scb.Fpccr = FPCCR_ASPEN
+	cpu.Scb.Fpdscr = FPSCR_DN | uint32(FPROUND_RZ)<<22
+	cpu.Fpscr = Fpscr{N: true, FZ: true}
+
+	/* The first instruction takes the FPSCR defaults, keeping the flags */
+	VaddT1{Sd: 0, Sn: 1, Sm: 2}.Execute(&cpu)
+	if expected := (Fpscr{N: true, DN: true, RMode: FPROUND_RZ}); !cpu.Control.Fpca || cpu.Fpscr != expected {
+		t.Errorf("new context: FPCA = %v, FPSCR = %+v, expected %+v", cpu.Control.Fpca, cpu.Fpscr, expected)
+	}
+
+	cpu.Fpscr = Fpscr{}
+	VaddT1{Sd: 0, Sn: 1, Sm: 2}.Execute(&cpu)
+	if cpu.Fpscr != (Fpscr{}) {
+		t.Errorf("existing context: FPSCR = %+v", cpu.Fpscr)
+	}
+
+	/* Without ASPEN, FPCA is left to software */
+	cpu = CPU{Profile: PROFILE_ARMV7EM_FP}
+	cpu.Scb.Cpacr = CPACR_FP_MASK
+	VaddT1{Sd: 0, Sn: 1, Sm: 2}.Execute(&cpu)
+	if cpu.Control.Fpca {
+		t.Errorf("ASPEN clear: FPCA set")
+	}
+}
+
+/* A CPU with floating-point state, in S0-S15 and FPSCR, about to take an exception */
+func fp_context_cpu() *CPU {
+	cpu := NewCPU(make(RAM, 0x100))
+	cpu.Profile = PROFILE_ARMV7EM_FP
+	cpu.Scb.Cpacr = CPACR_FP_MASK
+	cpu.Control.Fpca = true
+
+	for i := SRegIndex(0); i < 32; i++ {
+		cpu.SetS(i, 0x100+uint32(i))
+	}
+	cpu.Fpscr = Fpscr{Z: true, RMode: FPROUND_RM}
+
+	return cpu
+}
+
+func TestLazyFPContext(t *testing.T) {
+	cpu := fp_context_cpu()
+	ram := cpu.Mem.(RAM)
+
+	if size := cpu.FrameSize(); size != FRAME_SIZE_EXTENDED {
+		t.Errorf("frame size = %#x, expected %#x", size, FRAME_SIZE_EXTENDED)
+	}
+	if exc_return := cpu.ExcReturn(); exc_return != 0xffffffe9 {
+		t.Errorf("EXC_RETURN = %#x, expected %#x", exc_return, 0xffffffe9)
+	}
+
+	/* Space is reserved, but nothing saved */
+	cpu.PushFPContext(0x10)
+	if expected := uint32(FPCCR_RESET | FPCCR_LSPACT | FPCCR_THREAD | FPCCR_HFRDY); cpu.Scb.Fpccr != expected {
+		t.Errorf("FPCCR = %#x, expected %#x", cpu.Scb.Fpccr, expected)
+	}
+	if cpu.Scb.Fpcar != 0x30 {
+		t.Errorf("FPCAR = %#x, expected %#x", cpu.Scb.Fpcar, 0x30)
+	}
+	if word, _ := ram.Read32(0x30); word != 0 {
+		t.Errorf("S0 saved before use: %#x", word)
+	}
+
+	/* The handler's first floating-point instruction saves the context */
+	cpu.Mode = MODE_HANDLER
+	cpu.Control.Fpca = false
+	VmovImmT1{Sd: 0, Imm: 0x3f800000}.Execute(cpu)
+
+	if cpu.Scb.Fpccr&FPCCR_LSPACT != 0 {
+		t.Errorf("LSPACT still set after FP instruction")
+	}
+	if s0, _ := ram.Read32(0x30); s0 != 0x100 {
+		t.Errorf("saved S0 = %#x, expected %#x", s0, 0x100)
+	}
+	if s15, _ := ram.Read32(0x6c); s15 != 0x10f {
+		t.Errorf("saved S15 = %#x, expected %#x", s15, 0x10f)
+	}
+	if fpscr, _ := ram.Read32(0x70); fpscr != FPSCR_Z|uint32(FPROUND_RM)<<22 {
+		t.Errorf("saved FPSCR = %#x", fpscr)
+	}
+
+	/* Return restores the saved context */
+	cpu.PopFPContext(0x10)
+	if cpu.S(0) != 0x100 || cpu.Fpscr != (Fpscr{Z: true, RMode: FPROUND_RM}) {
+		t.Errorf("restored S0 = %#x, FPSCR = %+v", cpu.S(0), cpu.Fpscr)
+	}
+}
+
+func TestLazyFPContextUnused(t *testing.T) {
+	cpu := fp_context_cpu()
+	ram := cpu.Mem.(RAM)
+
+	cpu.PushFPContext(0x10)
+	cpu.Mode = MODE_HANDLER
+	cpu.Control.Fpca = false
+
+	/* A handler without floating-point instructions pushes basic frames */
+	if exc_return := cpu.ExcReturn(); exc_return != EXC_RETURN_HANDLER {
+		t.Errorf("nested EXC_RETURN = %#x, expected %#x", exc_return, EXC_RETURN_HANDLER)
+	}
+
+	/* Returning abandons the deferred save, leaving the registers */
+	ram.Write32(0x30, 0xdead)
+	cpu.PopFPContext(0x10)
+
+	if cpu.Scb.Fpccr&FPCCR_LSPACT != 0 {
+		t.Errorf("LSPACT still set after return")
+	}
+	if cpu.S(0) != 0x100 {
+		t.Errorf("S0 = %#x, expected %#x", cpu.S(0), 0x100)
+	}
+}
+
+func TestFPContextNotLazy(t *testing.T) {
+	cpu := fp_context_cpu()
+	ram := cpu.Mem.(RAM)
+	cpu.Scb.Fpccr = FPCCR_ASPEN
+	cpu.Control.Spsel = PSP
+
+	if exc_return := cpu.ExcReturn(); exc_return != 0xffffffed {
+		t.Errorf("EXC_RETURN = %#x, expected %#x", exc_return, 0xffffffed)
+	}
+
+	/* Without LSPEN the context is saved on entry */
+	cpu.PushFPContext(0x10)
+	if cpu.Scb.Fpccr != FPCCR_ASPEN {
+		t.Errorf("FPCCR = %#x, expected %#x", cpu.Scb.Fpccr, FPCCR_ASPEN)
+	}
+	if s1, _ := ram.Read32(0x34); s1 != 0x101 {
+		t.Errorf("saved S1 = %#x, expected %#x", s1, 0x101)
+	}
+
+	ram.Write32(0x34, 0x3f800000)
+	cpu.PopFPContext(0x10)
+	if cpu.S(1) != 0x3f800000 || cpu.S(16) != 0x110 {
+		t.Errorf("restored S1 = %#x, S16 = %#x", cpu.S(1), cpu.S(16))
+	}
+}
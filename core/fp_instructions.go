@@ -0,0 +1,606 @@
+package core
+
+import (
+	"fmt"
+	"math"
+)
+
+/* Extract the single-precision registers of the floating-point
+ * data-processing instructions, Vd:D, Vn:N and Vm:M */
+func decode_fp(raw_instr uint32) FPFields {
+	Sd := SRegIndex(((raw_instr>>12)&0xf)<<1 | (raw_instr>>22)&0x1)
+	Sn := SRegIndex(((raw_instr>>16)&0xf)<<1 | (raw_instr>>7)&0x1)
+	Sm := SRegIndex((raw_instr&0xf)<<1 | (raw_instr>>5)&0x1)
+
+	return FPFields{Sd: Sd, Sn: Sn, Sm: Sm}
+}
+
+func fp_operands(instr FPFields) string {
+	return fmt.Sprintf("%s, %s, %s", instr.Sd, instr.Sn, instr.Sm)
+}
+
+/* VMLA
+ * ARM ARM A7.7.235
+ * Encoding T1 */
+type VmlaT1 FPFields
+
+func Vmla32T1(instr FetchedInstr) DecodedInstr {
+	return VmlaT1(decode_fp(instr.Uint32()))
+}
+
+func (instr VmlaT1) Execute(cpu *CPU) {
+	if ExecuteFPCheck(cpu) {
+		product := FPMul(cpu.S(instr.Sn), cpu.S(instr.Sm), &cpu.Fpscr)
+		cpu.SetS(instr.Sd, FPAdd(cpu.S(instr.Sd), product, &cpu.Fpscr))
+	}
+}
+
+func (instr VmlaT1) String() string {
+	return fmt.Sprintf("vmla.f32 %s", fp_operands(FPFields(instr)))
+}
+
+/* VMLS
+ * ARM ARM A7.7.235
+ * Encoding T1 */
+type VmlsT1 FPFields
+
+func Vmls32T1(instr FetchedInstr) DecodedInstr {
+	return VmlsT1(decode_fp(instr.Uint32()))
+}
+
+func (instr VmlsT1) Execute(cpu *CPU) {
+	if ExecuteFPCheck(cpu) {
+		product := FPMul(cpu.S(instr.Sn), cpu.S(instr.Sm), &cpu.Fpscr)
+		cpu.SetS(instr.Sd, FPAdd(cpu.S(instr.Sd), FPNeg(product), &cpu.Fpscr))
+	}
+}
+
+func (instr VmlsT1) String() string {
+	return fmt.Sprintf("vmls.f32 %s", fp_operands(FPFields(instr)))
+}
+
+/* VNMLA
+ * ARM ARM A7.7.247
+ * Encoding T1 */
+type VnmlaT1 FPFields
+
+func Vnmla32T1(instr FetchedInstr) DecodedInstr {
+	return VnmlaT1(decode_fp(instr.Uint32()))
+}
+
+func (instr VnmlaT1) Execute(cpu *CPU) {
+	if ExecuteFPCheck(cpu) {
+		product := FPMul(cpu.S(instr.Sn), cpu.S(instr.Sm), &cpu.Fpscr)
+		cpu.SetS(instr.Sd, FPAdd(FPNeg(cpu.S(instr.Sd)), FPNeg(product), &cpu.Fpscr))
+	}
+}
+
+func (instr VnmlaT1) String() string {
+	return fmt.Sprintf("vnmla.f32 %s", fp_operands(FPFields(instr)))
+}
+
+/* VNMLS
+ * ARM ARM A7.7.247
+ * Encoding T1 */
+type VnmlsT1 FPFields
+
+func Vnmls32T1(instr FetchedInstr) DecodedInstr {
+	return VnmlsT1(decode_fp(instr.Uint32()))
+}
+
+func (instr VnmlsT1) Execute(cpu *CPU) {
+	if ExecuteFPCheck(cpu) {
+		product := FPMul(cpu.S(instr.Sn), cpu.S(instr.Sm), &cpu.Fpscr)
+		cpu.SetS(instr.Sd, FPAdd(FPNeg(cpu.S(instr.Sd)), product, &cpu.Fpscr))
+	}
+}
+
+func (instr VnmlsT1) String() string {
+	return fmt.Sprintf("vnmls.f32 %s", fp_operands(FPFields(instr)))
+}
+
+/* VMUL
+ * ARM ARM A7.7.245
+ * Encoding T1 */
+type VmulT1 FPFields
+
+func Vmul32T1(instr FetchedInstr) DecodedInstr {
+	return VmulT1(decode_fp(instr.Uint32()))
+}
+
+func (instr VmulT1) Execute(cpu *CPU) {
+	if ExecuteFPCheck(cpu) {
+		cpu.SetS(instr.Sd, FPMul(cpu.S(instr.Sn), cpu.S(instr.Sm), &cpu.Fpscr))
+	}
+}
+
+func (instr VmulT1) String() string {
+	return fmt.Sprintf("vmul.f32 %s", fp_operands(FPFields(instr)))
+}
+
+/* VNMUL
+ * ARM ARM A7.7.247
+ * Encoding T2 */
+type VnmulT2 FPFields
+
+func Vnmul32T2(instr FetchedInstr) DecodedInstr {
+	return VnmulT2(decode_fp(instr.Uint32()))
+}
+
+func (instr VnmulT2) Execute(cpu *CPU) {
+	if ExecuteFPCheck(cpu) {
+		cpu.SetS(instr.Sd, FPNeg(FPMul(cpu.S(instr.Sn), cpu.S(instr.Sm), &cpu.Fpscr)))
+	}
+}
+
+func (instr VnmulT2) String() string {
+	return fmt.Sprintf("vnmul.f32 %s", fp_operands(FPFields(instr)))
+}
+
+/* VADD
+ * ARM ARM A7.7.222
+ * Encoding T1 */
+type VaddT1 FPFields
+
+func Vadd32T1(instr FetchedInstr) DecodedInstr {
+	return VaddT1(decode_fp(instr.Uint32()))
+}
+
+func (instr VaddT1) Execute(cpu *CPU) {
+	if ExecuteFPCheck(cpu) {
+		cpu.SetS(instr.Sd, FPAdd(cpu.S(instr.Sn), cpu.S(instr.Sm), &cpu.Fpscr))
+	}
+}
+
+func (instr VaddT1) String() string {
+	return fmt.Sprintf("vadd.f32 %s", fp_operands(FPFields(instr)))
+}
+
+/* VSUB
+ * ARM ARM A7.7.257
+ * Encoding T1 */
+type VsubT1 FPFields
+
+func Vsub32T1(instr FetchedInstr) DecodedInstr {
+	return VsubT1(decode_fp(instr.Uint32()))
+}
+
+func (instr VsubT1) Execute(cpu *CPU) {
+	if ExecuteFPCheck(cpu) {
+		cpu.SetS(instr.Sd, FPSub(cpu.S(instr.Sn), cpu.S(instr.Sm), &cpu.Fpscr))
+	}
+}
+
+func (instr VsubT1) String() string {
+	return fmt.Sprintf("vsub.f32 %s", fp_operands(FPFields(instr)))
+}
+
+/* VDIV
+ * ARM ARM A7.7.229
+ * Encoding T1 */
+type VdivT1 FPFields
+
+func Vdiv32T1(instr FetchedInstr) DecodedInstr {
+	return VdivT1(decode_fp(instr.Uint32()))
+}
+
+func (instr VdivT1) Execute(cpu *CPU) {
+	if ExecuteFPCheck(cpu) {
+		cpu.SetS(instr.Sd, FPDiv(cpu.S(instr.Sn), cpu.S(instr.Sm), &cpu.Fpscr))
+	}
+}
+
+func (instr VdivT1) String() string {
+	return fmt.Sprintf("vdiv.f32 %s", fp_operands(FPFields(instr)))
+}
+
+/* VFMA
+ * ARM ARM A7.7.230
+ * Encoding T1 */
+type VfmaT1 FPFields
+
+func Vfma32T1(instr FetchedInstr) DecodedInstr {
+	return VfmaT1(decode_fp(instr.Uint32()))
+}
+
+func (instr VfmaT1) Execute(cpu *CPU) {
+	if ExecuteFPCheck(cpu) {
+		cpu.SetS(instr.Sd, FPMulAdd(cpu.S(instr.Sd), cpu.S(instr.Sn), cpu.S(instr.Sm), &cpu.Fpscr))
+	}
+}
+
+func (instr VfmaT1) String() string {
+	return fmt.Sprintf("vfma.f32 %s", fp_operands(FPFields(instr)))
+}
+
+/* VFMS
+ * ARM ARM A7.7.230
+ * Encoding T1 */
+type VfmsT1 FPFields
+
+func Vfms32T1(instr FetchedInstr) DecodedInstr {
+	return VfmsT1(decode_fp(instr.Uint32()))
+}
+
+func (instr VfmsT1) Execute(cpu *CPU) {
+	if ExecuteFPCheck(cpu) {
+		cpu.SetS(instr.Sd, FPMulAdd(cpu.S(instr.Sd), FPNeg(cpu.S(instr.Sn)), cpu.S(instr.Sm), &cpu.Fpscr))
+	}
+}
+
+func (instr VfmsT1) String() string {
+	return fmt.Sprintf("vfms.f32 %s", fp_operands(FPFields(instr)))
+}
+
+/* VFNMA
+ * ARM ARM A7.7.231
+ * Encoding T1 */
+type VfnmaT1 FPFields
+
+func Vfnma32T1(instr FetchedInstr) DecodedInstr {
+	return VfnmaT1(decode_fp(instr.Uint32()))
+}
+
+func (instr VfnmaT1) Execute(cpu *CPU) {
+	if ExecuteFPCheck(cpu) {
+		cpu.SetS(instr.Sd, FPMulAdd(FPNeg(cpu.S(instr.Sd)), FPNeg(cpu.S(instr.Sn)), cpu.S(instr.Sm), &cpu.Fpscr))
+	}
+}
+
+func (instr VfnmaT1) String() string {
+	return fmt.Sprintf("vfnma.f32 %s", fp_operands(FPFields(instr)))
+}
+
+/* VFNMS
+ * ARM ARM A7.7.231
+ * Encoding T1 */
+type VfnmsT1 FPFields
+
+func Vfnms32T1(instr FetchedInstr) DecodedInstr {
+	return VfnmsT1(decode_fp(instr.Uint32()))
+}
+
+func (instr VfnmsT1) Execute(cpu *CPU) {
+	if ExecuteFPCheck(cpu) {
+		cpu.SetS(instr.Sd, FPMulAdd(FPNeg(cpu.S(instr.Sd)), cpu.S(instr.Sn), cpu.S(instr.Sm), &cpu.Fpscr))
+	}
+}
+
+func (instr VfnmsT1) String() string {
+	return fmt.Sprintf("vfnms.f32 %s", fp_operands(FPFields(instr)))
+}
+
+/* VMOV (immediate)
+ * ARM ARM A7.7.236
+ * Encoding T1 */
+type VmovImmT1 FPFields
+
+func VmovImm32T1(instr FetchedInstr) DecodedInstr {
+	raw_instr := instr.Uint32()
+	fields := decode_fp(raw_instr)
+
+	imm8 := uint8((raw_instr>>12)&0xf0 | raw_instr&0xf)
+
+	return VmovImmT1{Sd: fields.Sd, Imm: VFPExpandImm(imm8)}
+}
+
+func (instr VmovImmT1) Execute(cpu *CPU) {
+	if ExecuteFPCheck(cpu) {
+		cpu.SetS(instr.Sd, instr.Imm)
+	}
+}
+
+func (instr VmovImmT1) String() string {
+	return fmt.Sprintf("vmov.f32 %s, #%e", instr.Sd, math.Float32frombits(instr.Imm))
+}
+
+/* VMOV (register)
+ * ARM ARM A7.7.237
+ * Encoding T1 */
+type VmovRegT1 FPFields
+
+func VmovReg32T1(instr FetchedInstr) DecodedInstr {
+	return VmovRegT1(decode_fp(instr.Uint32()))
+}
+
+func (instr VmovRegT1) Execute(cpu *CPU) {
+	if ExecuteFPCheck(cpu) {
+		cpu.SetS(instr.Sd, cpu.S(instr.Sm))
+	}
+}
+
+func (instr VmovRegT1) String() string {
+	return fmt.Sprintf("vmov.f32 %s, %s", instr.Sd, instr.Sm)
+}
+
+/* VABS
+ * ARM ARM A7.7.221
+ * Encoding T1 */
+type VabsT1 FPFields
+
+func Vabs32T1(instr FetchedInstr) DecodedInstr {
+	return VabsT1(decode_fp(instr.Uint32()))
+}
+
+func (instr VabsT1) Execute(cpu *CPU) {
+	if ExecuteFPCheck(cpu) {
+		cpu.SetS(instr.Sd, FPAbs(cpu.S(instr.Sm)))
+	}
+}
+
+func (instr VabsT1) String() string {
+	return fmt.Sprintf("vabs.f32 %s, %s", instr.Sd, instr.Sm)
+}
+
+/* VNEG
+ * ARM ARM A7.7.246
+ * Encoding T1 */
+type VnegT1 FPFields
+
+func Vneg32T1(instr FetchedInstr) DecodedInstr {
+	return VnegT1(decode_fp(instr.Uint32()))
+}
+
+func (instr VnegT1) Execute(cpu *CPU) {
+	if ExecuteFPCheck(cpu) {
+		cpu.SetS(instr.Sd, FPNeg(cpu.S(instr.Sm)))
+	}
+}
+
+func (instr VnegT1) String() string {
+	return fmt.Sprintf("vneg.f32 %s, %s", instr.Sd, instr.Sm)
+}
+
+/* VSQRT
+ * ARM ARM A7.7.254
+ * Encoding T1 */
+type VsqrtT1 FPFields
+
+func Vsqrt32T1(instr FetchedInstr) DecodedInstr {
+	return VsqrtT1(decode_fp(instr.Uint32()))
+}
+
+func (instr VsqrtT1) Execute(cpu *CPU) {
+	if ExecuteFPCheck(cpu) {
+		cpu.SetS(instr.Sd, FPSqrt(cpu.S(instr.Sm), &cpu.Fpscr))
+	}
+}
+
+func (instr VsqrtT1) String() string {
+	return fmt.Sprintf("vsqrt.f32 %s, %s", instr.Sd, instr.Sm)
+}
+
+/* VCMP, VCMPE
+ * ARM ARM A7.7.223
+ * Encoding T1 */
+type VcmpT1 struct {
+	Sd SRegIndex
+	Sm SRegIndex
+	E  bool // VCMPE, signalling Invalid Operation for quiet NaNs too
+}
+
+func Vcmp32T1(instr FetchedInstr) DecodedInstr {
+	raw_instr := instr.Uint32()
+	fields := decode_fp(raw_instr)
+
+	E := (raw_instr>>7)&0x1 != 0
+
+	return VcmpT1{Sd: fields.Sd, Sm: fields.Sm, E: E}
+}
+
+func (instr VcmpT1) Execute(cpu *CPU) {
+	if ExecuteFPCheck(cpu) {
+		FloatingPointCompare(cpu, cpu.S(instr.Sd), cpu.S(instr.Sm), instr.E)
+	}
+}
+
+func (instr VcmpT1) String() string {
+	return fmt.Sprintf("%s.f32 %s, %s", vcmp_mnemonic(instr.E), instr.Sd, instr.Sm)
+}
+
+/* VCMP, VCMPE
+ * ARM ARM A7.7.223
+ * Encoding T2 */
+type VcmpT2 struct {
+	Sd SRegIndex
+	E  bool
+}
+
+func Vcmp32T2(instr FetchedInstr) DecodedInstr {
+	raw_instr := instr.Uint32()
+	fields := decode_fp(raw_instr)
+
+	E := (raw_instr>>7)&0x1 != 0
+
+	return VcmpT2{Sd: fields.Sd, E: E}
+}
+
+func (instr VcmpT2) Execute(cpu *CPU) {
+	if ExecuteFPCheck(cpu) {
+		FloatingPointCompare(cpu, cpu.S(instr.Sd), fp_zero(fp_single, false), instr.E)
+	}
+}
+
+func (instr VcmpT2) String() string {
+	return fmt.Sprintf("%s.f32 %s, #0", vcmp_mnemonic(instr.E), instr.Sd)
+}
+
+func vcmp_mnemonic(E bool) string {
+	if E {
+		return "vcmpe"
+	}
+
+	return "vcmp"
+}
+
+/* VCVT, VCVTR (between floating-point and integer)
+ * ARM ARM A7.7.225
+ * Encoding T1 */
+type VcvtIntT1 struct {
+	Sd        SRegIndex
+	Sm        SRegIndex
+	ToInteger bool
+	Unsigned  bool
+	RoundZero bool // VCVT rounds towards zero, VCVTR as FPSCR
+}
+
+func VcvtInt32T1(instr FetchedInstr) DecodedInstr {
+	raw_instr := instr.Uint32()
+	fields := decode_fp(raw_instr)
+
+	opc2 := (raw_instr >> 16) & 0x7
+	op := (raw_instr>>7)&0x1 != 0
+
+	/* opc2 is 000 to floating-point, 10x to integer */
+	if opc2 == 0 {
+		return VcvtIntT1{Sd: fields.Sd, Sm: fields.Sm, ToInteger: false, Unsigned: !op}
+	}
+
+	return VcvtIntT1{Sd: fields.Sd, Sm: fields.Sm, ToInteger: true, Unsigned: opc2&0x1 == 0, RoundZero: op}
+}
+
+func (instr VcvtIntT1) Execute(cpu *CPU) {
+	if !ExecuteFPCheck(cpu) {
+		return
+	}
+
+	if instr.ToInteger {
+		cpu.SetS(instr.Sd, FPToFixed(cpu.S(instr.Sm), 32, 0, instr.Unsigned, instr.RoundZero, &cpu.Fpscr))
+	} else {
+		cpu.SetS(instr.Sd, FixedToFP(cpu.S(instr.Sm), 32, 0, instr.Unsigned, false, &cpu.Fpscr))
+	}
+}
+
+func (instr VcvtIntT1) String() string {
+	if !instr.ToInteger {
+		return fmt.Sprintf("vcvt.f32.%s32 %s, %s", fp_int_type(instr.Unsigned), instr.Sd, instr.Sm)
+	}
+
+	mnemonic := "vcvt"
+	if !instr.RoundZero {
+		mnemonic = "vcvtr"
+	}
+
+	return fmt.Sprintf("%s.%s32.f32 %s, %s", mnemonic, fp_int_type(instr.Unsigned), instr.Sd, instr.Sm)
+}
+
+func fp_int_type(unsigned bool) string {
+	if unsigned {
+		return "u"
+	}
+
+	return "s"
+}
+
+/* VCVT (between floating-point and fixed-point), converting Sd in place
+ * ARM ARM A7.7.226
+ * Encoding T1 */
+type VcvtFixedT1 struct {
+	Sd       SRegIndex
+	Size     uint8 // Width of the fixed-point value, 16 or 32
+	FracBits uint8
+	ToFixed  bool
+	Unsigned bool
+}
+
+func VcvtFixed32T1(instr FetchedInstr) DecodedInstr {
+	raw_instr := instr.Uint32()
+	fields := decode_fp(raw_instr)
+
+	to_fixed := (raw_instr>>18)&0x1 != 0
+	unsigned := (raw_instr>>16)&0x1 != 0
+	imm5 := uint8((raw_instr&0xf)<<1 | (raw_instr>>5)&0x1)
+
+	var size uint8 = 16
+	if (raw_instr>>7)&0x1 != 0 {
+		size = 32
+	}
+
+	if imm5 > size {
+		return UnpredictableInstr{}
+	}
+
+	return VcvtFixedT1{Sd: fields.Sd, Size: size, FracBits: size - imm5, ToFixed: to_fixed, Unsigned: unsigned}
+}
+
+func (instr VcvtFixedT1) Execute(cpu *CPU) {
+	if !ExecuteFPCheck(cpu) {
+		return
+	}
+
+	if !instr.ToFixed {
+		cpu.SetS(instr.Sd, FixedToFP(cpu.S(instr.Sd), instr.Size, instr.FracBits, instr.Unsigned, true, &cpu.Fpscr))
+		return
+	}
+
+	result := FPToFixed(cpu.S(instr.Sd), instr.Size, instr.FracBits, instr.Unsigned, true, &cpu.Fpscr)
+	if instr.Unsigned {
+		result &= uint32(1<<instr.Size - 1)
+	} else {
+		result = SignExtend(result, instr.Size)
+	}
+	cpu.SetS(instr.Sd, result)
+}
+
+func (instr VcvtFixedT1) String() string {
+	fixed := fmt.Sprintf("%s%d", fp_int_type(instr.Unsigned), instr.Size)
+
+	types := "f32." + fixed
+	if instr.ToFixed {
+		types = fixed + ".f32"
+	}
+
+	return fmt.Sprintf("vcvt.%s %s, %s, #%d", types, instr.Sd, instr.Sd, instr.FracBits)
+}
+
+/* VCVTB, VCVTT, converting to or from the bottom or top half of a register
+ * ARM ARM A7.7.227
+ * Encoding T1 */
+type VcvtHalfT1 struct {
+	Sd     SRegIndex
+	Sm     SRegIndex
+	ToHalf bool
+	Top    bool
+}
+
+func VcvtHalf32T1(instr FetchedInstr) DecodedInstr {
+	raw_instr := instr.Uint32()
+	fields := decode_fp(raw_instr)
+
+	to_half := (raw_instr>>16)&0x1 != 0
+	top := (raw_instr>>7)&0x1 != 0
+
+	return VcvtHalfT1{Sd: fields.Sd, Sm: fields.Sm, ToHalf: to_half, Top: top}
+}
+
+func (instr VcvtHalfT1) Execute(cpu *CPU) {
+	if !ExecuteFPCheck(cpu) {
+		return
+	}
+
+	var lowbit uint
+	if instr.Top {
+		lowbit = 16
+	}
+
+	if instr.ToHalf {
+		half := uint32(FPSingleToHalf(cpu.S(instr.Sm), &cpu.Fpscr))
+		cpu.SetS(instr.Sd, masked(cpu.S(instr.Sd), half<<lowbit, 0xffff<<lowbit))
+	} else {
+		cpu.SetS(instr.Sd, FPHalfToSingle(uint16(cpu.S(instr.Sm)>>lowbit), &cpu.Fpscr))
+	}
+}
+
+func (instr VcvtHalfT1) String() string {
+	mnemonic := "vcvtb"
+	if instr.Top {
+		mnemonic = "vcvtt"
+	}
+
+	types := "f32.f16"
+	if instr.ToHalf {
+		types = "f16.f32"
+	}
+
+	return fmt.Sprintf("%s.%s %s, %s", mnemonic, types, instr.Sd, instr.Sm)
+}
@@ -0,0 +1,236 @@
+package core
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestIdentifyVaddT1(t *testing.T) {
+	cases := []IdentifyCase{
+		{instr: FetchedInstr32(0xee300a81), instr_valid: true},  // vadd.f32 s0, s1, s2
+		{instr: FetchedInstr32(0xee3ffaee), instr_valid: false}, // vsub.f32 s30, s31, s29
+		{instr: FetchedInstr32(0xee300b01), instr_valid: false}, // vadd.f64 d0, d0, d1
+	}
+
+	test_identify(t, cases, reflect.TypeOf(VaddT1{}))
+}
+
+func TestIdentifyVcvtIntT1(t *testing.T) {
+	cases := []IdentifyCase{
+		{instr: FetchedInstr32(0xeebd0ae0), instr_valid: true},  // vcvt.s32.f32 s0, s1
+		{instr: FetchedInstr32(0xeebc0a60), instr_valid: true},  // vcvtr.u32.f32 s0, s1
+		{instr: FetchedInstr32(0xeeb80ae0), instr_valid: true},  // vcvt.f32.s32 s0, s1
+		{instr: FetchedInstr32(0xeebe0ac8), instr_valid: false}, // vcvt.s32.f32 s0, s0, #16
+		{instr: FetchedInstr32(0xeeb20a60), instr_valid: false}, // vcvtb.f32.f16 s0, s1
+	}
+
+	test_identify(t, cases, reflect.TypeOf(VcvtIntT1{}))
+}
+
+func TestDecodeFPUndefinedWithoutFPU(t *testing.T) {
+	instr := FetchedInstr32(0xee300a81) // vadd.f32 s0, s1, s2
+
+	/* Without the FP extension, CP10 is an absent coprocessor */
+	if decoded, err := instr.DecodeFor(PROFILE_ARMV7EM); err != nil || decoded != (CdpT1{Coproc: 10, Opc1: 3, CRd: 0, CRn: 0, CRm: 1, Opc2: 4}) {
+		t.Errorf("cortex-m4: %#v, %v", decoded, err)
+	}
+
+	if _, err := instr.DecodeFor(PROFILE_ARMV7EM_FP); err != nil {
+		t.Errorf("cortex-m4f: err = %v", err)
+	}
+}
+
+func TestDecodeFPDataProcessing(t *testing.T) {
+	cases := []DecodeCase{
+		// vmla.f32 s3, s4, s31
+		{instr: FetchedInstr32(0xee421a2f), decoded: VmlaT1{Sd: 3, Sn: 4, Sm: 31}},
+	}
+
+	test_decode(t, cases, Vmla32T1)
+
+	cases = []DecodeCase{
+		// vsub.f32 s30, s31, s29
+		{instr: FetchedInstr32(0xee3ffaee), decoded: VsubT1{Sd: 30, Sn: 31, Sm: 29}},
+	}
+
+	test_decode(t, cases, Vsub32T1)
+
+	cases = []DecodeCase{
+		// vmov.f32 s0, #1.0
+		{instr: FetchedInstr32(0xeeb70a00), decoded: VmovImmT1{Sd: 0, Imm: 0x3f800000}},
+		// vmov.f32 s5, #-0.125
+		{instr: FetchedInstr32(0xeefc2a00), decoded: VmovImmT1{Sd: 5, Imm: 0xbe000000}},
+	}
+
+	test_decode(t, cases, VmovImm32T1)
+}
+
+func TestDecodeVcvt(t *testing.T) {
+	cases := []DecodeCase{
+		// vcvt.s32.f32 s0, s1
+		{instr: FetchedInstr32(0xeebd0ae0), decoded: VcvtIntT1{Sd: 0, Sm: 1, ToInteger: true, Unsigned: false, RoundZero: true}},
+		// vcvtr.u32.f32 s0, s1
+		{instr: FetchedInstr32(0xeebc0a60), decoded: VcvtIntT1{Sd: 0, Sm: 1, ToInteger: true, Unsigned: true, RoundZero: false}},
+		// vcvt.f32.u32 s0, s1
+		{instr: FetchedInstr32(0xeeb80a60), decoded: VcvtIntT1{Sd: 0, Sm: 1, ToInteger: false, Unsigned: true}},
+	}
+
+	test_decode(t, cases, VcvtInt32T1)
+
+	cases = []DecodeCase{
+		// vcvt.s32.f32 s0, s0, #16
+		{instr: FetchedInstr32(0xeebe0ac8), decoded: VcvtFixedT1{Sd: 0, Size: 32, FracBits: 16, ToFixed: true, Unsigned: false}},
+		// vcvt.f32.u16 s0, s0, #8
+		{instr: FetchedInstr32(0xeebb0a44), decoded: VcvtFixedT1{Sd: 0, Size: 16, FracBits: 8, ToFixed: false, Unsigned: true}},
+		// vcvt.f32.s16 s0, s0, #-1
+		{instr: FetchedInstr32(0xeeba0a68), decoded: UnpredictableInstr{}},
+	}
+
+	test_decode(t, cases, VcvtFixed32T1)
+
+	cases = []DecodeCase{
+		// vcvtt.f16.f32 s0, s1
+		{instr: FetchedInstr32(0xeeb30ae0), decoded: VcvtHalfT1{Sd: 0, Sm: 1, ToHalf: true, Top: true}},
+	}
+
+	test_decode(t, cases, VcvtHalf32T1)
+}
+
+func TestFPString(t *testing.T) {
+	cases := []struct {
+		instr    FetchedInstr
+		expected string
+	}{
+		{instr: FetchedInstr32(0xee421a2f), expected: "vmla.f32 s3, s4, s31"},
+		{instr: FetchedInstr32(0xee200ac1), expected: "vnmul.f32 s0, s1, s2"},
+		{instr: FetchedInstr32(0xee900a81), expected: "vfnms.f32 s0, s1, s2"},
+		{instr: FetchedInstr32(0xeefc2a00), expected: "vmov.f32 s5, #-1.250000e-01"},
+		{instr: FetchedInstr32(0xeeb11ae1), expected: "vsqrt.f32 s2, s3"},
+		{instr: FetchedInstr32(0xeef51ac0), expected: "vcmpe.f32 s3, #0"},
+		{instr: FetchedInstr32(0xeebc0a60), expected: "vcvtr.u32.f32 s0, s1"},
+		{instr: FetchedInstr32(0xeebf0a44), expected: "vcvt.u16.f32 s0, s0, #8"},
+		{instr: FetchedInstr32(0xeeb20a60), expected: "vcvtb.f32.f16 s0, s1"},
+	}
+
+	for _, test := range cases {
+		decoded, err := test.instr.Decode()
+		if err != nil {
+			t.Errorf("%v: %v", test.instr, err)
+		} else if actual := Disassemble(decoded, 0); actual != test.expected {
+			t.Errorf("%v: %q, expected %q", test.instr, actual, test.expected)
+		}
+	}
+}
+
+func TestExecuteFPArithmetic(t *testing.T) {
+	cases := []ExecuteCase{
+		// vadd.f32 s0, s1, s2: 1.5 + 2.25
+		{instr: VaddT1{Sd: 0, Sn: 1, Sm: 2},
+			regs:     Registers{s: FPRegs{0, 0x3fc00000, 0x40100000}},
+			expected: Registers{s: FPRegs{0x40700000, 0x3fc00000, 0x40100000}}},
+		// vmls.f32 s0, s1, s2: 10 - 1.5 * 2.25
+		{instr: VmlsT1{Sd: 0, Sn: 1, Sm: 2},
+			regs:     Registers{s: FPRegs{0x41200000, 0x3fc00000, 0x40100000}},
+			expected: Registers{s: FPRegs{0x40d40000, 0x3fc00000, 0x40100000}}},
+		// vnmla.f32 s0, s1, s2: -10 - 1.5 * 2.25
+		{instr: VnmlaT1{Sd: 0, Sn: 1, Sm: 2},
+			regs:     Registers{s: FPRegs{0x41200000, 0x3fc00000, 0x40100000}},
+			expected: Registers{s: FPRegs{0xc1560000, 0x3fc00000, 0x40100000}}},
+		// vfms.f32 s0, s1, s2: 10 - 1.5 * 2.25, fused
+		{instr: VfmsT1{Sd: 0, Sn: 1, Sm: 2},
+			regs:     Registers{s: FPRegs{0x41200000, 0x3fc00000, 0x40100000}},
+			expected: Registers{s: FPRegs{0x40d40000, 0x3fc00000, 0x40100000}}},
+		// vdiv.f32 s0, s1, s2: 1 / 3, inexact
+		{instr: VdivT1{Sd: 0, Sn: 1, Sm: 2},
+			regs:     Registers{s: FPRegs{0, 0x3f800000, 0x40400000}},
+			expected: Registers{s: FPRegs{0x3eaaaaab, 0x3f800000, 0x40400000}, Fpscr: Fpscr{Exc: FPEXC_IXC}}},
+		// vdiv.f32 s0, s1, s2: -1 / 0
+		{instr: VdivT1{Sd: 0, Sn: 1, Sm: 2},
+			regs:     Registers{s: FPRegs{0, 0xbf800000, 0}},
+			expected: Registers{s: FPRegs{0xff800000, 0xbf800000, 0}, Fpscr: Fpscr{Exc: FPEXC_DZC}}},
+		// vsqrt.f32 s0, s1: sqrt(-1)
+		{instr: VsqrtT1{Sd: 0, Sm: 1},
+			regs:     Registers{s: FPRegs{0, 0xbf800000}},
+			expected: Registers{s: FPRegs{0x7fc00000, 0xbf800000}, Fpscr: Fpscr{Exc: FPEXC_IOC}}},
+		// vneg.f32 s0, s1: NaN operands are not processed
+		{instr: VnegT1{Sd: 0, Sm: 1},
+			regs:     Registers{s: FPRegs{0, 0x7f800001}},
+			expected: Registers{s: FPRegs{0xff800001, 0x7f800001}}},
+		// vabs.f32 s0, s1
+		{instr: VabsT1{Sd: 0, Sm: 1},
+			regs:     Registers{s: FPRegs{0, 0xc0000000}},
+			expected: Registers{s: FPRegs{0x40000000, 0xc0000000}}},
+		// vmov.f32 s31, #1.0
+		{instr: VmovImmT1{Sd: 31, Imm: 0x3f800000},
+			regs:     Registers{},
+			expected: Registers{s: FPRegs{31: 0x3f800000}}},
+	}
+
+	test_execute(t, cases)
+}
+
+func TestExecuteVcmp(t *testing.T) {
+	cases := []ExecuteCase{
+		// vcmp.f32 s0, s1: 1 < 2
+		{instr: VcmpT1{Sd: 0, Sm: 1},
+			regs:     Registers{s: FPRegs{0x3f800000, 0x40000000}},
+			expected: Registers{s: FPRegs{0x3f800000, 0x40000000}, Fpscr: Fpscr{N: true}}},
+		// vcmp.f32 s0, #0: -0 == 0
+		{instr: VcmpT2{Sd: 0},
+			regs:     Registers{s: FPRegs{0x80000000}},
+			expected: Registers{s: FPRegs{0x80000000}, Fpscr: Fpscr{Z: true, C: true}}},
+		// vcmp.f32 s0, s1: unordered, quiet NaN
+		{instr: VcmpT1{Sd: 0, Sm: 1},
+			regs:     Registers{s: FPRegs{0x7fc00000, 0x40000000}},
+			expected: Registers{s: FPRegs{0x7fc00000, 0x40000000}, Fpscr: Fpscr{C: true, V: true}}},
+		// vcmpe.f32 s0, s1: unordered, quiet NaN
+		{instr: VcmpT1{Sd: 0, Sm: 1, E: true},
+			regs:     Registers{s: FPRegs{0x7fc00000, 0x40000000}},
+			expected: Registers{s: FPRegs{0x7fc00000, 0x40000000}, Fpscr: Fpscr{C: true, V: true, Exc: FPEXC_IOC}}},
+	}
+
+	test_execute(t, cases)
+}
+
+func TestExecuteVcvt(t *testing.T) {
+	cases := []ExecuteCase{
+		// vcvt.s32.f32 s0, s1: -2.5 rounds towards zero
+		{instr: VcvtIntT1{Sd: 0, Sm: 1, ToInteger: true, RoundZero: true},
+			regs:     Registers{s: FPRegs{0, 0xc0200000}},
+			expected: Registers{s: FPRegs{0xfffffffe, 0xc0200000}, Fpscr: Fpscr{Exc: FPEXC_IXC}}},
+		// vcvtr.s32.f32 s0, s1: -2.5 rounds to even
+		{instr: VcvtIntT1{Sd: 0, Sm: 1, ToInteger: true},
+			regs:     Registers{s: FPRegs{0, 0xc0200000}},
+			expected: Registers{s: FPRegs{0xfffffffe, 0xc0200000}, Fpscr: Fpscr{Exc: FPEXC_IXC}}},
+		// vcvtr.s32.f32 s0, s1: -2.5 rounds towards minus infinity
+		{instr: VcvtIntT1{Sd: 0, Sm: 1, ToInteger: true},
+			regs:     Registers{s: FPRegs{0, 0xc0200000}, Fpscr: Fpscr{RMode: FPROUND_RM}},
+			expected: Registers{s: FPRegs{0xfffffffd, 0xc0200000}, Fpscr: Fpscr{RMode: FPROUND_RM, Exc: FPEXC_IXC}}},
+		// vcvt.u32.f32 s0, s1: -1 saturates
+		{instr: VcvtIntT1{Sd: 0, Sm: 1, ToInteger: true, Unsigned: true, RoundZero: true},
+			regs:     Registers{s: FPRegs{0, 0xbf800000}},
+			expected: Registers{s: FPRegs{0, 0xbf800000}, Fpscr: Fpscr{Exc: FPEXC_IOC}}},
+		// vcvt.f32.s32 s0, s1
+		{instr: VcvtIntT1{Sd: 0, Sm: 1},
+			regs:     Registers{s: FPRegs{0, 0xfffffffd}},
+			expected: Registers{s: FPRegs{0xc0400000, 0xfffffffd}}},
+		// vcvt.s16.f32 s0, s0, #8: -1.5 sign extends
+		{instr: VcvtFixedT1{Sd: 0, Size: 16, FracBits: 8, ToFixed: true},
+			regs:     Registers{s: FPRegs{0xbfc00000}},
+			expected: Registers{s: FPRegs{0xfffffe80}}},
+		// vcvt.f32.u16 s0, s0, #8: upper half ignored
+		{instr: VcvtFixedT1{Sd: 0, Size: 16, FracBits: 8, Unsigned: true},
+			regs:     Registers{s: FPRegs{0xffff0180}},
+			expected: Registers{s: FPRegs{0x3fc00000}}},
+		// vcvtt.f16.f32 s0, s1: bottom half kept
+		{instr: VcvtHalfT1{Sd: 0, Sm: 1, ToHalf: true, Top: true},
+			regs:     Registers{s: FPRegs{0x12345678, 0x3fc00000}},
+			expected: Registers{s: FPRegs{0x3e005678, 0x3fc00000}}},
+		// vcvtb.f32.f16 s0, s1
+		{instr: VcvtHalfT1{Sd: 0, Sm: 1},
+			regs:     Registers{s: FPRegs{0, 0x12343e00}},
+			expected: Registers{s: FPRegs{0x3fc00000, 0x12343e00}}},
+	}
+
+	test_execute(t, cases)
+}
@@ -0,0 +1,331 @@
+package core
+
+import "fmt"
+
+/* VLDR
+ * ARM ARM A7.7.233
+ * Encoding T1 */
+type VldrT1 FPLoadStoreFields
+
+func Vldr32T1(instr FetchedInstr) DecodedInstr {
+	fields := decode_fp_ldst(instr.Uint32(), true)
+	fields.Regs = 1
+
+	if bad_double_register(instr.Uint32(), 22) {
+		return UndefinedInstr{}
+	}
+
+	return VldrT1(fields)
+}
+
+func (instr VldrT1) Execute(cpu *CPU) {
+	if ExecuteFPCheck(cpu) {
+		FPLoad(cpu, FPLoadStoreFields(instr))
+	}
+}
+
+func (instr VldrT1) String() string {
+	fields := FPLoadStoreFields(instr)
+	return fmt.Sprintf("vldr %s, %s", fp_register(fields), imm_address(fp_offset_fields(fields)))
+}
+
+/* VLDR
+ * ARM ARM A7.7.233
+ * Encoding T2 */
+type VldrT2 FPLoadStoreFields
+
+func Vldr32T2(instr FetchedInstr) DecodedInstr {
+	fields := decode_fp_ldst(instr.Uint32(), false)
+	fields.Regs = 1
+
+	return VldrT2(fields)
+}
+
+func (instr VldrT2) Execute(cpu *CPU) {
+	if ExecuteFPCheck(cpu) {
+		FPLoad(cpu, FPLoadStoreFields(instr))
+	}
+}
+
+func (instr VldrT2) String() string {
+	fields := FPLoadStoreFields(instr)
+	return fmt.Sprintf("vldr %s, %s", fp_register(fields), imm_address(fp_offset_fields(fields)))
+}
+
+/* VSTR
+ * ARM ARM A7.7.256
+ * Encoding T1 */
+type VstrT1 FPLoadStoreFields
+
+func Vstr32T1(instr FetchedInstr) DecodedInstr {
+	fields := decode_fp_ldst(instr.Uint32(), true)
+	fields.Regs = 1
+
+	if bad_double_register(instr.Uint32(), 22) {
+		return UndefinedInstr{}
+	}
+
+	if fields.Rn == PC {
+		return UnpredictableInstr{}
+	}
+
+	return VstrT1(fields)
+}
+
+func (instr VstrT1) Execute(cpu *CPU) {
+	if ExecuteFPCheck(cpu) {
+		FPStore(cpu, FPLoadStoreFields(instr))
+	}
+}
+
+func (instr VstrT1) String() string {
+	fields := FPLoadStoreFields(instr)
+	return fmt.Sprintf("vstr %s, %s", fp_register(fields), imm_address(fp_offset_fields(fields)))
+}
+
+/* VSTR
+ * ARM ARM A7.7.256
+ * Encoding T2 */
+type VstrT2 FPLoadStoreFields
+
+func Vstr32T2(instr FetchedInstr) DecodedInstr {
+	fields := decode_fp_ldst(instr.Uint32(), false)
+	fields.Regs = 1
+
+	if fields.Rn == PC {
+		return UnpredictableInstr{}
+	}
+
+	return VstrT2(fields)
+}
+
+func (instr VstrT2) Execute(cpu *CPU) {
+	if ExecuteFPCheck(cpu) {
+		FPStore(cpu, FPLoadStoreFields(instr))
+	}
+}
+
+func (instr VstrT2) String() string {
+	fields := FPLoadStoreFields(instr)
+	return fmt.Sprintf("vstr %s, %s", fp_register(fields), imm_address(fp_offset_fields(fields)))
+}
+
+/* VLDM
+ * ARM ARM A7.7.232
+ * Encoding T1 */
+type VldmT1 FPLoadStoreFields
+
+func Vldm32T1(instr FetchedInstr) DecodedInstr {
+	fields := decode_fp_ldst(instr.Uint32(), true)
+
+	if fields.Rn == SP && fields.Wback && fields.Add {
+		return Vpop32T1(instr)
+	}
+
+	if fields.Rn == PC || bad_fp_register_list(fields) {
+		return UnpredictableInstr{}
+	}
+
+	return VldmT1(fields)
+}
+
+func (instr VldmT1) Execute(cpu *CPU) {
+	if ExecuteFPCheck(cpu) {
+		FPLoadMultiple(cpu, FPLoadStoreFields(instr))
+	}
+}
+
+func (instr VldmT1) String() string {
+	fields := FPLoadStoreFields(instr)
+	return fmt.Sprintf("vldm%s %s", fp_multiple_suffix(fields), fp_multiple_operands(fields))
+}
+
+/* VLDM
+ * ARM ARM A7.7.232
+ * Encoding T2 */
+type VldmT2 FPLoadStoreFields
+
+func Vldm32T2(instr FetchedInstr) DecodedInstr {
+	fields := decode_fp_ldst(instr.Uint32(), false)
+
+	if fields.Rn == SP && fields.Wback && fields.Add {
+		return Vpop32T2(instr)
+	}
+
+	if fields.Rn == PC || bad_fp_register_list(fields) {
+		return UnpredictableInstr{}
+	}
+
+	return VldmT2(fields)
+}
+
+func (instr VldmT2) Execute(cpu *CPU) {
+	if ExecuteFPCheck(cpu) {
+		FPLoadMultiple(cpu, FPLoadStoreFields(instr))
+	}
+}
+
+func (instr VldmT2) String() string {
+	fields := FPLoadStoreFields(instr)
+	return fmt.Sprintf("vldm%s %s", fp_multiple_suffix(fields), fp_multiple_operands(fields))
+}
+
+/* VPOP
+ * ARM ARM A7.7.248
+ * Encoding T1 */
+type VpopT1 FPLoadStoreFields
+
+func Vpop32T1(instr FetchedInstr) DecodedInstr {
+	fields := decode_fp_ldst(instr.Uint32(), true)
+
+	if bad_fp_register_list(fields) {
+		return UnpredictableInstr{}
+	}
+
+	return VpopT1(fields)
+}
+
+func (instr VpopT1) Execute(cpu *CPU) {
+	if ExecuteFPCheck(cpu) {
+		FPLoadMultiple(cpu, FPLoadStoreFields(instr))
+	}
+}
+
+func (instr VpopT1) String() string {
+	return fmt.Sprintf("vpop %s", fp_register_list(FPLoadStoreFields(instr)))
+}
+
+/* VPOP
+ * ARM ARM A7.7.248
+ * Encoding T2 */
+type VpopT2 FPLoadStoreFields
+
+func Vpop32T2(instr FetchedInstr) DecodedInstr {
+	fields := decode_fp_ldst(instr.Uint32(), false)
+
+	if bad_fp_register_list(fields) {
+		return UnpredictableInstr{}
+	}
+
+	return VpopT2(fields)
+}
+
+func (instr VpopT2) Execute(cpu *CPU) {
+	if ExecuteFPCheck(cpu) {
+		FPLoadMultiple(cpu, FPLoadStoreFields(instr))
+	}
+}
+
+func (instr VpopT2) String() string {
+	return fmt.Sprintf("vpop %s", fp_register_list(FPLoadStoreFields(instr)))
+}
+
+/* VSTM
+ * ARM ARM A7.7.255
+ * Encoding T1 */
+type VstmT1 FPLoadStoreFields
+
+func Vstm32T1(instr FetchedInstr) DecodedInstr {
+	fields := decode_fp_ldst(instr.Uint32(), true)
+
+	if fields.Rn == SP && fields.Wback && !fields.Add {
+		return Vpush32T1(instr)
+	}
+
+	if fields.Rn == PC || bad_fp_register_list(fields) {
+		return UnpredictableInstr{}
+	}
+
+	return VstmT1(fields)
+}
+
+func (instr VstmT1) Execute(cpu *CPU) {
+	if ExecuteFPCheck(cpu) {
+		FPStoreMultiple(cpu, FPLoadStoreFields(instr))
+	}
+}
+
+func (instr VstmT1) String() string {
+	fields := FPLoadStoreFields(instr)
+	return fmt.Sprintf("vstm%s %s", fp_multiple_suffix(fields), fp_multiple_operands(fields))
+}
+
+/* VSTM
+ * ARM ARM A7.7.255
+ * Encoding T2 */
+type VstmT2 FPLoadStoreFields
+
+func Vstm32T2(instr FetchedInstr) DecodedInstr {
+	fields := decode_fp_ldst(instr.Uint32(), false)
+
+	if fields.Rn == SP && fields.Wback && !fields.Add {
+		return Vpush32T2(instr)
+	}
+
+	if fields.Rn == PC || bad_fp_register_list(fields) {
+		return UnpredictableInstr{}
+	}
+
+	return VstmT2(fields)
+}
+
+func (instr VstmT2) Execute(cpu *CPU) {
+	if ExecuteFPCheck(cpu) {
+		FPStoreMultiple(cpu, FPLoadStoreFields(instr))
+	}
+}
+
+func (instr VstmT2) String() string {
+	fields := FPLoadStoreFields(instr)
+	return fmt.Sprintf("vstm%s %s", fp_multiple_suffix(fields), fp_multiple_operands(fields))
+}
+
+/* VPUSH
+ * ARM ARM A7.7.249
+ * Encoding T1 */
+type VpushT1 FPLoadStoreFields
+
+func Vpush32T1(instr FetchedInstr) DecodedInstr {
+	fields := decode_fp_ldst(instr.Uint32(), true)
+
+	if bad_fp_register_list(fields) {
+		return UnpredictableInstr{}
+	}
+
+	return VpushT1(fields)
+}
+
+func (instr VpushT1) Execute(cpu *CPU) {
+	if ExecuteFPCheck(cpu) {
+		FPStoreMultiple(cpu, FPLoadStoreFields(instr))
+	}
+}
+
+func (instr VpushT1) String() string {
+	return fmt.Sprintf("vpush %s", fp_register_list(FPLoadStoreFields(instr)))
+}
+
+/* VPUSH
+ * ARM ARM A7.7.249
+ * Encoding T2 */
+type VpushT2 FPLoadStoreFields
+
+func Vpush32T2(instr FetchedInstr) DecodedInstr {
+	fields := decode_fp_ldst(instr.Uint32(), false)
+
+	if bad_fp_register_list(fields) {
+		return UnpredictableInstr{}
+	}
+
+	return VpushT2(fields)
+}
+
+func (instr VpushT2) Execute(cpu *CPU) {
+	if ExecuteFPCheck(cpu) {
+		FPStoreMultiple(cpu, FPLoadStoreFields(instr))
+	}
+}
+
+func (instr VpushT2) String() string {
+	return fmt.Sprintf("vpush %s", fp_register_list(FPLoadStoreFields(instr)))
+}
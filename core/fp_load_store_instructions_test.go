@@ -0,0 +1,172 @@
+package core
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestIdentifyVpushT2(t *testing.T) {
+	cases := []IdentifyCase{
+		{instr: FetchedInstr32(0xed2d8a03), instr_valid: true},  // vpush {s16, s17, s18}
+		{instr: FetchedInstr32(0xed2d8b04), instr_valid: false}, // vpush {d8, d9}
+		{instr: FetchedInstr32(0xed200a02), instr_valid: false}, // vstmdb r0!, {s0, s1}
+		{instr: FetchedInstr32(0xeca00a02), instr_valid: false}, // vstmia r0!, {s0, s1}
+	}
+
+	test_identify(t, cases, reflect.TypeOf(VpushT2{}))
+}
+
+func TestIdentifyVldmT1(t *testing.T) {
+	cases := []IdentifyCase{
+		{instr: FetchedInstr32(0xed302b06), instr_valid: true},  // vldmdb r0!, {d2, d3, d4}
+		{instr: FetchedInstr32(0xec902b04), instr_valid: true},  // vldmia r0, {d2, d3}
+		{instr: FetchedInstr32(0xecbd8b10), instr_valid: false}, // vpop {d8-d15}
+		{instr: FetchedInstr32(0xed100b02), instr_valid: false}, // vldr d0, [r0, #-8]
+	}
+
+	test_identify(t, cases, reflect.TypeOf(VldmT1{}))
+}
+
+func TestDecodeVldr(t *testing.T) {
+	cases := []DecodeCase{
+		// vldr d0, [r0, #-8]
+		{instr: FetchedInstr32(0xed100b02), decoded: VldrT1{Sd: 0, Regs: 1, Double: true, Rn: 0, Imm: 8, Add: false}},
+		// vldr d16, [r0, #-8]
+		{instr: FetchedInstr32(0xed500b02), decoded: UndefinedInstr{}},
+	}
+
+	test_decode(t, cases, Vldr32T1)
+
+	cases = []DecodeCase{
+		// vstr d18, [r8, #-0x2e4]
+		{instr: FetchedInstr32(0xed482bb9), decoded: UndefinedInstr{}},
+	}
+
+	test_decode(t, cases, Vstr32T1)
+
+	cases = []DecodeCase{
+		// vldr s1, [pc, #4]
+		{instr: FetchedInstr32(0xeddf0a01), decoded: VldrT2{Sd: 1, Regs: 1, Rn: PC, Imm: 4, Add: true}},
+	}
+
+	test_decode(t, cases, Vldr32T2)
+
+	cases = []DecodeCase{
+		// vstr s0, [pc]
+		{instr: FetchedInstr32(0xed8f0a00), decoded: UnpredictableInstr{}},
+	}
+
+	test_decode(t, cases, Vstr32T2)
+}
+
+func TestDecodeVldm(t *testing.T) {
+	cases := []DecodeCase{
+		// vldmia r1, {s3}
+		{instr: FetchedInstr32(0xecd11a01), decoded: VldmT2{Sd: 3, Regs: 1, Rn: 1, Imm: 4, Add: true}},
+		// vldmia r0, {s31, s32}
+		{instr: FetchedInstr32(0xecd0fa02), decoded: UnpredictableInstr{}},
+		// vldmia r0, {}
+		{instr: FetchedInstr32(0xec900a00), decoded: UnpredictableInstr{}},
+		// vldmia pc, {s0}
+		{instr: FetchedInstr32(0xec9f0a01), decoded: UnpredictableInstr{}},
+	}
+
+	test_decode(t, cases, Vldm32T2)
+
+	cases = []DecodeCase{
+		// vpop {d8-d15}
+		{instr: FetchedInstr32(0xecbd8b10), decoded: VpopT1{Sd: 16, Regs: 8, Double: true, Rn: SP, Imm: 64, Add: true, Wback: true}},
+		// vldmia r0, {d0-d16}
+		{instr: FetchedInstr32(0xec900b22), decoded: UnpredictableInstr{}},
+	}
+
+	test_decode(t, cases, Vldm32T1)
+}
+
+func TestFPLoadStoreString(t *testing.T) {
+	cases := []struct {
+		instr    FetchedInstr
+		expected string
+	}{
+		{instr: FetchedInstr32(0xed900a01), expected: "vldr s0, [r0, #4]"},
+		{instr: FetchedInstr32(0xed100b02), expected: "vldr d0, [r0, #-8]"},
+		{instr: FetchedInstr32(0xed8d0a00), expected: "vstr s0, [sp]"},
+		{instr: FetchedInstr32(0xeca00a02), expected: "vstmia r0!, {s0, s1}"},
+		{instr: FetchedInstr32(0xed302b06), expected: "vldmdb r0!, {d2, d3, d4}"},
+		{instr: FetchedInstr32(0xed2d8a03), expected: "vpush {s16, s17, s18}"},
+		{instr: FetchedInstr32(0xecbd8b04), expected: "vpop {d8, d9}"},
+	}
+
+	for _, test := range cases {
+		decoded, err := test.instr.Decode()
+		if err != nil {
+			t.Errorf("%v: %v", test.instr, err)
+		} else if actual := Disassemble(decoded, 0); actual != test.expected {
+			t.Errorf("%v: %q, expected %q", test.instr, actual, test.expected)
+		}
+	}
+}
+
+func TestExecuteVldrVstr(t *testing.T) {
+	cases := []MemoryCase{
+		// vldr s1, [r0, #4]
+		{instr: VldrT2{Sd: 1, Regs: 1, Rn: 0, Imm: 4, Add: true},
+			regs:     Registers{r: GeneralRegs{0x8}},
+			expected: Registers{r: GeneralRegs{0x8}, s: FPRegs{0, 0x8f8e8d8c}},
+			addr:     0xc, word: 0x8f8e8d8c},
+		// vldr d1, [r0, #-8]
+		{instr: VldrT1{Sd: 2, Regs: 1, Double: true, Rn: 0, Imm: 8, Add: false},
+			regs:     Registers{r: GeneralRegs{0x18}},
+			expected: Registers{r: GeneralRegs{0x18}, s: FPRegs{0, 0, 0x93929190, 0x97969594}},
+			addr:     0x10, word: 0x93929190},
+		// vldr s0, [pc, #4], PC word aligned
+		{instr: VldrT2{Sd: 0, Regs: 1, Rn: PC, Imm: 4, Add: true},
+			regs:     Registers{pc: 0x0e},
+			expected: Registers{pc: 0x0e, s: FPRegs{0x93929190}},
+			addr:     0x10, word: 0x93929190},
+		// vstr d0, [r0]
+		{instr: VstrT1{Sd: 0, Regs: 1, Double: true, Rn: 0, Add: true},
+			regs:     Registers{r: GeneralRegs{0x10}, s: FPRegs{0x11111111, 0x22222222}},
+			expected: Registers{r: GeneralRegs{0x10}, s: FPRegs{0x11111111, 0x22222222}},
+			addr:     0x14, word: 0x22222222},
+		// vldr s0, [r0, #2], unaligned
+		{instr: VldrT2{Sd: 0, Regs: 1, Rn: 0, Imm: 4, Add: true},
+			regs:     Registers{r: GeneralRegs{0x2}},
+			expected: Registers{r: GeneralRegs{0x2}},
+			addr:     0x4, word: 0x87868584, fault: true},
+	}
+
+	test_execute_memory(t, cases)
+}
+
+func TestExecuteVldmVstm(t *testing.T) {
+	cases := []MemoryCase{
+		// vldmia r0!, {s1, s2}
+		{instr: VldmT2{Sd: 1, Regs: 2, Rn: 0, Add: true, Wback: true},
+			regs:     Registers{r: GeneralRegs{0x8}},
+			expected: Registers{r: GeneralRegs{0x10}, s: FPRegs{0, 0x8b8a8988, 0x8f8e8d8c}},
+			addr:     0x10, word: 0x93929190},
+		// vstmdb r0!, {d0, d1}
+		{instr: VstmT1{Sd: 0, Regs: 2, Double: true, Rn: 0, Add: false, Wback: true},
+			regs:     Registers{r: GeneralRegs{0x20}, s: FPRegs{1, 2, 3, 4}},
+			expected: Registers{r: GeneralRegs{0x10}, s: FPRegs{1, 2, 3, 4}},
+			addr:     0x10, word: 1},
+		// vpush {s16, s17}
+		{instr: VpushT2{Sd: 16, Regs: 2, Rn: SP, Add: false, Wback: true},
+			regs:     Registers{sp: SPRegs{0x18, 0}, s: FPRegs{16: 0x16, 17: 0x17}},
+			expected: Registers{sp: SPRegs{0x10, 0}, s: FPRegs{16: 0x16, 17: 0x17}},
+			addr:     0x14, word: 0x17},
+		// vpop {d8}
+		{instr: VpopT1{Sd: 16, Regs: 1, Double: true, Rn: SP, Add: true, Wback: true},
+			regs:     Registers{sp: SPRegs{0x10, 0}},
+			expected: Registers{sp: SPRegs{0x18, 0}, s: FPRegs{16: 0x93929190, 17: 0x97969594}},
+			addr:     0x10, word: 0x93929190},
+		// vldmia r0!, {s0, s1}, faulting on the second word
+		{instr: VldmT2{Sd: 0, Regs: 2, Rn: 0, Add: true, Wback: true},
+			regs:     Registers{r: GeneralRegs{TEST_MEM_SIZE - 4}},
+			expected: Registers{r: GeneralRegs{TEST_MEM_SIZE - 4}},
+			addr:     0x10, word: 0x93929190, fault: true},
+	}
+
+	test_execute_memory(t, cases)
+}
@@ -0,0 +1,181 @@
+package core
+
+import (
+	"bytes"
+	"fmt"
+)
+
+/* Number of words transferred */
+func (instr FPLoadStoreFields) words() uint8 {
+	if instr.Double {
+		return 2 * instr.Regs
+	}
+
+	return instr.Regs
+}
+
+/* Load consecutive words from address into the registers from Sd.
+ * Nothing is written if any access faults. */
+func fp_load(cpu *CPU, instr FPLoadStoreFields, address uint32) bool {
+	var values [32]uint32
+	for i := uint8(0); i < instr.words(); i++ {
+		value, ok := cpu.MemA(address+4*uint32(i), 4)
+		if !ok {
+			return false
+		}
+
+		values[i] = value
+	}
+
+	for i := uint8(0); i < instr.words(); i++ {
+		cpu.SetS(instr.Sd+SRegIndex(i), values[i])
+	}
+
+	return true
+}
+
+/* Store the registers from Sd to consecutive words from address */
+func fp_store(cpu *CPU, instr FPLoadStoreFields, address uint32) bool {
+	for i := uint8(0); i < instr.words(); i++ {
+		if !cpu.SetMemA(address+4*uint32(i), 4, cpu.S(instr.Sd+SRegIndex(i))) {
+			return false
+		}
+	}
+
+	return true
+}
+
+/* The offset address of VLDR and VSTR, as for LDR (immediate) */
+func fp_address(cpu *CPU, instr FPLoadStoreFields) uint32 {
+	_, address := load_store_address(cpu, fp_offset_fields(instr), instr.Imm)
+	return address
+}
+
+func fp_offset_fields(instr FPLoadStoreFields) LoadStoreFields {
+	return LoadStoreFields{Rn: instr.Rn, Imm: instr.Imm, Index: true, Add: instr.Add}
+}
+
+/* The lowest address, and the address written back, of VLDM, VSTM,
+ * VPUSH and VPOP, which increment after or decrement before */
+func fp_multiple_address(cpu *CPU, instr FPLoadStoreFields) (uint32, uint32) {
+	size := 4 * uint32(instr.words())
+	address := cpu.R(instr.Rn)
+
+	if instr.Add {
+		return address, address + size
+	}
+
+	return address - size, address - size
+}
+
+/* Perform VLDR */
+func FPLoad(cpu *CPU, instr FPLoadStoreFields) {
+	fp_load(cpu, instr, fp_address(cpu, instr))
+}
+
+/* Perform VSTR */
+func FPStore(cpu *CPU, instr FPLoadStoreFields) {
+	fp_store(cpu, instr, fp_address(cpu, instr))
+}
+
+/* Perform VLDM and VPOP. Rn is not written back if any access faults. */
+func FPLoadMultiple(cpu *CPU, instr FPLoadStoreFields) {
+	address, wback_addr := fp_multiple_address(cpu, instr)
+
+	if fp_load(cpu, instr, address) && instr.Wback {
+		cpu.SetR(instr.Rn, wback_addr)
+	}
+}
+
+/* Perform VSTM and VPUSH. Rn is not written back if any access faults. */
+func FPStoreMultiple(cpu *CPU, instr FPLoadStoreFields) {
+	address, wback_addr := fp_multiple_address(cpu, instr)
+
+	if fp_store(cpu, instr, address) && instr.Wback {
+		cpu.SetR(instr.Rn, wback_addr)
+	}
+}
+
+/* Extract the fields of the extension register load and store encodings.
+ * Single-precision registers are Vd:D, doubleword registers D:Vd. */
+func decode_fp_ldst(raw_instr uint32, double bool) FPLoadStoreFields {
+	Vd := (raw_instr >> 12) & 0xf
+	D := (raw_instr >> 22) & 0x1
+	imm8 := raw_instr & 0xff
+
+	fields := FPLoadStoreFields{
+		Double: double,
+		Rn:     RegIndex((raw_instr >> 16) & 0xf),
+		Imm:    imm8 << 2,
+		Add:    (raw_instr>>23)&0x1 != 0,
+		Wback:  (raw_instr>>21)&0x1 != 0,
+	}
+
+	if double {
+		fields.Sd = SRegIndex((D<<4 | Vd) << 1)
+		fields.Regs = uint8(imm8 / 2)
+	} else {
+		fields.Sd = SRegIndex(Vd<<1 | D)
+		fields.Regs = uint8(imm8)
+	}
+
+	return fields
+}
+
+/* UNPREDICTABLE register lists of VLDM, VSTM, VPUSH and VPOP, which must
+ * list 1-16 registers, all of them existing */
+func bad_fp_register_list(instr FPLoadStoreFields) bool {
+	if instr.Regs == 0 || instr.Regs > 16 {
+		return true
+	}
+
+	return uint(instr.Sd)+uint(instr.words()) > 32
+}
+
+/* Format a register list, e.g. {d8, d9} */
+func fp_register_list(instr FPLoadStoreFields) string {
+	var b bytes.Buffer
+
+	b.WriteString("{")
+	for i := uint8(0); i < instr.Regs; i++ {
+		if i != 0 {
+			b.WriteString(", ")
+		}
+
+		if instr.Double {
+			b.WriteString(DRegIndex(uint8(instr.Sd)/2 + i).String())
+		} else {
+			b.WriteString((instr.Sd + SRegIndex(i)).String())
+		}
+	}
+	b.WriteString("}")
+
+	return b.String()
+}
+
+/* The first register of VLDR and VSTR */
+func fp_register(instr FPLoadStoreFields) string {
+	if instr.Double {
+		return DRegIndex(instr.Sd / 2).String()
+	}
+
+	return instr.Sd.String()
+}
+
+/* Format the base register and register list of VLDM and VSTM */
+func fp_multiple_operands(instr FPLoadStoreFields) string {
+	wback := ""
+	if instr.Wback {
+		wback = "!"
+	}
+
+	return fmt.Sprintf("%s%s, %s", instr.Rn, wback, fp_register_list(instr))
+}
+
+func fp_multiple_suffix(instr FPLoadStoreFields) string {
+	if instr.Add {
+		return "ia"
+	}
+
+	return "db"
+}
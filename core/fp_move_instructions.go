@@ -0,0 +1,285 @@
+package core
+
+import "fmt"
+
+/* VMOV (ARM core register to scalar)
+ * ARM ARM A7.7.238
+ * Encoding T1 */
+type VmovToScalarT1 struct {
+	Dd    DRegIndex
+	Index uint8 // Word of Dd
+	Rt    RegIndex
+}
+
+func VmovToScalar32T1(instr FetchedInstr) DecodedInstr {
+	raw_instr := instr.Uint32()
+
+	Dd := DRegIndex((raw_instr>>7)&0x1<<4 | (raw_instr>>16)&0xf)
+	index := uint8((raw_instr >> 21) & 0x1)
+	Rt := RegIndex((raw_instr >> 12) & 0xf)
+
+	if BadReg(Rt) {
+		return UnpredictableInstr{}
+	}
+
+	return VmovToScalarT1{Dd: Dd, Index: index, Rt: Rt}
+}
+
+func (instr VmovToScalarT1) Execute(cpu *CPU) {
+	if ExecuteFPCheck(cpu) {
+		cpu.SetS(SRegIndex(2*uint8(instr.Dd)+instr.Index), cpu.R(instr.Rt))
+	}
+}
+
+func (instr VmovToScalarT1) String() string {
+	return fmt.Sprintf("vmov.32 %s[%d], %s", instr.Dd, instr.Index, instr.Rt)
+}
+
+/* VMOV (scalar to ARM core register)
+ * ARM ARM A7.7.239
+ * Encoding T1 */
+type VmovFromScalarT1 VmovToScalarT1
+
+func VmovFromScalar32T1(instr FetchedInstr) DecodedInstr {
+	decoded := VmovToScalar32T1(instr)
+	if fields, ok := decoded.(VmovToScalarT1); ok {
+		return VmovFromScalarT1(fields)
+	}
+
+	return decoded
+}
+
+func (instr VmovFromScalarT1) Execute(cpu *CPU) {
+	if ExecuteFPCheck(cpu) {
+		cpu.SetR(instr.Rt, cpu.S(SRegIndex(2*uint8(instr.Dd)+instr.Index)))
+	}
+}
+
+func (instr VmovFromScalarT1) String() string {
+	return fmt.Sprintf("vmov.32 %s, %s[%d]", instr.Rt, instr.Dd, instr.Index)
+}
+
+/* VMOV (between ARM core register and single-precision register)
+ * ARM ARM A7.7.240
+ * Encoding T1 */
+type VmovSingleT1 struct {
+	Sn     SRegIndex
+	Rt     RegIndex
+	ToCore bool
+}
+
+func VmovSingle32T1(instr FetchedInstr) DecodedInstr {
+	raw_instr := instr.Uint32()
+
+	Sn := SRegIndex(((raw_instr>>16)&0xf)<<1 | (raw_instr>>7)&0x1)
+	Rt := RegIndex((raw_instr >> 12) & 0xf)
+	to_core := (raw_instr>>20)&0x1 != 0
+
+	if BadReg(Rt) {
+		return UnpredictableInstr{}
+	}
+
+	return VmovSingleT1{Sn: Sn, Rt: Rt, ToCore: to_core}
+}
+
+func (instr VmovSingleT1) Execute(cpu *CPU) {
+	if !ExecuteFPCheck(cpu) {
+		return
+	}
+
+	if instr.ToCore {
+		cpu.SetR(instr.Rt, cpu.S(instr.Sn))
+	} else {
+		cpu.SetS(instr.Sn, cpu.R(instr.Rt))
+	}
+}
+
+func (instr VmovSingleT1) String() string {
+	if instr.ToCore {
+		return fmt.Sprintf("vmov %s, %s", instr.Rt, instr.Sn)
+	}
+
+	return fmt.Sprintf("vmov %s, %s", instr.Sn, instr.Rt)
+}
+
+/* Fields of the VMOV encodings between two core registers and a pair of
+ * single-precision registers, Sm and Sm+1, or a doubleword register,
+ * S(2m) and S(2m+1) */
+type vmov_pair struct {
+	Sm     SRegIndex
+	Rt     RegIndex
+	Rt2    RegIndex
+	ToCore bool
+}
+
+func decode_vmov_pair(raw_instr uint32, double bool) (vmov_pair, bool) {
+	Rt := RegIndex((raw_instr >> 12) & 0xf)
+	Rt2 := RegIndex((raw_instr >> 16) & 0xf)
+	to_core := (raw_instr>>20)&0x1 != 0
+
+	var Sm SRegIndex
+	if double {
+		Sm = SRegIndex(((raw_instr>>5)&0x1<<4 | raw_instr&0xf) << 1)
+	} else {
+		Sm = SRegIndex((raw_instr&0xf)<<1 | (raw_instr>>5)&0x1)
+	}
+
+	fields := vmov_pair{Sm: Sm, Rt: Rt, Rt2: Rt2, ToCore: to_core}
+
+	return fields, !BadReg(Rt) && !BadReg(Rt2) && Sm != 31 && !(to_core && Rt == Rt2)
+}
+
+func (instr vmov_pair) execute(cpu *CPU) {
+	if !ExecuteFPCheck(cpu) {
+		return
+	}
+
+	if instr.ToCore {
+		cpu.SetR(instr.Rt, cpu.S(instr.Sm))
+		cpu.SetR(instr.Rt2, cpu.S(instr.Sm+1))
+	} else {
+		cpu.SetS(instr.Sm, cpu.R(instr.Rt))
+		cpu.SetS(instr.Sm+1, cpu.R(instr.Rt2))
+	}
+}
+
+/* VMOV (between two ARM core registers and two single-precision registers)
+ * ARM ARM A7.7.241
+ * Encoding T1 */
+type VmovSinglePairT1 vmov_pair
+
+func VmovSinglePair32T1(instr FetchedInstr) DecodedInstr {
+	fields, ok := decode_vmov_pair(instr.Uint32(), false)
+	if !ok {
+		return UnpredictableInstr{}
+	}
+
+	return VmovSinglePairT1(fields)
+}
+
+func (instr VmovSinglePairT1) Execute(cpu *CPU) {
+	vmov_pair(instr).execute(cpu)
+}
+
+func (instr VmovSinglePairT1) String() string {
+	if instr.ToCore {
+		return fmt.Sprintf("vmov %s, %s, %s, %s", instr.Rt, instr.Rt2, instr.Sm, instr.Sm+1)
+	}
+
+	return fmt.Sprintf("vmov %s, %s, %s, %s", instr.Sm, instr.Sm+1, instr.Rt, instr.Rt2)
+}
+
+/* VMOV (between two ARM core registers and a doubleword extension register)
+ * ARM ARM A7.7.242
+ * Encoding T1 */
+type VmovDoubleT1 vmov_pair
+
+func VmovDouble32T1(instr FetchedInstr) DecodedInstr {
+	if bad_double_register(instr.Uint32(), 5) {
+		return UndefinedInstr{}
+	}
+
+	fields, ok := decode_vmov_pair(instr.Uint32(), true)
+	if !ok {
+		return UnpredictableInstr{}
+	}
+
+	return VmovDoubleT1(fields)
+}
+
+func (instr VmovDoubleT1) Execute(cpu *CPU) {
+	vmov_pair(instr).execute(cpu)
+}
+
+func (instr VmovDoubleT1) String() string {
+	Dm := DRegIndex(instr.Sm / 2)
+	if instr.ToCore {
+		return fmt.Sprintf("vmov %s, %s, %s", instr.Rt, instr.Rt2, Dm)
+	}
+
+	return fmt.Sprintf("vmov %s, %s, %s", Dm, instr.Rt, instr.Rt2)
+}
+
+/* VMRS, transferring FPSCR to Rt, or its flags to the APSR if Rt is PC
+ * ARM ARM A7.7.243
+ * Encoding T1 */
+type VmrsT1 struct {
+	Rt RegIndex
+}
+
+/* FPSCR is the only special register ARMv7-M transfers with VMRS and
+ * VMSR. FPEXC and the MVFRs are UNDEFINED, the MVFRs are in the SCB. */
+const VMRS_REG_FPSCR = 0x1
+
+func vmrs_reg(raw_instr uint32) uint32 {
+	return (raw_instr >> 16) & 0xf
+}
+
+func Vmrs32T1(instr FetchedInstr) DecodedInstr {
+	Rt := RegIndex((instr.Uint32() >> 12) & 0xf)
+
+	if vmrs_reg(instr.Uint32()) != VMRS_REG_FPSCR {
+		return UndefinedInstr{}
+	}
+
+	if Rt == SP {
+		return UnpredictableInstr{}
+	}
+
+	return VmrsT1{Rt: Rt}
+}
+
+func (instr VmrsT1) Execute(cpu *CPU) {
+	if !ExecuteFPCheck(cpu) {
+		return
+	}
+
+	if instr.Rt != PC {
+		cpu.SetR(instr.Rt, cpu.Fpscr.Uint32())
+		return
+	}
+
+	cpu.Apsr.N = cpu.Fpscr.N
+	cpu.Apsr.Z = cpu.Fpscr.Z
+	cpu.Apsr.C = cpu.Fpscr.C
+	cpu.Apsr.V = cpu.Fpscr.V
+}
+
+func (instr VmrsT1) String() string {
+	if instr.Rt == PC {
+		return "vmrs APSR_nzcv, fpscr"
+	}
+
+	return fmt.Sprintf("vmrs %s, fpscr", instr.Rt)
+}
+
+/* VMSR
+ * ARM ARM A7.7.244
+ * Encoding T1 */
+type VmsrT1 struct {
+	Rt RegIndex
+}
+
+func Vmsr32T1(instr FetchedInstr) DecodedInstr {
+	Rt := RegIndex((instr.Uint32() >> 12) & 0xf)
+
+	if vmrs_reg(instr.Uint32()) != VMRS_REG_FPSCR {
+		return UndefinedInstr{}
+	}
+
+	if BadReg(Rt) {
+		return UnpredictableInstr{}
+	}
+
+	return VmsrT1{Rt: Rt}
+}
+
+func (instr VmsrT1) Execute(cpu *CPU) {
+	if ExecuteFPCheck(cpu) {
+		cpu.Fpscr.SetUint32(cpu.R(instr.Rt))
+	}
+}
+
+func (instr VmsrT1) String() string {
+	return fmt.Sprintf("vmsr fpscr, %s", instr.Rt)
+}
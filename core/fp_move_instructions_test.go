@@ -0,0 +1,151 @@
+package core
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestIdentifyVmovSingleT1(t *testing.T) {
+	cases := []IdentifyCase{
+		{instr: FetchedInstr32(0xee000a10), instr_valid: true},  // vmov s0, r0
+		{instr: FetchedInstr32(0xee1f0a90), instr_valid: true},  // vmov r0, s31
+		{instr: FetchedInstr32(0xee210b10), instr_valid: false}, // vmov.32 d1[1], r0
+		{instr: FetchedInstr32(0xeef10a10), instr_valid: false}, // vmrs r0, fpscr
+	}
+
+	test_identify(t, cases, reflect.TypeOf(VmovSingleT1{}))
+}
+
+func TestIdentifyVmrsOtherRegisters(t *testing.T) {
+	cases := []IdentifyCase{
+		{instr: FetchedInstr32(0xeef70a10), instr_valid: true},  // vmrs r0, mvfr0
+		{instr: FetchedInstr32(0xeee80a10), instr_valid: true},  // vmsr fpexc, r0
+		{instr: FetchedInstr32(0xee110f10), instr_valid: false}, // mrc p15, #0, r0, c1, c0, #0
+	}
+
+	test_identify(t, cases, reflect.TypeOf(UndefinedInstr{}))
+}
+
+func TestDecodeVmov(t *testing.T) {
+	cases := []DecodeCase{
+		// vmov r0, s31
+		{instr: FetchedInstr32(0xee1f0a90), decoded: VmovSingleT1{Sn: 31, Rt: 0, ToCore: true}},
+		// vmov s0, sp
+		{instr: FetchedInstr32(0xee00da10), decoded: UnpredictableInstr{}},
+	}
+
+	test_decode(t, cases, VmovSingle32T1)
+
+	cases = []DecodeCase{
+		// vmov.32 d1[1], r0
+		{instr: FetchedInstr32(0xee210b10), decoded: VmovToScalarT1{Dd: 1, Index: 1, Rt: 0}},
+	}
+
+	test_decode(t, cases, VmovToScalar32T1)
+
+	cases = []DecodeCase{
+		// vmov s0, s1, r0, r1
+		{instr: FetchedInstr32(0xec410a10), decoded: VmovSinglePairT1{Sm: 0, Rt: 0, Rt2: 1, ToCore: false}},
+		// vmov r0, r0, s0, s1
+		{instr: FetchedInstr32(0xec500a10), decoded: UnpredictableInstr{}},
+		// vmov s31, s32, r0, r1
+		{instr: FetchedInstr32(0xec410a3f), decoded: UnpredictableInstr{}},
+	}
+
+	test_decode(t, cases, VmovSinglePair32T1)
+
+	cases = []DecodeCase{
+		// vmov r0, r1, d3
+		{instr: FetchedInstr32(0xec510b13), decoded: VmovDoubleT1{Sm: 6, Rt: 0, Rt2: 1, ToCore: true}},
+		// vmov lr, r1, d20
+		{instr: FetchedInstr32(0xec51eb34), decoded: UndefinedInstr{}},
+	}
+
+	test_decode(t, cases, VmovDouble32T1)
+
+	cases = []DecodeCase{
+		// vmrs APSR_nzcv, fpscr
+		{instr: FetchedInstr32(0xeef1fa10), decoded: VmrsT1{Rt: PC}},
+		// vmrs sp, fpscr
+		{instr: FetchedInstr32(0xeef1da10), decoded: UnpredictableInstr{}},
+		// vmrs r0, mvfr0
+		{instr: FetchedInstr32(0xeef70a10), decoded: UndefinedInstr{}},
+		// vmrs r0, mvfr1
+		{instr: FetchedInstr32(0xeef60a10), decoded: UndefinedInstr{}},
+		// vmrs r0, fpexc
+		{instr: FetchedInstr32(0xeef80a10), decoded: UndefinedInstr{}},
+	}
+
+	test_decode(t, cases, Vmrs32T1)
+
+	cases = []DecodeCase{
+		// vmsr fpscr, r0
+		{instr: FetchedInstr32(0xeee10a10), decoded: VmsrT1{Rt: 0}},
+		// vmsr fpexc, r0
+		{instr: FetchedInstr32(0xeee80a10), decoded: UndefinedInstr{}},
+	}
+
+	test_decode(t, cases, Vmsr32T1)
+}
+
+func TestFPMoveString(t *testing.T) {
+	cases := []struct {
+		instr    FetchedInstr
+		expected string
+	}{
+		{instr: FetchedInstr32(0xee1f0a90), expected: "vmov r0, s31"},
+		{instr: FetchedInstr32(0xee110b10), expected: "vmov.32 r0, d1[0]"},
+		{instr: FetchedInstr32(0xec510a10), expected: "vmov r0, r1, s0, s1"},
+		{instr: FetchedInstr32(0xec410b13), expected: "vmov d3, r0, r1"},
+		{instr: FetchedInstr32(0xeef1fa10), expected: "vmrs APSR_nzcv, fpscr"},
+		{instr: FetchedInstr32(0xeee10a10), expected: "vmsr fpscr, r0"},
+	}
+
+	for _, test := range cases {
+		decoded, err := test.instr.Decode()
+		if err != nil {
+			t.Errorf("%v: %v", test.instr, err)
+		} else if actual := Disassemble(decoded, 0); actual != test.expected {
+			t.Errorf("%v: %q, expected %q", test.instr, actual, test.expected)
+		}
+	}
+}
+
+func TestExecuteVmov(t *testing.T) {
+	cases := []ExecuteCase{
+		// vmov r1, s2
+		{instr: VmovSingleT1{Sn: 2, Rt: 1, ToCore: true},
+			regs:     Registers{s: FPRegs{0, 0, 0x3f800000}},
+			expected: Registers{r: GeneralRegs{0, 0x3f800000}, s: FPRegs{0, 0, 0x3f800000}}},
+		// vmov.32 d1[1], r0
+		{instr: VmovToScalarT1{Dd: 1, Index: 1, Rt: 0},
+			regs:     Registers{r: GeneralRegs{0x12345678}},
+			expected: Registers{r: GeneralRegs{0x12345678}, s: FPRegs{0, 0, 0, 0x12345678}}},
+		// vmov.32 r0, d1[0]
+		{instr: VmovFromScalarT1{Dd: 1, Index: 0, Rt: 0},
+			regs:     Registers{s: FPRegs{0, 0, 0x12345678}},
+			expected: Registers{r: GeneralRegs{0x12345678}, s: FPRegs{0, 0, 0x12345678}}},
+		// vmov d1, r2, r3
+		{instr: VmovDoubleT1{Sm: 2, Rt: 2, Rt2: 3},
+			regs:     Registers{r: GeneralRegs{0, 0, 0x22222222, 0x33333333}},
+			expected: Registers{r: GeneralRegs{0, 0, 0x22222222, 0x33333333}, s: FPRegs{0, 0, 0x22222222, 0x33333333}}},
+		// vmov r0, r1, s3, s4
+		{instr: VmovSinglePairT1{Sm: 3, Rt: 0, Rt2: 1, ToCore: true},
+			regs:     Registers{s: FPRegs{0, 0, 0, 3, 4}},
+			expected: Registers{r: GeneralRegs{3, 4}, s: FPRegs{0, 0, 0, 3, 4}}},
+		// vmsr fpscr, r0
+		{instr: VmsrT1{Rt: 0},
+			regs:     Registers{r: GeneralRegs{0xa7c0009f}},
+			expected: Registers{r: GeneralRegs{0xa7c0009f}, Fpscr: Fpscr{N: true, C: true, AHP: true, DN: true, FZ: true, RMode: FPROUND_RZ, Exc: FPEXC_MASK}}},
+		// vmrs r0, fpscr
+		{instr: VmrsT1{Rt: 0},
+			regs:     Registers{Fpscr: Fpscr{Z: true, V: true, RMode: FPROUND_RP, Exc: FPEXC_DZC}},
+			expected: Registers{r: GeneralRegs{0x50400002}, Fpscr: Fpscr{Z: true, V: true, RMode: FPROUND_RP, Exc: FPEXC_DZC}}},
+		// vmrs APSR_nzcv, fpscr
+		{instr: VmrsT1{Rt: PC},
+			regs:     Registers{Apsr: Apsr{N: true, Q: true}, Fpscr: Fpscr{Z: true, C: true}},
+			expected: Registers{Apsr: Apsr{Z: true, C: true, Q: true}, Fpscr: Fpscr{Z: true, C: true}}},
+	}
+
+	test_execute(t, cases)
+}
@@ -0,0 +1,759 @@
+package core
+
+import (
+	"math"
+	"math/bits"
+)
+
+/* A floating-point format, by the widths of its exponent and fraction */
+type fp_format struct {
+	E uint
+	F uint
+}
+
+var (
+	fp_half   = fp_format{E: 5, F: 10}
+	fp_single = fp_format{E: 8, F: 23}
+)
+
+func (format fp_format) bias() int {
+	return 1<<(format.E-1) - 1
+}
+
+func (format fp_format) sign_bit(sign bool) uint32 {
+	return uint32(booltou(sign)) << (format.E + format.F)
+}
+
+func (format fp_format) exp_mask() uint32 {
+	return (1<<format.E - 1) << format.F
+}
+
+/* ARM ARM pseudocode FPZero(), FPInfinity(), FPMaxNormal() and
+ * FPDefaultNaN() */
+
+func fp_zero(format fp_format, sign bool) uint32 {
+	return format.sign_bit(sign)
+}
+
+func fp_infinity(format fp_format, sign bool) uint32 {
+	return format.sign_bit(sign) | format.exp_mask()
+}
+
+func fp_max_normal(format fp_format, sign bool) uint32 {
+	return format.sign_bit(sign) | (format.exp_mask() - 1<<format.F) | (1<<format.F - 1)
+}
+
+func fp_default_nan(format fp_format) uint32 {
+	return format.exp_mask() | 1<<(format.F-1)
+}
+
+/* Class of an unpacked floating-point value
+ * ARM ARM pseudocode FPType */
+type fp_type uint8
+
+const (
+	FPTYPE_NONZERO fp_type = iota
+	FPTYPE_ZERO
+	FPTYPE_INFINITY
+	FPTYPE_QNAN
+	FPTYPE_SNAN
+)
+
+func (t fp_type) is_nan() bool {
+	return t == FPTYPE_QNAN || t == FPTYPE_SNAN
+}
+
+/* A real number, (-1)^sign * mant * 2^exp, that the operations compute
+ * exactly before rounding. Results that can't be held exactly, such as
+ * quotients, are truncated and marked sticky: their true magnitude is
+ * larger by less than 2^exp. */
+type fp_real struct {
+	sign   bool
+	mant   uint64
+	exp    int
+	sticky bool
+}
+
+func (value fp_real) is_zero() bool {
+	return value.mant == 0 && !value.sticky
+}
+
+/* Shift the mantissa of a nonzero exact value so its top bit is bit top */
+func (value fp_real) normalize(top int) fp_real {
+	shift := top - (bits.Len64(value.mant) - 1)
+	if shift >= 0 {
+		value.mant <<= uint(shift)
+	} else {
+		value.mant >>= uint(-shift)
+	}
+	value.exp -= shift
+
+	return value
+}
+
+/* Unpack fpval, flushing single-precision denormals to zero if FPSCR.FZ
+ * is set. Half-precision values use the alternative format, without
+ * infinities or NaNs, if FPSCR.AHP is set.
+ * ARM ARM pseudocode FPUnpack() */
+func fp_unpack(format fp_format, fpval uint32, fpscr *Fpscr) (fp_type, fp_real) {
+	exp := int((fpval & format.exp_mask()) >> format.F)
+	frac := uint64(fpval & (1<<format.F - 1))
+
+	value := fp_real{sign: fpval&format.sign_bit(true) != 0}
+
+	switch {
+	case exp == 0:
+		if frac == 0 {
+			return FPTYPE_ZERO, value
+		}
+
+		if format == fp_single && fpscr.FZ {
+			fpscr.Exc |= FPEXC_IDC
+			return FPTYPE_ZERO, value
+		}
+
+		value.mant = frac
+		value.exp = 1 - format.bias() - int(format.F)
+		return FPTYPE_NONZERO, value
+	case exp == 1<<format.E-1 && !(format == fp_half && fpscr.AHP):
+		if frac == 0 {
+			return FPTYPE_INFINITY, value
+		}
+
+		if frac&(1<<(format.F-1)) != 0 {
+			return FPTYPE_QNAN, value
+		}
+		return FPTYPE_SNAN, value
+	}
+
+	value.mant = 1<<format.F | frac
+	value.exp = exp - format.bias() - int(format.F)
+	return FPTYPE_NONZERO, value
+}
+
+/* Split the magnitude of value below bit shift of its mantissa into the
+ * integer part, whether the fraction discarded is at least a half, and
+ * whether anything beyond that half was discarded */
+func fp_split(value fp_real, shift int) (uint64, bool, bool) {
+	switch {
+	case shift <= 0:
+		return value.mant << uint(-shift), false, value.sticky
+	case shift > 64:
+		return 0, false, value.mant != 0 || value.sticky
+	}
+
+	int_mant := value.mant >> uint(shift)
+	half := (value.mant>>uint(shift-1))&0x1 != 0
+	rest := value.mant&(1<<uint(shift-1)-1) != 0 || value.sticky
+
+	return int_mant, half, rest
+}
+
+/* Round a nonzero real to format, under the rounding mode and
+ * flush-to-zero mode of fpscr
+ * ARM ARM pseudocode FPRound() */
+func fp_round(format fp_format, value fp_real, fpscr *Fpscr) uint32 {
+	F := int(format.F)
+	minimum_exp := 1 - format.bias()
+
+	/* 2^exponent <= |value| < 2^(exponent+1) */
+	exponent := bits.Len64(value.mant) - 1 + value.exp
+
+	if format == fp_single && fpscr.FZ && exponent < minimum_exp {
+		fpscr.Exc |= FPEXC_UFC
+		return fp_zero(format, value.sign)
+	}
+
+	/* Denormals keep the weight of the smallest normal's LSB */
+	biased_exp := exponent - minimum_exp + 1
+	lsb := exponent - F
+	if biased_exp <= 0 {
+		biased_exp = 0
+		lsb = minimum_exp - F
+	}
+
+	int_mant, half, rest := fp_split(value, lsb-value.exp)
+	inexact := half || rest
+
+	if biased_exp == 0 && inexact {
+		fpscr.Exc |= FPEXC_UFC
+	}
+
+	var round_up, overflow_to_inf bool
+	switch fpscr.RMode {
+	case FPROUND_RN:
+		round_up = half && (rest || int_mant&0x1 != 0)
+		overflow_to_inf = true
+	case FPROUND_RP:
+		round_up = inexact && !value.sign
+		overflow_to_inf = !value.sign
+	case FPROUND_RM:
+		round_up = inexact && value.sign
+		overflow_to_inf = value.sign
+	case FPROUND_RZ:
+		round_up = false
+		overflow_to_inf = false
+	}
+
+	if round_up {
+		int_mant++
+
+		/* A denormal rounded up to a normal, or a carry out */
+		if int_mant == 1<<F {
+			biased_exp = 1
+		}
+		if int_mant == 1<<(F+1) {
+			biased_exp++
+			int_mant >>= 1
+		}
+	}
+
+	var result uint32
+	if format != fp_half || !fpscr.AHP {
+		if biased_exp >= 1<<format.E-1 {
+			if overflow_to_inf {
+				result = fp_infinity(format, value.sign)
+			} else {
+				result = fp_max_normal(format, value.sign)
+			}
+			fpscr.Exc |= FPEXC_OFC
+			inexact = true
+		} else {
+			result = format.sign_bit(value.sign) | uint32(biased_exp)<<format.F | uint32(int_mant)&(1<<format.F-1)
+		}
+	} else {
+		/* The alternative half-precision format saturates */
+		if biased_exp >= 1<<format.E {
+			result = format.sign_bit(value.sign) | 0x7fff
+			fpscr.Exc |= FPEXC_IOC
+			inexact = false
+		} else {
+			result = format.sign_bit(value.sign) | uint32(biased_exp)<<format.F | uint32(int_mant)&(1<<format.F-1)
+		}
+	}
+
+	if inexact {
+		fpscr.Exc |= FPEXC_IXC
+	}
+
+	return result
+}
+
+/* Round an exact sum, which is zero only when its operands cancel. That
+ * zero is negative when rounding towards minus infinity, otherwise positive. */
+func fp_round_sum(value fp_real, fpscr *Fpscr) uint32 {
+	if value.is_zero() {
+		return fp_zero(fp_single, fpscr.RMode == FPROUND_RM)
+	}
+
+	return fp_round(fp_single, value, fpscr)
+}
+
+/* Quiet a single-precision NaN, signalling Invalid Operation if it was
+ * signalling, or replace it with the default NaN if FPSCR.DN is set
+ * ARM ARM pseudocode FPProcessNaN() */
+func fp_process_nan(t fp_type, op uint32, fpscr *Fpscr) uint32 {
+	result := op | 1<<(fp_single.F-1)
+	if t == FPTYPE_SNAN {
+		fpscr.Exc |= FPEXC_IOC
+	}
+
+	if fpscr.DN {
+		result = fp_default_nan(fp_single)
+	}
+
+	return result
+}
+
+/* Propagate the first signalling NaN operand, otherwise the first quiet
+ * NaN, reporting whether there was one
+ * ARM ARM pseudocode FPProcessNaNs(), FPProcessNaNs3() */
+func fp_process_nans(types []fp_type, ops []uint32, fpscr *Fpscr) (uint32, bool) {
+	for _, nan := range []fp_type{FPTYPE_SNAN, FPTYPE_QNAN} {
+		for i, t := range types {
+			if t == nan {
+				return fp_process_nan(t, ops[i], fpscr), true
+			}
+		}
+	}
+
+	return 0, false
+}
+
+/* Add two exact reals. Operands too far apart to add exactly leave the
+ * sum sticky, with enough bits for it to still round correctly. */
+func fp_real_add(a fp_real, b fp_real) fp_real {
+	if a.mant == 0 {
+		return b
+	} else if b.mant == 0 {
+		return a
+	}
+
+	a = a.normalize(61)
+	b = b.normalize(61)
+	if a.exp < b.exp || (a.exp == b.exp && a.mant < b.mant) {
+		a, b = b, a
+	}
+
+	shift := uint(a.exp - b.exp)
+	sticky := false
+	if shift >= 64 {
+		sticky = true
+		b.mant = 0
+	} else {
+		sticky = b.mant&(1<<shift-1) != 0
+		b.mant >>= shift
+	}
+
+	sum := fp_real{sign: a.sign, exp: a.exp, sticky: sticky}
+	if a.sign == b.sign {
+		sum.mant = a.mant + b.mant
+	} else {
+		/* The truncated part of b borrows from the difference */
+		sum.mant = a.mant - b.mant
+		if sticky {
+			sum.mant--
+		}
+	}
+
+	return sum
+}
+
+func fp_real_mul(a fp_real, b fp_real) fp_real {
+	return fp_real{sign: a.sign != b.sign, mant: a.mant * b.mant, exp: a.exp + b.exp}
+}
+
+func fp_real_div(a fp_real, b fp_real) fp_real {
+	a = a.normalize(23)
+	b = b.normalize(23)
+
+	num := a.mant << 40
+	return fp_real{
+		sign:   a.sign != b.sign,
+		mant:   num / b.mant,
+		exp:    a.exp - 40 - b.exp,
+		sticky: num%b.mant != 0,
+	}
+}
+
+func fp_real_sqrt(a fp_real) fp_real {
+	a = a.normalize(23)
+	if a.exp&0x1 != 0 {
+		a.mant <<= 1
+		a.exp--
+	}
+
+	x := a.mant << 38
+	root := uint64(math.Sqrt(float64(x)))
+	for root*root > x {
+		root--
+	}
+	for (root+1)*(root+1) <= x {
+		root++
+	}
+
+	return fp_real{mant: root, exp: (a.exp - 38) / 2, sticky: root*root != x}
+}
+
+/* ARM ARM pseudocode FPNeg() */
+func FPNeg(op uint32) uint32 {
+	return op ^ fp_single.sign_bit(true)
+}
+
+/* ARM ARM pseudocode FPAbs() */
+func FPAbs(op uint32) uint32 {
+	return op &^ fp_single.sign_bit(true)
+}
+
+/* Add, or subtract, two single-precision values */
+func fp_add(op1 uint32, op2 uint32, subtract bool, fpscr *Fpscr) uint32 {
+	type1, value1 := fp_unpack(fp_single, op1, fpscr)
+	type2, value2 := fp_unpack(fp_single, op2, fpscr)
+
+	if result, done := fp_process_nans([]fp_type{type1, type2}, []uint32{op1, op2}, fpscr); done {
+		return result
+	}
+
+	if subtract {
+		value2.sign = !value2.sign
+	}
+
+	inf1, inf2 := type1 == FPTYPE_INFINITY, type2 == FPTYPE_INFINITY
+	zero1, zero2 := type1 == FPTYPE_ZERO, type2 == FPTYPE_ZERO
+
+	switch {
+	case inf1 && inf2 && value1.sign != value2.sign:
+		fpscr.Exc |= FPEXC_IOC
+		return fp_default_nan(fp_single)
+	case (inf1 && !value1.sign) || (inf2 && !value2.sign):
+		return fp_infinity(fp_single, false)
+	case (inf1 && value1.sign) || (inf2 && value2.sign):
+		return fp_infinity(fp_single, true)
+	case zero1 && zero2 && value1.sign == value2.sign:
+		return fp_zero(fp_single, value1.sign)
+	}
+
+	return fp_round_sum(fp_real_add(value1, value2), fpscr)
+}
+
+/* ARM ARM pseudocode FPAdd() */
+func FPAdd(op1 uint32, op2 uint32, fpscr *Fpscr) uint32 {
+	return fp_add(op1, op2, false, fpscr)
+}
+
+/* ARM ARM pseudocode FPSub() */
+func FPSub(op1 uint32, op2 uint32, fpscr *Fpscr) uint32 {
+	return fp_add(op1, op2, true, fpscr)
+}
+
+/* ARM ARM pseudocode FPMul() */
+func FPMul(op1 uint32, op2 uint32, fpscr *Fpscr) uint32 {
+	type1, value1 := fp_unpack(fp_single, op1, fpscr)
+	type2, value2 := fp_unpack(fp_single, op2, fpscr)
+
+	if result, done := fp_process_nans([]fp_type{type1, type2}, []uint32{op1, op2}, fpscr); done {
+		return result
+	}
+
+	inf1, inf2 := type1 == FPTYPE_INFINITY, type2 == FPTYPE_INFINITY
+	zero1, zero2 := type1 == FPTYPE_ZERO, type2 == FPTYPE_ZERO
+	sign := value1.sign != value2.sign
+
+	switch {
+	case (inf1 && zero2) || (zero1 && inf2):
+		fpscr.Exc |= FPEXC_IOC
+		return fp_default_nan(fp_single)
+	case inf1 || inf2:
+		return fp_infinity(fp_single, sign)
+	case zero1 || zero2:
+		return fp_zero(fp_single, sign)
+	}
+
+	return fp_round(fp_single, fp_real_mul(value1, value2), fpscr)
+}
+
+/* ARM ARM pseudocode FPDiv() */
+func FPDiv(op1 uint32, op2 uint32, fpscr *Fpscr) uint32 {
+	type1, value1 := fp_unpack(fp_single, op1, fpscr)
+	type2, value2 := fp_unpack(fp_single, op2, fpscr)
+
+	if result, done := fp_process_nans([]fp_type{type1, type2}, []uint32{op1, op2}, fpscr); done {
+		return result
+	}
+
+	inf1, inf2 := type1 == FPTYPE_INFINITY, type2 == FPTYPE_INFINITY
+	zero1, zero2 := type1 == FPTYPE_ZERO, type2 == FPTYPE_ZERO
+	sign := value1.sign != value2.sign
+
+	switch {
+	case (inf1 && inf2) || (zero1 && zero2):
+		fpscr.Exc |= FPEXC_IOC
+		return fp_default_nan(fp_single)
+	case inf1 || zero2:
+		if !inf1 {
+			fpscr.Exc |= FPEXC_DZC
+		}
+		return fp_infinity(fp_single, sign)
+	case zero1 || inf2:
+		return fp_zero(fp_single, sign)
+	}
+
+	return fp_round(fp_single, fp_real_div(value1, value2), fpscr)
+}
+
+/* ARM ARM pseudocode FPSqrt() */
+func FPSqrt(op uint32, fpscr *Fpscr) uint32 {
+	t, value := fp_unpack(fp_single, op, fpscr)
+
+	switch {
+	case t.is_nan():
+		return fp_process_nan(t, op, fpscr)
+	case t == FPTYPE_ZERO:
+		return fp_zero(fp_single, value.sign)
+	case t == FPTYPE_INFINITY && !value.sign:
+		return fp_infinity(fp_single, false)
+	case value.sign:
+		fpscr.Exc |= FPEXC_IOC
+		return fp_default_nan(fp_single)
+	}
+
+	return fp_round(fp_single, fp_real_sqrt(value), fpscr)
+}
+
+/* Fused multiply-add, addend + op1 * op2 with a single rounding
+ * ARM ARM pseudocode FPMulAdd() */
+func FPMulAdd(addend uint32, op1 uint32, op2 uint32, fpscr *Fpscr) uint32 {
+	typeA, valueA := fp_unpack(fp_single, addend, fpscr)
+	type1, value1 := fp_unpack(fp_single, op1, fpscr)
+	type2, value2 := fp_unpack(fp_single, op2, fpscr)
+
+	inf1, inf2 := type1 == FPTYPE_INFINITY, type2 == FPTYPE_INFINITY
+	zero1, zero2 := type1 == FPTYPE_ZERO, type2 == FPTYPE_ZERO
+	invalid_product := (inf1 && zero2) || (zero1 && inf2)
+
+	result, done := fp_process_nans([]fp_type{typeA, type1, type2}, []uint32{addend, op1, op2}, fpscr)
+
+	/* A quiet NaN addend doesn't hide an invalid product */
+	if typeA == FPTYPE_QNAN && invalid_product {
+		fpscr.Exc |= FPEXC_IOC
+		return fp_default_nan(fp_single)
+	}
+
+	if done {
+		return result
+	}
+
+	infA, zeroA := typeA == FPTYPE_INFINITY, typeA == FPTYPE_ZERO
+	signP := value1.sign != value2.sign
+	infP, zeroP := inf1 || inf2, zero1 || zero2
+
+	switch {
+	case (infA && infP && valueA.sign != signP) || invalid_product:
+		fpscr.Exc |= FPEXC_IOC
+		return fp_default_nan(fp_single)
+	case (infA && !valueA.sign) || (infP && !signP):
+		return fp_infinity(fp_single, false)
+	case (infA && valueA.sign) || (infP && signP):
+		return fp_infinity(fp_single, true)
+	case zeroA && zeroP && valueA.sign == signP:
+		return fp_zero(fp_single, valueA.sign)
+	}
+
+	return fp_round_sum(fp_real_add(valueA, fp_real_mul(value1, value2)), fpscr)
+}
+
+/* Compare two single-precision values, returning the NZCV flags.
+ * Quiet NaNs only signal Invalid Operation if quiet_nan_exc is set.
+ * ARM ARM pseudocode FPCompare() */
+func FPCompare(op1 uint32, op2 uint32, quiet_nan_exc bool, fpscr *Fpscr) (n, z, c, v bool) {
+	type1, value1 := fp_unpack(fp_single, op1, fpscr)
+	type2, value2 := fp_unpack(fp_single, op2, fpscr)
+
+	if type1.is_nan() || type2.is_nan() {
+		if type1 == FPTYPE_SNAN || type2 == FPTYPE_SNAN || quiet_nan_exc {
+			fpscr.Exc |= FPEXC_IOC
+		}
+		return false, false, true, true
+	}
+
+	real1 := fp_value(type1, value1)
+	real2 := fp_value(type2, value2)
+
+	switch {
+	case real1 == real2:
+		return false, true, true, false
+	case real1 < real2:
+		return true, false, false, false
+	}
+
+	return false, false, true, false
+}
+
+/* The exact value of a number that isn't a NaN */
+func fp_value(t fp_type, value fp_real) float64 {
+	sign := 1
+	if value.sign {
+		sign = -1
+	}
+
+	if t == FPTYPE_INFINITY {
+		return math.Inf(sign)
+	}
+
+	return float64(sign) * math.Ldexp(float64(value.mant), value.exp)
+}
+
+/* Convert a single-precision value to an N-bit fixed-point value with
+ * fraction_bits fractional bits, saturating out of range values. Unless
+ * round_zero is set, FPSCR selects the rounding mode.
+ * ARM ARM pseudocode FPToFixed() */
+func FPToFixed(op uint32, N uint8, fraction_bits uint8, unsigned bool, round_zero bool, fpscr *Fpscr) uint32 {
+	t, value := fp_unpack(fp_single, op, fpscr)
+
+	if t.is_nan() {
+		fpscr.Exc |= FPEXC_IOC
+	}
+
+	/* Split value * 2^fraction_bits into integer and fraction. Anything
+	 * of 2^40 or more saturates, however it rounds. */
+	var int_mag uint64
+	var half, rest bool
+	switch {
+	case t == FPTYPE_INFINITY:
+		int_mag = 1 << 40
+	case t == FPTYPE_NONZERO:
+		value.exp += int(fraction_bits)
+		if value.exp > 16 {
+			int_mag = 1 << 40
+		} else {
+			int_mag, half, rest = fp_split(value, -value.exp)
+		}
+	}
+	inexact := half || rest
+
+	var round_up bool
+	switch {
+	case round_zero || fpscr.RMode == FPROUND_RZ:
+		round_up = false
+	case fpscr.RMode == FPROUND_RN:
+		round_up = half && (rest || int_mag&0x1 != 0)
+	case fpscr.RMode == FPROUND_RP:
+		round_up = inexact && !value.sign
+	case fpscr.RMode == FPROUND_RM:
+		round_up = inexact && value.sign
+	}
+
+	if round_up {
+		int_mag++
+	}
+
+	int_result := int64(int_mag)
+	if value.sign {
+		int_result = -int_result
+	}
+
+	var result uint32
+	var overflow bool
+	if unsigned {
+		result, overflow = UnsignedSatQ(int_result, N)
+	} else {
+		result, overflow = SignedSatQ(int_result, N)
+	}
+
+	if overflow {
+		fpscr.Exc |= FPEXC_IOC
+	} else if inexact {
+		fpscr.Exc |= FPEXC_IXC
+	}
+
+	return result
+}
+
+/* Convert the N-bit fixed-point operand, with fraction_bits fractional
+ * bits, to single precision. Unless round_to_nearest is set, FPSCR
+ * selects the rounding mode.
+ * ARM ARM pseudocode FixedToFP() */
+func FixedToFP(operand uint32, N uint8, fraction_bits uint8, unsigned bool, round_to_nearest bool, fpscr *Fpscr) uint32 {
+	int_operand := int64(operand & uint32(1<<N-1))
+	if !unsigned {
+		int_operand = int64(int32(SignExtend(operand, N)))
+	}
+
+	if int_operand == 0 {
+		return fp_zero(fp_single, false)
+	}
+
+	value := fp_real{sign: int_operand < 0, mant: uint64(int_operand), exp: -int(fraction_bits)}
+	if value.sign {
+		value.mant = uint64(-int_operand)
+	}
+
+	if !round_to_nearest {
+		return fp_round(fp_single, value, fpscr)
+	}
+
+	nearest := *fpscr
+	nearest.RMode = FPROUND_RN
+	result := fp_round(fp_single, value, &nearest)
+	fpscr.Exc = nearest.Exc
+
+	return result
+}
+
+/* ARM ARM pseudocode FPHalfToSingle() */
+func FPHalfToSingle(operand uint16, fpscr *Fpscr) uint32 {
+	t, value := fp_unpack(fp_half, uint32(operand), fpscr)
+
+	switch t {
+	case FPTYPE_QNAN, FPTYPE_SNAN:
+		if t == FPTYPE_SNAN {
+			fpscr.Exc |= FPEXC_IOC
+		}
+
+		if fpscr.DN {
+			return fp_default_nan(fp_single)
+		}
+
+		/* Quieted, keeping the payload */
+		return fp_single.sign_bit(value.sign) | 0x7fc00000 | uint32(operand&0x1ff)<<13
+	case FPTYPE_INFINITY:
+		return fp_infinity(fp_single, value.sign)
+	case FPTYPE_ZERO:
+		return fp_zero(fp_single, value.sign)
+	}
+
+	return fp_round(fp_single, value, fpscr)
+}
+
+/* ARM ARM pseudocode FPSingleToHalf() */
+func FPSingleToHalf(operand uint32, fpscr *Fpscr) uint16 {
+	t, value := fp_unpack(fp_single, operand, fpscr)
+
+	switch t {
+	case FPTYPE_QNAN, FPTYPE_SNAN:
+		if t == FPTYPE_SNAN || fpscr.AHP {
+			fpscr.Exc |= FPEXC_IOC
+		}
+
+		switch {
+		case fpscr.AHP:
+			return uint16(fp_zero(fp_half, value.sign))
+		case fpscr.DN:
+			return uint16(fp_default_nan(fp_half))
+		}
+
+		return uint16(fp_half.sign_bit(value.sign) | 0x7e00 | (operand>>13)&0x1ff)
+	case FPTYPE_INFINITY:
+		if fpscr.AHP {
+			fpscr.Exc |= FPEXC_IOC
+			return uint16(fp_half.sign_bit(value.sign) | 0x7fff)
+		}
+		return uint16(fp_infinity(fp_half, value.sign))
+	case FPTYPE_ZERO:
+		return uint16(fp_zero(fp_half, value.sign))
+	}
+
+	return uint16(fp_round(fp_half, value, fpscr))
+}
+
+/* Expand the 8-bit immediate of VMOV to single precision
+ * ARM ARM pseudocode VFPExpandImm() */
+func VFPExpandImm(imm8 uint8) uint32 {
+	sign := uint32(imm8>>7) & 0x1
+	b := uint32(imm8>>6) & 0x1
+
+	exp := (b^0x1)<<7 | b*0x7c | uint32(imm8>>4)&0x3
+	frac := uint32(imm8&0xf) << 19
+
+	return sign<<31 | exp<<23 | frac
+}
+
+/* Check that floating-point instructions may execute, returning false if
+ * the instruction must not, as when CPACR denies access. A deferred save
+ * of the previous context is completed first. With FPCCR.ASPEN, the first
+ * floating-point instruction of a context creates its floating-point
+ * state, marked by CONTROL.FPCA, starting from the FPSCR defaults in
+ * FPDSCR.
+ * ARMv7-M ARM pseudocode ExecuteFPCheck() */
+func ExecuteFPCheck(cpu *CPU) bool {
+	if !CheckVFPEnabled(cpu) || !cpu.PreserveFPState() {
+		return false
+	}
+
+	if cpu.Scb.Fpccr&FPCCR_ASPEN != 0 && !cpu.Control.Fpca {
+		cpu.Fpscr.SetUint32(masked(cpu.Fpscr.Uint32(), cpu.Scb.Fpdscr, FPDSCR_MASK))
+		cpu.Control.Fpca = true
+	}
+
+	return true
+}
+
+/* Perform VCMP and VCMPE, setting the FPSCR flags */
+func FloatingPointCompare(cpu *CPU, op1 uint32, op2 uint32, quiet_nan_exc bool) {
+	n, z, c, v := FPCompare(op1, op2, quiet_nan_exc, &cpu.Fpscr)
+
+	cpu.Fpscr.N = n
+	cpu.Fpscr.Z = z
+	cpu.Fpscr.C = c
+	cpu.Fpscr.V = v
+}
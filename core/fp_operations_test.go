@@ -0,0 +1,198 @@
+package core
+
+import "testing"
+
+func TestFPRounding(t *testing.T) {
+	cases := []struct {
+		name      string
+		op        func(fpscr *Fpscr) uint32
+		fpscr     Fpscr
+		result    uint32
+		exception FPException
+	}{
+		/* 1 + 2^-24 is a tie, 1 + 3*2^-24 lies above one */
+		{"1 + 2^-24 RN", func(f *Fpscr) uint32 { return FPAdd(0x3f800000, 0x33800000, f) }, Fpscr{RMode: FPROUND_RN}, 0x3f800000, FPEXC_IXC},
+		{"1 + 2^-24 RP", func(f *Fpscr) uint32 { return FPAdd(0x3f800000, 0x33800000, f) }, Fpscr{RMode: FPROUND_RP}, 0x3f800001, FPEXC_IXC},
+		{"1 + 3*2^-24 RN", func(f *Fpscr) uint32 { return FPAdd(0x3f800000, 0x34400000, f) }, Fpscr{RMode: FPROUND_RN}, 0x3f800002, FPEXC_IXC},
+		{"-1 - 2^-24 RM", func(f *Fpscr) uint32 { return FPSub(0xbf800000, 0x33800000, f) }, Fpscr{RMode: FPROUND_RM}, 0xbf800001, FPEXC_IXC},
+		{"-1 - 2^-24 RZ", func(f *Fpscr) uint32 { return FPSub(0xbf800000, 0x33800000, f) }, Fpscr{RMode: FPROUND_RZ}, 0xbf800000, FPEXC_IXC},
+		{"1 - 1 RN", func(f *Fpscr) uint32 { return FPSub(0x3f800000, 0x3f800000, f) }, Fpscr{RMode: FPROUND_RN}, 0x00000000, 0},
+		{"1 - 1 RM", func(f *Fpscr) uint32 { return FPSub(0x3f800000, 0x3f800000, f) }, Fpscr{RMode: FPROUND_RM}, 0x80000000, 0},
+		{"max * 2 RN", func(f *Fpscr) uint32 { return FPMul(0x7f7fffff, 0x40000000, f) }, Fpscr{RMode: FPROUND_RN}, 0x7f800000, FPEXC_OFC | FPEXC_IXC},
+		{"max * 2 RZ", func(f *Fpscr) uint32 { return FPMul(0x7f7fffff, 0x40000000, f) }, Fpscr{RMode: FPROUND_RZ}, 0x7f7fffff, FPEXC_OFC | FPEXC_IXC},
+		{"-max * 2 RP", func(f *Fpscr) uint32 { return FPMul(0xff7fffff, 0x40000000, f) }, Fpscr{RMode: FPROUND_RP}, 0xff7fffff, FPEXC_OFC | FPEXC_IXC},
+		{"min normal / 2", func(f *Fpscr) uint32 { return FPDiv(0x00800000, 0x40000000, f) }, Fpscr{}, 0x00400000, 0},
+		{"min normal / 3", func(f *Fpscr) uint32 { return FPDiv(0x00800000, 0x40400000, f) }, Fpscr{}, 0x002aaaab, FPEXC_UFC | FPEXC_IXC},
+		{"min normal / 2 FZ", func(f *Fpscr) uint32 { return FPDiv(0x00800000, 0x40000000, f) }, Fpscr{FZ: true}, 0x00000000, FPEXC_UFC},
+		{"denormal + 1 FZ", func(f *Fpscr) uint32 { return FPAdd(0x00000001, 0x3f800000, f) }, Fpscr{FZ: true}, 0x3f800000, FPEXC_IDC},
+		{"sqrt 2", func(f *Fpscr) uint32 { return FPSqrt(0x40000000, f) }, Fpscr{}, 0x3fb504f3, FPEXC_IXC},
+		{"sqrt -0", func(f *Fpscr) uint32 { return FPSqrt(0x80000000, f) }, Fpscr{}, 0x80000000, 0},
+		{"fused 1 + 3 * (1/3)", func(f *Fpscr) uint32 { return FPMulAdd(0xbf800000, 0x40400000, 0x3eaaaaab, f) }, Fpscr{}, 0x33000000, 0},
+	}
+
+	for _, test := range cases {
+		fpscr := test.fpscr
+		if result := test.op(&fpscr); result != test.result {
+			t.Errorf("%s: %#.8x, expected %#.8x", test.name, result, test.result)
+		}
+		if fpscr.Exc != test.exception {
+			t.Errorf("%s: exceptions %#x, expected %#x", test.name, fpscr.Exc, test.exception)
+		}
+	}
+}
+
+func TestFPNaNs(t *testing.T) {
+	cases := []struct {
+		name      string
+		op        func(fpscr *Fpscr) uint32
+		fpscr     Fpscr
+		result    uint32
+		exception FPException
+	}{
+		{"qnan + 1", func(f *Fpscr) uint32 { return FPAdd(0x7fc00123, 0x3f800000, f) }, Fpscr{}, 0x7fc00123, 0},
+		{"1 + snan", func(f *Fpscr) uint32 { return FPAdd(0x3f800000, 0xff800123, f) }, Fpscr{}, 0xffc00123, FPEXC_IOC},
+		{"qnan + snan", func(f *Fpscr) uint32 { return FPAdd(0x7fc00001, 0x7f800002, f) }, Fpscr{}, 0x7fc00002, FPEXC_IOC},
+		{"qnan + 1 DN", func(f *Fpscr) uint32 { return FPAdd(0x7fc00123, 0x3f800000, f) }, Fpscr{DN: true}, 0x7fc00000, 0},
+		{"inf - inf", func(f *Fpscr) uint32 { return FPSub(0x7f800000, 0x7f800000, f) }, Fpscr{}, 0x7fc00000, FPEXC_IOC},
+		{"0 * inf", func(f *Fpscr) uint32 { return FPMul(0x80000000, 0x7f800000, f) }, Fpscr{}, 0x7fc00000, FPEXC_IOC},
+		{"0 / 0", func(f *Fpscr) uint32 { return FPDiv(0, 0, f) }, Fpscr{}, 0x7fc00000, FPEXC_IOC},
+		{"qnan + 0 * inf", func(f *Fpscr) uint32 { return FPMulAdd(0x7fc00123, 0, 0x7f800000, f) }, Fpscr{}, 0x7fc00000, FPEXC_IOC},
+	}
+
+	for _, test := range cases {
+		fpscr := test.fpscr
+		if result := test.op(&fpscr); result != test.result {
+			t.Errorf("%s: %#.8x, expected %#.8x", test.name, result, test.result)
+		}
+		if fpscr.Exc != test.exception {
+			t.Errorf("%s: exceptions %#x, expected %#x", test.name, fpscr.Exc, test.exception)
+		}
+	}
+}
+
+func TestFPHalfPrecision(t *testing.T) {
+	cases := []struct {
+		single    uint32
+		half      uint16
+		fpscr     Fpscr
+		exception FPException
+	}{
+		{single: 0x3fc00000, half: 0x3e00},                                                // 1.5
+		{single: 0x477fe000, half: 0x7bff},                                                // 65504
+		{single: 0x47800000, half: 0x7c00, fpscr: Fpscr{AHP: true}},                       // 65536, alternative
+		{single: 0x47800000, half: 0x7c00, exception: FPEXC_OFC | FPEXC_IXC},              // 65536
+		{single: 0x48000000, half: 0x7fff, fpscr: Fpscr{AHP: true}, exception: FPEXC_IOC}, // 131072, alternative
+		{single: 0x33800000, half: 0x0001},                                                // 2^-24, denormal
+		{single: 0x7f800001, half: 0x7e00, exception: FPEXC_IOC},                          // SNaN
+		{single: 0x7fc00000, half: 0x0000, fpscr: Fpscr{AHP: true}, exception: FPEXC_IOC}, // NaN, alternative
+	}
+
+	for _, test := range cases {
+		fpscr := test.fpscr
+		if half := FPSingleToHalf(test.single, &fpscr); half != test.half {
+			t.Errorf("%#.8x: %#.4x, expected %#.4x", test.single, half, test.half)
+		}
+		if fpscr.Exc != test.exception {
+			t.Errorf("%#.8x: exceptions %#x, expected %#x", test.single, fpscr.Exc, test.exception)
+		}
+	}
+
+	/* Alternative half-precision has no infinities or NaNs */
+	fpscr := Fpscr{AHP: true}
+	if single := FPHalfToSingle(0x7c00, &fpscr); single != 0x47800000 {
+		t.Errorf("0x7c00 alternative: %#.8x, expected %#.8x", single, 0x47800000)
+	}
+
+	fpscr = Fpscr{}
+	if single := FPHalfToSingle(0xfc00, &fpscr); single != 0xff800000 {
+		t.Errorf("0xfc00: %#.8x, expected %#.8x", single, 0xff800000)
+	}
+}
+
+func TestFPCompare(t *testing.T) {
+	cases := []struct {
+		op1, op2   uint32
+		quiet      bool
+		n, z, c, v bool
+		exception  FPException
+	}{
+		{op1: 0x3f800000, op2: 0x40000000, n: true},          // 1 < 2
+		{op1: 0x40000000, op2: 0x3f800000, c: true},          // 2 > 1
+		{op1: 0xff800000, op2: 0xff800000, z: true, c: true}, // -inf == -inf
+		{op1: 0x7fc00000, op2: 0, c: true, v: true},          // QNaN
+		{op1: 0x7fc00000, op2: 0, quiet: true, c: true, v: true, exception: FPEXC_IOC},
+		{op1: 0x7f800001, op2: 0, c: true, v: true, exception: FPEXC_IOC}, // SNaN
+	}
+
+	for _, test := range cases {
+		var fpscr Fpscr
+		n, z, c, v := FPCompare(test.op1, test.op2, test.quiet, &fpscr)
+		if n != test.n || z != test.z || c != test.c || v != test.v {
+			t.Errorf("%#.8x, %#.8x: nzcv %v %v %v %v", test.op1, test.op2, n, z, c, v)
+		}
+		if fpscr.Exc != test.exception {
+			t.Errorf("%#.8x, %#.8x: exceptions %#x, expected %#x", test.op1, test.op2, fpscr.Exc, test.exception)
+		}
+	}
+}
+
+func TestFPToFixed(t *testing.T) {
+	cases := []struct {
+		op            uint32
+		fraction_bits uint8
+		unsigned      bool
+		result        uint32
+		exception     FPException
+	}{
+		{op: 0x3fc00000, fraction_bits: 0, result: 1, exception: FPEXC_IXC},                 // 1.5
+		{op: 0x3fc00000, fraction_bits: 1, result: 3},                                       // 1.5
+		{op: 0x4f000000, fraction_bits: 0, result: 0x7fffffff, exception: FPEXC_IOC},        // 2^31
+		{op: 0x4f000000, fraction_bits: 0, unsigned: true, result: 0x80000000},              // 2^31
+		{op: 0xcf000000, fraction_bits: 0, result: 0x80000000},                              // -2^31
+		{op: 0x7fc00000, fraction_bits: 0, result: 0, exception: FPEXC_IOC},                 // NaN
+		{op: 0xff800000, fraction_bits: 0, unsigned: true, result: 0, exception: FPEXC_IOC}, // -inf
+	}
+
+	for _, test := range cases {
+		var fpscr Fpscr
+		if result := FPToFixed(test.op, 32, test.fraction_bits, test.unsigned, true, &fpscr); result != test.result {
+			t.Errorf("%#.8x: %#.8x, expected %#.8x", test.op, result, test.result)
+		}
+		if fpscr.Exc != test.exception {
+			t.Errorf("%#.8x: exceptions %#x, expected %#x", test.op, fpscr.Exc, test.exception)
+		}
+	}
+
+	var fpscr Fpscr
+	if result := FixedToFP(0x01000001, 32, 0, false, false, &fpscr); result != 0x4b800000 || fpscr.Exc != FPEXC_IXC {
+		t.Errorf("2^24 + 1: %#.8x, exceptions %#x", result, fpscr.Exc)
+	}
+}
+
+func TestVFPExpandImm(t *testing.T) {
+	cases := []struct {
+		imm8     uint8
+		expected uint32
+	}{
+		{imm8: 0x70, expected: 0x3f800000}, // 1.0
+		{imm8: 0x00, expected: 0x40000000}, // 2.0
+		{imm8: 0xc0, expected: 0xbe000000}, // -0.125
+		{imm8: 0x7f, expected: 0x3ff80000}, // 1.9375
+	}
+
+	for _, test := range cases {
+		if actual := VFPExpandImm(test.imm8); actual != test.expected {
+			t.Errorf("%#x: %#.8x, expected %#.8x", test.imm8, actual, test.expected)
+		}
+	}
+}
+
+func TestFpscr(t *testing.T) {
+	var fpscr Fpscr
+
+	/* Reserved bits read as zero */
+	fpscr.SetUint32(0xffffffff)
+	if value := fpscr.Uint32(); value != 0xf7c0009f {
+		t.Errorf("FPSCR = %#.8x, expected %#.8x", value, 0xf7c0009f)
+	}
+}
@@ -0,0 +1,147 @@
+package core
+
+import (
+	"bytes"
+	"fmt"
+)
+
+/* Floating-point extension registers, S0-S31, overlapping the doubleword
+ * registers D0-D15
+ * ARMv7-M ARM A2.5.1 */
+type FPRegs [32]uint32
+type SRegIndex uint8
+type DRegIndex uint8
+
+/* Floating-point rounding modes, FPSCR.RMode
+ * ARMv7-M ARM A2.5.3 */
+type RoundingMode uint8
+
+const (
+	FPROUND_RN RoundingMode = iota // Round to Nearest
+	FPROUND_RP                     // Round towards Plus Infinity
+	FPROUND_RM                     // Round towards Minus Infinity
+	FPROUND_RZ                     // Round towards Zero
+)
+
+/* Floating-point exceptions, each the bit of its FPSCR cumulative flag
+ * ARMv7-M ARM A2.5.4 */
+type FPException uint8
+
+const (
+	FPEXC_IOC FPException = 1 << 0 // Invalid Operation
+	FPEXC_DZC FPException = 1 << 1 // Division by Zero
+	FPEXC_OFC FPException = 1 << 2 // Overflow
+	FPEXC_UFC FPException = 1 << 3 // Underflow
+	FPEXC_IXC FPException = 1 << 4 // Inexact
+	FPEXC_IDC FPException = 1 << 7 // Input Denormal
+
+	FPEXC_MASK = FPEXC_IOC | FPEXC_DZC | FPEXC_OFC | FPEXC_UFC | FPEXC_IXC | FPEXC_IDC
+)
+
+/* Floating-point Status and Control Register
+ * ARMv7-M ARM A2.5.3 */
+type Fpscr struct {
+	N     bool         // Negative
+	Z     bool         // Zero
+	C     bool         // Carry
+	V     bool         // Overflow
+	AHP   bool         // Alternative half-precision
+	DN    bool         // Default NaN
+	FZ    bool         // Flush-to-zero
+	RMode RoundingMode // Rounding mode
+	Exc   FPException  // Cumulative exception flags
+}
+
+/* Bit positions of FPSCR */
+const (
+	FPSCR_N     = 1 << 31
+	FPSCR_Z     = 1 << 30
+	FPSCR_C     = 1 << 29
+	FPSCR_V     = 1 << 28
+	FPSCR_AHP   = 1 << 26
+	FPSCR_DN    = 1 << 25
+	FPSCR_FZ    = 1 << 24
+	FPSCR_RMODE = 0x3 << 22
+
+	FPSCR_NZCV_MASK = FPSCR_N | FPSCR_Z | FPSCR_C | FPSCR_V
+)
+
+func (fpscr Fpscr) Uint32() uint32 {
+	var value uint32
+
+	value |= uint32(booltou(fpscr.N)) << 31
+	value |= uint32(booltou(fpscr.Z)) << 30
+	value |= uint32(booltou(fpscr.C)) << 29
+	value |= uint32(booltou(fpscr.V)) << 28
+	value |= uint32(booltou(fpscr.AHP)) << 26
+	value |= uint32(booltou(fpscr.DN)) << 25
+	value |= uint32(booltou(fpscr.FZ)) << 24
+	value |= uint32(fpscr.RMode&0x3) << 22
+	value |= uint32(fpscr.Exc & FPEXC_MASK)
+
+	return value
+}
+
+/* Set every field of FPSCR from value, ignoring the reserved bits */
+func (fpscr *Fpscr) SetUint32(value uint32) {
+	fpscr.N = value&FPSCR_N != 0
+	fpscr.Z = value&FPSCR_Z != 0
+	fpscr.C = value&FPSCR_C != 0
+	fpscr.V = value&FPSCR_V != 0
+	fpscr.AHP = value&FPSCR_AHP != 0
+	fpscr.DN = value&FPSCR_DN != 0
+	fpscr.FZ = value&FPSCR_FZ != 0
+	fpscr.RMode = RoundingMode((value & FPSCR_RMODE) >> 22)
+	fpscr.Exc = FPException(value) & FPEXC_MASK
+}
+
+func (regs Registers) S(i SRegIndex) uint32 {
+	return regs.s[i]
+}
+
+func (regs *Registers) SetS(i SRegIndex, value uint32) {
+	regs.s[i] = value
+}
+
+/* Dn is S(2n+1):S(2n) */
+func (regs Registers) D(i DRegIndex) uint64 {
+	return uint64(regs.s[2*i+1])<<32 | uint64(regs.s[2*i])
+}
+
+func (regs *Registers) SetD(i DRegIndex, value uint64) {
+	regs.s[2*i] = uint32(value)
+	regs.s[2*i+1] = uint32(value >> 32)
+}
+
+/* Only D0-D15 exist, so a doubleword register encoding with its top bit,
+ * at bit, set is UNDEFINED */
+func bad_double_register(raw_instr uint32, bit uint) bool {
+	return (raw_instr>>bit)&0x1 != 0
+}
+
+func (regs Registers) FPPretty() string {
+	var b bytes.Buffer
+
+	for i := SRegIndex(0); i < 32; i++ {
+		if i != 0 {
+			if (i % 4) == 0 {
+				fmt.Fprintf(&b, "\n")
+			} else {
+				fmt.Fprintf(&b, "\t")
+			}
+		}
+		fmt.Fprintf(&b, "S%-2d = %#.8x", i, regs.S(i))
+	}
+
+	fmt.Fprintf(&b, "\nFPSCR = %#.8x\n", regs.Fpscr.Uint32())
+
+	return b.String()
+}
+
+func (i SRegIndex) String() string {
+	return fmt.Sprintf("s%d", i)
+}
+
+func (i DRegIndex) String() string {
+	return fmt.Sprintf("d%d", i)
+}
@@ -13,3 +13,16 @@ func utobool(i uint8) bool {
 	}
 	return false
 }
+
+/* Round x down to a multiple of y
+ * ARM ARM pseudocode Align() */
+func Align(x uint32, y uint32) uint32 {
+	return y * (x / y)
+}
+
+/* Sign extend the bottom bits of x
+ * ARM ARM pseudocode SignExtend() */
+func SignExtend(x uint32, bits uint8) uint32 {
+	shift := 32 - bits
+	return uint32(int32(x<<shift) >> shift)
+}
@@ -0,0 +1,437 @@
+package core
+
+import "fmt"
+
+/* NOP
+ * ARM ARM A7.7.87
+ * Encoding T1 */
+type NopT1 struct{}
+
+func Nop16T1(instr FetchedInstr) DecodedInstr {
+	return NopT1{}
+}
+
+func (instr NopT1) Execute(cpu *CPU) {}
+
+func (instr NopT1) String() string {
+	return "nop"
+}
+
+/* NOP
+ * ARM ARM A7.7.87
+ * Encoding T2 */
+type NopT2 struct{}
+
+func Nop32T2(instr FetchedInstr) DecodedInstr {
+	return NopT2{}
+}
+
+func (instr NopT2) Execute(cpu *CPU) {}
+
+func (instr NopT2) String() string {
+	return "nop.w"
+}
+
+/* YIELD
+ * ARM ARM A7.7.263
+ * Encoding T1 */
+type YieldT1 struct{}
+
+func Yield16T1(instr FetchedInstr) DecodedInstr {
+	return YieldT1{}
+}
+
+/* With one thread of execution there is nothing to yield to */
+func (instr YieldT1) Execute(cpu *CPU) {}
+
+func (instr YieldT1) String() string {
+	return "yield"
+}
+
+/* YIELD
+ * ARM ARM A7.7.263
+ * Encoding T2 */
+type YieldT2 struct{}
+
+func Yield32T2(instr FetchedInstr) DecodedInstr {
+	return YieldT2{}
+}
+
+func (instr YieldT2) Execute(cpu *CPU) {}
+
+func (instr YieldT2) String() string {
+	return "yield.w"
+}
+
+/* WFE
+ * ARM ARM A7.7.261
+ * Encoding T1 */
+type WfeT1 struct{}
+
+func Wfe16T1(instr FetchedInstr) DecodedInstr {
+	return WfeT1{}
+}
+
+func (instr WfeT1) Execute(cpu *CPU) {
+	cpu.WaitForEvent()
+}
+
+func (instr WfeT1) String() string {
+	return "wfe"
+}
+
+/* WFE
+ * ARM ARM A7.7.261
+ * Encoding T2 */
+type WfeT2 struct{}
+
+func Wfe32T2(instr FetchedInstr) DecodedInstr {
+	return WfeT2{}
+}
+
+func (instr WfeT2) Execute(cpu *CPU) {
+	cpu.WaitForEvent()
+}
+
+func (instr WfeT2) String() string {
+	return "wfe.w"
+}
+
+/* WFI
+ * ARM ARM A7.7.262
+ * Encoding T1 */
+type WfiT1 struct{}
+
+func Wfi16T1(instr FetchedInstr) DecodedInstr {
+	return WfiT1{}
+}
+
+func (instr WfiT1) Execute(cpu *CPU) {
+	cpu.WaitForInterrupt()
+}
+
+func (instr WfiT1) String() string {
+	return "wfi"
+}
+
+/* WFI
+ * ARM ARM A7.7.262
+ * Encoding T2 */
+type WfiT2 struct{}
+
+func Wfi32T2(instr FetchedInstr) DecodedInstr {
+	return WfiT2{}
+}
+
+func (instr WfiT2) Execute(cpu *CPU) {
+	cpu.WaitForInterrupt()
+}
+
+func (instr WfiT2) String() string {
+	return "wfi.w"
+}
+
+/* SEV
+ * ARM ARM A7.7.127
+ * Encoding T1 */
+type SevT1 struct{}
+
+func Sev16T1(instr FetchedInstr) DecodedInstr {
+	return SevT1{}
+}
+
+func (instr SevT1) Execute(cpu *CPU) {
+	cpu.SendEvent()
+}
+
+func (instr SevT1) String() string {
+	return "sev"
+}
+
+/* SEV
+ * ARM ARM A7.7.127
+ * Encoding T2 */
+type SevT2 struct{}
+
+func Sev32T2(instr FetchedInstr) DecodedInstr {
+	return SevT2{}
+}
+
+func (instr SevT2) Execute(cpu *CPU) {
+	cpu.SendEvent()
+}
+
+func (instr SevT2) String() string {
+	return "sev.w"
+}
+
+/* DBG
+ * ARM ARM A7.7.29
+ * Encoding T1 */
+type DbgT1 struct {
+	Option uint8
+}
+
+func Dbg32T1(instr FetchedInstr) DecodedInstr {
+	return DbgT1{Option: uint8(instr.Uint32() & 0xf)}
+}
+
+/* No debug system is implemented, so the hint has no effect */
+func (instr DbgT1) Execute(cpu *CPU) {}
+
+func (instr DbgT1) String() string {
+	return fmt.Sprintf("dbg #%d", instr.Option)
+}
+
+/* Memory barrier instructions, reported to the host through CPU.Barrier */
+type Barrier uint8
+
+const (
+	BARRIER_DMB Barrier = iota
+	BARRIER_DSB
+	BARRIER_ISB
+)
+
+/* Fields of DMB, DSB and ISB. Option selects the shareability domain
+ * and access types ordered; only SY (0xf) is defined for ARMv7-M. */
+type BarrierFields struct {
+	Option uint8
+}
+
+var barrier_option_names = map[uint8]string{
+	0x2: "oshst",
+	0x3: "osh",
+	0x6: "nshst",
+	0x7: "nsh",
+	0xa: "ishst",
+	0xb: "ish",
+	0xe: "st",
+	0xf: "sy",
+}
+
+func barrier_option(option uint8) string {
+	if name, ok := barrier_option_names[option]; ok {
+		return name
+	}
+
+	return fmt.Sprintf("#%#x", option)
+}
+
+/* The emulator executes in order, so a barrier has nothing to wait for.
+ * The host is told, so it can discard any cached translations. */
+func MemoryBarrier(cpu *CPU, barrier Barrier) {
+	if cpu.Barrier != nil {
+		cpu.Barrier(barrier)
+	}
+}
+
+/* DMB
+ * ARM ARM A7.7.32
+ * Encoding T1 */
+type DmbT1 BarrierFields
+
+func Dmb32T1(instr FetchedInstr) DecodedInstr {
+	return DmbT1{Option: uint8(instr.Uint32() & 0xf)}
+}
+
+func (instr DmbT1) Execute(cpu *CPU) {
+	MemoryBarrier(cpu, BARRIER_DMB)
+}
+
+func (instr DmbT1) String() string {
+	return fmt.Sprintf("dmb %s", barrier_option(instr.Option))
+}
+
+/* DSB
+ * ARM ARM A7.7.33
+ * Encoding T1 */
+type DsbT1 BarrierFields
+
+func Dsb32T1(instr FetchedInstr) DecodedInstr {
+	return DsbT1{Option: uint8(instr.Uint32() & 0xf)}
+}
+
+func (instr DsbT1) Execute(cpu *CPU) {
+	MemoryBarrier(cpu, BARRIER_DSB)
+}
+
+func (instr DsbT1) String() string {
+	return fmt.Sprintf("dsb %s", barrier_option(instr.Option))
+}
+
+/* ISB
+ * ARM ARM A7.7.36
+ * Encoding T1 */
+type IsbT1 BarrierFields
+
+func Isb32T1(instr FetchedInstr) DecodedInstr {
+	return IsbT1{Option: uint8(instr.Uint32() & 0xf)}
+}
+
+func (instr IsbT1) Execute(cpu *CPU) {
+	MemoryBarrier(cpu, BARRIER_ISB)
+}
+
+func (instr IsbT1) String() string {
+	return fmt.Sprintf("isb %s", barrier_option(instr.Option))
+}
+
+/* PLD (immediate)
+ * ARM ARM A7.7.93
+ * Encoding T1 */
+type PldImmT1 LoadStoreFields
+
+func PldImm32T1(instr FetchedInstr) DecodedInstr {
+	fields := decode_ldst_imm12(instr.Uint32())
+	fields.Rt = 0
+
+	return PldImmT1(fields)
+}
+
+/* Preloads have no architecturally visible effect */
+func (instr PldImmT1) Execute(cpu *CPU) {}
+
+func (instr PldImmT1) String() string {
+	return fmt.Sprintf("pld %s", imm_address(LoadStoreFields(instr)))
+}
+
+/* PLD (immediate)
+ * ARM ARM A7.7.93
+ * Encoding T2 */
+type PldImmT2 LoadStoreFields
+
+func PldImm32T2(instr FetchedInstr) DecodedInstr {
+	fields := decode_ldst_imm8(instr.Uint32())
+	fields.Rt = 0
+
+	return PldImmT2(fields)
+}
+
+func (instr PldImmT2) Execute(cpu *CPU) {}
+
+func (instr PldImmT2) String() string {
+	return fmt.Sprintf("pld %s", imm_address(LoadStoreFields(instr)))
+}
+
+/* PLD (literal)
+ * ARM ARM A7.7.94
+ * Encoding T1 */
+type PldLitT1 LoadStoreFields
+
+func PldLit32T1(instr FetchedInstr) DecodedInstr {
+	fields := decode_ldst_literal(instr.Uint32())
+	fields.Rt = 0
+
+	return PldLitT1(fields)
+}
+
+func (instr PldLitT1) Execute(cpu *CPU) {}
+
+func (instr PldLitT1) String() string {
+	return fmt.Sprintf("pld %s", imm_address(LoadStoreFields(instr)))
+}
+
+/* PLD (register)
+ * ARM ARM A7.7.95
+ * Encoding T1 */
+type PldRegT1 LoadStoreFields
+
+func PldReg32T1(instr FetchedInstr) DecodedInstr {
+	fields := decode_ldst_reg32(instr.Uint32())
+	fields.Rt = 0
+
+	if BadReg(fields.Rm) {
+		return UnpredictableInstr{}
+	}
+
+	return PldRegT1(fields)
+}
+
+func (instr PldRegT1) Execute(cpu *CPU) {}
+
+func (instr PldRegT1) String() string {
+	return fmt.Sprintf("pld %s", reg_address(LoadStoreFields(instr)))
+}
+
+/* PLI (immediate, literal)
+ * ARM ARM A7.7.96
+ * Encoding T1 */
+type PliImmT1 LoadStoreFields
+
+func PliImm32T1(instr FetchedInstr) DecodedInstr {
+	fields := decode_ldst_imm12(instr.Uint32())
+	fields.Rt = 0
+
+	return PliImmT1(fields)
+}
+
+func (instr PliImmT1) Execute(cpu *CPU) {}
+
+func (instr PliImmT1) String() string {
+	return fmt.Sprintf("pli %s", imm_address(LoadStoreFields(instr)))
+}
+
+/* PLI (immediate, literal)
+ * ARM ARM A7.7.96
+ * Encoding T2 */
+type PliImmT2 LoadStoreFields
+
+func PliImm32T2(instr FetchedInstr) DecodedInstr {
+	fields := decode_ldst_imm8(instr.Uint32())
+	fields.Rt = 0
+
+	return PliImmT2(fields)
+}
+
+func (instr PliImmT2) Execute(cpu *CPU) {}
+
+func (instr PliImmT2) String() string {
+	return fmt.Sprintf("pli %s", imm_address(LoadStoreFields(instr)))
+}
+
+/* PLI (immediate, literal)
+ * ARM ARM A7.7.96
+ * Encoding T3 */
+type PliLitT3 LoadStoreFields
+
+func PliLit32T3(instr FetchedInstr) DecodedInstr {
+	fields := decode_ldst_literal(instr.Uint32())
+	fields.Rt = 0
+
+	return PliLitT3(fields)
+}
+
+func (instr PliLitT3) Execute(cpu *CPU) {}
+
+func (instr PliLitT3) String() string {
+	return fmt.Sprintf("pli %s", imm_address(LoadStoreFields(instr)))
+}
+
+/* PLI (register)
+ * ARM ARM A7.7.97
+ * Encoding T1 */
+type PliRegT1 LoadStoreFields
+
+func PliReg32T1(instr FetchedInstr) DecodedInstr {
+	fields := decode_ldst_reg32(instr.Uint32())
+	fields.Rt = 0
+
+	if BadReg(fields.Rm) {
+		return UnpredictableInstr{}
+	}
+
+	return PliRegT1(fields)
+}
+
+func (instr PliRegT1) Execute(cpu *CPU) {}
+
+func (instr PliRegT1) String() string {
+	return fmt.Sprintf("pli %s", reg_address(LoadStoreFields(instr)))
+}
+
+/* Unallocated memory hints, the LDRH and LDRSH encodings with Rt == PC,
+ * execute as NOP
+ * ARM ARM A5.3.10 */
+func memory_hint(instr FetchedInstr) DecodedInstr {
+	return NopT2{}
+}
@@ -0,0 +1,168 @@
+package core
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestIdentifyHints(t *testing.T) {
+	cases := []IdentifyCase{
+		{instr: FetchedInstr16(0xbf30), instr_valid: true},  // wfi
+		{instr: FetchedInstr16(0xbf20), instr_valid: false}, // wfe
+		{instr: FetchedInstr16(0xbf38), instr_valid: false}, // itt cc
+	}
+
+	test_identify(t, cases, reflect.TypeOf(WfiT1{}))
+
+	cases = []IdentifyCase{
+		{instr: FetchedInstr16(0xbf00), instr_valid: true},      // nop
+		{instr: FetchedInstr16(0xbf08), instr_valid: false},     // it eq
+		{instr: FetchedInstr32(0xf3af8000), instr_valid: false}, // nop.w
+		{instr: FetchedInstr16(0xbfa0), instr_valid: true},      // unallocated hint
+	}
+
+	test_identify(t, cases, reflect.TypeOf(NopT1{}))
+
+	cases = []IdentifyCase{
+		{instr: FetchedInstr32(0xf3af8000), instr_valid: true},  // nop.w
+		{instr: FetchedInstr32(0xf3af8014), instr_valid: true},  // unallocated hint
+		{instr: FetchedInstr32(0xf3af8004), instr_valid: false}, // sev.w
+		{instr: FetchedInstr32(0xf3af80f5), instr_valid: false}, // dbg #5
+	}
+
+	test_identify(t, cases, reflect.TypeOf(NopT2{}))
+
+	cases = []IdentifyCase{
+		{instr: FetchedInstr32(0xf3af8002), instr_valid: true},  // wfe.w
+		{instr: FetchedInstr32(0xf3af8003), instr_valid: false}, // wfi.w
+	}
+
+	test_identify(t, cases, reflect.TypeOf(WfeT2{}))
+}
+
+func TestIdentifyBarriers(t *testing.T) {
+	cases := []IdentifyCase{
+		{instr: FetchedInstr32(0xf3bf8f5f), instr_valid: true},  // dmb sy
+		{instr: FetchedInstr32(0xf3bf8f5b), instr_valid: true},  // dmb ish
+		{instr: FetchedInstr32(0xf3bf8f4f), instr_valid: false}, // dsb sy
+		{instr: FetchedInstr32(0xf3bf8f2f), instr_valid: false}, // clrex
+	}
+
+	test_identify(t, cases, reflect.TypeOf(DmbT1{}))
+}
+
+func TestDecodeMemoryHints(t *testing.T) {
+	cases := []DecodeCase{
+		// pld [r0, #4]
+		{instr: FetchedInstr32(0xf890f004), decoded: PldImmT1{Rn: 0, Imm: 4, Index: true, Add: true}},
+		// pld [pc, #8]
+		{instr: FetchedInstr32(0xf89ff008), decoded: PldLitT1{Rn: PC, Imm: 8, Index: true, Add: true}},
+	}
+
+	test_decode(t, cases, LdrbImm32T2)
+
+	cases = []DecodeCase{
+		// pld [r0, #-4]
+		{instr: FetchedInstr32(0xf810fc04), decoded: PldImmT2{Rn: 0, Imm: 4, Index: true, Add: false}},
+		// ldrb pc, [r0, #4]!
+		{instr: FetchedInstr32(0xf810ff04), decoded: UnpredictableInstr{}},
+	}
+
+	test_decode(t, cases, LdrbImm32T3)
+
+	cases = []DecodeCase{
+		// pld [r0, r1, lsl #2]
+		{instr: FetchedInstr32(0xf810f021), decoded: PldRegT1{Rn: 0, Rm: 1,
+			Shift: Shift{srtype: SRType_LSL, amount: 2}, Index: true, Add: true}},
+		// pld [r0, sp]
+		{instr: FetchedInstr32(0xf810f00d), decoded: UnpredictableInstr{}},
+	}
+
+	test_decode(t, cases, LdrbReg32T2)
+
+	cases = []DecodeCase{
+		// pli [r0, #-4]
+		{instr: FetchedInstr32(0xf910fc04), decoded: PliImmT2{Rn: 0, Imm: 4, Index: true, Add: false}},
+	}
+
+	test_decode(t, cases, LdrsbImm32T2)
+
+	cases = []DecodeCase{
+		// pli [pc, #-8]
+		{instr: FetchedInstr32(0xf91ff008), decoded: PliLitT3{Rn: PC, Imm: 8, Index: true, Add: false}},
+	}
+
+	test_decode(t, cases, LdrsbLit32T1)
+}
+
+func TestHintString(t *testing.T) {
+	cases := []struct {
+		instr    FetchedInstr
+		expected string
+	}{
+		{instr: FetchedInstr16(0xbf10), expected: "yield"},
+		{instr: FetchedInstr32(0xf3af8004), expected: "sev.w"},
+		{instr: FetchedInstr32(0xf3af80f5), expected: "dbg #5"},
+		{instr: FetchedInstr32(0xf3bf8f5a), expected: "dmb ishst"},
+		{instr: FetchedInstr32(0xf3bf8f6f), expected: "isb sy"},
+		{instr: FetchedInstr32(0xf3bf8f40), expected: "dsb #0x0"},
+		{instr: FetchedInstr32(0xf910f001), expected: "pli [r0, r1]"},
+		{instr: FetchedInstr32(0xf81ff008), expected: "pld [pc, #-8]"},
+	}
+
+	for _, test := range cases {
+		decoded, err := test.instr.Decode()
+		if err != nil {
+			t.Errorf("%v: %v", test.instr, err)
+		} else if actual := Disassemble(decoded, 0); actual != test.expected {
+			t.Errorf("%v: %q, expected %q", test.instr, actual, test.expected)
+		}
+	}
+}
+
+func TestExecuteWaitForEvent(t *testing.T) {
+	cpu := CPU{}
+
+	/* SEV sets the event register, consumed by the next WFE */
+	SevT1{}.Execute(&cpu)
+	WfeT1{}.Execute(&cpu)
+	if cpu.Sleep != AWAKE || cpu.Event {
+		t.Errorf("wfe after sev: sleep = %v, event = %v", cpu.Sleep, cpu.Event)
+	}
+
+	WfeT2{}.Execute(&cpu)
+	if cpu.Sleep != SLEEP_WFE {
+		t.Errorf("wfe: sleep = %v, expected %v", cpu.Sleep, SLEEP_WFE)
+	}
+
+	cpu.SendEvent()
+	if cpu.Sleep != AWAKE || cpu.Event {
+		t.Errorf("event: sleep = %v, event = %v", cpu.Sleep, cpu.Event)
+	}
+
+	/* Events don't wake WFI, but are remembered */
+	WfiT1{}.Execute(&cpu)
+	cpu.SendEvent()
+	if cpu.Sleep != SLEEP_WFI || !cpu.Event {
+		t.Errorf("wfi: sleep = %v, event = %v", cpu.Sleep, cpu.Event)
+	}
+
+	cpu.Wake()
+	if cpu.Sleep != AWAKE {
+		t.Errorf("wake: sleep = %v, expected %v", cpu.Sleep, AWAKE)
+	}
+}
+
+func TestExecuteBarrier(t *testing.T) {
+	var barriers []Barrier
+	cpu := CPU{Barrier: func(barrier Barrier) { barriers = append(barriers, barrier) }}
+
+	DmbT1{Option: 0xf}.Execute(&cpu)
+	DsbT1{Option: 0xf}.Execute(&cpu)
+	IsbT1{Option: 0xf}.Execute(&cpu)
+
+	expected := []Barrier{BARRIER_DMB, BARRIER_DSB, BARRIER_ISB}
+	if !reflect.DeepEqual(barriers, expected) {
+		t.Errorf("barriers = %v, expected %v", barriers, expected)
+	}
+}
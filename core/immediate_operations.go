@@ -0,0 +1,78 @@
+package core
+
+/* Expand a Thumb-2 modified immediate constant, with carry out
+ * ARM ARM A5.3.2 ThumbExpandImm_C() */
+func ThumbExpandImm_C(imm12 uint32, carry_in bool) (uint32, bool) {
+	if (imm12 & 0xc00) == 0 {
+		imm8 := imm12 & 0xff
+
+		var imm32 uint32
+		switch (imm12 >> 8) & 0x3 {
+		case 0x0:
+			imm32 = imm8
+		case 0x1:
+			imm32 = (imm8 << 16) | imm8
+		case 0x2:
+			imm32 = (imm8 << 24) | (imm8 << 8)
+		case 0x3:
+			imm32 = (imm8 << 24) | (imm8 << 16) | (imm8 << 8) | imm8
+		}
+
+		return imm32, carry_in
+	}
+
+	unrotated := 0x80 | (imm12 & 0x7f)
+
+	return ROR_C(unrotated, uint8((imm12>>7)&0x1f))
+}
+
+/* Expand a Thumb-2 modified immediate constant
+ * ARM ARM A5.3.2 ThumbExpandImm() */
+func ThumbExpandImm(imm12 uint32) uint32 {
+	imm32, _ := ThumbExpandImm_C(imm12, false)
+	return imm32
+}
+
+/* Extract the fields common to the data processing (modified immediate)
+ * encodings. Returns the unexpanded imm12.
+ * ARM ARM A5.3.1 */
+func decode_modified_imm(raw_instr uint32) (Rd RegIndex, Rn RegIndex, imm12 uint32, setflags SetFlags) {
+	Rd = RegIndex((raw_instr >> 8) & 0xf)
+	Rn = RegIndex((raw_instr >> 16) & 0xf)
+
+	i := (raw_instr >> 26) & 0x1
+	imm3 := (raw_instr >> 12) & 0x7
+	imm8 := raw_instr & 0xff
+	imm12 = (i << 11) | (imm3 << 8) | imm8
+
+	setflags = NEVER
+	if (raw_instr>>20)&0x1 == 1 {
+		setflags = ALWAYS
+	}
+
+	return Rd, Rn, imm12, setflags
+}
+
+/* Extract the zero extended i:imm3:imm8 of ADDW, SUBW and ADR
+ * ARM ARM A5.3.3 */
+func decode_plain_imm12(raw_instr uint32) uint32 {
+	i := (raw_instr >> 26) & 0x1
+	imm3 := (raw_instr >> 12) & 0x7
+	imm8 := raw_instr & 0xff
+
+	return (i << 11) | (imm3 << 8) | imm8
+}
+
+/* Extract the imm4:i:imm3:imm8 of MOVW and MOVT
+ * ARM ARM A5.3.3 */
+func decode_plain_imm16(raw_instr uint32) uint32 {
+	imm4 := (raw_instr >> 16) & 0xf
+
+	return (imm4 << 12) | decode_plain_imm12(raw_instr)
+}
+
+/* The saturate and bitfield instructions have no i field, bit 26 is (0)
+ * ARM ARM A5.3.3 */
+func plain_imm_sbz(raw_instr uint32) bool {
+	return raw_instr&(1<<26) == 0
+}
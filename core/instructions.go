@@ -1,9 +1,11 @@
 package core
 
+import "fmt"
+
 type DecodeFunc func(FetchedInstr) DecodedInstr
 
 type DecodedInstr interface {
-	Execute(*Registers)
+	Execute(*CPU)
 }
 
 type SetFlags uint8
@@ -35,4 +37,153 @@ type InstrFields struct {
 	Rd       RegIndex
 	Rm       RegIndex
 	Rn       RegIndex
+	Shift    Shift // Applied to Rm
+}
+
+/* SP and PC are UNPREDICTABLE in most 32-bit register fields
+ * ARM ARM A5.1.1 */
+func BadReg(r RegIndex) bool {
+	return r == SP || r == PC
+}
+
+/* Fields of the bitfield instructions (BFI, BFC, SBFX, UBFX) */
+type BitfieldFields struct {
+	Rd    RegIndex
+	Rn    RegIndex
+	Lsb   uint8
+	Width uint8
+}
+
+/* Fields of the saturate instructions (SSAT, USAT) */
+type SaturateFields struct {
+	Rd         RegIndex
+	Rn         RegIndex
+	SaturateTo uint8
+	ShiftType  SRType
+	ShiftN     uint8
+}
+
+/* Fields of the 32-bit multiply and divide instructions. The long
+ * multiplies write the 64-bit result to RdHi:Rd. */
+type MultiplyFields struct {
+	Rd       RegIndex // RdLo of the long multiplies
+	RdHi     RegIndex
+	Rn       RegIndex
+	Rm       RegIndex
+	Ra       RegIndex // Accumulator
+	NHigh    bool     // Multiply the top halfword of Rn (SMLA<x><y>, ...)
+	MHigh    bool     // Multiply the top halfword of Rm
+	Exchange bool     // Swap the halfwords of Rm (SMUAD, SMLAD, ...)
+	Round    bool     // Round the most significant word (SMMUL, SMMLA, ...)
+}
+
+/* Fields of the special register access instructions (MRS, MSR). Mask
+ * selects the APSR fields written by MSR: bit 1 NZCVQ, bit 0 GE. */
+type SpecialRegFields struct {
+	Rd   RegIndex // MRS destination
+	Rn   RegIndex // MSR source
+	SYSm SpecialReg
+	Mask uint8
+}
+
+/* Fields of the single and dual register load and store instructions */
+type LoadStoreFields struct {
+	Rt    RegIndex
+	Rt2   RegIndex // Second register of LDRD, STRD
+	Rd    RegIndex // Status register of STREX
+	Rn    RegIndex
+	Rm    RegIndex // Register offset forms only
+	Imm   uint32
+	Shift Shift // Applied to Rm
+	Index bool  // Apply the offset before the access
+	Add   bool  // Add, rather than subtract, the offset
+	Wback bool  // Write the offset address back to Rn
+}
+
+/* Fields of the load and store multiple instructions (LDM, STM, PUSH,
+ * POP). Registers has bit n set for each Rn in the list. */
+type MultipleFields struct {
+	Rn        RegIndex
+	Registers uint16
+	Wback     bool
+}
+
+/* Fields of the floating-point data-processing instructions, on the
+ * single-precision registers Sd, Sn and Sm */
+type FPFields struct {
+	Sd  SRegIndex
+	Sn  SRegIndex
+	Sm  SRegIndex
+	Imm uint32 // Expanded immediate of VMOV
+}
+
+/* Fields of the floating-point load and store instructions (VLDR, VSTR,
+ * VLDM, VSTM, VPUSH, VPOP). Doubleword register Dn is transferred as
+ * Sd = S(2n) then S(2n+1). */
+type FPLoadStoreFields struct {
+	Sd     SRegIndex // First register
+	Regs   uint8     // Number of registers listed, of VLDM, VSTM, VPUSH, VPOP
+	Double bool      // Transfer doubleword registers
+	Rn     RegIndex
+	Imm    uint32
+	Add    bool // Add, rather than subtract, the offset
+	Wback  bool // Write the final address back to Rn
+}
+
+/* Fields of the coprocessor instructions (CDP, MCR, MRC, MCRR, MRRC).
+ * Opc1 and Opc2 are opcodes, and CRd, CRn and CRm registers, of the
+ * coprocessor itself. */
+type CoprocFields struct {
+	Coproc uint8
+	Opc1   uint8
+	Opc2   uint8
+	CRd    uint8
+	CRn    uint8
+	CRm    uint8
+	Rt     RegIndex
+	Rt2    RegIndex
+}
+
+/* Fields of the coprocessor load and store instructions (LDC, STC). The
+ * unindexed form passes Imm to the coprocessor as an option. */
+type CoprocLoadStoreFields struct {
+	Coproc uint8
+	CRd    uint8
+	Long   bool // Long transfer, the D bit
+	Rn     RegIndex
+	Imm    uint32
+	Index  bool
+	Add    bool
+	Wback  bool
+}
+
+/* Fields of the branch instructions */
+type BranchFields struct {
+	Cond Condition
+	Imm  uint32 // Sign extended offset from PC
+	Rn   RegIndex
+	Rm   RegIndex
+}
+
+/* Instructions with a 16-bit encoding that only sets the flags outside an
+ * IT block, so only has an S suffix there */
+type FlagSettingInstr interface {
+	DecodedInstr
+	SetsFlags() SetFlags
+}
+
+/* Instructions whose disassembly depends on their address, such as
+ * PC-relative branches */
+type AddressedInstr interface {
+	DecodedInstr
+	Disassemble(addr uint32) string
+}
+
+/* Disassemble instr, located at addr */
+func Disassemble(instr DecodedInstr, addr uint32) string {
+	if addressed, ok := instr.(AddressedInstr); ok {
+		return addressed.Disassemble(addr)
+	}
+
+	return fmt.Sprint(instr)
 }
@@ -48,13 +48,14 @@ func test_decode(t *testing.T, cases []DecodeCase, decode DecodeFunc) {
 
 func test_execute(t *testing.T, cases []ExecuteCase) {
 	for _, test := range cases {
-		original := test.regs
-		test.instr.Execute(&test.regs)
+		cpu := CPU{Registers: test.regs}
+		cpu.Scb.Cpacr = CPACR_FP_MASK // Full access to the FP extension
+		test.instr.Execute(&cpu)
 
-		if test.regs != test.expected {
+		if cpu.Registers != test.expected {
 			t.Errorf("instr: %#v", test.instr)
-			t.Errorf("Before:\n%s", original.Pretty())
-			t.Errorf("After:\n%s", test.regs.Pretty())
+			t.Errorf("Before:\n%s", test.regs.Pretty())
+			t.Errorf("After:\n%s", cpu.Registers.Pretty())
 			t.Errorf("Expected:\n%s", test.expected.Pretty())
 		}
 	}
@@ -87,3 +88,54 @@ func verify_identify(t *testing.T, test IdentifyCase, instr_type reflect.Type, i
 		t.Errorf("expected NOT type: %v", instr_type)
 	}
 }
+
+// Execute given load or store on a
+// set of registers and test memory,
+// with expected registers, word of
+// memory and fault
+type MemoryCase struct {
+	instr    DecodedInstr
+	regs     Registers
+	expected Registers
+	addr     uint32
+	word     uint32
+	fault    bool
+}
+
+const TEST_MEM_SIZE = 0x40
+
+/* Memory for MemoryCase, each byte holding 0x80 plus its address */
+func test_memory() RAM {
+	ram := make(RAM, TEST_MEM_SIZE)
+	for i := range ram {
+		ram[i] = uint8(0x80 + i)
+	}
+
+	return ram
+}
+
+func test_execute_memory(t *testing.T, cases []MemoryCase) {
+	for _, test := range cases {
+		ram := test_memory()
+		cpu := CPU{Registers: test.regs, Mem: ram}
+		cpu.Scb.Cpacr = CPACR_FP_MASK
+		test.instr.Execute(&cpu)
+
+		if cpu.Registers != test.expected {
+			t.Errorf("instr: %#v", test.instr)
+			t.Errorf("Before:\n%s", test.regs.Pretty())
+			t.Errorf("After:\n%s", cpu.Registers.Pretty())
+			t.Errorf("Expected:\n%s", test.expected.Pretty())
+		}
+
+		if word, _ := ram.Read32(test.addr); word != test.word {
+			t.Errorf("instr: %#v", test.instr)
+			t.Errorf("mem[%#x] = %#x, expected %#x", test.addr, word, test.word)
+		}
+
+		if (cpu.fault != nil) != test.fault {
+			t.Errorf("instr: %#v", test.instr)
+			t.Errorf("fault = %v, expected fault %v", cpu.fault, test.fault)
+		}
+	}
+}
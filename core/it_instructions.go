@@ -0,0 +1,61 @@
+package core
+
+import (
+	"fmt"
+	"math/bits"
+)
+
+/* IT
+ * ARM ARM A7.7.37
+ * Encoding T1 */
+type ItT1 struct {
+	FirstCond Condition
+	Mask      uint8
+}
+
+func It16T1(instr FetchedInstr) DecodedInstr {
+	raw_instr := instr.Uint32()
+
+	firstcond := Condition((raw_instr >> 4) & 0xf)
+	mask := uint8(raw_instr & 0xf)
+
+	if firstcond == 0xf || (firstcond == COND_AL && bits.OnesCount8(mask) != 1) {
+		return UnpredictableInstr{}
+	}
+
+	return ItT1{FirstCond: firstcond, Mask: mask}
+}
+
+func (instr ItT1) Execute(cpu *CPU) {
+	if cpu.InITBlock() {
+		// UNPREDICTABLE
+		return
+	}
+
+	cpu.Epsr.IT = instr.State()
+}
+
+/* ITSTATE at the start of the block */
+func (instr ItT1) State() ITState {
+	return ITState(uint8(instr.FirstCond)<<4 | instr.Mask)
+}
+
+func (instr ItT1) String() string {
+	/* Each mask bit above the terminating 1 adds an instruction, which is
+	 * Then if it matches the LSB of firstcond, otherwise Else */
+	suffix := ""
+	for i := 3; instr.Mask&((1<<i)-1) != 0; i-- {
+		if (instr.Mask>>i)&0x1 == uint8(instr.FirstCond)&0x1 {
+			suffix += "t"
+		} else {
+			suffix += "e"
+		}
+	}
+
+	cond := instr.FirstCond.String()
+	if instr.FirstCond == COND_AL {
+		cond = "al"
+	}
+
+	return fmt.Sprintf("it%s %s", suffix, cond)
+}
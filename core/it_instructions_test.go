@@ -0,0 +1,97 @@
+package core
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestIdentifyItT1(t *testing.T) {
+	cases := []IdentifyCase{
+		{instr: FetchedInstr16(0xbf06), instr_valid: true},  // itte eq
+		{instr: FetchedInstr16(0xbfe8), instr_valid: true},  // it al
+		{instr: FetchedInstr16(0xbf81), instr_valid: true},  // itttt hi
+		{instr: FetchedInstr16(0xbf00), instr_valid: false}, // nop
+		{instr: FetchedInstr16(0xbfe6), instr_valid: false}, // ite al
+	}
+
+	test_identify(t, cases, reflect.TypeOf(ItT1{}))
+}
+
+func TestDecodeIt(t *testing.T) {
+	cases := []DecodeCase{
+		// itte eq
+		{instr: FetchedInstr16(0xbf06), decoded: ItT1{FirstCond: COND_EQ, Mask: 0x6}},
+		// ittet ne
+		{instr: FetchedInstr16(0xbf1b), decoded: ItT1{FirstCond: COND_NE, Mask: 0xb}},
+		// ite al
+		{instr: FetchedInstr16(0xbfe6), decoded: UnpredictableInstr{}},
+		// firstcond == 1111
+		{instr: FetchedInstr16(0xbff8), decoded: UnpredictableInstr{}},
+	}
+
+	test_decode(t, cases, It16T1)
+}
+
+func TestItString(t *testing.T) {
+	cases := []struct {
+		instr    DecodedInstr
+		expected string
+	}{
+		{instr: ItT1{FirstCond: COND_EQ, Mask: 0x6}, expected: "itte eq"},
+		{instr: ItT1{FirstCond: COND_NE, Mask: 0xb}, expected: "ittet ne"},
+		{instr: ItT1{FirstCond: COND_HI, Mask: 0x1}, expected: "itttt hi"},
+		{instr: ItT1{FirstCond: COND_AL, Mask: 0x8}, expected: "it al"},
+		{instr: ITInstr{Instr: AddRegT1{Rd: 0, Rn: 1, Rm: 2, setflags: NOT_IT}, Cond: COND_EQ},
+			expected: "addeq r0, r1, r2"},
+		{instr: ITInstr{Instr: AddImmT2{Rd: 3, Rn: 3, Imm: 1, setflags: NOT_IT}, Cond: COND_EQ},
+			expected: "addeq r3, #1"},
+		{instr: ITInstr{Instr: LslImm{Rd: 0, Rm: 1, Imm: 2, setflags: NOT_IT}, Cond: COND_LT},
+			expected: "lsllt r0, r1, #2"},
+		{instr: ITInstr{Instr: CmpImmT1{Rn: 2, Imm: 5, setflags: ALWAYS}, Cond: COND_EQ},
+			expected: "cmpeq r2, #5"},
+		{instr: ITInstr{Instr: AddImmT3{Rd: 0, Rn: 1, Imm: 1, setflags: ALWAYS}, Cond: COND_NE},
+			expected: "addsne.w r0, r1, #1"},
+		{instr: ITInstr{Instr: AddImmT3{Rd: 0, Rn: 1, Imm: 1, setflags: NEVER}, Cond: COND_NE},
+			expected: "addne.w r0, r1, #1"},
+		{instr: ITInstr{Instr: BxT1{Cond: COND_AL, Rm: LR}, Cond: COND_HI},
+			expected: "bxhi lr"},
+	}
+
+	for _, test := range cases {
+		if actual := Disassemble(test.instr, 0x1000); actual != test.expected {
+			t.Errorf("Disassemble(%#v) = %q, expected %q", test.instr, actual, test.expected)
+		}
+	}
+}
+
+func TestExecuteIt(t *testing.T) {
+	cases := []ExecuteCase{
+		// itte eq
+		{instr: ItT1{FirstCond: COND_EQ, Mask: 0x6},
+			regs:     Registers{},
+			expected: Registers{Epsr: Epsr{IT: 0x06}}},
+		// ittet ne
+		{instr: ItT1{FirstCond: COND_NE, Mask: 0xb},
+			regs:     Registers{},
+			expected: Registers{Epsr: Epsr{IT: 0x1b}}},
+	}
+
+	test_execute(t, cases)
+}
+
+func TestITAdvance(t *testing.T) {
+	/* ittet ne: ne, ne, eq, ne */
+	state := ITState(0x1b)
+	expected := []Condition{COND_NE, COND_NE, COND_EQ, COND_NE}
+
+	for i, cond := range expected {
+		if !state.InBlock() || state.Cond() != cond || state.Last() != (i == len(expected)-1) {
+			t.Errorf("Instruction %d: ITSTATE = %#x, expected condition %v", i, uint8(state), cond)
+		}
+		state.Advance()
+	}
+
+	if state != 0 {
+		t.Errorf("ITSTATE = %#x after block, expected 0", uint8(state))
+	}
+}
@@ -0,0 +1,1020 @@
+package core
+
+import "fmt"
+
+/* LDR (immediate)
+ * ARM ARM A7.7.42
+ * Encoding T1 */
+type LdrImmT1 LoadStoreFields
+
+func LdrImm16T1(instr FetchedInstr) DecodedInstr {
+	return LdrImmT1(decode_ldst_imm5(instr.Uint32(), 4))
+}
+
+func (instr LdrImmT1) Execute(cpu *CPU) {
+	LoadImmediate(cpu, LoadStoreFields(instr), 4, false)
+}
+
+func (instr LdrImmT1) String() string {
+	return fmt.Sprintf("ldr %s, %s", instr.Rt, imm_address(LoadStoreFields(instr)))
+}
+
+/* LDR (immediate)
+ * ARM ARM A7.7.42
+ * Encoding T2 */
+type LdrImmT2 LoadStoreFields
+
+func LdrImm16T2(instr FetchedInstr) DecodedInstr {
+	raw_instr := instr.Uint32()
+
+	Rt := RegIndex((raw_instr >> 8) & 0x7)
+	Imm := (raw_instr & 0xff) << 2
+
+	return LdrImmT2{Rt: Rt, Rn: SP, Imm: Imm, Index: true, Add: true, Wback: false}
+}
+
+func (instr LdrImmT2) Execute(cpu *CPU) {
+	LoadImmediate(cpu, LoadStoreFields(instr), 4, false)
+}
+
+func (instr LdrImmT2) String() string {
+	return fmt.Sprintf("ldr %s, %s", instr.Rt, imm_address(LoadStoreFields(instr)))
+}
+
+/* LDR (immediate)
+ * ARM ARM A7.7.42
+ * Encoding T3 */
+type LdrImmT3 LoadStoreFields
+
+func LdrImm32T3(instr FetchedInstr) DecodedInstr {
+	raw_instr := instr.Uint32()
+	fields := decode_ldst_imm12(raw_instr)
+
+	if fields.Rn == PC {
+		return LdrLit32T2(instr)
+	}
+
+	return LdrImmT3(fields)
+}
+
+func (instr LdrImmT3) Execute(cpu *CPU) {
+	LoadImmediate(cpu, LoadStoreFields(instr), 4, false)
+}
+
+func (instr LdrImmT3) String() string {
+	return fmt.Sprintf("ldr.w %s, %s", instr.Rt, imm_address(LoadStoreFields(instr)))
+}
+
+/* LDR (immediate)
+ * ARM ARM A7.7.42
+ * Encoding T4 */
+type LdrImmT4 LoadStoreFields
+
+func LdrImm32T4(instr FetchedInstr) DecodedInstr {
+	raw_instr := instr.Uint32()
+	fields := decode_ldst_imm8(raw_instr)
+
+	if fields.Rn == PC {
+		return LdrLit32T2(instr)
+	}
+
+	if ldst_imm8_unpriv(raw_instr) {
+		return Ldrt32T1(instr)
+	}
+
+	if fields.Rn == SP && !fields.Index && fields.Add && fields.Wback && fields.Imm == 4 {
+		return Pop32T3(instr)
+	}
+
+	if !fields.Index && !fields.Wback {
+		return UndefinedInstr{}
+	}
+
+	if fields.Wback && fields.Rn == fields.Rt {
+		return UnpredictableInstr{}
+	}
+
+	return LdrImmT4(fields)
+}
+
+func (instr LdrImmT4) Execute(cpu *CPU) {
+	LoadImmediate(cpu, LoadStoreFields(instr), 4, false)
+}
+
+func (instr LdrImmT4) String() string {
+	return fmt.Sprintf("ldr %s, %s", instr.Rt, imm_address(LoadStoreFields(instr)))
+}
+
+/* LDR (literal)
+ * ARM ARM A7.7.43
+ * Encoding T1 */
+type LdrLitT1 LoadStoreFields
+
+func LdrLit16T1(instr FetchedInstr) DecodedInstr {
+	raw_instr := instr.Uint32()
+
+	Rt := RegIndex((raw_instr >> 8) & 0x7)
+	Imm := (raw_instr & 0xff) << 2
+
+	return LdrLitT1{Rt: Rt, Rn: PC, Imm: Imm, Index: true, Add: true, Wback: false}
+}
+
+func (instr LdrLitT1) Execute(cpu *CPU) {
+	LoadImmediate(cpu, LoadStoreFields(instr), 4, false)
+}
+
+func (instr LdrLitT1) String() string {
+	return fmt.Sprintf("ldr %s, %s", instr.Rt, imm_address(LoadStoreFields(instr)))
+}
+
+/* LDR (literal)
+ * ARM ARM A7.7.43
+ * Encoding T2 */
+type LdrLitT2 LoadStoreFields
+
+func LdrLit32T2(instr FetchedInstr) DecodedInstr {
+	return LdrLitT2(decode_ldst_literal(instr.Uint32()))
+}
+
+func (instr LdrLitT2) Execute(cpu *CPU) {
+	LoadImmediate(cpu, LoadStoreFields(instr), 4, false)
+}
+
+func (instr LdrLitT2) String() string {
+	return fmt.Sprintf("ldr.w %s, %s", instr.Rt, imm_address(LoadStoreFields(instr)))
+}
+
+/* LDR (register)
+ * ARM ARM A7.7.44
+ * Encoding T1 */
+type LdrRegT1 LoadStoreFields
+
+func LdrReg16T1(instr FetchedInstr) DecodedInstr {
+	return LdrRegT1(decode_ldst_reg16(instr.Uint32()))
+}
+
+func (instr LdrRegT1) Execute(cpu *CPU) {
+	LoadRegister(cpu, LoadStoreFields(instr), 4, false)
+}
+
+func (instr LdrRegT1) String() string {
+	return fmt.Sprintf("ldr %s, %s", instr.Rt, reg_address(LoadStoreFields(instr)))
+}
+
+/* LDR (register)
+ * ARM ARM A7.7.44
+ * Encoding T2 */
+type LdrRegT2 LoadStoreFields
+
+func LdrReg32T2(instr FetchedInstr) DecodedInstr {
+	raw_instr := instr.Uint32()
+	fields := decode_ldst_reg32(raw_instr)
+
+	if fields.Rn == PC {
+		return LdrLit32T2(instr)
+	}
+
+	if BadReg(fields.Rm) {
+		return UnpredictableInstr{}
+	}
+
+	return LdrRegT2(fields)
+}
+
+func (instr LdrRegT2) Execute(cpu *CPU) {
+	LoadRegister(cpu, LoadStoreFields(instr), 4, false)
+}
+
+func (instr LdrRegT2) String() string {
+	return fmt.Sprintf("ldr.w %s, %s", instr.Rt, reg_address(LoadStoreFields(instr)))
+}
+
+/* LDRT
+ * ARM ARM A7.7.66
+ * Encoding T1 */
+type LdrtT1 LoadStoreFields
+
+func Ldrt32T1(instr FetchedInstr) DecodedInstr {
+	raw_instr := instr.Uint32()
+	fields := decode_ldst_imm8(raw_instr)
+
+	if fields.Rn == PC {
+		return LdrLit32T2(instr)
+	}
+
+	if BadReg(fields.Rt) {
+		return UnpredictableInstr{}
+	}
+
+	return LdrtT1(fields)
+}
+
+func (instr LdrtT1) Execute(cpu *CPU) {
+	LoadUnprivileged(cpu, LoadStoreFields(instr), 4, false)
+}
+
+func (instr LdrtT1) String() string {
+	return fmt.Sprintf("ldrt %s, %s", instr.Rt, imm_address(LoadStoreFields(instr)))
+}
+
+/* LDRB (immediate)
+ * ARM ARM A7.7.45
+ * Encoding T1 */
+type LdrbImmT1 LoadStoreFields
+
+func LdrbImm16T1(instr FetchedInstr) DecodedInstr {
+	return LdrbImmT1(decode_ldst_imm5(instr.Uint32(), 1))
+}
+
+func (instr LdrbImmT1) Execute(cpu *CPU) {
+	LoadImmediate(cpu, LoadStoreFields(instr), 1, false)
+}
+
+func (instr LdrbImmT1) String() string {
+	return fmt.Sprintf("ldrb %s, %s", instr.Rt, imm_address(LoadStoreFields(instr)))
+}
+
+/* LDRB (immediate)
+ * ARM ARM A7.7.45
+ * Encoding T2 */
+type LdrbImmT2 LoadStoreFields
+
+func LdrbImm32T2(instr FetchedInstr) DecodedInstr {
+	raw_instr := instr.Uint32()
+	fields := decode_ldst_imm12(raw_instr)
+
+	if fields.Rn == PC {
+		return LdrbLit32T1(instr)
+	}
+
+	if fields.Rt == PC {
+		return PldImm32T1(instr)
+	}
+
+	if BadReg(fields.Rt) {
+		return UnpredictableInstr{}
+	}
+
+	return LdrbImmT2(fields)
+}
+
+func (instr LdrbImmT2) Execute(cpu *CPU) {
+	LoadImmediate(cpu, LoadStoreFields(instr), 1, false)
+}
+
+func (instr LdrbImmT2) String() string {
+	return fmt.Sprintf("ldrb.w %s, %s", instr.Rt, imm_address(LoadStoreFields(instr)))
+}
+
+/* LDRB (immediate)
+ * ARM ARM A7.7.45
+ * Encoding T3 */
+type LdrbImmT3 LoadStoreFields
+
+func LdrbImm32T3(instr FetchedInstr) DecodedInstr {
+	raw_instr := instr.Uint32()
+	fields := decode_ldst_imm8(raw_instr)
+
+	if fields.Rn == PC {
+		return LdrbLit32T1(instr)
+	}
+
+	if ldst_imm8_unpriv(raw_instr) {
+		return Ldrbt32T1(instr)
+	}
+
+	if fields.Rt == PC && fields.Index && !fields.Add && !fields.Wback {
+		return PldImm32T2(instr)
+	}
+
+	if !fields.Index && !fields.Wback {
+		return UndefinedInstr{}
+	}
+
+	if BadReg(fields.Rt) || (fields.Wback && fields.Rn == fields.Rt) {
+		return UnpredictableInstr{}
+	}
+
+	return LdrbImmT3(fields)
+}
+
+func (instr LdrbImmT3) Execute(cpu *CPU) {
+	LoadImmediate(cpu, LoadStoreFields(instr), 1, false)
+}
+
+func (instr LdrbImmT3) String() string {
+	return fmt.Sprintf("ldrb %s, %s", instr.Rt, imm_address(LoadStoreFields(instr)))
+}
+
+/* LDRB (literal)
+ * ARM ARM A7.7.46
+ * Encoding T1 */
+type LdrbLitT1 LoadStoreFields
+
+func LdrbLit32T1(instr FetchedInstr) DecodedInstr {
+	raw_instr := instr.Uint32()
+	fields := decode_ldst_literal(raw_instr)
+
+	if fields.Rt == PC {
+		return PldLit32T1(instr)
+	}
+
+	if BadReg(fields.Rt) {
+		return UnpredictableInstr{}
+	}
+
+	return LdrbLitT1(fields)
+}
+
+func (instr LdrbLitT1) Execute(cpu *CPU) {
+	LoadImmediate(cpu, LoadStoreFields(instr), 1, false)
+}
+
+func (instr LdrbLitT1) String() string {
+	return fmt.Sprintf("ldrb.w %s, %s", instr.Rt, imm_address(LoadStoreFields(instr)))
+}
+
+/* LDRB (register)
+ * ARM ARM A7.7.47
+ * Encoding T1 */
+type LdrbRegT1 LoadStoreFields
+
+func LdrbReg16T1(instr FetchedInstr) DecodedInstr {
+	return LdrbRegT1(decode_ldst_reg16(instr.Uint32()))
+}
+
+func (instr LdrbRegT1) Execute(cpu *CPU) {
+	LoadRegister(cpu, LoadStoreFields(instr), 1, false)
+}
+
+func (instr LdrbRegT1) String() string {
+	return fmt.Sprintf("ldrb %s, %s", instr.Rt, reg_address(LoadStoreFields(instr)))
+}
+
+/* LDRB (register)
+ * ARM ARM A7.7.47
+ * Encoding T2 */
+type LdrbRegT2 LoadStoreFields
+
+func LdrbReg32T2(instr FetchedInstr) DecodedInstr {
+	raw_instr := instr.Uint32()
+	fields := decode_ldst_reg32(raw_instr)
+
+	if fields.Rn == PC {
+		return LdrbLit32T1(instr)
+	}
+
+	if fields.Rt == PC {
+		return PldReg32T1(instr)
+	}
+
+	if BadReg(fields.Rt) || BadReg(fields.Rm) {
+		return UnpredictableInstr{}
+	}
+
+	return LdrbRegT2(fields)
+}
+
+func (instr LdrbRegT2) Execute(cpu *CPU) {
+	LoadRegister(cpu, LoadStoreFields(instr), 1, false)
+}
+
+func (instr LdrbRegT2) String() string {
+	return fmt.Sprintf("ldrb.w %s, %s", instr.Rt, reg_address(LoadStoreFields(instr)))
+}
+
+/* LDRBT
+ * ARM ARM A7.7.48
+ * Encoding T1 */
+type LdrbtT1 LoadStoreFields
+
+func Ldrbt32T1(instr FetchedInstr) DecodedInstr {
+	raw_instr := instr.Uint32()
+	fields := decode_ldst_imm8(raw_instr)
+
+	if fields.Rn == PC {
+		return LdrbLit32T1(instr)
+	}
+
+	if BadReg(fields.Rt) {
+		return UnpredictableInstr{}
+	}
+
+	return LdrbtT1(fields)
+}
+
+func (instr LdrbtT1) Execute(cpu *CPU) {
+	LoadUnprivileged(cpu, LoadStoreFields(instr), 1, false)
+}
+
+func (instr LdrbtT1) String() string {
+	return fmt.Sprintf("ldrbt %s, %s", instr.Rt, imm_address(LoadStoreFields(instr)))
+}
+
+/* LDRH (immediate)
+ * ARM ARM A7.7.54
+ * Encoding T1 */
+type LdrhImmT1 LoadStoreFields
+
+func LdrhImm16T1(instr FetchedInstr) DecodedInstr {
+	return LdrhImmT1(decode_ldst_imm5(instr.Uint32(), 2))
+}
+
+func (instr LdrhImmT1) Execute(cpu *CPU) {
+	LoadImmediate(cpu, LoadStoreFields(instr), 2, false)
+}
+
+func (instr LdrhImmT1) String() string {
+	return fmt.Sprintf("ldrh %s, %s", instr.Rt, imm_address(LoadStoreFields(instr)))
+}
+
+/* LDRH (immediate)
+ * ARM ARM A7.7.54
+ * Encoding T2 */
+type LdrhImmT2 LoadStoreFields
+
+func LdrhImm32T2(instr FetchedInstr) DecodedInstr {
+	raw_instr := instr.Uint32()
+	fields := decode_ldst_imm12(raw_instr)
+
+	if fields.Rn == PC {
+		return LdrhLit32T1(instr)
+	}
+
+	if fields.Rt == PC {
+		return memory_hint(instr)
+	}
+
+	if BadReg(fields.Rt) {
+		return UnpredictableInstr{}
+	}
+
+	return LdrhImmT2(fields)
+}
+
+func (instr LdrhImmT2) Execute(cpu *CPU) {
+	LoadImmediate(cpu, LoadStoreFields(instr), 2, false)
+}
+
+func (instr LdrhImmT2) String() string {
+	return fmt.Sprintf("ldrh.w %s, %s", instr.Rt, imm_address(LoadStoreFields(instr)))
+}
+
+/* LDRH (immediate)
+ * ARM ARM A7.7.54
+ * Encoding T3 */
+type LdrhImmT3 LoadStoreFields
+
+func LdrhImm32T3(instr FetchedInstr) DecodedInstr {
+	raw_instr := instr.Uint32()
+	fields := decode_ldst_imm8(raw_instr)
+
+	if fields.Rn == PC {
+		return LdrhLit32T1(instr)
+	}
+
+	if ldst_imm8_unpriv(raw_instr) {
+		return Ldrht32T1(instr)
+	}
+
+	if fields.Rt == PC && fields.Index && !fields.Add && !fields.Wback {
+		return memory_hint(instr)
+	}
+
+	if !fields.Index && !fields.Wback {
+		return UndefinedInstr{}
+	}
+
+	if BadReg(fields.Rt) || (fields.Wback && fields.Rn == fields.Rt) {
+		return UnpredictableInstr{}
+	}
+
+	return LdrhImmT3(fields)
+}
+
+func (instr LdrhImmT3) Execute(cpu *CPU) {
+	LoadImmediate(cpu, LoadStoreFields(instr), 2, false)
+}
+
+func (instr LdrhImmT3) String() string {
+	return fmt.Sprintf("ldrh %s, %s", instr.Rt, imm_address(LoadStoreFields(instr)))
+}
+
+/* LDRH (literal)
+ * ARM ARM A7.7.55
+ * Encoding T1 */
+type LdrhLitT1 LoadStoreFields
+
+func LdrhLit32T1(instr FetchedInstr) DecodedInstr {
+	raw_instr := instr.Uint32()
+	fields := decode_ldst_literal(raw_instr)
+
+	if fields.Rt == PC {
+		return memory_hint(instr)
+	}
+
+	if BadReg(fields.Rt) {
+		return UnpredictableInstr{}
+	}
+
+	return LdrhLitT1(fields)
+}
+
+func (instr LdrhLitT1) Execute(cpu *CPU) {
+	LoadImmediate(cpu, LoadStoreFields(instr), 2, false)
+}
+
+func (instr LdrhLitT1) String() string {
+	return fmt.Sprintf("ldrh.w %s, %s", instr.Rt, imm_address(LoadStoreFields(instr)))
+}
+
+/* LDRH (register)
+ * ARM ARM A7.7.56
+ * Encoding T1 */
+type LdrhRegT1 LoadStoreFields
+
+func LdrhReg16T1(instr FetchedInstr) DecodedInstr {
+	return LdrhRegT1(decode_ldst_reg16(instr.Uint32()))
+}
+
+func (instr LdrhRegT1) Execute(cpu *CPU) {
+	LoadRegister(cpu, LoadStoreFields(instr), 2, false)
+}
+
+func (instr LdrhRegT1) String() string {
+	return fmt.Sprintf("ldrh %s, %s", instr.Rt, reg_address(LoadStoreFields(instr)))
+}
+
+/* LDRH (register)
+ * ARM ARM A7.7.56
+ * Encoding T2 */
+type LdrhRegT2 LoadStoreFields
+
+func LdrhReg32T2(instr FetchedInstr) DecodedInstr {
+	raw_instr := instr.Uint32()
+	fields := decode_ldst_reg32(raw_instr)
+
+	if fields.Rn == PC {
+		return LdrhLit32T1(instr)
+	}
+
+	if fields.Rt == PC {
+		return memory_hint(instr)
+	}
+
+	if BadReg(fields.Rt) || BadReg(fields.Rm) {
+		return UnpredictableInstr{}
+	}
+
+	return LdrhRegT2(fields)
+}
+
+func (instr LdrhRegT2) Execute(cpu *CPU) {
+	LoadRegister(cpu, LoadStoreFields(instr), 2, false)
+}
+
+func (instr LdrhRegT2) String() string {
+	return fmt.Sprintf("ldrh.w %s, %s", instr.Rt, reg_address(LoadStoreFields(instr)))
+}
+
+/* LDRHT
+ * ARM ARM A7.7.57
+ * Encoding T1 */
+type LdrhtT1 LoadStoreFields
+
+func Ldrht32T1(instr FetchedInstr) DecodedInstr {
+	raw_instr := instr.Uint32()
+	fields := decode_ldst_imm8(raw_instr)
+
+	if fields.Rn == PC {
+		return LdrhLit32T1(instr)
+	}
+
+	if BadReg(fields.Rt) {
+		return UnpredictableInstr{}
+	}
+
+	return LdrhtT1(fields)
+}
+
+func (instr LdrhtT1) Execute(cpu *CPU) {
+	LoadUnprivileged(cpu, LoadStoreFields(instr), 2, false)
+}
+
+func (instr LdrhtT1) String() string {
+	return fmt.Sprintf("ldrht %s, %s", instr.Rt, imm_address(LoadStoreFields(instr)))
+}
+
+/* LDRSB (immediate)
+ * ARM ARM A7.7.58
+ * Encoding T1 */
+type LdrsbImmT1 LoadStoreFields
+
+func LdrsbImm32T1(instr FetchedInstr) DecodedInstr {
+	raw_instr := instr.Uint32()
+	fields := decode_ldst_imm12(raw_instr)
+
+	if fields.Rn == PC {
+		return LdrsbLit32T1(instr)
+	}
+
+	if fields.Rt == PC {
+		return PliImm32T1(instr)
+	}
+
+	if BadReg(fields.Rt) {
+		return UnpredictableInstr{}
+	}
+
+	return LdrsbImmT1(fields)
+}
+
+func (instr LdrsbImmT1) Execute(cpu *CPU) {
+	LoadImmediate(cpu, LoadStoreFields(instr), 1, true)
+}
+
+func (instr LdrsbImmT1) String() string {
+	return fmt.Sprintf("ldrsb.w %s, %s", instr.Rt, imm_address(LoadStoreFields(instr)))
+}
+
+/* LDRSB (immediate)
+ * ARM ARM A7.7.58
+ * Encoding T2 */
+type LdrsbImmT2 LoadStoreFields
+
+func LdrsbImm32T2(instr FetchedInstr) DecodedInstr {
+	raw_instr := instr.Uint32()
+	fields := decode_ldst_imm8(raw_instr)
+
+	if fields.Rn == PC {
+		return LdrsbLit32T1(instr)
+	}
+
+	if ldst_imm8_unpriv(raw_instr) {
+		return Ldrsbt32T1(instr)
+	}
+
+	if fields.Rt == PC && fields.Index && !fields.Add && !fields.Wback {
+		return PliImm32T2(instr)
+	}
+
+	if !fields.Index && !fields.Wback {
+		return UndefinedInstr{}
+	}
+
+	if BadReg(fields.Rt) || (fields.Wback && fields.Rn == fields.Rt) {
+		return UnpredictableInstr{}
+	}
+
+	return LdrsbImmT2(fields)
+}
+
+func (instr LdrsbImmT2) Execute(cpu *CPU) {
+	LoadImmediate(cpu, LoadStoreFields(instr), 1, true)
+}
+
+func (instr LdrsbImmT2) String() string {
+	return fmt.Sprintf("ldrsb %s, %s", instr.Rt, imm_address(LoadStoreFields(instr)))
+}
+
+/* LDRSB (literal)
+ * ARM ARM A7.7.59
+ * Encoding T1 */
+type LdrsbLitT1 LoadStoreFields
+
+func LdrsbLit32T1(instr FetchedInstr) DecodedInstr {
+	raw_instr := instr.Uint32()
+	fields := decode_ldst_literal(raw_instr)
+
+	if fields.Rt == PC {
+		return PliLit32T3(instr)
+	}
+
+	if BadReg(fields.Rt) {
+		return UnpredictableInstr{}
+	}
+
+	return LdrsbLitT1(fields)
+}
+
+func (instr LdrsbLitT1) Execute(cpu *CPU) {
+	LoadImmediate(cpu, LoadStoreFields(instr), 1, true)
+}
+
+func (instr LdrsbLitT1) String() string {
+	return fmt.Sprintf("ldrsb.w %s, %s", instr.Rt, imm_address(LoadStoreFields(instr)))
+}
+
+/* LDRSB (register)
+ * ARM ARM A7.7.60
+ * Encoding T1 */
+type LdrsbRegT1 LoadStoreFields
+
+func LdrsbReg16T1(instr FetchedInstr) DecodedInstr {
+	return LdrsbRegT1(decode_ldst_reg16(instr.Uint32()))
+}
+
+func (instr LdrsbRegT1) Execute(cpu *CPU) {
+	LoadRegister(cpu, LoadStoreFields(instr), 1, true)
+}
+
+func (instr LdrsbRegT1) String() string {
+	return fmt.Sprintf("ldrsb %s, %s", instr.Rt, reg_address(LoadStoreFields(instr)))
+}
+
+/* LDRSB (register)
+ * ARM ARM A7.7.60
+ * Encoding T2 */
+type LdrsbRegT2 LoadStoreFields
+
+func LdrsbReg32T2(instr FetchedInstr) DecodedInstr {
+	raw_instr := instr.Uint32()
+	fields := decode_ldst_reg32(raw_instr)
+
+	if fields.Rn == PC {
+		return LdrsbLit32T1(instr)
+	}
+
+	if fields.Rt == PC {
+		return PliReg32T1(instr)
+	}
+
+	if BadReg(fields.Rt) || BadReg(fields.Rm) {
+		return UnpredictableInstr{}
+	}
+
+	return LdrsbRegT2(fields)
+}
+
+func (instr LdrsbRegT2) Execute(cpu *CPU) {
+	LoadRegister(cpu, LoadStoreFields(instr), 1, true)
+}
+
+func (instr LdrsbRegT2) String() string {
+	return fmt.Sprintf("ldrsb.w %s, %s", instr.Rt, reg_address(LoadStoreFields(instr)))
+}
+
+/* LDRSBT
+ * ARM ARM A7.7.61
+ * Encoding T1 */
+type LdrsbtT1 LoadStoreFields
+
+func Ldrsbt32T1(instr FetchedInstr) DecodedInstr {
+	raw_instr := instr.Uint32()
+	fields := decode_ldst_imm8(raw_instr)
+
+	if fields.Rn == PC {
+		return LdrsbLit32T1(instr)
+	}
+
+	if BadReg(fields.Rt) {
+		return UnpredictableInstr{}
+	}
+
+	return LdrsbtT1(fields)
+}
+
+func (instr LdrsbtT1) Execute(cpu *CPU) {
+	LoadUnprivileged(cpu, LoadStoreFields(instr), 1, true)
+}
+
+func (instr LdrsbtT1) String() string {
+	return fmt.Sprintf("ldrsbt %s, %s", instr.Rt, imm_address(LoadStoreFields(instr)))
+}
+
+/* LDRSH (immediate)
+ * ARM ARM A7.7.62
+ * Encoding T1 */
+type LdrshImmT1 LoadStoreFields
+
+func LdrshImm32T1(instr FetchedInstr) DecodedInstr {
+	raw_instr := instr.Uint32()
+	fields := decode_ldst_imm12(raw_instr)
+
+	if fields.Rn == PC {
+		return LdrshLit32T1(instr)
+	}
+
+	if fields.Rt == PC {
+		return memory_hint(instr)
+	}
+
+	if BadReg(fields.Rt) {
+		return UnpredictableInstr{}
+	}
+
+	return LdrshImmT1(fields)
+}
+
+func (instr LdrshImmT1) Execute(cpu *CPU) {
+	LoadImmediate(cpu, LoadStoreFields(instr), 2, true)
+}
+
+func (instr LdrshImmT1) String() string {
+	return fmt.Sprintf("ldrsh.w %s, %s", instr.Rt, imm_address(LoadStoreFields(instr)))
+}
+
+/* LDRSH (immediate)
+ * ARM ARM A7.7.62
+ * Encoding T2 */
+type LdrshImmT2 LoadStoreFields
+
+func LdrshImm32T2(instr FetchedInstr) DecodedInstr {
+	raw_instr := instr.Uint32()
+	fields := decode_ldst_imm8(raw_instr)
+
+	if fields.Rn == PC {
+		return LdrshLit32T1(instr)
+	}
+
+	if ldst_imm8_unpriv(raw_instr) {
+		return Ldrsht32T1(instr)
+	}
+
+	if fields.Rt == PC && fields.Index && !fields.Add && !fields.Wback {
+		return memory_hint(instr)
+	}
+
+	if !fields.Index && !fields.Wback {
+		return UndefinedInstr{}
+	}
+
+	if BadReg(fields.Rt) || (fields.Wback && fields.Rn == fields.Rt) {
+		return UnpredictableInstr{}
+	}
+
+	return LdrshImmT2(fields)
+}
+
+func (instr LdrshImmT2) Execute(cpu *CPU) {
+	LoadImmediate(cpu, LoadStoreFields(instr), 2, true)
+}
+
+func (instr LdrshImmT2) String() string {
+	return fmt.Sprintf("ldrsh %s, %s", instr.Rt, imm_address(LoadStoreFields(instr)))
+}
+
+/* LDRSH (literal)
+ * ARM ARM A7.7.63
+ * Encoding T1 */
+type LdrshLitT1 LoadStoreFields
+
+func LdrshLit32T1(instr FetchedInstr) DecodedInstr {
+	raw_instr := instr.Uint32()
+	fields := decode_ldst_literal(raw_instr)
+
+	if fields.Rt == PC {
+		return memory_hint(instr)
+	}
+
+	if BadReg(fields.Rt) {
+		return UnpredictableInstr{}
+	}
+
+	return LdrshLitT1(fields)
+}
+
+func (instr LdrshLitT1) Execute(cpu *CPU) {
+	LoadImmediate(cpu, LoadStoreFields(instr), 2, true)
+}
+
+func (instr LdrshLitT1) String() string {
+	return fmt.Sprintf("ldrsh.w %s, %s", instr.Rt, imm_address(LoadStoreFields(instr)))
+}
+
+/* LDRSH (register)
+ * ARM ARM A7.7.64
+ * Encoding T1 */
+type LdrshRegT1 LoadStoreFields
+
+func LdrshReg16T1(instr FetchedInstr) DecodedInstr {
+	return LdrshRegT1(decode_ldst_reg16(instr.Uint32()))
+}
+
+func (instr LdrshRegT1) Execute(cpu *CPU) {
+	LoadRegister(cpu, LoadStoreFields(instr), 2, true)
+}
+
+func (instr LdrshRegT1) String() string {
+	return fmt.Sprintf("ldrsh %s, %s", instr.Rt, reg_address(LoadStoreFields(instr)))
+}
+
+/* LDRSH (register)
+ * ARM ARM A7.7.64
+ * Encoding T2 */
+type LdrshRegT2 LoadStoreFields
+
+func LdrshReg32T2(instr FetchedInstr) DecodedInstr {
+	raw_instr := instr.Uint32()
+	fields := decode_ldst_reg32(raw_instr)
+
+	if fields.Rn == PC {
+		return LdrshLit32T1(instr)
+	}
+
+	if fields.Rt == PC {
+		return memory_hint(instr)
+	}
+
+	if BadReg(fields.Rt) || BadReg(fields.Rm) {
+		return UnpredictableInstr{}
+	}
+
+	return LdrshRegT2(fields)
+}
+
+func (instr LdrshRegT2) Execute(cpu *CPU) {
+	LoadRegister(cpu, LoadStoreFields(instr), 2, true)
+}
+
+func (instr LdrshRegT2) String() string {
+	return fmt.Sprintf("ldrsh.w %s, %s", instr.Rt, reg_address(LoadStoreFields(instr)))
+}
+
+/* LDRSHT
+ * ARM ARM A7.7.65
+ * Encoding T1 */
+type LdrshtT1 LoadStoreFields
+
+func Ldrsht32T1(instr FetchedInstr) DecodedInstr {
+	raw_instr := instr.Uint32()
+	fields := decode_ldst_imm8(raw_instr)
+
+	if fields.Rn == PC {
+		return LdrshLit32T1(instr)
+	}
+
+	if BadReg(fields.Rt) {
+		return UnpredictableInstr{}
+	}
+
+	return LdrshtT1(fields)
+}
+
+func (instr LdrshtT1) Execute(cpu *CPU) {
+	LoadUnprivileged(cpu, LoadStoreFields(instr), 2, true)
+}
+
+func (instr LdrshtT1) String() string {
+	return fmt.Sprintf("ldrsht %s, %s", instr.Rt, imm_address(LoadStoreFields(instr)))
+}
+
+/* LDRD (immediate)
+ * ARM ARM A7.7.49
+ * Encoding T1 */
+type LdrdImmT1 LoadStoreFields
+
+func LdrdImm32T1(instr FetchedInstr) DecodedInstr {
+	raw_instr := instr.Uint32()
+	fields := decode_ldst_dual(raw_instr)
+
+	if fields.Rn == PC {
+		return LdrdLit32T1(instr)
+	}
+
+	if fields.Wback && (fields.Rn == fields.Rt || fields.Rn == fields.Rt2) {
+		return UnpredictableInstr{}
+	}
+
+	if BadReg(fields.Rt) || BadReg(fields.Rt2) || fields.Rt == fields.Rt2 {
+		return UnpredictableInstr{}
+	}
+
+	return LdrdImmT1(fields)
+}
+
+func (instr LdrdImmT1) Execute(cpu *CPU) {
+	LoadDual(cpu, LoadStoreFields(instr))
+}
+
+func (instr LdrdImmT1) String() string {
+	return fmt.Sprintf("ldrd %s, %s, %s", instr.Rt, instr.Rt2, imm_address(LoadStoreFields(instr)))
+}
+
+/* LDRD (literal)
+ * ARM ARM A7.7.50
+ * Encoding T1 */
+type LdrdLitT1 LoadStoreFields
+
+func LdrdLit32T1(instr FetchedInstr) DecodedInstr {
+	raw_instr := instr.Uint32()
+	fields := decode_ldst_dual(raw_instr)
+
+	if fields.Wback {
+		return UnpredictableInstr{}
+	}
+
+	if BadReg(fields.Rt) || BadReg(fields.Rt2) || fields.Rt == fields.Rt2 {
+		return UnpredictableInstr{}
+	}
+
+	return LdrdLitT1(fields)
+}
+
+func (instr LdrdLitT1) Execute(cpu *CPU) {
+	LoadDual(cpu, LoadStoreFields(instr))
+}
+
+func (instr LdrdLitT1) String() string {
+	return fmt.Sprintf("ldrd %s, %s, %s", instr.Rt, instr.Rt2, imm_address(LoadStoreFields(instr)))
+}
@@ -0,0 +1,327 @@
+package core
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestIdentifyLdrImmT4(t *testing.T) {
+	cases := []IdentifyCase{
+		{instr: FetchedInstr32(0xf8565c04), instr_valid: true},  // ldr r5, [r6, #-4]
+		{instr: FetchedInstr32(0xf8565f04), instr_valid: true},  // ldr r5, [r6, #4]!
+		{instr: FetchedInstr32(0xf8565904), instr_valid: true},  // ldr r5, [r6], #-4
+		{instr: FetchedInstr32(0xf850ff04), instr_valid: true},  // ldr pc, [r0, #4]!
+		{instr: FetchedInstr32(0xf8510e04), instr_valid: false}, // ldrt r0, [r1, #4]
+		{instr: FetchedInstr32(0xf85f8008), instr_valid: false}, // ldr.w r8, [pc, #-8]
+		{instr: FetchedInstr32(0xf8d43fff), instr_valid: false}, // ldr.w r3, [r4, #4095]
+		{instr: FetchedInstr32(0xf8565804), instr_valid: false}, // P == 0 && W == 0
+		{instr: FetchedInstr32(0xf8566f04), instr_valid: false}, // ldr r6, [r6, #4]!
+	}
+
+	test_identify(t, cases, reflect.TypeOf(LdrImmT4{}))
+}
+
+func TestIdentifyLdrLitT2(t *testing.T) {
+	cases := []IdentifyCase{
+		{instr: FetchedInstr32(0xf85f8008), instr_valid: true},  // ldr.w r8, [pc, #-8]
+		{instr: FetchedInstr32(0xf8df8008), instr_valid: true},  // ldr.w r8, [pc, #8]
+		{instr: FetchedInstr32(0xf8d43fff), instr_valid: false}, // ldr.w r3, [r4, #4095]
+		{instr: FetchedInstr16(0x4802), instr_valid: false},     // ldr r0, [pc, #8]
+	}
+
+	test_identify(t, cases, reflect.TypeOf(LdrLitT2{}))
+}
+
+func TestIdentifyLdrbImmT2(t *testing.T) {
+	cases := []IdentifyCase{
+		{instr: FetchedInstr32(0xf8998001), instr_valid: true},  // ldrb.w r8, [r9, #1]
+		{instr: FetchedInstr32(0xf899f001), instr_valid: false}, // pld [r9, #1]
+		{instr: FetchedInstr32(0xf899d001), instr_valid: false}, // ldrb.w sp, [r9, #1]
+		{instr: FetchedInstr32(0xf8b98002), instr_valid: false}, // ldrh.w r8, [r9, #2]
+	}
+
+	test_identify(t, cases, reflect.TypeOf(LdrbImmT2{}))
+}
+
+func TestIdentifyLdrdImmT1(t *testing.T) {
+	cases := []IdentifyCase{
+		{instr: FetchedInstr32(0xe9d20102), instr_valid: true},  // ldrd r0, r1, [r2, #8]
+		{instr: FetchedInstr32(0xe9720102), instr_valid: true},  // ldrd r0, r1, [r2, #-8]!
+		{instr: FetchedInstr32(0xe8f20102), instr_valid: true},  // ldrd r0, r1, [r2], #8
+		{instr: FetchedInstr32(0xe95f0104), instr_valid: false}, // ldrd r0, r1, [pc, #-16]
+		{instr: FetchedInstr32(0xe9d20002), instr_valid: false}, // ldrd r0, r0, [r2, #8]
+		{instr: FetchedInstr32(0xe9f20202), instr_valid: false}, // ldrd r0, r2, [r2, #8]!
+		{instr: FetchedInstr32(0xe9c20102), instr_valid: false}, // strd r0, r1, [r2, #8]
+	}
+
+	test_identify(t, cases, reflect.TypeOf(LdrdImmT1{}))
+}
+
+func TestDecodeLdrImm16(t *testing.T) {
+	cases := []DecodeCase{
+		// ldr r0, [r1, #4]
+		{instr: FetchedInstr16(0x6848), decoded: LdrImmT1{Rt: 0, Rn: 1, Imm: 4, Index: true, Add: true, Wback: false}},
+	}
+
+	test_decode(t, cases, LdrImm16T1)
+
+	cases = []DecodeCase{
+		// ldr r2, [sp, #8]
+		{instr: FetchedInstr16(0x9a02), decoded: LdrImmT2{Rt: 2, Rn: SP, Imm: 8, Index: true, Add: true, Wback: false}},
+	}
+
+	test_decode(t, cases, LdrImm16T2)
+
+	cases = []DecodeCase{
+		// ldr r0, [pc, #8]
+		{instr: FetchedInstr16(0x4802), decoded: LdrLitT1{Rt: 0, Rn: PC, Imm: 8, Index: true, Add: true, Wback: false}},
+	}
+
+	test_decode(t, cases, LdrLit16T1)
+
+	cases = []DecodeCase{
+		// ldrsb r0, [r1, r2]
+		{instr: FetchedInstr16(0x5688), decoded: LdrsbRegT1{Rt: 0, Rn: 1, Rm: 2, Index: true, Add: true, Wback: false}},
+	}
+
+	test_decode(t, cases, LdrsbReg16T1)
+}
+
+func TestDecodeLdrImm32(t *testing.T) {
+	cases := []DecodeCase{
+		// ldr.w r3, [r4, #4095]
+		{instr: FetchedInstr32(0xf8d43fff), decoded: LdrImmT3{Rt: 3, Rn: 4, Imm: 4095, Index: true, Add: true, Wback: false}},
+		// ldr.w r0, [pc, #4]
+		{instr: FetchedInstr32(0xf8df0004), decoded: LdrLitT2{Rt: 0, Rn: PC, Imm: 4, Index: true, Add: true, Wback: false}},
+	}
+
+	test_decode(t, cases, LdrImm32T3)
+
+	cases = []DecodeCase{
+		// ldr r5, [r6, #-4]
+		{instr: FetchedInstr32(0xf8565c04), decoded: LdrImmT4{Rt: 5, Rn: 6, Imm: 4, Index: true, Add: false, Wback: false}},
+		// ldr r5, [r6, #4]!
+		{instr: FetchedInstr32(0xf8565f04), decoded: LdrImmT4{Rt: 5, Rn: 6, Imm: 4, Index: true, Add: true, Wback: true}},
+		// ldr r5, [r6], #-4
+		{instr: FetchedInstr32(0xf8565904), decoded: LdrImmT4{Rt: 5, Rn: 6, Imm: 4, Index: false, Add: false, Wback: true}},
+		// ldrt r0, [r1, #4]
+		{instr: FetchedInstr32(0xf8510e04), decoded: LdrtT1{Rt: 0, Rn: 1, Imm: 4, Index: true, Add: true, Wback: false}},
+		// P == 0 && W == 0
+		{instr: FetchedInstr32(0xf8565804), decoded: UndefinedInstr{}},
+		// ldr r6, [r6, #4]!
+		{instr: FetchedInstr32(0xf8566f04), decoded: UnpredictableInstr{}},
+	}
+
+	test_decode(t, cases, LdrImm32T4)
+
+	cases = []DecodeCase{
+		// ldr.w r8, [pc, #-8]
+		{instr: FetchedInstr32(0xf85f8008), decoded: LdrLitT2{Rt: 8, Rn: PC, Imm: 8, Index: true, Add: false, Wback: false}},
+	}
+
+	test_decode(t, cases, LdrLit32T2)
+}
+
+func TestDecodeLdrReg32T2(t *testing.T) {
+	cases := []DecodeCase{
+		// ldr.w r8, [r9, r10, lsl #2]
+		{instr: FetchedInstr32(0xf859802a), decoded: LdrRegT2{Rt: 8, Rn: 9, Rm: 10,
+			Shift: Shift{srtype: SRType_LSL, amount: 2}, Index: true, Add: true, Wback: false}},
+		// ldr.w r8, [r9, sp]
+		{instr: FetchedInstr32(0xf859800d), decoded: UnpredictableInstr{}},
+	}
+
+	test_decode(t, cases, LdrReg32T2)
+
+	cases = []DecodeCase{
+		// ldrsh.w r0, [r1, r2]
+		{instr: FetchedInstr32(0xf9310002), decoded: LdrshRegT2{Rt: 0, Rn: 1, Rm: 2, Index: true, Add: true, Wback: false}},
+		// ldrsh.w sp, [r1, r2]
+		{instr: FetchedInstr32(0xf931d002), decoded: UnpredictableInstr{}},
+	}
+
+	test_decode(t, cases, LdrshReg32T2)
+}
+
+func TestDecodeLdrbLdrh32(t *testing.T) {
+	cases := []DecodeCase{
+		// ldrb r8, [r9, #-1]!
+		{instr: FetchedInstr32(0xf8198d01), decoded: LdrbImmT3{Rt: 8, Rn: 9, Imm: 1, Index: true, Add: false, Wback: true}},
+		// ldrbt r0, [r1, #1]
+		{instr: FetchedInstr32(0xf8110e01), decoded: LdrbtT1{Rt: 0, Rn: 1, Imm: 1, Index: true, Add: true, Wback: false}},
+		// ldrb.w r0, [pc, #-3076]
+		{instr: FetchedInstr32(0xf81f0c04), decoded: LdrbLitT1{Rt: 0, Rn: PC, Imm: 0xc04, Index: true, Add: false, Wback: false}},
+	}
+
+	test_decode(t, cases, LdrbImm32T3)
+
+	cases = []DecodeCase{
+		// ldrh r8, [r9], #2
+		{instr: FetchedInstr32(0xf8398b02), decoded: LdrhImmT3{Rt: 8, Rn: 9, Imm: 2, Index: false, Add: true, Wback: true}},
+		// ldrh r9, [r9], #2
+		{instr: FetchedInstr32(0xf8399b02), decoded: UnpredictableInstr{}},
+	}
+
+	test_decode(t, cases, LdrhImm32T3)
+
+	cases = []DecodeCase{
+		// ldrsb.w r8, [r9, #1]
+		{instr: FetchedInstr32(0xf9998001), decoded: LdrsbImmT1{Rt: 8, Rn: 9, Imm: 1, Index: true, Add: true, Wback: false}},
+		// pli [r9, #1]
+		{instr: FetchedInstr32(0xf999f001), decoded: PliImmT1{Rn: 9, Imm: 1, Index: true, Add: true}},
+	}
+
+	test_decode(t, cases, LdrsbImm32T1)
+}
+
+func TestDecodeLdrd32(t *testing.T) {
+	cases := []DecodeCase{
+		// ldrd r0, r1, [r2, #8]
+		{instr: FetchedInstr32(0xe9d20102), decoded: LdrdImmT1{Rt: 0, Rt2: 1, Rn: 2, Imm: 8, Index: true, Add: true, Wback: false}},
+		// ldrd r0, r1, [r2, #-8]!
+		{instr: FetchedInstr32(0xe9720102), decoded: LdrdImmT1{Rt: 0, Rt2: 1, Rn: 2, Imm: 8, Index: true, Add: false, Wback: true}},
+		// ldrd r0, r1, [r2], #8
+		{instr: FetchedInstr32(0xe8f20102), decoded: LdrdImmT1{Rt: 0, Rt2: 1, Rn: 2, Imm: 8, Index: false, Add: true, Wback: true}},
+		// ldrd r0, r1, [pc, #-16]
+		{instr: FetchedInstr32(0xe95f0104), decoded: LdrdLitT1{Rt: 0, Rt2: 1, Rn: PC, Imm: 16, Index: true, Add: false, Wback: false}},
+		// ldrd r0, r0, [r2, #8]
+		{instr: FetchedInstr32(0xe9d20002), decoded: UnpredictableInstr{}},
+		// ldrd r0, r2, [r2, #8]!
+		{instr: FetchedInstr32(0xe9f20202), decoded: UnpredictableInstr{}},
+	}
+
+	test_decode(t, cases, LdrdImm32T1)
+}
+
+func TestExecuteLdr(t *testing.T) {
+	cases := []MemoryCase{
+		// ldr r0, [r1, #4]
+		{instr: LdrImmT1{Rt: 0, Rn: 1, Imm: 4, Index: true, Add: true, Wback: false},
+			regs:     Registers{r: GeneralRegs{0, 0x10, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12}},
+			expected: Registers{r: GeneralRegs{0x97969594, 0x10, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12}},
+			addr:     0x10, word: 0x93929190},
+		// ldr r0, [r1, #4] (unaligned)
+		{instr: LdrImmT1{Rt: 0, Rn: 1, Imm: 4, Index: true, Add: true, Wback: false},
+			regs:     Registers{r: GeneralRegs{0, 0x11, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12}},
+			expected: Registers{r: GeneralRegs{0x98979695, 0x11, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12}},
+			addr:     0x10, word: 0x93929190},
+		// ldr r5, [r6, #4]!
+		{instr: LdrImmT4{Rt: 5, Rn: 6, Imm: 4, Index: true, Add: true, Wback: true},
+			regs:     Registers{r: GeneralRegs{0, 1, 2, 3, 4, 5, 0x10, 7, 8, 9, 10, 11, 12}},
+			expected: Registers{r: GeneralRegs{0, 1, 2, 3, 4, 0x97969594, 0x14, 7, 8, 9, 10, 11, 12}},
+			addr:     0x10, word: 0x93929190},
+		// ldr r5, [r6], #-4
+		{instr: LdrImmT4{Rt: 5, Rn: 6, Imm: 4, Index: false, Add: false, Wback: true},
+			regs:     Registers{r: GeneralRegs{0, 1, 2, 3, 4, 5, 0x10, 7, 8, 9, 10, 11, 12}},
+			expected: Registers{r: GeneralRegs{0, 1, 2, 3, 4, 0x93929190, 0xc, 7, 8, 9, 10, 11, 12}},
+			addr:     0x10, word: 0x93929190},
+		// ldr r5, [r6, #4]! (unmapped)
+		{instr: LdrImmT4{Rt: 5, Rn: 6, Imm: 4, Index: true, Add: true, Wback: true},
+			regs:     Registers{r: GeneralRegs{0, 1, 2, 3, 4, 5, 0x100, 7, 8, 9, 10, 11, 12}},
+			expected: Registers{r: GeneralRegs{0, 1, 2, 3, 4, 5, 0x100, 7, 8, 9, 10, 11, 12}},
+			addr:     0x10, word: 0x93929190, fault: true},
+		// ldr r0, [pc, #8]
+		{instr: LdrLitT1{Rt: 0, Rn: PC, Imm: 8, Index: true, Add: true, Wback: false},
+			regs:     Registers{r: GeneralRegs{0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12}, pc: 0x6},
+			expected: Registers{r: GeneralRegs{0x8f8e8d8c, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12}, pc: 0x6},
+			addr:     0x10, word: 0x93929190},
+		// ldr.w r8, [r9, r10, lsl #2]
+		{instr: LdrRegT2{Rt: 8, Rn: 9, Rm: 10, Shift: Shift{srtype: SRType_LSL, amount: 2}, Index: true, Add: true, Wback: false},
+			regs:     Registers{r: GeneralRegs{0, 1, 2, 3, 4, 5, 6, 7, 8, 0x4, 3, 11, 12}},
+			expected: Registers{r: GeneralRegs{0, 1, 2, 3, 4, 5, 6, 7, 0x93929190, 0x4, 3, 11, 12}},
+			addr:     0x10, word: 0x93929190},
+		// ldr pc, [r0, #4]! (interworking, clears the Thumb bit)
+		{instr: LdrImmT4{Rt: PC, Rn: 0, Imm: 4, Index: true, Add: true, Wback: true},
+			regs:     Registers{r: GeneralRegs{0xc, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12}, Epsr: Epsr{T: true}},
+			expected: Registers{r: GeneralRegs{0x10, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12}, pc: 0x93929190, branched: true},
+			addr:     0x10, word: 0x93929190},
+		// ldrt r0, [r1, #4]
+		{instr: LdrtT1{Rt: 0, Rn: 1, Imm: 4, Index: true, Add: true, Wback: false},
+			regs:     Registers{r: GeneralRegs{0, 0x10, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12}},
+			expected: Registers{r: GeneralRegs{0x97969594, 0x10, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12}},
+			addr:     0x10, word: 0x93929190},
+		// ldrt r0, [r1, #4] (SCS, unprivileged even from privileged code)
+		{instr: LdrtT1{Rt: 0, Rn: 1, Imm: 4, Index: true, Add: true, Wback: false},
+			regs:     Registers{r: GeneralRegs{0, SCB_ICSR - 4, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12}},
+			expected: Registers{r: GeneralRegs{0, SCB_ICSR - 4, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12}},
+			addr:     0x10, word: 0x93929190, fault: true},
+	}
+
+	test_execute_memory(t, cases)
+}
+
+func TestLdrtSCS(t *testing.T) {
+	cpu := exception_cpu(t)
+	cpu.SetR(1, SCB_ICSR-4)
+
+	/* Privileged Thread mode may read ICSR, but not with LDRT */
+	LdrImmT4{Rt: 0, Rn: 1, Imm: 4, Index: true, Add: true}.Execute(cpu)
+	if cpu.fault != nil {
+		t.Fatalf("ldr: %v", cpu.fault)
+	}
+
+	LdrtT1{Rt: 0, Rn: 1, Imm: 4, Index: true, Add: true}.Execute(cpu)
+	expected := MemoryFault{Addr: SCB_ICSR, Write: false, Err: ErrUnprivilegedAccess}
+	if cpu.fault != expected {
+		t.Errorf("ldrt: %v, expected %v", cpu.fault, expected)
+	}
+}
+
+func TestExecuteLdrbLdrh(t *testing.T) {
+	cases := []MemoryCase{
+		// ldrb r8, [r9, #-1]!
+		{instr: LdrbImmT3{Rt: 8, Rn: 9, Imm: 1, Index: true, Add: false, Wback: true},
+			regs:     Registers{r: GeneralRegs{0, 1, 2, 3, 4, 5, 6, 7, 8, 0x11, 10, 11, 12}},
+			expected: Registers{r: GeneralRegs{0, 1, 2, 3, 4, 5, 6, 7, 0x90, 0x10, 10, 11, 12}},
+			addr:     0x10, word: 0x93929190},
+		// ldrsb r0, [r1, r2]
+		{instr: LdrsbRegT1{Rt: 0, Rn: 1, Rm: 2, Index: true, Add: true, Wback: false},
+			regs:     Registers{r: GeneralRegs{0, 0x10, 3, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12}},
+			expected: Registers{r: GeneralRegs{0xffffff93, 0x10, 3, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12}},
+			addr:     0x10, word: 0x93929190},
+		// ldrh.w r0, [r1, r2, lsl #3]
+		{instr: LdrhRegT2{Rt: 0, Rn: 1, Rm: 2, Shift: Shift{srtype: SRType_LSL, amount: 3}, Index: true, Add: true, Wback: false},
+			regs:     Registers{r: GeneralRegs{0, 0x8, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12}},
+			expected: Registers{r: GeneralRegs{0x9998, 0x8, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12}},
+			addr:     0x10, word: 0x93929190},
+		// ldrsh r8, [r9, #-2]!
+		{instr: LdrshImmT2{Rt: 8, Rn: 9, Imm: 2, Index: true, Add: false, Wback: true},
+			regs:     Registers{r: GeneralRegs{0, 1, 2, 3, 4, 5, 6, 7, 8, 0x1a, 10, 11, 12}},
+			expected: Registers{r: GeneralRegs{0, 1, 2, 3, 4, 5, 6, 7, 0xffff9998, 0x18, 10, 11, 12}},
+			addr:     0x10, word: 0x93929190},
+		// ldrh r0, [r1, #62] (unmapped)
+		{instr: LdrhImmT1{Rt: 0, Rn: 1, Imm: 62, Index: true, Add: true, Wback: false},
+			regs:     Registers{r: GeneralRegs{0, 0x10, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12}},
+			expected: Registers{r: GeneralRegs{0, 0x10, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12}},
+			addr:     0x10, word: 0x93929190, fault: true},
+	}
+
+	test_execute_memory(t, cases)
+}
+
+func TestExecuteLdrd(t *testing.T) {
+	cases := []MemoryCase{
+		// ldrd r0, r1, [r2, #8]
+		{instr: LdrdImmT1{Rt: 0, Rt2: 1, Rn: 2, Imm: 8, Index: true, Add: true, Wback: false},
+			regs:     Registers{r: GeneralRegs{0, 1, 0x8, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12}},
+			expected: Registers{r: GeneralRegs{0x93929190, 0x97969594, 0x8, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12}},
+			addr:     0x10, word: 0x93929190},
+		// ldrd r0, r1, [r2], #8
+		{instr: LdrdImmT1{Rt: 0, Rt2: 1, Rn: 2, Imm: 8, Index: false, Add: true, Wback: true},
+			regs:     Registers{r: GeneralRegs{0, 1, 0x8, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12}},
+			expected: Registers{r: GeneralRegs{0x8b8a8988, 0x8f8e8d8c, 0x10, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12}},
+			addr:     0x10, word: 0x93929190},
+		// ldrd r0, r1, [pc, #-16]
+		{instr: LdrdLitT1{Rt: 0, Rt2: 1, Rn: PC, Imm: 16, Index: true, Add: false, Wback: false},
+			regs:     Registers{r: GeneralRegs{0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12}, pc: 0x1e},
+			expected: Registers{r: GeneralRegs{0x8f8e8d8c, 0x93929190, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12}, pc: 0x1e},
+			addr:     0x10, word: 0x93929190},
+		// ldrd r0, r1, [r2, #8]! (unaligned)
+		{instr: LdrdImmT1{Rt: 0, Rt2: 1, Rn: 2, Imm: 8, Index: true, Add: true, Wback: true},
+			regs:     Registers{r: GeneralRegs{0, 1, 0x6, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12}},
+			expected: Registers{r: GeneralRegs{0, 1, 0x6, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12}},
+			addr:     0x10, word: 0x93929190, fault: true},
+	}
+
+	test_execute_memory(t, cases)
+}
@@ -0,0 +1,318 @@
+package core
+
+import "fmt"
+
+/* LDM, LDMIA, LDMFD
+ * ARM ARM A7.7.40
+ * Encoding T1 */
+type LdmT1 MultipleFields
+
+func Ldm16T1(instr FetchedInstr) DecodedInstr {
+	raw_instr := instr.Uint32()
+
+	Rn := RegIndex((raw_instr >> 8) & 0x7)
+	registers := uint16(raw_instr & 0xff)
+
+	if registers == 0 {
+		return UnpredictableInstr{}
+	}
+
+	/* Rn is only written back if it isn't loaded */
+	wback := !in_register_list(registers, Rn)
+
+	return LdmT1{Rn: Rn, Registers: registers, Wback: wback}
+}
+
+func (instr LdmT1) Execute(cpu *CPU) {
+	LoadMultipleIA(cpu, MultipleFields(instr))
+}
+
+func (instr LdmT1) String() string {
+	return fmt.Sprintf("ldm %s", multiple_operands(MultipleFields(instr)))
+}
+
+/* LDM, LDMIA, LDMFD
+ * ARM ARM A7.7.40
+ * Encoding T2 */
+type LdmT2 MultipleFields
+
+func Ldm32T2(instr FetchedInstr) DecodedInstr {
+	fields := decode_multiple(instr.Uint32())
+
+	if fields.Wback && fields.Rn == SP {
+		return Pop32T2(instr)
+	}
+
+	if fields.Rn == PC || bad_register_list(fields.Registers, true) {
+		return UnpredictableInstr{}
+	}
+
+	if fields.Wback && in_register_list(fields.Registers, fields.Rn) {
+		return UnpredictableInstr{}
+	}
+
+	return LdmT2(fields)
+}
+
+func (instr LdmT2) Execute(cpu *CPU) {
+	LoadMultipleIA(cpu, MultipleFields(instr))
+}
+
+func (instr LdmT2) String() string {
+	return fmt.Sprintf("ldm.w %s", multiple_operands(MultipleFields(instr)))
+}
+
+/* LDMDB, LDMEA
+ * ARM ARM A7.7.41
+ * Encoding T1 */
+type LdmdbT1 MultipleFields
+
+func Ldmdb32T1(instr FetchedInstr) DecodedInstr {
+	fields := decode_multiple(instr.Uint32())
+
+	if fields.Rn == PC || bad_register_list(fields.Registers, true) {
+		return UnpredictableInstr{}
+	}
+
+	if fields.Wback && in_register_list(fields.Registers, fields.Rn) {
+		return UnpredictableInstr{}
+	}
+
+	return LdmdbT1(fields)
+}
+
+func (instr LdmdbT1) Execute(cpu *CPU) {
+	LoadMultipleDB(cpu, MultipleFields(instr))
+}
+
+func (instr LdmdbT1) String() string {
+	return fmt.Sprintf("ldmdb %s", multiple_operands(MultipleFields(instr)))
+}
+
+/* STM, STMIA, STMEA
+ * ARM ARM A7.7.156
+ * Encoding T1 */
+type StmT1 MultipleFields
+
+func Stm16T1(instr FetchedInstr) DecodedInstr {
+	raw_instr := instr.Uint32()
+
+	Rn := RegIndex((raw_instr >> 8) & 0x7)
+	registers := uint16(raw_instr & 0xff)
+
+	if registers == 0 {
+		return UnpredictableInstr{}
+	}
+
+	return StmT1{Rn: Rn, Registers: registers, Wback: true}
+}
+
+func (instr StmT1) Execute(cpu *CPU) {
+	StoreMultipleIA(cpu, MultipleFields(instr))
+}
+
+func (instr StmT1) String() string {
+	return fmt.Sprintf("stm %s", multiple_operands(MultipleFields(instr)))
+}
+
+/* STM, STMIA, STMEA
+ * ARM ARM A7.7.156
+ * Encoding T2 */
+type StmT2 MultipleFields
+
+func Stm32T2(instr FetchedInstr) DecodedInstr {
+	fields := decode_multiple(instr.Uint32())
+
+	if fields.Rn == PC || bad_register_list(fields.Registers, false) {
+		return UnpredictableInstr{}
+	}
+
+	if fields.Wback && in_register_list(fields.Registers, fields.Rn) {
+		return UnpredictableInstr{}
+	}
+
+	return StmT2(fields)
+}
+
+func (instr StmT2) Execute(cpu *CPU) {
+	StoreMultipleIA(cpu, MultipleFields(instr))
+}
+
+func (instr StmT2) String() string {
+	return fmt.Sprintf("stm.w %s", multiple_operands(MultipleFields(instr)))
+}
+
+/* STMDB, STMFD
+ * ARM ARM A7.7.157
+ * Encoding T1 */
+type StmdbT1 MultipleFields
+
+func Stmdb32T1(instr FetchedInstr) DecodedInstr {
+	fields := decode_multiple(instr.Uint32())
+
+	if fields.Wback && fields.Rn == SP {
+		return Push32T2(instr)
+	}
+
+	if fields.Rn == PC || bad_register_list(fields.Registers, false) {
+		return UnpredictableInstr{}
+	}
+
+	if fields.Wback && in_register_list(fields.Registers, fields.Rn) {
+		return UnpredictableInstr{}
+	}
+
+	return StmdbT1(fields)
+}
+
+func (instr StmdbT1) Execute(cpu *CPU) {
+	StoreMultipleDB(cpu, MultipleFields(instr))
+}
+
+func (instr StmdbT1) String() string {
+	return fmt.Sprintf("stmdb %s", multiple_operands(MultipleFields(instr)))
+}
+
+/* PUSH
+ * ARM ARM A7.7.99
+ * Encoding T1 */
+type PushT1 MultipleFields
+
+func Push16T1(instr FetchedInstr) DecodedInstr {
+	raw_instr := instr.Uint32()
+
+	M := uint16((raw_instr >> 8) & 0x1)
+	registers := (M << LR) | uint16(raw_instr&0xff)
+
+	if registers == 0 {
+		return UnpredictableInstr{}
+	}
+
+	return PushT1{Rn: SP, Registers: registers, Wback: true}
+}
+
+func (instr PushT1) Execute(cpu *CPU) {
+	StoreMultipleDB(cpu, MultipleFields(instr))
+}
+
+func (instr PushT1) String() string {
+	return fmt.Sprintf("push %s", register_list(instr.Registers))
+}
+
+/* PUSH
+ * ARM ARM A7.7.99
+ * Encoding T2 */
+type PushT2 MultipleFields
+
+func Push32T2(instr FetchedInstr) DecodedInstr {
+	registers := uint16(instr.Uint32() & 0xffff)
+
+	if bad_register_list(registers, false) {
+		return UnpredictableInstr{}
+	}
+
+	return PushT2{Rn: SP, Registers: registers, Wback: true}
+}
+
+func (instr PushT2) Execute(cpu *CPU) {
+	StoreMultipleDB(cpu, MultipleFields(instr))
+}
+
+func (instr PushT2) String() string {
+	return fmt.Sprintf("push.w %s", register_list(instr.Registers))
+}
+
+/* PUSH
+ * ARM ARM A7.7.99
+ * Encoding T3, a single register */
+type PushT3 MultipleFields
+
+func Push32T3(instr FetchedInstr) DecodedInstr {
+	Rt := RegIndex((instr.Uint32() >> 12) & 0xf)
+
+	if BadReg(Rt) {
+		return UnpredictableInstr{}
+	}
+
+	return PushT3{Rn: SP, Registers: 1 << Rt, Wback: true}
+}
+
+func (instr PushT3) Execute(cpu *CPU) {
+	StoreMultipleDB(cpu, MultipleFields(instr))
+}
+
+func (instr PushT3) String() string {
+	return fmt.Sprintf("push.w %s", register_list(instr.Registers))
+}
+
+/* POP
+ * ARM ARM A7.7.98
+ * Encoding T1 */
+type PopT1 MultipleFields
+
+func Pop16T1(instr FetchedInstr) DecodedInstr {
+	raw_instr := instr.Uint32()
+
+	P := uint16((raw_instr >> 8) & 0x1)
+	registers := (P << PC) | uint16(raw_instr&0xff)
+
+	if registers == 0 {
+		return UnpredictableInstr{}
+	}
+
+	return PopT1{Rn: SP, Registers: registers, Wback: true}
+}
+
+func (instr PopT1) Execute(cpu *CPU) {
+	LoadMultipleIA(cpu, MultipleFields(instr))
+}
+
+func (instr PopT1) String() string {
+	return fmt.Sprintf("pop %s", register_list(instr.Registers))
+}
+
+/* POP
+ * ARM ARM A7.7.98
+ * Encoding T2 */
+type PopT2 MultipleFields
+
+func Pop32T2(instr FetchedInstr) DecodedInstr {
+	registers := uint16(instr.Uint32() & 0xffff)
+
+	if bad_register_list(registers, true) {
+		return UnpredictableInstr{}
+	}
+
+	return PopT2{Rn: SP, Registers: registers, Wback: true}
+}
+
+func (instr PopT2) Execute(cpu *CPU) {
+	LoadMultipleIA(cpu, MultipleFields(instr))
+}
+
+func (instr PopT2) String() string {
+	return fmt.Sprintf("pop.w %s", register_list(instr.Registers))
+}
+
+/* POP
+ * ARM ARM A7.7.98
+ * Encoding T3, a single register */
+type PopT3 MultipleFields
+
+func Pop32T3(instr FetchedInstr) DecodedInstr {
+	Rt := RegIndex((instr.Uint32() >> 12) & 0xf)
+
+	if Rt == SP {
+		return UnpredictableInstr{}
+	}
+
+	return PopT3{Rn: SP, Registers: 1 << Rt, Wback: true}
+}
+
+func (instr PopT3) Execute(cpu *CPU) {
+	LoadMultipleIA(cpu, MultipleFields(instr))
+}
+
+func (instr PopT3) String() string {
+	return fmt.Sprintf("pop.w %s", register_list(instr.Registers))
+}
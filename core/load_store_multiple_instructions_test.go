@@ -0,0 +1,217 @@
+package core
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestIdentifyLdmT2(t *testing.T) {
+	cases := []IdentifyCase{
+		{instr: FetchedInstr32(0xe8b80203), instr_valid: true},  // ldm.w r8!, {r0, r1, r9}
+		{instr: FetchedInstr32(0xe8984003), instr_valid: true},  // ldm.w r8, {r0, r1, lr}
+		{instr: FetchedInstr32(0xe8bd8130), instr_valid: false}, // pop.w {r4, r5, r8, pc}
+		{instr: FetchedInstr32(0xe8b80103), instr_valid: false}, // ldm.w r8!, {r0, r1, r8}
+		{instr: FetchedInstr32(0xe898c003), instr_valid: false}, // ldm.w r8, {r0, r1, lr, pc}
+		{instr: FetchedInstr32(0xe8980001), instr_valid: false}, // ldm.w r8, {r0}
+		{instr: FetchedInstr32(0xe9380203), instr_valid: false}, // ldmdb r8!, {r0, r1, r9}
+	}
+
+	test_identify(t, cases, reflect.TypeOf(LdmT2{}))
+}
+
+func TestIdentifyPushT2(t *testing.T) {
+	cases := []IdentifyCase{
+		{instr: FetchedInstr32(0xe92d4130), instr_valid: true},  // push.w {r4, r5, r8, lr}
+		{instr: FetchedInstr32(0xe92d8130), instr_valid: false}, // push.w {r4, r5, r8, pc}
+		{instr: FetchedInstr32(0xe9284130), instr_valid: false}, // stmdb r8!, {r4, r5, r8, lr}
+		{instr: FetchedInstr16(0xb530), instr_valid: false},     // push {r4, r5, lr}
+	}
+
+	test_identify(t, cases, reflect.TypeOf(PushT2{}))
+}
+
+func TestIdentifyPopT3(t *testing.T) {
+	cases := []IdentifyCase{
+		{instr: FetchedInstr32(0xf85d4b04), instr_valid: true},  // pop.w {r4}
+		{instr: FetchedInstr32(0xf85dfb04), instr_valid: true},  // pop.w {pc}
+		{instr: FetchedInstr32(0xf85d4b08), instr_valid: false}, // ldr r4, [sp], #8
+		{instr: FetchedInstr32(0xf85ddb04), instr_valid: false}, // pop.w {sp}
+	}
+
+	test_identify(t, cases, reflect.TypeOf(PopT3{}))
+}
+
+func TestDecodeLdmStm16(t *testing.T) {
+	cases := []DecodeCase{
+		// ldm r0!, {r1, r2}
+		{instr: FetchedInstr16(0xc806), decoded: LdmT1{Rn: 0, Registers: 0x6, Wback: true}},
+		// ldm r0, {r0, r1}
+		{instr: FetchedInstr16(0xc803), decoded: LdmT1{Rn: 0, Registers: 0x3, Wback: false}},
+		// ldm r0!, {}
+		{instr: FetchedInstr16(0xc800), decoded: UnpredictableInstr{}},
+	}
+
+	test_decode(t, cases, Ldm16T1)
+
+	cases = []DecodeCase{
+		// stm r0!, {r1, r2}
+		{instr: FetchedInstr16(0xc006), decoded: StmT1{Rn: 0, Registers: 0x6, Wback: true}},
+	}
+
+	test_decode(t, cases, Stm16T1)
+}
+
+func TestDecodePushPop16(t *testing.T) {
+	cases := []DecodeCase{
+		// push {r4, r5, lr}
+		{instr: FetchedInstr16(0xb530), decoded: PushT1{Rn: SP, Registers: 0x4030, Wback: true}},
+		// push {}
+		{instr: FetchedInstr16(0xb400), decoded: UnpredictableInstr{}},
+	}
+
+	test_decode(t, cases, Push16T1)
+
+	cases = []DecodeCase{
+		// pop {r4, r5, pc}
+		{instr: FetchedInstr16(0xbd30), decoded: PopT1{Rn: SP, Registers: 0x8030, Wback: true}},
+	}
+
+	test_decode(t, cases, Pop16T1)
+}
+
+func TestDecodeLdmStm32(t *testing.T) {
+	cases := []DecodeCase{
+		// ldm.w r8!, {r0, r1, r9}
+		{instr: FetchedInstr32(0xe8b80203), decoded: LdmT2{Rn: 8, Registers: 0x203, Wback: true}},
+		// pop.w {r4, r5, r8, pc}
+		{instr: FetchedInstr32(0xe8bd8130), decoded: PopT2{Rn: SP, Registers: 0x8130, Wback: true}},
+		// ldm.w r8, {r0, sp}
+		{instr: FetchedInstr32(0xe8982001), decoded: UnpredictableInstr{}},
+	}
+
+	test_decode(t, cases, Ldm32T2)
+
+	cases = []DecodeCase{
+		// ldmdb r8, {r0, r1, r9}
+		{instr: FetchedInstr32(0xe9180203), decoded: LdmdbT1{Rn: 8, Registers: 0x203, Wback: false}},
+	}
+
+	test_decode(t, cases, Ldmdb32T1)
+
+	cases = []DecodeCase{
+		// stm.w r8!, {r0, r1, r9}
+		{instr: FetchedInstr32(0xe8a80203), decoded: StmT2{Rn: 8, Registers: 0x203, Wback: true}},
+		// stm.w r8, {r0, pc}
+		{instr: FetchedInstr32(0xe8888001), decoded: UnpredictableInstr{}},
+	}
+
+	test_decode(t, cases, Stm32T2)
+
+	cases = []DecodeCase{
+		// stmdb r8, {r0, r1, lr}
+		{instr: FetchedInstr32(0xe9084003), decoded: StmdbT1{Rn: 8, Registers: 0x4003, Wback: false}},
+		// push.w {r4, r5, r8, lr}
+		{instr: FetchedInstr32(0xe92d4130), decoded: PushT2{Rn: SP, Registers: 0x4130, Wback: true}},
+	}
+
+	test_decode(t, cases, Stmdb32T1)
+}
+
+func TestDecodePushPop32(t *testing.T) {
+	cases := []DecodeCase{
+		// push.w {r4}
+		{instr: FetchedInstr32(0xf84d4d04), decoded: PushT3{Rn: SP, Registers: 0x10, Wback: true}},
+		// push.w {sp}
+		{instr: FetchedInstr32(0xf84ddd04), decoded: UnpredictableInstr{}},
+	}
+
+	test_decode(t, cases, StrImm32T4)
+
+	cases = []DecodeCase{
+		// pop.w {pc}
+		{instr: FetchedInstr32(0xf85dfb04), decoded: PopT3{Rn: SP, Registers: 0x8000, Wback: true}},
+	}
+
+	test_decode(t, cases, LdrImm32T4)
+}
+
+func TestExecuteLdm(t *testing.T) {
+	cases := []MemoryCase{
+		// ldm r0!, {r1, r2}
+		{instr: LdmT1{Rn: 0, Registers: 0x6, Wback: true},
+			regs:     Registers{r: GeneralRegs{0x8, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12}},
+			expected: Registers{r: GeneralRegs{0x10, 0x8b8a8988, 0x8f8e8d8c, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12}},
+			addr:     0x10, word: 0x93929190},
+		// ldm r0, {r0, r1}
+		{instr: LdmT1{Rn: 0, Registers: 0x3, Wback: false},
+			regs:     Registers{r: GeneralRegs{0x8, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12}},
+			expected: Registers{r: GeneralRegs{0x8b8a8988, 0x8f8e8d8c, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12}},
+			addr:     0x10, word: 0x93929190},
+		// ldmdb r8!, {r0, r1, r9}
+		{instr: LdmdbT1{Rn: 8, Registers: 0x203, Wback: true},
+			regs:     Registers{r: GeneralRegs{0, 1, 2, 3, 4, 5, 6, 7, 0x1c, 9, 10, 11, 12}},
+			expected: Registers{r: GeneralRegs{0x93929190, 0x97969594, 2, 3, 4, 5, 6, 7, 0x10, 0x9b9a9998, 10, 11, 12}},
+			addr:     0x10, word: 0x93929190},
+		// ldm r0!, {r1, r2} (unaligned)
+		{instr: LdmT1{Rn: 0, Registers: 0x6, Wback: true},
+			regs:     Registers{r: GeneralRegs{0x2, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12}},
+			expected: Registers{r: GeneralRegs{0x2, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12}},
+			addr:     0x10, word: 0x93929190, fault: true},
+	}
+
+	test_execute_memory(t, cases)
+}
+
+func TestExecuteStm(t *testing.T) {
+	cases := []MemoryCase{
+		// stm r0!, {r1, r2}
+		{instr: StmT1{Rn: 0, Registers: 0x6, Wback: true},
+			regs:     Registers{r: GeneralRegs{0xc, 0x11111111, 0x22222222, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12}},
+			expected: Registers{r: GeneralRegs{0x14, 0x11111111, 0x22222222, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12}},
+			addr:     0x10, word: 0x22222222},
+		// stm.w r8, {r0, r1, r9}
+		{instr: StmT2{Rn: 8, Registers: 0x203, Wback: false},
+			regs:     Registers{r: GeneralRegs{0x11111111, 1, 2, 3, 4, 5, 6, 7, 0x10, 9, 10, 11, 12}},
+			expected: Registers{r: GeneralRegs{0x11111111, 1, 2, 3, 4, 5, 6, 7, 0x10, 9, 10, 11, 12}},
+			addr:     0x10, word: 0x11111111},
+		// stmdb r8!, {r0, r1, lr}
+		{instr: StmdbT1{Rn: 8, Registers: 0x4003, Wback: true},
+			regs:     Registers{r: GeneralRegs{0, 1, 2, 3, 4, 5, 6, 7, 0x1c, 9, 10, 11, 12}, lr: 0xeeeeeeee},
+			expected: Registers{r: GeneralRegs{0, 1, 2, 3, 4, 5, 6, 7, 0x10, 9, 10, 11, 12}, lr: 0xeeeeeeee},
+			addr:     0x18, word: 0xeeeeeeee},
+		// stm r0!, {r1, r2} (unmapped)
+		{instr: StmT1{Rn: 0, Registers: 0x6, Wback: true},
+			regs:     Registers{r: GeneralRegs{0x40, 0x11111111, 0x22222222, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12}},
+			expected: Registers{r: GeneralRegs{0x40, 0x11111111, 0x22222222, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12}},
+			addr:     0x10, word: 0x93929190, fault: true},
+	}
+
+	test_execute_memory(t, cases)
+}
+
+func TestExecutePushPop(t *testing.T) {
+	cases := []MemoryCase{
+		// push {r4, r5, lr}
+		{instr: PushT1{Rn: SP, Registers: 0x4030, Wback: true},
+			regs:     Registers{r: GeneralRegs{0, 1, 2, 3, 0x44, 0x55, 6, 7, 8, 9, 10, 11, 12}, sp: SPRegs{0x1c, 0}, lr: 0xeeeeeeee},
+			expected: Registers{r: GeneralRegs{0, 1, 2, 3, 0x44, 0x55, 6, 7, 8, 9, 10, 11, 12}, sp: SPRegs{0x10, 0}, lr: 0xeeeeeeee},
+			addr:     0x10, word: 0x44},
+		// push.w {r4}
+		{instr: PushT3{Rn: SP, Registers: 0x10, Wback: true},
+			regs:     Registers{r: GeneralRegs{0, 1, 2, 3, 0x44, 5, 6, 7, 8, 9, 10, 11, 12}, sp: SPRegs{0x14, 0}},
+			expected: Registers{r: GeneralRegs{0, 1, 2, 3, 0x44, 5, 6, 7, 8, 9, 10, 11, 12}, sp: SPRegs{0x10, 0}},
+			addr:     0x10, word: 0x44},
+		// pop {r4, r5, pc}
+		{instr: PopT1{Rn: SP, Registers: 0x8030, Wback: true},
+			regs:     Registers{r: GeneralRegs{0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12}, sp: SPRegs{0x10, 0}, Epsr: Epsr{T: true}},
+			expected: Registers{r: GeneralRegs{0, 1, 2, 3, 0x93929190, 0x97969594, 6, 7, 8, 9, 10, 11, 12}, sp: SPRegs{0x1c, 0}, pc: 0x9b9a9998, branched: true},
+			addr:     0x10, word: 0x93929190},
+		// pop {r4, r5} (unmapped)
+		{instr: PopT1{Rn: SP, Registers: 0x30, Wback: true},
+			regs:     Registers{r: GeneralRegs{0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12}, sp: SPRegs{0x3c, 0}},
+			expected: Registers{r: GeneralRegs{0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12}, sp: SPRegs{0x3c, 0}},
+			addr:     0x10, word: 0x93929190, fault: true},
+	}
+
+	test_execute_memory(t, cases)
+}
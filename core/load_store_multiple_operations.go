@@ -0,0 +1,155 @@
+package core
+
+import (
+	"bytes"
+	"fmt"
+	"math/bits"
+)
+
+func in_register_list(registers uint16, r RegIndex) bool {
+	return registers&(1<<r) != 0
+}
+
+/* Load the listed registers from consecutive words, the lowest numbered
+ * register from address. Nothing is written if any access faults. */
+func load_multiple(cpu *CPU, instr MultipleFields, address uint32, wback_addr uint32) {
+	if in_register_list(instr.Registers, PC) && cpu.InITBlock() && !cpu.LastInITBlock() {
+		// UNPREDICTABLE
+		return
+	}
+
+	var values [16]uint32
+	for i := RegIndex(0); i <= PC; i++ {
+		if !in_register_list(instr.Registers, i) {
+			continue
+		}
+
+		value, ok := cpu.MemA(address, 4)
+		if !ok {
+			return
+		}
+
+		values[i] = value
+		address += 4
+	}
+
+	if instr.Wback {
+		cpu.SetR(instr.Rn, wback_addr)
+	}
+
+	for i := RegIndex(0); i < PC; i++ {
+		if in_register_list(instr.Registers, i) {
+			cpu.SetR(i, values[i])
+		}
+	}
+
+	if in_register_list(instr.Registers, PC) {
+		cpu.LoadWritePC(values[PC])
+	}
+}
+
+/* Store the listed registers to consecutive words, the lowest numbered
+ * register to address. Rn is not written back if any access faults. */
+func store_multiple(cpu *CPU, instr MultipleFields, address uint32, wback_addr uint32) {
+	for i := RegIndex(0); i <= PC; i++ {
+		if !in_register_list(instr.Registers, i) {
+			continue
+		}
+
+		if !cpu.SetMemA(address, 4, cpu.R(i)) {
+			return
+		}
+
+		address += 4
+	}
+
+	if instr.Wback {
+		cpu.SetR(instr.Rn, wback_addr)
+	}
+}
+
+func register_list_size(registers uint16) uint32 {
+	return 4 * uint32(bits.OnesCount16(registers))
+}
+
+/* Perform load multiple, increment after (LDM, POP) */
+func LoadMultipleIA(cpu *CPU, instr MultipleFields) {
+	address := cpu.R(instr.Rn)
+
+	load_multiple(cpu, instr, address, address+register_list_size(instr.Registers))
+}
+
+/* Perform load multiple, decrement before (LDMDB) */
+func LoadMultipleDB(cpu *CPU, instr MultipleFields) {
+	address := cpu.R(instr.Rn) - register_list_size(instr.Registers)
+
+	load_multiple(cpu, instr, address, address)
+}
+
+/* Perform store multiple, increment after (STM) */
+func StoreMultipleIA(cpu *CPU, instr MultipleFields) {
+	address := cpu.R(instr.Rn)
+
+	store_multiple(cpu, instr, address, address+register_list_size(instr.Registers))
+}
+
+/* Perform store multiple, decrement before (STMDB, PUSH) */
+func StoreMultipleDB(cpu *CPU, instr MultipleFields) {
+	address := cpu.R(instr.Rn) - register_list_size(instr.Registers)
+
+	store_multiple(cpu, instr, address, address)
+}
+
+/* Extract the base register, register list and writeback bit of the
+ * 32-bit LDM, LDMDB, STM and STMDB encodings */
+func decode_multiple(raw_instr uint32) MultipleFields {
+	Rn := RegIndex((raw_instr >> 16) & 0xf)
+	registers := uint16(raw_instr & 0xffff)
+	wback := utobool(uint8((raw_instr >> 21) & 0x1))
+
+	return MultipleFields{Rn: Rn, Registers: registers, Wback: wback}
+}
+
+/* UNPREDICTABLE register lists of the 32-bit encodings. SP may never be
+ * listed. Loads may not list both LR and PC, stores may not list PC. */
+func bad_register_list(registers uint16, load bool) bool {
+	if bits.OnesCount16(registers) < 2 || in_register_list(registers, SP) {
+		return true
+	}
+
+	if load {
+		return in_register_list(registers, LR) && in_register_list(registers, PC)
+	}
+
+	return in_register_list(registers, PC)
+}
+
+/* Format a register list, e.g. {r0, r4, lr} */
+func register_list(registers uint16) string {
+	var b bytes.Buffer
+
+	b.WriteString("{")
+	for i := RegIndex(0); i <= PC; i++ {
+		if !in_register_list(registers, i) {
+			continue
+		}
+
+		if b.Len() > 1 {
+			b.WriteString(", ")
+		}
+		b.WriteString(i.String())
+	}
+	b.WriteString("}")
+
+	return b.String()
+}
+
+/* Format the base register and register list of LDM and STM */
+func multiple_operands(instr MultipleFields) string {
+	wback := ""
+	if instr.Wback {
+		wback = "!"
+	}
+
+	return fmt.Sprintf("%s%s, %s", instr.Rn, wback, register_list(instr.Registers))
+}
@@ -0,0 +1,266 @@
+package core
+
+import "fmt"
+
+/* Compute the offset address, written back to Rn, and the address
+ * accessed. Literal accesses use the word aligned PC as their base. */
+func load_store_address(cpu *CPU, instr LoadStoreFields, offset uint32) (offset_addr uint32, address uint32) {
+	base := cpu.R(instr.Rn)
+	if instr.Rn == PC {
+		base = Align(base, 4)
+	}
+
+	if instr.Add {
+		offset_addr = base + offset
+	} else {
+		offset_addr = base - offset
+	}
+
+	if instr.Index {
+		address = offset_addr
+	} else {
+		address = base
+	}
+
+	return offset_addr, address
+}
+
+/* Register offsets are only ever shifted left */
+func register_offset(cpu *CPU, instr LoadStoreFields) uint32 {
+	offset, _ := instr.Shift.EvaluateC(cpu.R(instr.Rm), cpu.Apsr.C)
+	return offset
+}
+
+/* Load size bytes into Rt, sign extending if signed. Nothing is written
+ * if the access faults. */
+func load(cpu *CPU, instr LoadStoreFields, offset uint32, size uint32, signed bool, unpriv bool) {
+	if instr.Rt == PC && cpu.InITBlock() && !cpu.LastInITBlock() {
+		// UNPREDICTABLE
+		return
+	}
+
+	offset_addr, address := load_store_address(cpu, instr, offset)
+
+	var data uint32
+	var ok bool
+	if unpriv {
+		data, ok = cpu.MemU_unpriv(address, size)
+	} else {
+		data, ok = cpu.MemU(address, size)
+	}
+	if !ok {
+		return
+	}
+
+	if signed {
+		data = SignExtend(data, uint8(8*size))
+	}
+
+	if instr.Wback {
+		cpu.SetR(instr.Rn, offset_addr)
+	}
+
+	if instr.Rt == PC {
+		if address&0x3 != 0 {
+			// UNPREDICTABLE
+			return
+		}
+		cpu.LoadWritePC(data)
+	} else {
+		cpu.SetR(instr.Rt, data)
+	}
+}
+
+/* Store the bottom size bytes of Rt. Rn is not written back if the
+ * access faults. */
+func store(cpu *CPU, instr LoadStoreFields, offset uint32, size uint32, unpriv bool) {
+	offset_addr, address := load_store_address(cpu, instr, offset)
+
+	var ok bool
+	if unpriv {
+		ok = cpu.SetMemU_unpriv(address, size, cpu.R(instr.Rt))
+	} else {
+		ok = cpu.SetMemU(address, size, cpu.R(instr.Rt))
+	}
+	if !ok {
+		return
+	}
+
+	if instr.Wback {
+		cpu.SetR(instr.Rn, offset_addr)
+	}
+}
+
+/* Perform load instruction (imm or literal) */
+func LoadImmediate(cpu *CPU, instr LoadStoreFields, size uint32, signed bool) {
+	load(cpu, instr, instr.Imm, size, signed, false)
+}
+
+/* Perform load instruction (reg), with shifted offset */
+func LoadRegister(cpu *CPU, instr LoadStoreFields, size uint32, signed bool) {
+	load(cpu, instr, register_offset(cpu, instr), size, signed, false)
+}
+
+/* Perform unprivileged load instruction (LDRT, LDRBT, ...) */
+func LoadUnprivileged(cpu *CPU, instr LoadStoreFields, size uint32, signed bool) {
+	load(cpu, instr, instr.Imm, size, signed, true)
+}
+
+/* Perform store instruction (imm) */
+func StoreImmediate(cpu *CPU, instr LoadStoreFields, size uint32) {
+	store(cpu, instr, instr.Imm, size, false)
+}
+
+/* Perform store instruction (reg), with shifted offset */
+func StoreRegister(cpu *CPU, instr LoadStoreFields, size uint32) {
+	store(cpu, instr, register_offset(cpu, instr), size, false)
+}
+
+/* Perform unprivileged store instruction (STRT, STRBT, ...) */
+func StoreUnprivileged(cpu *CPU, instr LoadStoreFields, size uint32) {
+	store(cpu, instr, instr.Imm, size, true)
+}
+
+/* Perform load dual instruction (imm or literal). Both words must be
+ * word aligned. */
+func LoadDual(cpu *CPU, instr LoadStoreFields) {
+	offset_addr, address := load_store_address(cpu, instr, instr.Imm)
+
+	low, ok := cpu.MemA(address, 4)
+	if !ok {
+		return
+	}
+
+	high, ok := cpu.MemA(address+4, 4)
+	if !ok {
+		return
+	}
+
+	if instr.Wback {
+		cpu.SetR(instr.Rn, offset_addr)
+	}
+
+	cpu.SetR(instr.Rt, low)
+	cpu.SetR(instr.Rt2, high)
+}
+
+/* Perform store dual instruction (imm). Both words must be word aligned. */
+func StoreDual(cpu *CPU, instr LoadStoreFields) {
+	offset_addr, address := load_store_address(cpu, instr, instr.Imm)
+
+	if !cpu.SetMemA(address, 4, cpu.R(instr.Rt)) {
+		return
+	}
+
+	if !cpu.SetMemA(address+4, 4, cpu.R(instr.Rt2)) {
+		return
+	}
+
+	if instr.Wback {
+		cpu.SetR(instr.Rn, offset_addr)
+	}
+}
+
+/* Extract the fields of the 16-bit immediate offset encodings, with
+ * imm5 scaled by the access size */
+func decode_ldst_imm5(raw_instr uint32, scale uint32) LoadStoreFields {
+	Rt := RegIndex(raw_instr & 0x7)
+	Rn := RegIndex((raw_instr >> 3) & 0x7)
+	Imm := ((raw_instr >> 6) & 0x1f) * scale
+
+	return LoadStoreFields{Rt: Rt, Rn: Rn, Imm: Imm, Index: true, Add: true, Wback: false}
+}
+
+/* Extract the fields of the 16-bit register offset encodings */
+func decode_ldst_reg16(raw_instr uint32) LoadStoreFields {
+	Rt := RegIndex(raw_instr & 0x7)
+	Rn := RegIndex((raw_instr >> 3) & 0x7)
+	Rm := RegIndex((raw_instr >> 6) & 0x7)
+
+	return LoadStoreFields{Rt: Rt, Rn: Rn, Rm: Rm, Index: true, Add: true, Wback: false}
+}
+
+/* Extract the fields of the 32-bit positive 12-bit immediate offset encodings */
+func decode_ldst_imm12(raw_instr uint32) LoadStoreFields {
+	Rt := RegIndex((raw_instr >> 12) & 0xf)
+	Rn := RegIndex((raw_instr >> 16) & 0xf)
+	Imm := raw_instr & 0xfff
+
+	return LoadStoreFields{Rt: Rt, Rn: Rn, Imm: Imm, Index: true, Add: true, Wback: false}
+}
+
+/* Extract the fields of the 32-bit 8-bit immediate offset encodings,
+ * including the P (index), U (add) and W (writeback) bits. The
+ * unprivileged encodings have P, U and W fixed at 1, 1, 0. */
+func decode_ldst_imm8(raw_instr uint32) LoadStoreFields {
+	Rt := RegIndex((raw_instr >> 12) & 0xf)
+	Rn := RegIndex((raw_instr >> 16) & 0xf)
+	Imm := raw_instr & 0xff
+	Index := utobool(uint8((raw_instr >> 10) & 0x1))
+	Add := utobool(uint8((raw_instr >> 9) & 0x1))
+	Wback := utobool(uint8((raw_instr >> 8) & 0x1))
+
+	return LoadStoreFields{Rt: Rt, Rn: Rn, Imm: Imm, Index: Index, Add: Add, Wback: Wback}
+}
+
+/* Is this 8-bit immediate encoding really an unprivileged access? */
+func ldst_imm8_unpriv(raw_instr uint32) bool {
+	return (raw_instr>>8)&0x7 == 0x6
+}
+
+/* Extract the fields of the 32-bit register offset encodings */
+func decode_ldst_reg32(raw_instr uint32) LoadStoreFields {
+	Rt := RegIndex((raw_instr >> 12) & 0xf)
+	Rn := RegIndex((raw_instr >> 16) & 0xf)
+	Rm := RegIndex(raw_instr & 0xf)
+	shift := Shift{srtype: SRType_LSL, amount: uint8((raw_instr >> 4) & 0x3)}
+
+	return LoadStoreFields{Rt: Rt, Rn: Rn, Rm: Rm, Shift: shift, Index: true, Add: true, Wback: false}
+}
+
+/* Extract the fields of the 32-bit literal encodings */
+func decode_ldst_literal(raw_instr uint32) LoadStoreFields {
+	Rt := RegIndex((raw_instr >> 12) & 0xf)
+	Imm := raw_instr & 0xfff
+	Add := utobool(uint8((raw_instr >> 23) & 0x1))
+
+	return LoadStoreFields{Rt: Rt, Rn: PC, Imm: Imm, Index: true, Add: Add, Wback: false}
+}
+
+/* Extract the fields of the LDRD and STRD encodings */
+func decode_ldst_dual(raw_instr uint32) LoadStoreFields {
+	Rt2 := RegIndex((raw_instr >> 8) & 0xf)
+	Rt := RegIndex((raw_instr >> 12) & 0xf)
+	Rn := RegIndex((raw_instr >> 16) & 0xf)
+	Imm := (raw_instr & 0xff) << 2
+	Index := utobool(uint8((raw_instr >> 24) & 0x1))
+	Add := utobool(uint8((raw_instr >> 23) & 0x1))
+	Wback := utobool(uint8((raw_instr >> 21) & 0x1))
+
+	return LoadStoreFields{Rt: Rt, Rt2: Rt2, Rn: Rn, Imm: Imm, Index: Index, Add: Add, Wback: Wback}
+}
+
+/* Format an immediate offset address, in offset, pre-indexed or
+ * post-indexed form */
+func imm_address(instr LoadStoreFields) string {
+	imm := fmt.Sprintf("#%d", instr.Imm)
+	if !instr.Add {
+		imm = fmt.Sprintf("#-%d", instr.Imm)
+	}
+
+	switch {
+	case !instr.Index:
+		return fmt.Sprintf("[%s], %s", instr.Rn, imm)
+	case instr.Wback:
+		return fmt.Sprintf("[%s, %s]!", instr.Rn, imm)
+	case instr.Imm == 0 && instr.Add:
+		return fmt.Sprintf("[%s]", instr.Rn)
+	}
+
+	return fmt.Sprintf("[%s, %s]", instr.Rn, imm)
+}
+
+/* Format a register offset address */
+func reg_address(instr LoadStoreFields) string {
+	return fmt.Sprintf("[%s, %s]", instr.Rn, shifted_operand(instr.Rm, instr.Shift))
+}
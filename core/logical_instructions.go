@@ -0,0 +1,526 @@
+package core
+
+import "fmt"
+
+/* AND (register)
+ * ARM ARM A7.7.9
+ * Encoding T1 */
+type AndRegT1 InstrFields
+
+func AndReg16T1(instr FetchedInstr) DecodedInstr {
+	raw_instr := instr.Uint32()
+
+	Rdn := RegIndex(raw_instr & 0x7)
+	Rm := RegIndex((raw_instr >> 3) & 0x7)
+
+	return AndRegT1{Rd: Rdn, Rm: Rm, Rn: Rdn, Imm: 0, setflags: NOT_IT}
+}
+
+func (instr AndRegT1) Execute(cpu *CPU) {
+	AndRegister(cpu, InstrFields(instr), Shift{srtype: SRType_LSL, amount: 0})
+}
+
+func (instr AndRegT1) String() string {
+	return fmt.Sprintf("and%s %s, %s", instr.setflags, instr.Rd, instr.Rm)
+}
+
+func (instr AndRegT1) SetsFlags() SetFlags {
+	return instr.setflags
+}
+
+/* EOR (register)
+ * ARM ARM A7.7.35
+ * Encoding T1 */
+type EorRegT1 InstrFields
+
+func EorReg16T1(instr FetchedInstr) DecodedInstr {
+	raw_instr := instr.Uint32()
+
+	Rdn := RegIndex(raw_instr & 0x7)
+	Rm := RegIndex((raw_instr >> 3) & 0x7)
+
+	return EorRegT1{Rd: Rdn, Rm: Rm, Rn: Rdn, Imm: 0, setflags: NOT_IT}
+}
+
+func (instr EorRegT1) Execute(cpu *CPU) {
+	EorRegister(cpu, InstrFields(instr), Shift{srtype: SRType_LSL, amount: 0})
+}
+
+func (instr EorRegT1) String() string {
+	return fmt.Sprintf("eor%s %s, %s", instr.setflags, instr.Rd, instr.Rm)
+}
+
+func (instr EorRegT1) SetsFlags() SetFlags {
+	return instr.setflags
+}
+
+/* ORR (register)
+ * ARM ARM A7.7.91
+ * Encoding T1 */
+type OrrRegT1 InstrFields
+
+func OrrReg16T1(instr FetchedInstr) DecodedInstr {
+	raw_instr := instr.Uint32()
+
+	Rdn := RegIndex(raw_instr & 0x7)
+	Rm := RegIndex((raw_instr >> 3) & 0x7)
+
+	return OrrRegT1{Rd: Rdn, Rm: Rm, Rn: Rdn, Imm: 0, setflags: NOT_IT}
+}
+
+func (instr OrrRegT1) Execute(cpu *CPU) {
+	OrrRegister(cpu, InstrFields(instr), Shift{srtype: SRType_LSL, amount: 0})
+}
+
+func (instr OrrRegT1) String() string {
+	return fmt.Sprintf("orr%s %s, %s", instr.setflags, instr.Rd, instr.Rm)
+}
+
+func (instr OrrRegT1) SetsFlags() SetFlags {
+	return instr.setflags
+}
+
+/* BIC (register)
+ * ARM ARM A7.7.16
+ * Encoding T1 */
+type BicRegT1 InstrFields
+
+func BicReg16T1(instr FetchedInstr) DecodedInstr {
+	raw_instr := instr.Uint32()
+
+	Rdn := RegIndex(raw_instr & 0x7)
+	Rm := RegIndex((raw_instr >> 3) & 0x7)
+
+	return BicRegT1{Rd: Rdn, Rm: Rm, Rn: Rdn, Imm: 0, setflags: NOT_IT}
+}
+
+func (instr BicRegT1) Execute(cpu *CPU) {
+	BicRegister(cpu, InstrFields(instr), Shift{srtype: SRType_LSL, amount: 0})
+}
+
+func (instr BicRegT1) String() string {
+	return fmt.Sprintf("bic%s %s, %s", instr.setflags, instr.Rd, instr.Rm)
+}
+
+func (instr BicRegT1) SetsFlags() SetFlags {
+	return instr.setflags
+}
+
+/* TST (register)
+ * ARM ARM A7.7.186
+ * Encoding T1 */
+type TstRegT1 InstrFields
+
+func TstReg16T1(instr FetchedInstr) DecodedInstr {
+	raw_instr := instr.Uint32()
+
+	Rn := RegIndex(raw_instr & 0x7)
+	Rm := RegIndex((raw_instr >> 3) & 0x7)
+
+	return TstRegT1{Rd: 0, Rm: Rm, Rn: Rn, Imm: 0, setflags: ALWAYS}
+}
+
+func (instr TstRegT1) Execute(cpu *CPU) {
+	TstRegister(cpu, InstrFields(instr), Shift{srtype: SRType_LSL, amount: 0})
+}
+
+func (instr TstRegT1) String() string {
+	return fmt.Sprintf("tst %s, %s", instr.Rn, instr.Rm)
+}
+
+/* AND (immediate)
+ * ARM ARM A7.7.8
+ * Encoding T1
+ *
+ * Imm holds the encoded imm12, which is expanded on execution since the
+ * carry out of the expansion depends on APSR.C */
+type AndImmT1 InstrFields
+
+func AndImm32T1(instr FetchedInstr) DecodedInstr {
+	Rd, Rn, imm12, setflags := decode_modified_imm(instr.Uint32())
+
+	if Rd == PC && setflags == ALWAYS {
+		return TstImm32T1(instr)
+	}
+
+	if BadReg(Rd) || BadReg(Rn) {
+		return UnpredictableInstr{Rd: Rd, Rm: 0, Rn: Rn, Imm: imm12, setflags: setflags}
+	}
+
+	return AndImmT1{Rd: Rd, Rm: 0, Rn: Rn, Imm: imm12, setflags: setflags}
+}
+
+func (instr AndImmT1) Execute(cpu *CPU) {
+	imm32, carry := ThumbExpandImm_C(instr.Imm, cpu.Apsr.C)
+
+	fields := InstrFields(instr)
+	fields.Imm = imm32
+	AndImmediate(cpu, fields, carry)
+}
+
+func (instr AndImmT1) String() string {
+	return fmt.Sprintf("and%s %s, %s, #%#x", instr.setflags, instr.Rd, instr.Rn, ThumbExpandImm(instr.Imm))
+}
+
+/* TST (immediate)
+ * ARM ARM A7.7.185
+ * Encoding T1
+ *
+ * Imm holds the encoded imm12 */
+type TstImmT1 InstrFields
+
+func TstImm32T1(instr FetchedInstr) DecodedInstr {
+	_, Rn, imm12, _ := decode_modified_imm(instr.Uint32())
+
+	if BadReg(Rn) {
+		return UnpredictableInstr{Rd: 0, Rm: 0, Rn: Rn, Imm: imm12, setflags: ALWAYS}
+	}
+
+	return TstImmT1{Rd: 0, Rm: 0, Rn: Rn, Imm: imm12, setflags: ALWAYS}
+}
+
+func (instr TstImmT1) Execute(cpu *CPU) {
+	imm32, carry := ThumbExpandImm_C(instr.Imm, cpu.Apsr.C)
+
+	fields := InstrFields(instr)
+	fields.Imm = imm32
+	TstImmediate(cpu, fields, carry)
+}
+
+func (instr TstImmT1) String() string {
+	return fmt.Sprintf("tst %s, #%#x", instr.Rn, ThumbExpandImm(instr.Imm))
+}
+
+/* BIC (immediate)
+ * ARM ARM A7.7.15
+ * Encoding T1
+ *
+ * Imm holds the encoded imm12 */
+type BicImmT1 InstrFields
+
+func BicImm32T1(instr FetchedInstr) DecodedInstr {
+	Rd, Rn, imm12, setflags := decode_modified_imm(instr.Uint32())
+
+	if BadReg(Rd) || BadReg(Rn) {
+		return UnpredictableInstr{Rd: Rd, Rm: 0, Rn: Rn, Imm: imm12, setflags: setflags}
+	}
+
+	return BicImmT1{Rd: Rd, Rm: 0, Rn: Rn, Imm: imm12, setflags: setflags}
+}
+
+func (instr BicImmT1) Execute(cpu *CPU) {
+	imm32, carry := ThumbExpandImm_C(instr.Imm, cpu.Apsr.C)
+
+	fields := InstrFields(instr)
+	fields.Imm = imm32
+	BicImmediate(cpu, fields, carry)
+}
+
+func (instr BicImmT1) String() string {
+	return fmt.Sprintf("bic%s %s, %s, #%#x", instr.setflags, instr.Rd, instr.Rn, ThumbExpandImm(instr.Imm))
+}
+
+/* ORR (immediate)
+ * ARM ARM A7.7.90
+ * Encoding T1
+ *
+ * Imm holds the encoded imm12 */
+type OrrImmT1 InstrFields
+
+func OrrImm32T1(instr FetchedInstr) DecodedInstr {
+	Rd, Rn, imm12, setflags := decode_modified_imm(instr.Uint32())
+
+	if Rn == PC {
+		return MovImm32T2(instr)
+	}
+
+	if BadReg(Rd) || BadReg(Rn) {
+		return UnpredictableInstr{Rd: Rd, Rm: 0, Rn: Rn, Imm: imm12, setflags: setflags}
+	}
+
+	return OrrImmT1{Rd: Rd, Rm: 0, Rn: Rn, Imm: imm12, setflags: setflags}
+}
+
+func (instr OrrImmT1) Execute(cpu *CPU) {
+	imm32, carry := ThumbExpandImm_C(instr.Imm, cpu.Apsr.C)
+
+	fields := InstrFields(instr)
+	fields.Imm = imm32
+	OrrImmediate(cpu, fields, carry)
+}
+
+func (instr OrrImmT1) String() string {
+	return fmt.Sprintf("orr%s %s, %s, #%#x", instr.setflags, instr.Rd, instr.Rn, ThumbExpandImm(instr.Imm))
+}
+
+/* ORN (immediate)
+ * ARM ARM A7.7.88
+ * Encoding T1
+ *
+ * Imm holds the encoded imm12 */
+type OrnImmT1 InstrFields
+
+func OrnImm32T1(instr FetchedInstr) DecodedInstr {
+	Rd, Rn, imm12, setflags := decode_modified_imm(instr.Uint32())
+
+	if Rn == PC {
+		return MvnImm32T1(instr)
+	}
+
+	if BadReg(Rd) || BadReg(Rn) {
+		return UnpredictableInstr{Rd: Rd, Rm: 0, Rn: Rn, Imm: imm12, setflags: setflags}
+	}
+
+	return OrnImmT1{Rd: Rd, Rm: 0, Rn: Rn, Imm: imm12, setflags: setflags}
+}
+
+func (instr OrnImmT1) Execute(cpu *CPU) {
+	imm32, carry := ThumbExpandImm_C(instr.Imm, cpu.Apsr.C)
+
+	fields := InstrFields(instr)
+	fields.Imm = imm32
+	OrnImmediate(cpu, fields, carry)
+}
+
+func (instr OrnImmT1) String() string {
+	return fmt.Sprintf("orn%s %s, %s, #%#x", instr.setflags, instr.Rd, instr.Rn, ThumbExpandImm(instr.Imm))
+}
+
+/* EOR (immediate)
+ * ARM ARM A7.7.34
+ * Encoding T1
+ *
+ * Imm holds the encoded imm12 */
+type EorImmT1 InstrFields
+
+func EorImm32T1(instr FetchedInstr) DecodedInstr {
+	Rd, Rn, imm12, setflags := decode_modified_imm(instr.Uint32())
+
+	if Rd == PC && setflags == ALWAYS {
+		return TeqImm32T1(instr)
+	}
+
+	if BadReg(Rd) || BadReg(Rn) {
+		return UnpredictableInstr{Rd: Rd, Rm: 0, Rn: Rn, Imm: imm12, setflags: setflags}
+	}
+
+	return EorImmT1{Rd: Rd, Rm: 0, Rn: Rn, Imm: imm12, setflags: setflags}
+}
+
+func (instr EorImmT1) Execute(cpu *CPU) {
+	imm32, carry := ThumbExpandImm_C(instr.Imm, cpu.Apsr.C)
+
+	fields := InstrFields(instr)
+	fields.Imm = imm32
+	EorImmediate(cpu, fields, carry)
+}
+
+func (instr EorImmT1) String() string {
+	return fmt.Sprintf("eor%s %s, %s, #%#x", instr.setflags, instr.Rd, instr.Rn, ThumbExpandImm(instr.Imm))
+}
+
+/* TEQ (immediate)
+ * ARM ARM A7.7.183
+ * Encoding T1
+ *
+ * Imm holds the encoded imm12 */
+type TeqImmT1 InstrFields
+
+func TeqImm32T1(instr FetchedInstr) DecodedInstr {
+	_, Rn, imm12, _ := decode_modified_imm(instr.Uint32())
+
+	if BadReg(Rn) {
+		return UnpredictableInstr{Rd: 0, Rm: 0, Rn: Rn, Imm: imm12, setflags: ALWAYS}
+	}
+
+	return TeqImmT1{Rd: 0, Rm: 0, Rn: Rn, Imm: imm12, setflags: ALWAYS}
+}
+
+func (instr TeqImmT1) Execute(cpu *CPU) {
+	imm32, carry := ThumbExpandImm_C(instr.Imm, cpu.Apsr.C)
+
+	fields := InstrFields(instr)
+	fields.Imm = imm32
+	TeqImmediate(cpu, fields, carry)
+}
+
+func (instr TeqImmT1) String() string {
+	return fmt.Sprintf("teq %s, #%#x", instr.Rn, ThumbExpandImm(instr.Imm))
+}
+
+/* AND (register)
+ * ARM ARM A7.7.9
+ * Encoding T2 */
+type AndRegT2 InstrFields
+
+func AndReg32T2(instr FetchedInstr) DecodedInstr {
+	Rd, Rn, Rm, shift, setflags := decode_shifted_reg(instr.Uint32())
+
+	if Rd == PC && setflags == ALWAYS {
+		return TstReg32T2(instr)
+	}
+
+	if BadReg(Rd) || BadReg(Rn) || BadReg(Rm) {
+		return UnpredictableInstr{Rd: Rd, Rm: Rm, Rn: Rn, Imm: 0, setflags: setflags, Shift: shift}
+	}
+
+	return AndRegT2{Rd: Rd, Rm: Rm, Rn: Rn, Imm: 0, setflags: setflags, Shift: shift}
+}
+
+func (instr AndRegT2) Execute(cpu *CPU) {
+	AndRegister(cpu, InstrFields(instr), instr.Shift)
+}
+
+func (instr AndRegT2) String() string {
+	return fmt.Sprintf("and%s %s, %s, %s", instr.setflags, instr.Rd, instr.Rn, shifted_operand(instr.Rm, instr.Shift))
+}
+
+/* TST (register)
+ * ARM ARM A7.7.186
+ * Encoding T2 */
+type TstRegT2 InstrFields
+
+func TstReg32T2(instr FetchedInstr) DecodedInstr {
+	_, Rn, Rm, shift, _ := decode_shifted_reg(instr.Uint32())
+
+	if BadReg(Rn) || BadReg(Rm) {
+		return UnpredictableInstr{Rd: 0, Rm: Rm, Rn: Rn, Imm: 0, setflags: ALWAYS, Shift: shift}
+	}
+
+	return TstRegT2{Rd: 0, Rm: Rm, Rn: Rn, Imm: 0, setflags: ALWAYS, Shift: shift}
+}
+
+func (instr TstRegT2) Execute(cpu *CPU) {
+	TstRegister(cpu, InstrFields(instr), instr.Shift)
+}
+
+func (instr TstRegT2) String() string {
+	return fmt.Sprintf("tst.w %s, %s", instr.Rn, shifted_operand(instr.Rm, instr.Shift))
+}
+
+/* BIC (register)
+ * ARM ARM A7.7.16
+ * Encoding T2 */
+type BicRegT2 InstrFields
+
+func BicReg32T2(instr FetchedInstr) DecodedInstr {
+	Rd, Rn, Rm, shift, setflags := decode_shifted_reg(instr.Uint32())
+
+	if BadReg(Rd) || BadReg(Rn) || BadReg(Rm) {
+		return UnpredictableInstr{Rd: Rd, Rm: Rm, Rn: Rn, Imm: 0, setflags: setflags, Shift: shift}
+	}
+
+	return BicRegT2{Rd: Rd, Rm: Rm, Rn: Rn, Imm: 0, setflags: setflags, Shift: shift}
+}
+
+func (instr BicRegT2) Execute(cpu *CPU) {
+	BicRegister(cpu, InstrFields(instr), instr.Shift)
+}
+
+func (instr BicRegT2) String() string {
+	return fmt.Sprintf("bic%s %s, %s, %s", instr.setflags, instr.Rd, instr.Rn, shifted_operand(instr.Rm, instr.Shift))
+}
+
+/* ORR (register)
+ * ARM ARM A7.7.91
+ * Encoding T2 */
+type OrrRegT2 InstrFields
+
+func OrrReg32T2(instr FetchedInstr) DecodedInstr {
+	Rd, Rn, Rm, shift, setflags := decode_shifted_reg(instr.Uint32())
+
+	if Rn == PC {
+		return MovRegImmShift32(instr)
+	}
+
+	if BadReg(Rd) || Rn == SP || BadReg(Rm) {
+		return UnpredictableInstr{Rd: Rd, Rm: Rm, Rn: Rn, Imm: 0, setflags: setflags, Shift: shift}
+	}
+
+	return OrrRegT2{Rd: Rd, Rm: Rm, Rn: Rn, Imm: 0, setflags: setflags, Shift: shift}
+}
+
+func (instr OrrRegT2) Execute(cpu *CPU) {
+	OrrRegister(cpu, InstrFields(instr), instr.Shift)
+}
+
+func (instr OrrRegT2) String() string {
+	return fmt.Sprintf("orr%s %s, %s, %s", instr.setflags, instr.Rd, instr.Rn, shifted_operand(instr.Rm, instr.Shift))
+}
+
+/* ORN (register)
+ * ARM ARM A7.7.89
+ * Encoding T1 */
+type OrnRegT1 InstrFields
+
+func OrnReg32T1(instr FetchedInstr) DecodedInstr {
+	Rd, Rn, Rm, shift, setflags := decode_shifted_reg(instr.Uint32())
+
+	if Rn == PC {
+		return MvnReg32T2(instr)
+	}
+
+	if BadReg(Rd) || Rn == SP || BadReg(Rm) {
+		return UnpredictableInstr{Rd: Rd, Rm: Rm, Rn: Rn, Imm: 0, setflags: setflags, Shift: shift}
+	}
+
+	return OrnRegT1{Rd: Rd, Rm: Rm, Rn: Rn, Imm: 0, setflags: setflags, Shift: shift}
+}
+
+func (instr OrnRegT1) Execute(cpu *CPU) {
+	OrnRegister(cpu, InstrFields(instr), instr.Shift)
+}
+
+func (instr OrnRegT1) String() string {
+	return fmt.Sprintf("orn%s %s, %s, %s", instr.setflags, instr.Rd, instr.Rn, shifted_operand(instr.Rm, instr.Shift))
+}
+
+/* EOR (register)
+ * ARM ARM A7.7.35
+ * Encoding T2 */
+type EorRegT2 InstrFields
+
+func EorReg32T2(instr FetchedInstr) DecodedInstr {
+	Rd, Rn, Rm, shift, setflags := decode_shifted_reg(instr.Uint32())
+
+	if Rd == PC && setflags == ALWAYS {
+		return TeqReg32T1(instr)
+	}
+
+	if BadReg(Rd) || BadReg(Rn) || BadReg(Rm) {
+		return UnpredictableInstr{Rd: Rd, Rm: Rm, Rn: Rn, Imm: 0, setflags: setflags, Shift: shift}
+	}
+
+	return EorRegT2{Rd: Rd, Rm: Rm, Rn: Rn, Imm: 0, setflags: setflags, Shift: shift}
+}
+
+func (instr EorRegT2) Execute(cpu *CPU) {
+	EorRegister(cpu, InstrFields(instr), instr.Shift)
+}
+
+func (instr EorRegT2) String() string {
+	return fmt.Sprintf("eor%s %s, %s, %s", instr.setflags, instr.Rd, instr.Rn, shifted_operand(instr.Rm, instr.Shift))
+}
+
+/* TEQ (register)
+ * ARM ARM A7.7.184
+ * Encoding T1 */
+type TeqRegT1 InstrFields
+
+func TeqReg32T1(instr FetchedInstr) DecodedInstr {
+	_, Rn, Rm, shift, _ := decode_shifted_reg(instr.Uint32())
+
+	if BadReg(Rn) || BadReg(Rm) {
+		return UnpredictableInstr{Rd: 0, Rm: Rm, Rn: Rn, Imm: 0, setflags: ALWAYS, Shift: shift}
+	}
+
+	return TeqRegT1{Rd: 0, Rm: Rm, Rn: Rn, Imm: 0, setflags: ALWAYS, Shift: shift}
+}
+
+func (instr TeqRegT1) Execute(cpu *CPU) {
+	TeqRegister(cpu, InstrFields(instr), instr.Shift)
+}
+
+func (instr TeqRegT1) String() string {
+	return fmt.Sprintf("teq %s, %s", instr.Rn, shifted_operand(instr.Rm, instr.Shift))
+}
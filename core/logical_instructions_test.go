@@ -0,0 +1,567 @@
+package core
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestIdentifyAndRegT1(t *testing.T) {
+	cases := []IdentifyCase{
+		{instr: FetchedInstr16(0x4008), instr_valid: true},  // ands r0, r1
+		{instr: FetchedInstr16(0x403f), instr_valid: true},  // ands r7, r7
+		{instr: FetchedInstr16(0x405a), instr_valid: false}, // eors r2, r3
+		{instr: FetchedInstr16(0x4311), instr_valid: false}, // orrs r1, r2
+		{instr: FetchedInstr16(0x4080), instr_valid: false}, // lsl r0, r0, r0
+		{instr: FetchedInstr16(0xffff), instr_valid: false},
+	}
+
+	test_identify(t, cases, reflect.TypeOf(AndRegT1{}))
+}
+
+func TestDecodeAndReg16T1(t *testing.T) {
+	cases := []DecodeCase{
+		// ands r0, r1
+		{instr: FetchedInstr16(0x4008), decoded: AndRegT1{Rd: 0, Rm: 1, Rn: 0, Imm: 0, setflags: NOT_IT}},
+		// ands r7, r7
+		{instr: FetchedInstr16(0x403f), decoded: AndRegT1{Rd: 7, Rm: 7, Rn: 7, Imm: 0, setflags: NOT_IT}},
+	}
+
+	test_decode(t, cases, AndReg16T1)
+}
+
+func TestExecuteAndRegT1(t *testing.T) {
+	cases := []ExecuteCase{
+		// ands r0, r1
+		{instr: AndRegT1{Rd: 0, Rm: 1, Rn: 0, Imm: 0, setflags: NOT_IT},
+			regs:     Registers{r: GeneralRegs{0xf0f0, 0xff00, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13}},
+			expected: Registers{r: GeneralRegs{0xf000, 0xff00, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13}}},
+		// ands r0, r1 (carry preserved)
+		{instr: AndRegT1{Rd: 0, Rm: 1, Rn: 0, Imm: 0, setflags: NOT_IT},
+			regs:     Registers{r: GeneralRegs{0x0f0f, 0xf0f0, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13}, Apsr: Apsr{C: true, V: true}},
+			expected: Registers{r: GeneralRegs{0, 0xf0f0, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13}, Apsr: Apsr{Z: true, C: true, V: true}}},
+		// ands r0, r1
+		{instr: AndRegT1{Rd: 0, Rm: 1, Rn: 0, Imm: 0, setflags: NOT_IT},
+			regs:     Registers{r: GeneralRegs{0x80000001, 0xffffffff, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13}},
+			expected: Registers{r: GeneralRegs{0x80000001, 0xffffffff, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13}, Apsr: Apsr{N: true}}},
+	}
+
+	test_execute(t, cases)
+}
+
+func TestIdentifyEorRegT1(t *testing.T) {
+	cases := []IdentifyCase{
+		{instr: FetchedInstr16(0x405a), instr_valid: true},  // eors r2, r3
+		{instr: FetchedInstr16(0x4008), instr_valid: false}, // ands r0, r1
+		{instr: FetchedInstr16(0xffff), instr_valid: false},
+	}
+
+	test_identify(t, cases, reflect.TypeOf(EorRegT1{}))
+}
+
+func TestDecodeEorReg16T1(t *testing.T) {
+	cases := []DecodeCase{
+		// eors r2, r3
+		{instr: FetchedInstr16(0x405a), decoded: EorRegT1{Rd: 2, Rm: 3, Rn: 2, Imm: 0, setflags: NOT_IT}},
+	}
+
+	test_decode(t, cases, EorReg16T1)
+}
+
+func TestExecuteEorRegT1(t *testing.T) {
+	cases := []ExecuteCase{
+		// eors r2, r3
+		{instr: EorRegT1{Rd: 2, Rm: 3, Rn: 2, Imm: 0, setflags: NOT_IT},
+			regs:     Registers{r: GeneralRegs{1, 2, 0xff00ff00, 0xffff0000, 5, 6, 7, 8, 9, 10, 11, 12, 13}},
+			expected: Registers{r: GeneralRegs{1, 2, 0x00ffff00, 0xffff0000, 5, 6, 7, 8, 9, 10, 11, 12, 13}}},
+		// eors r2, r3
+		{instr: EorRegT1{Rd: 2, Rm: 3, Rn: 2, Imm: 0, setflags: NOT_IT},
+			regs:     Registers{r: GeneralRegs{1, 2, 0x1234, 0x1234, 5, 6, 7, 8, 9, 10, 11, 12, 13}},
+			expected: Registers{r: GeneralRegs{1, 2, 0, 0x1234, 5, 6, 7, 8, 9, 10, 11, 12, 13}, Apsr: Apsr{Z: true}}},
+	}
+
+	test_execute(t, cases)
+}
+
+func TestIdentifyOrrRegT1(t *testing.T) {
+	cases := []IdentifyCase{
+		{instr: FetchedInstr16(0x4311), instr_valid: true},  // orrs r1, r2
+		{instr: FetchedInstr16(0x43b5), instr_valid: false}, // bics r5, r6
+		{instr: FetchedInstr16(0xffff), instr_valid: false},
+	}
+
+	test_identify(t, cases, reflect.TypeOf(OrrRegT1{}))
+}
+
+func TestDecodeOrrReg16T1(t *testing.T) {
+	cases := []DecodeCase{
+		// orrs r1, r2
+		{instr: FetchedInstr16(0x4311), decoded: OrrRegT1{Rd: 1, Rm: 2, Rn: 1, Imm: 0, setflags: NOT_IT}},
+	}
+
+	test_decode(t, cases, OrrReg16T1)
+}
+
+func TestExecuteOrrRegT1(t *testing.T) {
+	cases := []ExecuteCase{
+		// orrs r1, r2
+		{instr: OrrRegT1{Rd: 1, Rm: 2, Rn: 1, Imm: 0, setflags: NOT_IT},
+			regs:     Registers{r: GeneralRegs{1, 0x80000000, 0x1, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13}},
+			expected: Registers{r: GeneralRegs{1, 0x80000001, 0x1, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13}, Apsr: Apsr{N: true}}},
+	}
+
+	test_execute(t, cases)
+}
+
+func TestIdentifyBicRegT1(t *testing.T) {
+	cases := []IdentifyCase{
+		{instr: FetchedInstr16(0x43b5), instr_valid: true},  // bics r5, r6
+		{instr: FetchedInstr16(0x43c7), instr_valid: false}, // mvns r7, r0
+		{instr: FetchedInstr16(0xffff), instr_valid: false},
+	}
+
+	test_identify(t, cases, reflect.TypeOf(BicRegT1{}))
+}
+
+func TestDecodeBicReg16T1(t *testing.T) {
+	cases := []DecodeCase{
+		// bics r5, r6
+		{instr: FetchedInstr16(0x43b5), decoded: BicRegT1{Rd: 5, Rm: 6, Rn: 5, Imm: 0, setflags: NOT_IT}},
+	}
+
+	test_decode(t, cases, BicReg16T1)
+}
+
+func TestExecuteBicRegT1(t *testing.T) {
+	cases := []ExecuteCase{
+		// bics r5, r6
+		{instr: BicRegT1{Rd: 5, Rm: 6, Rn: 5, Imm: 0, setflags: NOT_IT},
+			regs:     Registers{r: GeneralRegs{1, 2, 3, 4, 5, 0xffff, 0xff0f, 8, 9, 10, 11, 12, 13}},
+			expected: Registers{r: GeneralRegs{1, 2, 3, 4, 5, 0x00f0, 0xff0f, 8, 9, 10, 11, 12, 13}}},
+	}
+
+	test_execute(t, cases)
+}
+
+func TestIdentifyTstRegT1(t *testing.T) {
+	cases := []IdentifyCase{
+		{instr: FetchedInstr16(0x4211), instr_valid: true},  // tst r1, r2
+		{instr: FetchedInstr16(0x4263), instr_valid: false}, // rsbs r3, r4, #0
+		{instr: FetchedInstr16(0xffff), instr_valid: false},
+	}
+
+	test_identify(t, cases, reflect.TypeOf(TstRegT1{}))
+}
+
+func TestDecodeTstReg16T1(t *testing.T) {
+	cases := []DecodeCase{
+		// tst r1, r2
+		{instr: FetchedInstr16(0x4211), decoded: TstRegT1{Rd: 0, Rm: 2, Rn: 1, Imm: 0, setflags: ALWAYS}},
+	}
+
+	test_decode(t, cases, TstReg16T1)
+}
+
+func TestExecuteTstRegT1(t *testing.T) {
+	cases := []ExecuteCase{
+		// tst r1, r2
+		{instr: TstRegT1{Rd: 0, Rm: 2, Rn: 1, Imm: 0, setflags: ALWAYS},
+			regs:     Registers{r: GeneralRegs{1, 0xf0, 0x0f, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13}},
+			expected: Registers{r: GeneralRegs{1, 0xf0, 0x0f, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13}, Apsr: Apsr{Z: true}}},
+		// tst r1, r2 (in IT block)
+		{instr: TstRegT1{Rd: 0, Rm: 2, Rn: 1, Imm: 0, setflags: ALWAYS},
+			regs:     Registers{r: GeneralRegs{1, 0x80000000, 0x80000000, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13}, Epsr: Epsr{IT: 0x8}},
+			expected: Registers{r: GeneralRegs{1, 0x80000000, 0x80000000, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13}, Epsr: Epsr{IT: 0x8}, Apsr: Apsr{N: true}}},
+	}
+
+	test_execute(t, cases)
+}
+
+func TestIdentifyAndImmT1(t *testing.T) {
+	cases := []IdentifyCase{
+		{instr: FetchedInstr32(0xf00201ff), instr_valid: true},  // and r1, r2, #0xff
+		{instr: FetchedInstr32(0xf0124100), instr_valid: true},  // ands r1, r2, #0x80000000
+		{instr: FetchedInstr32(0xf0132fff), instr_valid: false}, // tst r3, #0xff00ff00
+		{instr: FetchedInstr32(0xf425747f), instr_valid: false}, // bic r4, r5, #0x3fc
+		{instr: FetchedInstr32(0xf00d01ff), instr_valid: false}, // and r1, sp, #0xff (UNPREDICTABLE)
+	}
+
+	test_identify(t, cases, reflect.TypeOf(AndImmT1{}))
+}
+
+func TestDecodeAndImm32T1(t *testing.T) {
+	cases := []DecodeCase{
+		// and r1, r2, #0xff
+		{instr: FetchedInstr32(0xf00201ff), decoded: AndImmT1{Rd: 1, Rm: 0, Rn: 2, Imm: 0x0ff, setflags: NEVER}},
+		// ands r1, r2, #0x80000000
+		{instr: FetchedInstr32(0xf0124100), decoded: AndImmT1{Rd: 1, Rm: 0, Rn: 2, Imm: 0x400, setflags: ALWAYS}},
+		// tst r3, #0xff00ff00
+		{instr: FetchedInstr32(0xf0132fff), decoded: TstImmT1{Rd: 0, Rm: 0, Rn: 3, Imm: 0x2ff, setflags: ALWAYS}},
+	}
+
+	test_decode(t, cases, AndImm32T1)
+}
+
+func TestExecuteAndImmT1(t *testing.T) {
+	cases := []ExecuteCase{
+		// and r1, r2, #0xff
+		{instr: AndImmT1{Rd: 1, Rm: 0, Rn: 2, Imm: 0x0ff, setflags: NEVER},
+			regs:     Registers{r: GeneralRegs{0, 1, 0x1234, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12}},
+			expected: Registers{r: GeneralRegs{0, 0x34, 0x1234, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12}}},
+		// ands r1, r2, #0x80000000 (carry out of rotated constant)
+		{instr: AndImmT1{Rd: 1, Rm: 0, Rn: 2, Imm: 0x400, setflags: ALWAYS},
+			regs:     Registers{r: GeneralRegs{0, 1, 0x80001234, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12}},
+			expected: Registers{r: GeneralRegs{0, 0x80000000, 0x80001234, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12}, Apsr: Apsr{N: true, C: true}}},
+		// ands r1, r2, #0xff (carry unchanged)
+		{instr: AndImmT1{Rd: 1, Rm: 0, Rn: 2, Imm: 0x0ff, setflags: ALWAYS},
+			regs:     Registers{r: GeneralRegs{0, 1, 0x1200, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12}, Apsr: Apsr{C: true}},
+			expected: Registers{r: GeneralRegs{0, 0, 0x1200, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12}, Apsr: Apsr{Z: true, C: true}}},
+	}
+
+	test_execute(t, cases)
+}
+
+func TestIdentifyTstImmT1(t *testing.T) {
+	cases := []IdentifyCase{
+		{instr: FetchedInstr32(0xf0132fff), instr_valid: true},  // tst r3, #0xff00ff00
+		{instr: FetchedInstr32(0xf0124100), instr_valid: false}, // ands r1, r2, #0x80000000
+		{instr: FetchedInstr32(0xf0920f80), instr_valid: false}, // teq r2, #0x80
+	}
+
+	test_identify(t, cases, reflect.TypeOf(TstImmT1{}))
+}
+
+func TestDecodeTstImm32T1(t *testing.T) {
+	cases := []DecodeCase{
+		// tst r3, #0xff00ff00
+		{instr: FetchedInstr32(0xf0132fff), decoded: TstImmT1{Rd: 0, Rm: 0, Rn: 3, Imm: 0x2ff, setflags: ALWAYS}},
+	}
+
+	test_decode(t, cases, TstImm32T1)
+}
+
+func TestExecuteTstImmT1(t *testing.T) {
+	cases := []ExecuteCase{
+		// tst r3, #0xff00ff00
+		{instr: TstImmT1{Rd: 0, Rm: 0, Rn: 3, Imm: 0x2ff, setflags: ALWAYS},
+			regs:     Registers{r: GeneralRegs{0, 1, 2, 0x00ff00ff, 4, 5, 6, 7, 8, 9, 10, 11, 12}, Apsr: Apsr{C: true}},
+			expected: Registers{r: GeneralRegs{0, 1, 2, 0x00ff00ff, 4, 5, 6, 7, 8, 9, 10, 11, 12}, Apsr: Apsr{Z: true, C: true}}},
+	}
+
+	test_execute(t, cases)
+}
+
+func TestIdentifyBicImmT1(t *testing.T) {
+	cases := []IdentifyCase{
+		{instr: FetchedInstr32(0xf425747f), instr_valid: true},  // bic r4, r5, #0x3fc
+		{instr: FetchedInstr32(0xf00201ff), instr_valid: false}, // and r1, r2, #0xff
+	}
+
+	test_identify(t, cases, reflect.TypeOf(BicImmT1{}))
+}
+
+func TestDecodeBicImm32T1(t *testing.T) {
+	cases := []DecodeCase{
+		// bic r4, r5, #0x3fc
+		{instr: FetchedInstr32(0xf425747f), decoded: BicImmT1{Rd: 4, Rm: 0, Rn: 5, Imm: 0xf7f, setflags: NEVER}},
+	}
+
+	test_decode(t, cases, BicImm32T1)
+}
+
+func TestExecuteBicImmT1(t *testing.T) {
+	cases := []ExecuteCase{
+		// bic r4, r5, #0x3fc
+		{instr: BicImmT1{Rd: 4, Rm: 0, Rn: 5, Imm: 0xf7f, setflags: NEVER},
+			regs:     Registers{r: GeneralRegs{0, 1, 2, 3, 4, 0xffff, 6, 7, 8, 9, 10, 11, 12}},
+			expected: Registers{r: GeneralRegs{0, 1, 2, 3, 0xfc03, 0xffff, 6, 7, 8, 9, 10, 11, 12}}},
+	}
+
+	test_execute(t, cases)
+}
+
+func TestIdentifyOrrImmT1(t *testing.T) {
+	cases := []IdentifyCase{
+		{instr: FetchedInstr32(0xf0473612), instr_valid: true},  // orr r6, r7, #0x12121212
+		{instr: FetchedInstr32(0xf04f28ab), instr_valid: false}, // mov.w r8, #0xab00ab00
+		{instr: FetchedInstr32(0xf06a0901), instr_valid: false}, // orn r9, r10, #1
+	}
+
+	test_identify(t, cases, reflect.TypeOf(OrrImmT1{}))
+}
+
+func TestDecodeOrrImm32T1(t *testing.T) {
+	cases := []DecodeCase{
+		// orr r6, r7, #0x12121212
+		{instr: FetchedInstr32(0xf0473612), decoded: OrrImmT1{Rd: 6, Rm: 0, Rn: 7, Imm: 0x312, setflags: NEVER}},
+		// mov.w r8, #0xab00ab00
+		{instr: FetchedInstr32(0xf04f28ab), decoded: MovImmT2{Rd: 8, Rm: 0, Rn: 0, Imm: 0x2ab, setflags: NEVER}},
+	}
+
+	test_decode(t, cases, OrrImm32T1)
+}
+
+func TestExecuteOrrImmT1(t *testing.T) {
+	cases := []ExecuteCase{
+		// orr r6, r7, #0x12121212
+		{instr: OrrImmT1{Rd: 6, Rm: 0, Rn: 7, Imm: 0x312, setflags: NEVER},
+			regs:     Registers{r: GeneralRegs{0, 1, 2, 3, 4, 5, 6, 0x80000000, 8, 9, 10, 11, 12}},
+			expected: Registers{r: GeneralRegs{0, 1, 2, 3, 4, 5, 0x92121212, 0x80000000, 8, 9, 10, 11, 12}}},
+	}
+
+	test_execute(t, cases)
+}
+
+func TestIdentifyOrnImmT1(t *testing.T) {
+	cases := []IdentifyCase{
+		{instr: FetchedInstr32(0xf06a0901), instr_valid: true},  // orn r9, r10, #1
+		{instr: FetchedInstr32(0xf06f0bff), instr_valid: false}, // mvn r11, #0xff
+	}
+
+	test_identify(t, cases, reflect.TypeOf(OrnImmT1{}))
+}
+
+func TestDecodeOrnImm32T1(t *testing.T) {
+	cases := []DecodeCase{
+		// orn r9, r10, #1
+		{instr: FetchedInstr32(0xf06a0901), decoded: OrnImmT1{Rd: 9, Rm: 0, Rn: 10, Imm: 0x001, setflags: NEVER}},
+		// mvn r11, #0xff
+		{instr: FetchedInstr32(0xf06f0bff), decoded: MvnImmT1{Rd: 11, Rm: 0, Rn: 0, Imm: 0x0ff, setflags: NEVER}},
+	}
+
+	test_decode(t, cases, OrnImm32T1)
+}
+
+func TestExecuteOrnImmT1(t *testing.T) {
+	cases := []ExecuteCase{
+		// orn r9, r10, #1
+		{instr: OrnImmT1{Rd: 9, Rm: 0, Rn: 10, Imm: 0x001, setflags: NEVER},
+			regs:     Registers{r: GeneralRegs{0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 0, 11, 12}},
+			expected: Registers{r: GeneralRegs{0, 1, 2, 3, 4, 5, 6, 7, 8, 0xfffffffe, 0, 11, 12}}},
+	}
+
+	test_execute(t, cases)
+}
+
+func TestIdentifyEorImmT1(t *testing.T) {
+	cases := []IdentifyCase{
+		{instr: FetchedInstr32(0xf0810c55), instr_valid: true},  // eor r12, r1, #0x55
+		{instr: FetchedInstr32(0xf0920f80), instr_valid: false}, // teq r2, #0x80
+	}
+
+	test_identify(t, cases, reflect.TypeOf(EorImmT1{}))
+}
+
+func TestDecodeEorImm32T1(t *testing.T) {
+	cases := []DecodeCase{
+		// eor r12, r1, #0x55
+		{instr: FetchedInstr32(0xf0810c55), decoded: EorImmT1{Rd: 12, Rm: 0, Rn: 1, Imm: 0x055, setflags: NEVER}},
+		// teq r2, #0x80
+		{instr: FetchedInstr32(0xf0920f80), decoded: TeqImmT1{Rd: 0, Rm: 0, Rn: 2, Imm: 0x080, setflags: ALWAYS}},
+	}
+
+	test_decode(t, cases, EorImm32T1)
+}
+
+func TestExecuteEorImmT1(t *testing.T) {
+	cases := []ExecuteCase{
+		// eor r12, r1, #0x55
+		{instr: EorImmT1{Rd: 12, Rm: 0, Rn: 1, Imm: 0x055, setflags: NEVER},
+			regs:     Registers{r: GeneralRegs{0, 0xff, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12}},
+			expected: Registers{r: GeneralRegs{0, 0xff, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 0xaa}}},
+	}
+
+	test_execute(t, cases)
+}
+
+func TestIdentifyTeqImmT1(t *testing.T) {
+	cases := []IdentifyCase{
+		{instr: FetchedInstr32(0xf0920f80), instr_valid: true},  // teq r2, #0x80
+		{instr: FetchedInstr32(0xf0810c55), instr_valid: false}, // eor r12, r1, #0x55
+	}
+
+	test_identify(t, cases, reflect.TypeOf(TeqImmT1{}))
+}
+
+func TestDecodeTeqImm32T1(t *testing.T) {
+	cases := []DecodeCase{
+		// teq r2, #0x80
+		{instr: FetchedInstr32(0xf0920f80), decoded: TeqImmT1{Rd: 0, Rm: 0, Rn: 2, Imm: 0x080, setflags: ALWAYS}},
+	}
+
+	test_decode(t, cases, TeqImm32T1)
+}
+
+func TestExecuteTeqImmT1(t *testing.T) {
+	cases := []ExecuteCase{
+		// teq r2, #0x80
+		{instr: TeqImmT1{Rd: 0, Rm: 0, Rn: 2, Imm: 0x080, setflags: ALWAYS},
+			regs:     Registers{r: GeneralRegs{0, 1, 0x80, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12}, Apsr: Apsr{N: true}},
+			expected: Registers{r: GeneralRegs{0, 1, 0x80, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12}, Apsr: Apsr{Z: true}}},
+	}
+
+	test_execute(t, cases)
+}
+
+func TestIdentifyAndRegT2(t *testing.T) {
+	cases := []IdentifyCase{
+		{instr: FetchedInstr32(0xea021103), instr_valid: true},  // and.w r1, r2, r3, lsl #4
+		{instr: FetchedInstr32(0xea122133), instr_valid: true},  // ands.w r1, r2, r3, ror #8
+		{instr: FetchedInstr32(0xea140f15), instr_valid: false}, // tst.w r4, r5, lsr #32
+		{instr: FetchedInstr32(0xea270668), instr_valid: false}, // bic.w r6, r7, r8, asr #1
+	}
+
+	test_identify(t, cases, reflect.TypeOf(AndRegT2{}))
+}
+
+func TestDecodeAndReg32T2(t *testing.T) {
+	cases := []DecodeCase{
+		// and.w r1, r2, r3, lsl #4
+		{instr: FetchedInstr32(0xea021103), decoded: AndRegT2{Rd: 1, Rm: 3, Rn: 2, Imm: 0, setflags: NEVER, Shift: Shift{srtype: SRType_LSL, amount: 4}}},
+		// ands.w r1, r2, r3, ror #8
+		{instr: FetchedInstr32(0xea122133), decoded: AndRegT2{Rd: 1, Rm: 3, Rn: 2, Imm: 0, setflags: ALWAYS, Shift: Shift{srtype: SRType_ROR, amount: 8}}},
+		// tst.w r4, r5, lsr #32
+		{instr: FetchedInstr32(0xea140f15), decoded: TstRegT2{Rd: 0, Rm: 5, Rn: 4, Imm: 0, setflags: ALWAYS, Shift: Shift{srtype: SRType_LSR, amount: 32}}},
+	}
+
+	test_decode(t, cases, AndReg32T2)
+}
+
+func TestExecuteAndRegT2(t *testing.T) {
+	cases := []ExecuteCase{
+		// and.w r1, r2, r3, lsl #4
+		{instr: AndRegT2{Rd: 1, Rm: 3, Rn: 2, Imm: 0, setflags: NEVER, Shift: Shift{srtype: SRType_LSL, amount: 4}},
+			regs:     Registers{r: GeneralRegs{0, 1, 0xff0, 0x3f, 4, 5, 6, 7, 8, 9, 10, 11, 12}},
+			expected: Registers{r: GeneralRegs{0, 0x3f0, 0xff0, 0x3f, 4, 5, 6, 7, 8, 9, 10, 11, 12}}},
+		// ands.w r1, r2, r3, ror #8
+		{instr: AndRegT2{Rd: 1, Rm: 3, Rn: 2, Imm: 0, setflags: ALWAYS, Shift: Shift{srtype: SRType_ROR, amount: 8}},
+			regs:     Registers{r: GeneralRegs{0, 1, 0xffffffff, 0x80, 4, 5, 6, 7, 8, 9, 10, 11, 12}},
+			expected: Registers{r: GeneralRegs{0, 0x80000000, 0xffffffff, 0x80, 4, 5, 6, 7, 8, 9, 10, 11, 12}, Apsr: Apsr{N: true, C: true}}},
+	}
+
+	test_execute(t, cases)
+}
+
+func TestIdentifyTstRegT2(t *testing.T) {
+	cases := []IdentifyCase{
+		{instr: FetchedInstr32(0xea140f15), instr_valid: true},  // tst.w r4, r5, lsr #32
+		{instr: FetchedInstr32(0xea122133), instr_valid: false}, // ands.w r1, r2, r3, ror #8
+	}
+
+	test_identify(t, cases, reflect.TypeOf(TstRegT2{}))
+}
+
+func TestExecuteTstRegT2(t *testing.T) {
+	cases := []ExecuteCase{
+		// tst.w r4, r5, lsr #32
+		{instr: TstRegT2{Rd: 0, Rm: 5, Rn: 4, Imm: 0, setflags: ALWAYS, Shift: Shift{srtype: SRType_LSR, amount: 32}},
+			regs:     Registers{r: GeneralRegs{0, 1, 2, 3, 0xffffffff, 0x80000000, 6, 7, 8, 9, 10, 11, 12}},
+			expected: Registers{r: GeneralRegs{0, 1, 2, 3, 0xffffffff, 0x80000000, 6, 7, 8, 9, 10, 11, 12}, Apsr: Apsr{Z: true, C: true}}},
+	}
+
+	test_execute(t, cases)
+}
+
+func TestDecodeBicReg32T2(t *testing.T) {
+	cases := []DecodeCase{
+		// bic.w r6, r7, r8, asr #1
+		{instr: FetchedInstr32(0xea270668), decoded: BicRegT2{Rd: 6, Rm: 8, Rn: 7, Imm: 0, setflags: NEVER, Shift: Shift{srtype: SRType_ASR, amount: 1}}},
+	}
+
+	test_decode(t, cases, BicReg32T2)
+}
+
+func TestExecuteBicRegT2(t *testing.T) {
+	cases := []ExecuteCase{
+		// bic.w r6, r7, r8, asr #1
+		{instr: BicRegT2{Rd: 6, Rm: 8, Rn: 7, Imm: 0, setflags: NEVER, Shift: Shift{srtype: SRType_ASR, amount: 1}},
+			regs:     Registers{r: GeneralRegs{0, 1, 2, 3, 4, 5, 6, 0xffffffff, 0x80000000, 9, 10, 11, 12}},
+			expected: Registers{r: GeneralRegs{0, 1, 2, 3, 4, 5, 0x3fffffff, 0xffffffff, 0x80000000, 9, 10, 11, 12}}},
+	}
+
+	test_execute(t, cases)
+}
+
+func TestIdentifyOrrRegT2(t *testing.T) {
+	cases := []IdentifyCase{
+		{instr: FetchedInstr32(0xea4a093b), instr_valid: true},  // orr.w r9, r10, r11, rrx
+		{instr: FetchedInstr32(0xea4f0809), instr_valid: false}, // mov.w r8, r9
+		{instr: FetchedInstr32(0xea6170c2), instr_valid: false}, // orn r0, r1, r2, lsl #31
+	}
+
+	test_identify(t, cases, reflect.TypeOf(OrrRegT2{}))
+}
+
+func TestDecodeOrrReg32T2(t *testing.T) {
+	cases := []DecodeCase{
+		// orr.w r9, r10, r11, rrx
+		{instr: FetchedInstr32(0xea4a093b), decoded: OrrRegT2{Rd: 9, Rm: 11, Rn: 10, Imm: 0, setflags: NEVER, Shift: Shift{srtype: SRType_RRX, amount: 1}}},
+		// mov.w r8, r9
+		{instr: FetchedInstr32(0xea4f0809), decoded: MovRegT3{Rd: 8, Rm: 9, Rn: 0, Imm: 0, setflags: NEVER}},
+	}
+
+	test_decode(t, cases, OrrReg32T2)
+}
+
+func TestExecuteOrrRegT2(t *testing.T) {
+	cases := []ExecuteCase{
+		// orr.w r9, r10, r11, rrx
+		{instr: OrrRegT2{Rd: 9, Rm: 11, Rn: 10, Imm: 0, setflags: NEVER, Shift: Shift{srtype: SRType_RRX, amount: 1}},
+			regs:     Registers{r: GeneralRegs{0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 0x1, 0x10, 12}, Apsr: Apsr{C: true}},
+			expected: Registers{r: GeneralRegs{0, 1, 2, 3, 4, 5, 6, 7, 8, 0x80000009, 0x1, 0x10, 12}, Apsr: Apsr{C: true}}},
+	}
+
+	test_execute(t, cases)
+}
+
+func TestDecodeOrnReg32T1(t *testing.T) {
+	cases := []DecodeCase{
+		// orn r0, r1, r2, lsl #31
+		{instr: FetchedInstr32(0xea6170c2), decoded: OrnRegT1{Rd: 0, Rm: 2, Rn: 1, Imm: 0, setflags: NEVER, Shift: Shift{srtype: SRType_LSL, amount: 31}}},
+		// mvn.w r3, r4, asr #32
+		{instr: FetchedInstr32(0xea6f0324), decoded: MvnRegT2{Rd: 3, Rm: 4, Rn: 0, Imm: 0, setflags: NEVER, Shift: Shift{srtype: SRType_ASR, amount: 32}}},
+	}
+
+	test_decode(t, cases, OrnReg32T1)
+}
+
+func TestExecuteOrnRegT1(t *testing.T) {
+	cases := []ExecuteCase{
+		// orn r0, r1, r2, lsl #31
+		{instr: OrnRegT1{Rd: 0, Rm: 2, Rn: 1, Imm: 0, setflags: NEVER, Shift: Shift{srtype: SRType_LSL, amount: 31}},
+			regs:     Registers{r: GeneralRegs{0, 0, 1, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12}},
+			expected: Registers{r: GeneralRegs{0x7fffffff, 0, 1, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12}}},
+	}
+
+	test_execute(t, cases)
+}
+
+func TestIdentifyEorRegT2(t *testing.T) {
+	cases := []IdentifyCase{
+		{instr: FetchedInstr32(0xea860507), instr_valid: true},  // eor.w r5, r6, r7
+		{instr: FetchedInstr32(0xea980f49), instr_valid: false}, // teq.w r8, r9, lsl #1
+	}
+
+	test_identify(t, cases, reflect.TypeOf(EorRegT2{}))
+}
+
+func TestDecodeEorReg32T2(t *testing.T) {
+	cases := []DecodeCase{
+		// eor.w r5, r6, r7
+		{instr: FetchedInstr32(0xea860507), decoded: EorRegT2{Rd: 5, Rm: 7, Rn: 6, Imm: 0, setflags: NEVER}},
+		// teq.w r8, r9, lsl #1
+		{instr: FetchedInstr32(0xea980f49), decoded: TeqRegT1{Rd: 0, Rm: 9, Rn: 8, Imm: 0, setflags: ALWAYS, Shift: Shift{srtype: SRType_LSL, amount: 1}}},
+	}
+
+	test_decode(t, cases, EorReg32T2)
+}
+
+func TestExecuteTeqRegT1(t *testing.T) {
+	cases := []ExecuteCase{
+		// teq.w r8, r9, lsl #1
+		{instr: TeqRegT1{Rd: 0, Rm: 9, Rn: 8, Imm: 0, setflags: ALWAYS, Shift: Shift{srtype: SRType_LSL, amount: 1}},
+			regs:     Registers{r: GeneralRegs{0, 1, 2, 3, 4, 5, 6, 7, 0x2, 0x80000001, 10, 11, 12}},
+			expected: Registers{r: GeneralRegs{0, 1, 2, 3, 4, 5, 6, 7, 0x2, 0x80000001, 10, 11, 12}, Apsr: Apsr{Z: true, C: true}}},
+	}
+
+	test_execute(t, cases)
+}
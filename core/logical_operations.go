@@ -0,0 +1,128 @@
+package core
+
+/* Perform AND instruction (reg), with shift, updating condition codes */
+func AndRegister(cpu *CPU, instr InstrFields, shift Shift) {
+	shifted, carry := shift.EvaluateC(cpu.R(instr.Rm), cpu.Apsr.C)
+
+	logical_update_condition_codes(cpu, instr, cpu.R(instr.Rn)&shifted, carry)
+}
+
+/* Perform EOR instruction (reg), with shift, updating condition codes */
+func EorRegister(cpu *CPU, instr InstrFields, shift Shift) {
+	shifted, carry := shift.EvaluateC(cpu.R(instr.Rm), cpu.Apsr.C)
+
+	logical_update_condition_codes(cpu, instr, cpu.R(instr.Rn)^shifted, carry)
+}
+
+/* Perform ORR instruction (reg), with shift, updating condition codes */
+func OrrRegister(cpu *CPU, instr InstrFields, shift Shift) {
+	shifted, carry := shift.EvaluateC(cpu.R(instr.Rm), cpu.Apsr.C)
+
+	logical_update_condition_codes(cpu, instr, cpu.R(instr.Rn)|shifted, carry)
+}
+
+/* Perform BIC instruction (reg), with shift, updating condition codes */
+func BicRegister(cpu *CPU, instr InstrFields, shift Shift) {
+	shifted, carry := shift.EvaluateC(cpu.R(instr.Rm), cpu.Apsr.C)
+
+	logical_update_condition_codes(cpu, instr, cpu.R(instr.Rn)&^shifted, carry)
+}
+
+/* Perform MVN instruction (reg), with shift, updating condition codes */
+func MvnRegister(cpu *CPU, instr InstrFields, shift Shift) {
+	shifted, carry := shift.EvaluateC(cpu.R(instr.Rm), cpu.Apsr.C)
+
+	logical_update_condition_codes(cpu, instr, ^shifted, carry)
+}
+
+/* Perform TST instruction (reg), with shift, updating condition codes */
+func TstRegister(cpu *CPU, instr InstrFields, shift Shift) {
+	shifted, carry := shift.EvaluateC(cpu.R(instr.Rm), cpu.Apsr.C)
+
+	test_update_condition_codes(cpu, cpu.R(instr.Rn)&shifted, carry)
+}
+
+/* Perform ORN instruction (reg), with shift, updating condition codes */
+func OrnRegister(cpu *CPU, instr InstrFields, shift Shift) {
+	shifted, carry := shift.EvaluateC(cpu.R(instr.Rm), cpu.Apsr.C)
+
+	logical_update_condition_codes(cpu, instr, cpu.R(instr.Rn)|^shifted, carry)
+}
+
+/* Perform TEQ instruction (reg), with shift, updating condition codes */
+func TeqRegister(cpu *CPU, instr InstrFields, shift Shift) {
+	shifted, carry := shift.EvaluateC(cpu.R(instr.Rm), cpu.Apsr.C)
+
+	test_update_condition_codes(cpu, cpu.R(instr.Rn)^shifted, carry)
+}
+
+/* Perform MOV instruction (reg), with shift, updating condition codes.
+ * This is also the immediate form of LSL, LSR, ASR, ROR and RRX. */
+func MovShiftedRegister(cpu *CPU, instr InstrFields, shift Shift) {
+	shifted, carry := shift.EvaluateC(cpu.R(instr.Rm), cpu.Apsr.C)
+
+	logical_update_condition_codes(cpu, instr, shifted, carry)
+}
+
+/* Perform AND instruction (imm), updating condition codes.
+ * instr.Imm is the expanded immediate, and carry the carry out of its expansion. */
+func AndImmediate(cpu *CPU, instr InstrFields, carry bool) {
+	logical_update_condition_codes(cpu, instr, cpu.R(instr.Rn)&instr.Imm, carry)
+}
+
+/* Perform EOR instruction (imm), updating condition codes */
+func EorImmediate(cpu *CPU, instr InstrFields, carry bool) {
+	logical_update_condition_codes(cpu, instr, cpu.R(instr.Rn)^instr.Imm, carry)
+}
+
+/* Perform ORR instruction (imm), updating condition codes */
+func OrrImmediate(cpu *CPU, instr InstrFields, carry bool) {
+	logical_update_condition_codes(cpu, instr, cpu.R(instr.Rn)|instr.Imm, carry)
+}
+
+/* Perform ORN instruction (imm), updating condition codes */
+func OrnImmediate(cpu *CPU, instr InstrFields, carry bool) {
+	logical_update_condition_codes(cpu, instr, cpu.R(instr.Rn)|^instr.Imm, carry)
+}
+
+/* Perform BIC instruction (imm), updating condition codes */
+func BicImmediate(cpu *CPU, instr InstrFields, carry bool) {
+	logical_update_condition_codes(cpu, instr, cpu.R(instr.Rn)&^instr.Imm, carry)
+}
+
+/* Perform MVN instruction (imm), updating condition codes */
+func MvnImmediate(cpu *CPU, instr InstrFields, carry bool) {
+	logical_update_condition_codes(cpu, instr, ^instr.Imm, carry)
+}
+
+/* Perform TST instruction (imm), updating condition codes */
+func TstImmediate(cpu *CPU, instr InstrFields, carry bool) {
+	test_update_condition_codes(cpu, cpu.R(instr.Rn)&instr.Imm, carry)
+}
+
+/* Perform TEQ instruction (imm), updating condition codes */
+func TeqImmediate(cpu *CPU, instr InstrFields, carry bool) {
+	test_update_condition_codes(cpu, cpu.R(instr.Rn)^instr.Imm, carry)
+}
+
+/* Update condition codes for logical instruction */
+func logical_update_condition_codes(cpu *CPU, instr InstrFields, result uint32, carry bool) {
+	if instr.Rd == PC {
+		cpu.ALUWritePC(result)
+	} else {
+		cpu.SetR(instr.Rd, result)
+		if instr.setflags.ShouldSetFlags(cpu.Registers) {
+			cpu.Apsr.N = (result & 0x80000000) != 0
+			cpu.Apsr.Z = (result) == 0
+			cpu.Apsr.C = carry
+		}
+	}
+}
+
+/* Update condition codes for TST/TEQ instruction, which always set flags
+ * and discard the result */
+func test_update_condition_codes(cpu *CPU, result uint32, carry bool) {
+	cpu.Apsr.N = (result & 0x80000000) != 0
+	cpu.Apsr.Z = (result) == 0
+	cpu.Apsr.C = carry
+}
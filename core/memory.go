@@ -0,0 +1,308 @@
+package core
+
+import (
+	"errors"
+	"fmt"
+)
+
+var ErrUnmappedAccess = errors.New("Access to unmapped memory.")
+var ErrRegionOverlap = errors.New("Region overlaps an existing mapping.")
+var ErrReadOnly = errors.New("Write to read-only memory.")
+
+/* Access to a placeholder region, part of the memory map without a model */
+type UnmodelledAccess struct {
+	Region string
+}
+
+func (err UnmodelledAccess) Error() string {
+	return fmt.Sprintf("Access to unmodelled %s region.", err.Region)
+}
+
+/* Default memory map, matching assembly/link.ld
+ * ARMv7-M ARM B3.1 */
+const (
+	FLASH_BASE = 0x00000000
+	FLASH_SIZE = 256 * 1024
+
+	SRAM_BASE = 0x20000000
+	SRAM_SIZE = 32 * 1024
+
+	PERIPH_BASE = 0x40000000
+	PERIPH_SIZE = 0x20000000
+
+	PPB_BASE = 0xe0000000
+	PPB_SIZE = 0x00100000
+)
+
+/* Guest memory, as seen by the CPU. All accesses are little-endian. */
+type Memory interface {
+	Read8(addr uint32) (uint8, error)
+	Read16(addr uint32) (uint16, error)
+	Read32(addr uint32) (uint32, error)
+	Write8(addr uint32, value uint8) error
+	Write16(addr uint32, value uint16) error
+	Write32(addr uint32, value uint32) error
+}
+
+/* Memory the host can load regardless of what the guest may write */
+type Loader interface {
+	Load(addr uint32, data []byte) error
+}
+
+/* Copy data into memory starting at addr, as the host */
+func LoadBytes(mem Memory, addr uint32, data []byte) error {
+	if loader, ok := mem.(Loader); ok {
+		return loader.Load(addr, data)
+	}
+
+	for i, b := range data {
+		if err := mem.Write8(addr+uint32(i), b); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+/* Flat RAM starting at address 0 */
+type RAM []byte
+
+func (ram RAM) check(addr uint32, size uint32) error {
+	if uint64(addr)+uint64(size) > uint64(len(ram)) {
+		return ErrUnmappedAccess
+	}
+	return nil
+}
+
+func (ram RAM) Read8(addr uint32) (uint8, error) {
+	if err := ram.check(addr, 1); err != nil {
+		return 0, err
+	}
+
+	return ram[addr], nil
+}
+
+func (ram RAM) Read16(addr uint32) (uint16, error) {
+	if err := ram.check(addr, 2); err != nil {
+		return 0, err
+	}
+
+	return uint16(ram[addr]) | (uint16(ram[addr+1]) << 8), nil
+}
+
+func (ram RAM) Read32(addr uint32) (uint32, error) {
+	if err := ram.check(addr, 4); err != nil {
+		return 0, err
+	}
+
+	return uint32(ram[addr]) | (uint32(ram[addr+1]) << 8) |
+		(uint32(ram[addr+2]) << 16) | (uint32(ram[addr+3]) << 24), nil
+}
+
+func (ram RAM) Write8(addr uint32, value uint8) error {
+	if err := ram.check(addr, 1); err != nil {
+		return err
+	}
+
+	ram[addr] = value
+	return nil
+}
+
+func (ram RAM) Write16(addr uint32, value uint16) error {
+	if err := ram.check(addr, 2); err != nil {
+		return err
+	}
+
+	ram[addr] = uint8(value)
+	ram[addr+1] = uint8(value >> 8)
+	return nil
+}
+
+func (ram RAM) Write32(addr uint32, value uint32) error {
+	if err := ram.check(addr, 4); err != nil {
+		return err
+	}
+
+	ram[addr] = uint8(value)
+	ram[addr+1] = uint8(value >> 8)
+	ram[addr+2] = uint8(value >> 16)
+	ram[addr+3] = uint8(value >> 24)
+	return nil
+}
+
+/* A range of the address space, backed by Mem. Mem is accessed with
+ * addresses relative to Base. A region without Mem is a placeholder:
+ * accesses fail with UnmodelledAccess, and models may be mapped over it. */
+type Region struct {
+	Name     string
+	Base     uint32
+	Size     uint32
+	Mem      Memory
+	ReadOnly bool // Guest writes fail, the host loads with LoadBytes
+}
+
+func (region Region) contains(addr uint32, size uint32) bool {
+	return addr >= region.Base &&
+		uint64(addr)+uint64(size) <= uint64(region.Base)+uint64(region.Size)
+}
+
+/* Memory bus dispatching accesses to mapped regions */
+type Bus struct {
+	regions []Region
+}
+
+func NewBus() *Bus {
+	return new(Bus)
+}
+
+/* Bus with RAM backed Flash and SRAM regions at their default addresses,
+ * and placeholders for the peripheral region and the PPB. Flash is
+ * read-only to the guest. The host maps its peripheral models over the
+ * placeholders; the CPU itself handles the System Control Space. */
+func NewDefaultBus() *Bus {
+	bus := NewBus()
+
+	bus.Map(Region{Name: "Flash", Base: FLASH_BASE, Size: FLASH_SIZE, Mem: make(RAM, FLASH_SIZE), ReadOnly: true})
+	bus.Map(Region{Name: "SRAM", Base: SRAM_BASE, Size: SRAM_SIZE, Mem: make(RAM, SRAM_SIZE)})
+	bus.Map(Region{Name: "Peripheral", Base: PERIPH_BASE, Size: PERIPH_SIZE})
+	bus.Map(Region{Name: "PPB", Base: PPB_BASE, Size: PPB_SIZE})
+
+	return bus
+}
+
+/* Add region to the bus. Regions may only overlap placeholders. */
+func (bus *Bus) Map(region Region) error {
+	end := uint64(region.Base) + uint64(region.Size)
+
+	for _, r := range bus.regions {
+		if r.Mem == nil || region.Mem == nil {
+			continue
+		}
+
+		r_end := uint64(r.Base) + uint64(r.Size)
+		if uint64(region.Base) < r_end && uint64(r.Base) < end {
+			return ErrRegionOverlap
+		}
+	}
+
+	bus.regions = append(bus.regions, region)
+	return nil
+}
+
+func (bus *Bus) Regions() []Region {
+	return bus.regions
+}
+
+/* Find the region containing the entire access, returning the region
+ * relative address */
+func (bus *Bus) lookup(addr uint32, size uint32) (Memory, uint32, error) {
+	region, err := bus.region(addr, size)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return region.Mem, addr - region.Base, nil
+}
+
+/* Find the region containing the entire access, preferring models to
+ * the placeholders they're mapped over */
+func (bus *Bus) region(addr uint32, size uint32) (Region, error) {
+	var err error = ErrUnmappedAccess
+
+	for _, region := range bus.regions {
+		if !region.contains(addr, size) {
+			continue
+		}
+
+		if region.Mem != nil {
+			return region, nil
+		}
+		err = UnmodelledAccess{Region: region.Name}
+	}
+
+	return Region{}, err
+}
+
+/* As lookup, but failing writes to read-only regions */
+func (bus *Bus) lookup_write(addr uint32, size uint32) (Memory, uint32, error) {
+	region, err := bus.region(addr, size)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	if region.ReadOnly {
+		return nil, 0, ErrReadOnly
+	}
+
+	return region.Mem, addr - region.Base, nil
+}
+
+/* Load data into the mapped regions, including read-only ones */
+func (bus *Bus) Load(addr uint32, data []byte) error {
+	for i, b := range data {
+		mem, offset, err := bus.lookup(addr+uint32(i), 1)
+		if err != nil {
+			return err
+		}
+
+		if err := mem.Write8(offset, b); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (bus *Bus) Read8(addr uint32) (uint8, error) {
+	mem, offset, err := bus.lookup(addr, 1)
+	if err != nil {
+		return 0, err
+	}
+
+	return mem.Read8(offset)
+}
+
+func (bus *Bus) Read16(addr uint32) (uint16, error) {
+	mem, offset, err := bus.lookup(addr, 2)
+	if err != nil {
+		return 0, err
+	}
+
+	return mem.Read16(offset)
+}
+
+func (bus *Bus) Read32(addr uint32) (uint32, error) {
+	mem, offset, err := bus.lookup(addr, 4)
+	if err != nil {
+		return 0, err
+	}
+
+	return mem.Read32(offset)
+}
+
+func (bus *Bus) Write8(addr uint32, value uint8) error {
+	mem, offset, err := bus.lookup_write(addr, 1)
+	if err != nil {
+		return err
+	}
+
+	return mem.Write8(offset, value)
+}
+
+func (bus *Bus) Write16(addr uint32, value uint16) error {
+	mem, offset, err := bus.lookup_write(addr, 2)
+	if err != nil {
+		return err
+	}
+
+	return mem.Write16(offset, value)
+}
+
+func (bus *Bus) Write32(addr uint32, value uint32) error {
+	mem, offset, err := bus.lookup_write(addr, 4)
+	if err != nil {
+		return err
+	}
+
+	return mem.Write32(offset, value)
+}
@@ -0,0 +1,112 @@
+package core
+
+/* Instruction memory accesses. Each returns false, having raised a
+ * fault, if the access could not complete; the instruction must then
+ * stop without updating any registers.
+ * ARMv7-M ARM A3.2 */
+
+/* Accesses are made with the privilege given, rather than that of the
+ * current mode, so the SCS is checked here as well as by the CPU */
+func (cpu *CPU) read(addr uint32, size uint32, privileged bool) (uint32, error) {
+	if in_scs(addr) && cpu.scs_denied(addr, false, privileged) {
+		return 0, ErrUnprivilegedAccess
+	}
+
+	switch size {
+	case 1:
+		value, err := cpu.Read8(addr)
+		return uint32(value), err
+	case 2:
+		value, err := cpu.Read16(addr)
+		return uint32(value), err
+	}
+
+	return cpu.Read32(addr)
+}
+
+func (cpu *CPU) write(addr uint32, size uint32, value uint32, privileged bool) error {
+	if in_scs(addr) && cpu.scs_denied(addr, true, privileged) {
+		return ErrUnprivilegedAccess
+	}
+
+	switch size {
+	case 1:
+		return cpu.Write8(addr, uint8(value))
+	case 2:
+		return cpu.Write16(addr, uint16(value))
+	}
+
+	return cpu.Write32(addr, value)
+}
+
+func (cpu *CPU) check_alignment(addr uint32, size uint32, aligned bool, write bool) bool {
+	if addr&(size-1) == 0 {
+		return true
+	}
+
+	if aligned || cpu.Scb.Ccr&CCR_UNALIGN_TRP != 0 {
+		cpu.raise(MemoryFault{Addr: addr, Write: write, Err: ErrUnalignedAccess})
+		return false
+	}
+
+	return true
+}
+
+func (cpu *CPU) mem_read(addr uint32, size uint32, aligned bool, privileged bool) (uint32, bool) {
+	if !cpu.check_alignment(addr, size, aligned, false) {
+		return 0, false
+	}
+
+	value, err := cpu.read(addr, size, privileged)
+	if err != nil {
+		cpu.raise(MemoryFault{Addr: addr, Write: false, Err: err})
+		return 0, false
+	}
+
+	return value, true
+}
+
+func (cpu *CPU) mem_write(addr uint32, size uint32, value uint32, aligned bool, privileged bool) bool {
+	if !cpu.check_alignment(addr, size, aligned, true) {
+		return false
+	}
+
+	if err := cpu.write(addr, size, value, privileged); err != nil {
+		cpu.raise(MemoryFault{Addr: addr, Write: true, Err: err})
+		return false
+	}
+
+	return true
+}
+
+/* Read size bytes, which must be aligned
+ * ARM ARM pseudocode MemA[] */
+func (cpu *CPU) MemA(addr uint32, size uint32) (uint32, bool) {
+	return cpu.mem_read(addr, size, true, cpu.CurrentModeIsPrivileged())
+}
+
+func (cpu *CPU) SetMemA(addr uint32, size uint32, value uint32) bool {
+	return cpu.mem_write(addr, size, value, true, cpu.CurrentModeIsPrivileged())
+}
+
+/* Read size bytes, which may be unaligned unless CCR.UNALIGN_TRP is set
+ * ARM ARM pseudocode MemU[] */
+func (cpu *CPU) MemU(addr uint32, size uint32) (uint32, bool) {
+	return cpu.mem_read(addr, size, false, cpu.CurrentModeIsPrivileged())
+}
+
+func (cpu *CPU) SetMemU(addr uint32, size uint32, value uint32) bool {
+	return cpu.mem_write(addr, size, value, false, cpu.CurrentModeIsPrivileged())
+}
+
+/* Unprivileged accesses (LDRT, STRT), made as if from unprivileged code
+ * whatever the current mode, so the SCS refuses them. There is no MPU to
+ * check them against otherwise.
+ * ARM ARM pseudocode MemU_unpriv[] */
+func (cpu *CPU) MemU_unpriv(addr uint32, size uint32) (uint32, bool) {
+	return cpu.mem_read(addr, size, false, false)
+}
+
+func (cpu *CPU) SetMemU_unpriv(addr uint32, size uint32, value uint32) bool {
+	return cpu.mem_write(addr, size, value, false, false)
+}
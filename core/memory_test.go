@@ -0,0 +1,114 @@
+package core
+
+import "testing"
+
+func TestRAMEndianness(t *testing.T) {
+	ram := make(RAM, 8)
+
+	if err := ram.Write32(0, 0x12345678); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+
+	if b, _ := ram.Read8(0); b != 0x78 {
+		t.Errorf("Read8(0) = %#x, expected 0x78", b)
+	}
+
+	if h, _ := ram.Read16(2); h != 0x1234 {
+		t.Errorf("Read16(2) = %#x, expected 0x1234", h)
+	}
+
+	/* Unaligned */
+	ram.Write16(5, 0xbeef)
+	if w, _ := ram.Read32(3); w != 0xbeef0012 {
+		t.Errorf("Read32(3) = %#x, expected 0xbeef0012", w)
+	}
+
+	if _, err := ram.Read32(5); err != ErrUnmappedAccess {
+		t.Errorf("Read32(5) err = %v, expected %v", err, ErrUnmappedAccess)
+	}
+}
+
+func TestBus(t *testing.T) {
+	bus := NewDefaultBus()
+
+	if err := bus.Write32(SRAM_BASE+0x10, 0xcafebabe); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+
+	if w, err := bus.Read32(SRAM_BASE + 0x10); err != nil || w != 0xcafebabe {
+		t.Errorf("Read32 = %#x, %v", w, err)
+	}
+
+	if h, err := bus.Read16(SRAM_BASE + 0x12); err != nil || h != 0xcafe {
+		t.Errorf("Read16 = %#x, %v", h, err)
+	}
+
+	/* Flash is only written by the host */
+	if err := bus.Write8(FLASH_BASE+FLASH_SIZE-1, 0xaa); err != ErrReadOnly {
+		t.Errorf("Write8 to flash err = %v, expected %v", err, ErrReadOnly)
+	}
+
+	if err := LoadBytes(bus, FLASH_BASE+FLASH_SIZE-2, []byte{0x34, 0x12}); err != nil {
+		t.Errorf("LoadBytes at end of flash: %v", err)
+	}
+
+	if h, err := bus.Read16(FLASH_BASE + FLASH_SIZE - 2); err != nil || h != 0x1234 {
+		t.Errorf("Read16 = %#x, %v", h, err)
+	}
+
+	cases := []uint32{
+		FLASH_BASE + FLASH_SIZE,
+		SRAM_BASE - 4,
+		SRAM_BASE + SRAM_SIZE - 2, // straddles end of SRAM
+	}
+
+	for _, addr := range cases {
+		if _, err := bus.Read32(addr); err != ErrUnmappedAccess {
+			t.Errorf("Read32(%#x) err = %v, expected %v", addr, err, ErrUnmappedAccess)
+		}
+		if err := bus.Write32(addr, 0); err != ErrUnmappedAccess {
+			t.Errorf("Write32(%#x) err = %v, expected %v", addr, err, ErrUnmappedAccess)
+		}
+	}
+}
+
+func TestBusPlaceholders(t *testing.T) {
+	bus := NewDefaultBus()
+
+	cases := []struct {
+		addr   uint32
+		region string
+	}{
+		{addr: PERIPH_BASE + 0x1000, region: "Peripheral"},
+		{addr: PPB_BASE + 0x1000, region: "PPB"}, // DWT
+	}
+
+	for _, test := range cases {
+		expected := UnmodelledAccess{Region: test.region}
+		if _, err := bus.Read32(test.addr); err != expected {
+			t.Errorf("Read32(%#x) err = %v, expected %v", test.addr, err, expected)
+		}
+		if err := bus.Write32(test.addr, 0); err != expected {
+			t.Errorf("Write32(%#x) err = %v, expected %v", test.addr, err, expected)
+		}
+	}
+}
+
+func TestBusMap(t *testing.T) {
+	bus := NewDefaultBus()
+
+	periph := make(RAM, 0x100)
+	if err := bus.Map(Region{Name: "GPIO", Base: PERIPH_BASE, Size: 0x100, Mem: periph}); err != nil {
+		t.Fatalf("map: %v", err)
+	}
+
+	bus.Write16(PERIPH_BASE+0x20, 0x1234)
+	if periph[0x20] != 0x34 || periph[0x21] != 0x12 {
+		t.Errorf("Write not relative to region base: % x", periph[0x20:0x22])
+	}
+
+	overlap := Region{Name: "bad", Base: SRAM_BASE + SRAM_SIZE - 4, Size: 8, Mem: make(RAM, 8)}
+	if err := bus.Map(overlap); err != ErrRegionOverlap {
+		t.Errorf("err = %v, expected %v", err, ErrRegionOverlap)
+	}
+}
@@ -15,16 +15,20 @@ func MovImm16(instr FetchedInstr) DecodedInstr {
 	return MovImm{Rd: Rd, Rm: 0, Rn: 0, Imm: Imm, setflags: NOT_IT}
 }
 
-func (instr MovImm) Execute(regs *Registers) {
+func (instr MovImm) Execute(cpu *CPU) {
 	value := instr.Imm
 
-	MoveValue(regs, instr.Rd, value, instr.setflags, regs.Apsr.C)
+	MoveValue(cpu, instr.Rd, value, instr.setflags, cpu.Apsr.C)
 }
 
 func (instr MovImm) String() string {
 	return fmt.Sprintf("mov%s %s, #%#x", instr.setflags, instr.Rd, instr.Imm)
 }
 
+func (instr MovImm) SetsFlags() SetFlags {
+	return instr.setflags
+}
+
 /* MOV - Move (register)
  * ARM ARM A7.7.76
  * Encoding T1 */
@@ -42,14 +46,14 @@ func MovReg16T1(instr FetchedInstr) DecodedInstr {
 	return MovRegT1{Rd: d, Rm: Rm, Rn: 0, Imm: 0, setflags: NEVER}
 }
 
-func (instr MovRegT1) Execute(regs *Registers) {
-	if instr.Rd == 15 && regs.InITBlock() && !regs.LastInITBlock() {
+func (instr MovRegT1) Execute(cpu *CPU) {
+	if instr.Rd == 15 && cpu.InITBlock() && !cpu.LastInITBlock() {
 		// UNPREDICTABLE
 		// Raise exception (UsageFault?)
 		return
 	}
 
-	MoveRegister(regs, instr.Rd, instr.Rm, instr.setflags, regs.Apsr.C)
+	MoveRegister(cpu, instr.Rd, instr.Rm, instr.setflags, cpu.Apsr.C)
 }
 
 func (instr MovRegT1) String() string {
@@ -70,16 +74,227 @@ func MovReg16T2(instr FetchedInstr) DecodedInstr {
 	return MovRegT2{Rd: Rd, Rm: Rm, Rn: 0, Imm: 0, setflags: ALWAYS}
 }
 
-func (instr MovRegT2) Execute(regs *Registers) {
-	if regs.InITBlock() {
+func (instr MovRegT2) Execute(cpu *CPU) {
+	if cpu.InITBlock() {
 		// UNPREDICTABLE
 		// Raise exception (UsageFault?)
 		return
 	}
 
-	MoveRegister(regs, instr.Rd, instr.Rm, instr.setflags, regs.Apsr.C)
+	MoveRegister(cpu, instr.Rd, instr.Rm, instr.setflags, cpu.Apsr.C)
 }
 
 func (instr MovRegT2) String() string {
 	return fmt.Sprintf("movs %s, %s", instr.Rd, instr.Rm)
 }
+
+/* MVN - Bitwise NOT (register)
+ * ARM ARM A7.7.85
+ * Encoding T1 */
+type MvnRegT1 InstrFields
+
+func MvnReg16T1(instr FetchedInstr) DecodedInstr {
+	raw_instr := instr.Uint32()
+
+	Rd := RegIndex(raw_instr & 0x7)
+	Rm := RegIndex((raw_instr >> 3) & 0x7)
+
+	return MvnRegT1{Rd: Rd, Rm: Rm, Rn: 0, Imm: 0, setflags: NOT_IT}
+}
+
+func (instr MvnRegT1) Execute(cpu *CPU) {
+	MvnRegister(cpu, InstrFields(instr), Shift{srtype: SRType_LSL, amount: 0})
+}
+
+func (instr MvnRegT1) String() string {
+	return fmt.Sprintf("mvn%s %s, %s", instr.setflags, instr.Rd, instr.Rm)
+}
+
+func (instr MvnRegT1) SetsFlags() SetFlags {
+	return instr.setflags
+}
+
+/* MOV - Move (immediate)
+ * ARM ARM A7.7.75
+ * Encoding T2
+ *
+ * Imm holds the encoded imm12 */
+type MovImmT2 InstrFields
+
+func MovImm32T2(instr FetchedInstr) DecodedInstr {
+	Rd, _, imm12, setflags := decode_modified_imm(instr.Uint32())
+
+	if BadReg(Rd) {
+		return UnpredictableInstr{Rd: Rd, Rm: 0, Rn: 0, Imm: imm12, setflags: setflags}
+	}
+
+	return MovImmT2{Rd: Rd, Rm: 0, Rn: 0, Imm: imm12, setflags: setflags}
+}
+
+func (instr MovImmT2) Execute(cpu *CPU) {
+	imm32, carry := ThumbExpandImm_C(instr.Imm, cpu.Apsr.C)
+
+	MoveValue(cpu, instr.Rd, imm32, instr.setflags, carry)
+}
+
+func (instr MovImmT2) String() string {
+	return fmt.Sprintf("mov%s.w %s, #%#x", instr.setflags, instr.Rd, ThumbExpandImm(instr.Imm))
+}
+
+/* MVN - Bitwise NOT (immediate)
+ * ARM ARM A7.7.84
+ * Encoding T1
+ *
+ * Imm holds the encoded imm12 */
+type MvnImmT1 InstrFields
+
+func MvnImm32T1(instr FetchedInstr) DecodedInstr {
+	Rd, _, imm12, setflags := decode_modified_imm(instr.Uint32())
+
+	if BadReg(Rd) {
+		return UnpredictableInstr{Rd: Rd, Rm: 0, Rn: 0, Imm: imm12, setflags: setflags}
+	}
+
+	return MvnImmT1{Rd: Rd, Rm: 0, Rn: 0, Imm: imm12, setflags: setflags}
+}
+
+func (instr MvnImmT1) Execute(cpu *CPU) {
+	imm32, carry := ThumbExpandImm_C(instr.Imm, cpu.Apsr.C)
+
+	fields := InstrFields(instr)
+	fields.Imm = imm32
+	MvnImmediate(cpu, fields, carry)
+}
+
+func (instr MvnImmT1) String() string {
+	return fmt.Sprintf("mvn%s %s, #%#x", instr.setflags, instr.Rd, ThumbExpandImm(instr.Imm))
+}
+
+/* MOV (immediate)
+ * ARM ARM A7.7.75
+ * Encoding T3 */
+type MovImmT3 InstrFields
+
+func MovImm32T3(instr FetchedInstr) DecodedInstr {
+	raw_instr := instr.Uint32()
+
+	Rd := RegIndex((raw_instr >> 8) & 0xf)
+	Imm := decode_plain_imm16(raw_instr)
+
+	if BadReg(Rd) {
+		return UnpredictableInstr{Rd: Rd, Rm: 0, Rn: 0, Imm: Imm, setflags: NEVER}
+	}
+
+	return MovImmT3{Rd: Rd, Rm: 0, Rn: 0, Imm: Imm, setflags: NEVER}
+}
+
+func (instr MovImmT3) Execute(cpu *CPU) {
+	MoveValue(cpu, instr.Rd, instr.Imm, instr.setflags, cpu.Apsr.C)
+}
+
+func (instr MovImmT3) String() string {
+	return fmt.Sprintf("movw %s, #%#x", instr.Rd, instr.Imm)
+}
+
+/* MOVT
+ * ARM ARM A7.7.78
+ * Encoding T1 */
+type MovtT1 InstrFields
+
+func Movt32T1(instr FetchedInstr) DecodedInstr {
+	raw_instr := instr.Uint32()
+
+	Rd := RegIndex((raw_instr >> 8) & 0xf)
+	Imm := decode_plain_imm16(raw_instr)
+
+	if BadReg(Rd) {
+		return UnpredictableInstr{Rd: Rd, Rm: 0, Rn: 0, Imm: Imm, setflags: NEVER}
+	}
+
+	return MovtT1{Rd: Rd, Rm: 0, Rn: 0, Imm: Imm, setflags: NEVER}
+}
+
+func (instr MovtT1) Execute(cpu *CPU) {
+	value := (instr.Imm << 16) | (cpu.R(instr.Rd) & 0xffff)
+
+	cpu.SetR(instr.Rd, value)
+}
+
+func (instr MovtT1) String() string {
+	return fmt.Sprintf("movt %s, #%#x", instr.Rd, instr.Imm)
+}
+
+/* Move register and immediate shifts
+ * ARM ARM A5.3.11 */
+func MovRegImmShift32(instr FetchedInstr) DecodedInstr {
+	raw_instr := instr.Uint32()
+
+	srtype := (raw_instr >> 4) & 0x3
+	imm5 := (((raw_instr >> 12) & 0x7) << 2) | ((raw_instr >> 6) & 0x3)
+
+	switch srtype {
+	case 0x0:
+		if imm5 == 0 {
+			return MovReg32T3(instr)
+		}
+		return LslImm32T2(instr)
+	case 0x1:
+		return LsrImm32T2(instr)
+	case 0x2:
+		return AsrImm32T2(instr)
+	}
+
+	if imm5 == 0 {
+		return Rrx32T1(instr)
+	}
+	return RorImm32T1(instr)
+}
+
+/* MOV - Move (register)
+ * ARM ARM A7.7.76
+ * Encoding T3 */
+type MovRegT3 InstrFields
+
+func MovReg32T3(instr FetchedInstr) DecodedInstr {
+	Rd, _, Rm, _, setflags := decode_shifted_reg(instr.Uint32())
+
+	/* SP may be moved to or from a low register, but not both, and
+	 * not when setting flags */
+	if Rd == PC || Rm == PC || (Rd == SP && Rm == SP) ||
+		(setflags == ALWAYS && (Rd == SP || Rm == SP)) {
+		return UnpredictableInstr{Rd: Rd, Rm: Rm, Rn: 0, Imm: 0, setflags: setflags}
+	}
+
+	return MovRegT3{Rd: Rd, Rm: Rm, Rn: 0, Imm: 0, setflags: setflags}
+}
+
+func (instr MovRegT3) Execute(cpu *CPU) {
+	MoveRegister(cpu, instr.Rd, instr.Rm, instr.setflags, cpu.Apsr.C)
+}
+
+func (instr MovRegT3) String() string {
+	return fmt.Sprintf("mov%s.w %s, %s", instr.setflags, instr.Rd, instr.Rm)
+}
+
+/* MVN - Bitwise NOT (register)
+ * ARM ARM A7.7.85
+ * Encoding T2 */
+type MvnRegT2 InstrFields
+
+func MvnReg32T2(instr FetchedInstr) DecodedInstr {
+	Rd, _, Rm, shift, setflags := decode_shifted_reg(instr.Uint32())
+
+	if BadReg(Rd) || BadReg(Rm) {
+		return UnpredictableInstr{Rd: Rd, Rm: Rm, Rn: 0, Imm: 0, setflags: setflags, Shift: shift}
+	}
+
+	return MvnRegT2{Rd: Rd, Rm: Rm, Rn: 0, Imm: 0, setflags: setflags, Shift: shift}
+}
+
+func (instr MvnRegT2) Execute(cpu *CPU) {
+	MvnRegister(cpu, InstrFields(instr), instr.Shift)
+}
+
+func (instr MvnRegT2) String() string {
+	return fmt.Sprintf("mvn%s.w %s, %s", instr.setflags, instr.Rd, shifted_operand(instr.Rm, instr.Shift))
+}
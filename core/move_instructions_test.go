@@ -83,11 +83,11 @@ func TestExecuteMovRegT1(t *testing.T) {
 		// mov pc, r12
 		{instr: MovRegT1{Rd: 15, Rm: 12, Rn: 0, Imm: 0, setflags: NEVER},
 			regs:     Registers{r: GeneralRegs{0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 0xCAFE}, pc: 0xDEAD, Apsr: Apsr{C: true}},
-			expected: Registers{r: GeneralRegs{0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 0xCAFE}, pc: 0xCAFE, Apsr: Apsr{C: true}}},
+			expected: Registers{r: GeneralRegs{0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 0xCAFE}, pc: 0xCAFE, Apsr: Apsr{C: true}, branched: true}},
 		// mov pc, r12
 		{instr: MovRegT1{Rd: 15, Rm: 12, Rn: 0, Imm: 0, setflags: NEVER},
 			regs:     Registers{r: GeneralRegs{0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 0xCAFF}, pc: 0xDEAD, Apsr: Apsr{C: true}},
-			expected: Registers{r: GeneralRegs{0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 0xCAFF}, pc: 0xCAFE, Apsr: Apsr{C: true}}},
+			expected: Registers{r: GeneralRegs{0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 0xCAFF}, pc: 0xCAFE, Apsr: Apsr{C: true}, branched: true}},
 	}
 
 	test_execute(t, cases)
@@ -131,3 +131,230 @@ func TestExecuteMovRegT2(t *testing.T) {
 
 	test_execute(t, cases)
 }
+
+func TestIdentifyMvnRegT1(t *testing.T) {
+	cases := []IdentifyCase{
+		{instr: FetchedInstr16(0x43c7), instr_valid: true},  // mvns r7, r0
+		{instr: FetchedInstr16(0x43b5), instr_valid: false}, // bics r5, r6
+		{instr: FetchedInstr16(0x4600), instr_valid: false}, // mov r0, r0
+		{instr: FetchedInstr16(0xffff), instr_valid: false},
+	}
+
+	test_identify(t, cases, reflect.TypeOf(MvnRegT1{}))
+}
+
+func TestDecodeMvnReg16T1(t *testing.T) {
+	cases := []DecodeCase{
+		// mvns r7, r0
+		{instr: FetchedInstr16(0x43c7), decoded: MvnRegT1{Rd: 7, Rm: 0, Rn: 0, Imm: 0, setflags: NOT_IT}},
+	}
+
+	test_decode(t, cases, MvnReg16T1)
+}
+
+func TestExecuteMvnRegT1(t *testing.T) {
+	cases := []ExecuteCase{
+		// mvns r7, r0
+		{instr: MvnRegT1{Rd: 7, Rm: 0, Rn: 0, Imm: 0, setflags: NOT_IT},
+			regs:     Registers{r: GeneralRegs{0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0}, Apsr: Apsr{C: true}},
+			expected: Registers{r: GeneralRegs{0, 0, 0, 0, 0, 0, 0, 0xffffffff, 0, 0, 0, 0, 0}, Apsr: Apsr{N: true, C: true}}},
+		// mvns r7, r0
+		{instr: MvnRegT1{Rd: 7, Rm: 0, Rn: 0, Imm: 0, setflags: NOT_IT},
+			regs:     Registers{r: GeneralRegs{0xffffffff, 0, 0, 0, 0, 0, 0, 1, 0, 0, 0, 0, 0}},
+			expected: Registers{r: GeneralRegs{0xffffffff, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0}, Apsr: Apsr{Z: true}}},
+	}
+
+	test_execute(t, cases)
+}
+
+func TestIdentifyMovImmT2(t *testing.T) {
+	cases := []IdentifyCase{
+		{instr: FetchedInstr32(0xf04f28ab), instr_valid: true},  // mov.w r8, #0xab00ab00
+		{instr: FetchedInstr32(0xf45f7080), instr_valid: true},  // movs.w r0, #0x100
+		{instr: FetchedInstr32(0xf0473612), instr_valid: false}, // orr r6, r7, #0x12121212
+		{instr: FetchedInstr32(0xf04f2dab), instr_valid: false}, // mov.w sp, #0xab00ab00 (UNPREDICTABLE)
+	}
+
+	test_identify(t, cases, reflect.TypeOf(MovImmT2{}))
+}
+
+func TestDecodeMovImm32T2(t *testing.T) {
+	cases := []DecodeCase{
+		// mov.w r8, #0xab00ab00
+		{instr: FetchedInstr32(0xf04f28ab), decoded: MovImmT2{Rd: 8, Rm: 0, Rn: 0, Imm: 0x2ab, setflags: NEVER}},
+		// movs.w r0, #0x100
+		{instr: FetchedInstr32(0xf45f7080), decoded: MovImmT2{Rd: 0, Rm: 0, Rn: 0, Imm: 0xf80, setflags: ALWAYS}},
+	}
+
+	test_decode(t, cases, MovImm32T2)
+}
+
+func TestExecuteMovImmT2(t *testing.T) {
+	cases := []ExecuteCase{
+		// mov.w r8, #0xab00ab00
+		{instr: MovImmT2{Rd: 8, Rm: 0, Rn: 0, Imm: 0x2ab, setflags: NEVER},
+			regs:     Registers{},
+			expected: Registers{r: GeneralRegs{0, 0, 0, 0, 0, 0, 0, 0, 0xab00ab00, 0, 0, 0, 0}}},
+		// mov.w r8, #0x00ab00ab
+		{instr: MovImmT2{Rd: 8, Rm: 0, Rn: 0, Imm: 0x1ab, setflags: NEVER},
+			regs:     Registers{},
+			expected: Registers{r: GeneralRegs{0, 0, 0, 0, 0, 0, 0, 0, 0x00ab00ab, 0, 0, 0, 0}}},
+		// mov.w r8, #0xabababab
+		{instr: MovImmT2{Rd: 8, Rm: 0, Rn: 0, Imm: 0x3ab, setflags: NEVER},
+			regs:     Registers{},
+			expected: Registers{r: GeneralRegs{0, 0, 0, 0, 0, 0, 0, 0, 0xabababab, 0, 0, 0, 0}}},
+		// movs.w r0, #0x100 (rotated, carry from bit 31)
+		{instr: MovImmT2{Rd: 0, Rm: 0, Rn: 0, Imm: 0xf80, setflags: ALWAYS},
+			regs:     Registers{Apsr: Apsr{C: true}},
+			expected: Registers{r: GeneralRegs{0x100, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0}}},
+		// movs.w r0, #0xff000000 (rotated, carry from bit 31)
+		{instr: MovImmT2{Rd: 0, Rm: 0, Rn: 0, Imm: 0x47f, setflags: ALWAYS},
+			regs:     Registers{},
+			expected: Registers{r: GeneralRegs{0xff000000, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0}, Apsr: Apsr{N: true, C: true}}},
+	}
+
+	test_execute(t, cases)
+}
+
+func TestIdentifyMvnImmT1(t *testing.T) {
+	cases := []IdentifyCase{
+		{instr: FetchedInstr32(0xf06f0bff), instr_valid: true},  // mvn r11, #0xff
+		{instr: FetchedInstr32(0xf06a0901), instr_valid: false}, // orn r9, r10, #1
+	}
+
+	test_identify(t, cases, reflect.TypeOf(MvnImmT1{}))
+}
+
+func TestDecodeMvnImm32T1(t *testing.T) {
+	cases := []DecodeCase{
+		// mvn r11, #0xff
+		{instr: FetchedInstr32(0xf06f0bff), decoded: MvnImmT1{Rd: 11, Rm: 0, Rn: 0, Imm: 0x0ff, setflags: NEVER}},
+	}
+
+	test_decode(t, cases, MvnImm32T1)
+}
+
+func TestExecuteMvnImmT1(t *testing.T) {
+	cases := []ExecuteCase{
+		// mvn r11, #0xff
+		{instr: MvnImmT1{Rd: 11, Rm: 0, Rn: 0, Imm: 0x0ff, setflags: NEVER},
+			regs:     Registers{},
+			expected: Registers{r: GeneralRegs{0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0xffffff00, 0}}},
+	}
+
+	test_execute(t, cases)
+}
+
+func TestIdentifyMovImmT3(t *testing.T) {
+	cases := []IdentifyCase{
+		{instr: FetchedInstr32(0xf2412034), instr_valid: true},  // movw r0, #0x1234
+		{instr: FetchedInstr32(0xf64f69dc), instr_valid: true},  // movw r9, #0xfedc
+		{instr: FetchedInstr32(0xf2c40001), instr_valid: false}, // movt r0, #0x4001
+		{instr: FetchedInstr32(0xf04f28ab), instr_valid: false}, // mov.w r8, #0xab00ab00
+	}
+
+	test_identify(t, cases, reflect.TypeOf(MovImmT3{}))
+}
+
+func TestDecodeMovImm32T3(t *testing.T) {
+	cases := []DecodeCase{
+		// movw r0, #0x1234
+		{instr: FetchedInstr32(0xf2412034), decoded: MovImmT3{Rd: 0, Rm: 0, Rn: 0, Imm: 0x1234, setflags: NEVER}},
+		// movw r9, #0xfedc
+		{instr: FetchedInstr32(0xf64f69dc), decoded: MovImmT3{Rd: 9, Rm: 0, Rn: 0, Imm: 0xfedc, setflags: NEVER}},
+	}
+
+	test_decode(t, cases, MovImm32T3)
+}
+
+func TestExecuteMovImmT3(t *testing.T) {
+	cases := []ExecuteCase{
+		// movw r9, #0xfedc
+		{instr: MovImmT3{Rd: 9, Rm: 0, Rn: 0, Imm: 0xfedc, setflags: NEVER},
+			regs:     Registers{r: GeneralRegs{0, 1, 2, 3, 4, 5, 6, 7, 8, 0xffffffff, 10, 11, 12}},
+			expected: Registers{r: GeneralRegs{0, 1, 2, 3, 4, 5, 6, 7, 8, 0xfedc, 10, 11, 12}}},
+	}
+
+	test_execute(t, cases)
+}
+
+func TestIdentifyMovtT1(t *testing.T) {
+	cases := []IdentifyCase{
+		{instr: FetchedInstr32(0xf2c40001), instr_valid: true},  // movt r0, #0x4001
+		{instr: FetchedInstr32(0xf6cf7cff), instr_valid: true},  // movt r12, #0xffff
+		{instr: FetchedInstr32(0xf2412034), instr_valid: false}, // movw r0, #0x1234
+	}
+
+	test_identify(t, cases, reflect.TypeOf(MovtT1{}))
+}
+
+func TestDecodeMovt32T1(t *testing.T) {
+	cases := []DecodeCase{
+		// movt r0, #0x4001
+		{instr: FetchedInstr32(0xf2c40001), decoded: MovtT1{Rd: 0, Rm: 0, Rn: 0, Imm: 0x4001, setflags: NEVER}},
+		// movt r12, #0xffff
+		{instr: FetchedInstr32(0xf6cf7cff), decoded: MovtT1{Rd: 12, Rm: 0, Rn: 0, Imm: 0xffff, setflags: NEVER}},
+		// movt sp, #0xffff
+		{instr: FetchedInstr32(0xf6cf7dff), decoded: UnpredictableInstr{Rd: SP, Rm: 0, Rn: 0, Imm: 0xffff, setflags: NEVER}},
+	}
+
+	test_decode(t, cases, Movt32T1)
+}
+
+func TestExecuteMovtT1(t *testing.T) {
+	cases := []ExecuteCase{
+		// movw r0, #0x1234; movt r0, #0x4001
+		{instr: MovtT1{Rd: 0, Rm: 0, Rn: 0, Imm: 0x4001, setflags: NEVER},
+			regs:     Registers{r: GeneralRegs{0x1234, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12}},
+			expected: Registers{r: GeneralRegs{0x40011234, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12}}},
+		// movt r12, #0xffff
+		{instr: MovtT1{Rd: 12, Rm: 0, Rn: 0, Imm: 0xffff, setflags: NEVER},
+			regs:     Registers{r: GeneralRegs{0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 0x1234abcd}},
+			expected: Registers{r: GeneralRegs{0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 0xffffabcd}}},
+	}
+
+	test_execute(t, cases)
+}
+
+func TestIdentifyMovRegT3(t *testing.T) {
+	cases := []IdentifyCase{
+		{instr: FetchedInstr32(0xea4f0809), instr_valid: true},  // mov.w r8, r9
+		{instr: FetchedInstr32(0xea5f0a0b), instr_valid: true},  // movs.w r10, r11
+		{instr: FetchedInstr32(0xea4f0d00), instr_valid: true},  // mov.w sp, r0
+		{instr: FetchedInstr32(0xea5f0d00), instr_valid: false}, // movs.w sp, r0 (UNPREDICTABLE)
+		{instr: FetchedInstr32(0xea4f1041), instr_valid: false}, // lsl.w r0, r1, #5
+	}
+
+	test_identify(t, cases, reflect.TypeOf(MovRegT3{}))
+}
+
+func TestExecuteMovRegT3(t *testing.T) {
+	cases := []ExecuteCase{
+		// movs.w r10, r11
+		{instr: MovRegT3{Rd: 10, Rm: 11, Rn: 0, Imm: 0, setflags: ALWAYS},
+			regs:     Registers{r: GeneralRegs{0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 0, 12}, Apsr: Apsr{C: true}},
+			expected: Registers{r: GeneralRegs{0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 0, 0, 12}, Apsr: Apsr{Z: true, C: true}}},
+	}
+
+	test_execute(t, cases)
+}
+
+func TestIdentifyMvnRegT2(t *testing.T) {
+	cases := []IdentifyCase{
+		{instr: FetchedInstr32(0xea6f0324), instr_valid: true},  // mvn.w r3, r4, asr #32
+		{instr: FetchedInstr32(0xea6170c2), instr_valid: false}, // orn r0, r1, r2, lsl #31
+	}
+
+	test_identify(t, cases, reflect.TypeOf(MvnRegT2{}))
+}
+
+func TestExecuteMvnRegT2(t *testing.T) {
+	cases := []ExecuteCase{
+		// mvn.w r3, r4, asr #32
+		{instr: MvnRegT2{Rd: 3, Rm: 4, Rn: 0, Imm: 0, setflags: NEVER, Shift: Shift{srtype: SRType_ASR, amount: 32}},
+			regs:     Registers{r: GeneralRegs{0, 1, 2, 3, 0x80000000, 5, 6, 7, 8, 9, 10, 11, 12}},
+			expected: Registers{r: GeneralRegs{0, 1, 2, 0, 0x80000000, 5, 6, 7, 8, 9, 10, 11, 12}}},
+	}
+
+	test_execute(t, cases)
+}
@@ -1,22 +1,22 @@
 package core
 
 /* Move value into destination register, updating condition codes */
-func MoveValue(regs *Registers, dest RegIndex, value uint32, setflags SetFlags, carry bool) {
-	regs.SetR(dest, value)
+func MoveValue(cpu *CPU, dest RegIndex, value uint32, setflags SetFlags, carry bool) {
+	cpu.SetR(dest, value)
 
-	if setflags.ShouldSetFlags(*regs) {
-		regs.Apsr.N = (value & 0x80000000) != 0
-		regs.Apsr.Z = value == 0
-		regs.Apsr.C = carry
+	if setflags.ShouldSetFlags(cpu.Registers) {
+		cpu.Apsr.N = (value & 0x80000000) != 0
+		cpu.Apsr.Z = value == 0
+		cpu.Apsr.C = carry
 	}
 }
 
-func MoveRegister(regs *Registers, dest RegIndex, source RegIndex, setflags SetFlags, carry bool) {
-	value := regs.R(source)
+func MoveRegister(cpu *CPU, dest RegIndex, source RegIndex, setflags SetFlags, carry bool) {
+	value := cpu.R(source)
 
 	if dest == PC {
-		regs.ALUWritePC(value)
+		cpu.ALUWritePC(value)
 	} else {
-		MoveValue(regs, dest, value, setflags, carry)
+		MoveValue(cpu, dest, value, setflags, carry)
 	}
 }
@@ -0,0 +1,642 @@
+package core
+
+import "fmt"
+
+/* MUL - Multiply
+ * ARM ARM A7.7.83
+ * Encoding T1 */
+type MulT1 InstrFields
+
+func Mul16T1(instr FetchedInstr) DecodedInstr {
+	raw_instr := instr.Uint32()
+
+	Rdm := RegIndex(raw_instr & 0x7)
+	Rn := RegIndex((raw_instr >> 3) & 0x7)
+
+	return MulT1{Rd: Rdm, Rm: Rdm, Rn: Rn, Imm: 0, setflags: NOT_IT}
+}
+
+func (instr MulT1) Execute(cpu *CPU) {
+	Multiply(cpu, InstrFields(instr))
+}
+
+func (instr MulT1) String() string {
+	return fmt.Sprintf("mul%s %s, %s, %s", instr.setflags, instr.Rd, instr.Rn, instr.Rm)
+}
+
+func (instr MulT1) SetsFlags() SetFlags {
+	return instr.setflags
+}
+
+/* MUL - Multiply
+ * ARM ARM A7.7.83
+ * Encoding T2 */
+type MulT2 InstrFields
+
+func Mul32T2(instr FetchedInstr) DecodedInstr {
+	fields := decode_multiply(instr.Uint32())
+
+	if bad_multiply(fields, false) {
+		return UnpredictableInstr{}
+	}
+
+	return MulT2{Rd: fields.Rd, Rm: fields.Rm, Rn: fields.Rn, Imm: 0, setflags: NEVER}
+}
+
+func (instr MulT2) Execute(cpu *CPU) {
+	Multiply(cpu, InstrFields(instr))
+}
+
+func (instr MulT2) String() string {
+	return fmt.Sprintf("mul %s, %s, %s", instr.Rd, instr.Rn, instr.Rm)
+}
+
+/* MLA - Multiply Accumulate
+ * ARM ARM A7.7.73
+ * Encoding T1 */
+type MlaT1 MultiplyFields
+
+func Mla32T1(instr FetchedInstr) DecodedInstr {
+	fields := decode_multiply(instr.Uint32())
+
+	/* Without an accumulator, this is MUL */
+	if fields.Ra == PC {
+		return Mul32T2(instr)
+	}
+
+	if bad_multiply(fields, false) || fields.Ra == SP {
+		return UnpredictableInstr{}
+	}
+
+	return MlaT1(fields)
+}
+
+func (instr MlaT1) Execute(cpu *CPU) {
+	MultiplyAccumulate(cpu, MultiplyFields(instr))
+}
+
+func (instr MlaT1) String() string {
+	return fmt.Sprintf("mla %s, %s, %s, %s", instr.Rd, instr.Rn, instr.Rm, instr.Ra)
+}
+
+/* MLS - Multiply and Subtract
+ * ARM ARM A7.7.74
+ * Encoding T1 */
+type MlsT1 MultiplyFields
+
+func Mls32T1(instr FetchedInstr) DecodedInstr {
+	fields := decode_multiply(instr.Uint32())
+
+	if bad_multiply(fields, false) || BadReg(fields.Ra) {
+		return UnpredictableInstr{}
+	}
+
+	return MlsT1(fields)
+}
+
+func (instr MlsT1) Execute(cpu *CPU) {
+	MultiplySubtract(cpu, MultiplyFields(instr))
+}
+
+func (instr MlsT1) String() string {
+	return fmt.Sprintf("mls %s, %s, %s, %s", instr.Rd, instr.Rn, instr.Rm, instr.Ra)
+}
+
+/* SMULL - Signed Multiply Long
+ * ARM ARM A7.7.147
+ * Encoding T1 */
+type SmullT1 MultiplyFields
+
+func Smull32T1(instr FetchedInstr) DecodedInstr {
+	fields := decode_multiply_long(instr.Uint32())
+
+	if bad_multiply(fields, true) {
+		return UnpredictableInstr{}
+	}
+
+	return SmullT1(fields)
+}
+
+func (instr SmullT1) Execute(cpu *CPU) {
+	MultiplyLong(cpu, MultiplyFields(instr), true, false)
+}
+
+func (instr SmullT1) String() string {
+	return fmt.Sprintf("smull %s, %s, %s, %s", instr.Rd, instr.RdHi, instr.Rn, instr.Rm)
+}
+
+/* UMULL - Unsigned Multiply Long
+ * ARM ARM A7.7.201
+ * Encoding T1 */
+type UmullT1 MultiplyFields
+
+func Umull32T1(instr FetchedInstr) DecodedInstr {
+	fields := decode_multiply_long(instr.Uint32())
+
+	if bad_multiply(fields, true) {
+		return UnpredictableInstr{}
+	}
+
+	return UmullT1(fields)
+}
+
+func (instr UmullT1) Execute(cpu *CPU) {
+	MultiplyLong(cpu, MultiplyFields(instr), false, false)
+}
+
+func (instr UmullT1) String() string {
+	return fmt.Sprintf("umull %s, %s, %s, %s", instr.Rd, instr.RdHi, instr.Rn, instr.Rm)
+}
+
+/* SMLAL - Signed Multiply Accumulate Long
+ * ARM ARM A7.7.136
+ * Encoding T1 */
+type SmlalT1 MultiplyFields
+
+func Smlal32T1(instr FetchedInstr) DecodedInstr {
+	fields := decode_multiply_long(instr.Uint32())
+
+	if bad_multiply(fields, true) {
+		return UnpredictableInstr{}
+	}
+
+	return SmlalT1(fields)
+}
+
+func (instr SmlalT1) Execute(cpu *CPU) {
+	MultiplyLong(cpu, MultiplyFields(instr), true, true)
+}
+
+func (instr SmlalT1) String() string {
+	return fmt.Sprintf("smlal %s, %s, %s, %s", instr.Rd, instr.RdHi, instr.Rn, instr.Rm)
+}
+
+/* UMLAL - Unsigned Multiply Accumulate Long
+ * ARM ARM A7.7.200
+ * Encoding T1 */
+type UmlalT1 MultiplyFields
+
+func Umlal32T1(instr FetchedInstr) DecodedInstr {
+	fields := decode_multiply_long(instr.Uint32())
+
+	if bad_multiply(fields, true) {
+		return UnpredictableInstr{}
+	}
+
+	return UmlalT1(fields)
+}
+
+func (instr UmlalT1) Execute(cpu *CPU) {
+	MultiplyLong(cpu, MultiplyFields(instr), false, true)
+}
+
+func (instr UmlalT1) String() string {
+	return fmt.Sprintf("umlal %s, %s, %s, %s", instr.Rd, instr.RdHi, instr.Rn, instr.Rm)
+}
+
+/* UMAAL - Unsigned Multiply Accumulate Accumulate Long
+ * ARM ARM A7.7.199
+ * Encoding T1 */
+type UmaalT1 MultiplyFields
+
+func Umaal32T1(instr FetchedInstr) DecodedInstr {
+	fields := decode_multiply_long(instr.Uint32())
+
+	if bad_multiply(fields, true) {
+		return UnpredictableInstr{}
+	}
+
+	return UmaalT1(fields)
+}
+
+func (instr UmaalT1) Execute(cpu *CPU) {
+	MultiplyAccumulateLong(cpu, MultiplyFields(instr))
+}
+
+func (instr UmaalT1) String() string {
+	return fmt.Sprintf("umaal %s, %s, %s, %s", instr.Rd, instr.RdHi, instr.Rn, instr.Rm)
+}
+
+/* SDIV - Signed Divide
+ * ARM ARM A7.7.125
+ * Encoding T1 */
+type SdivT1 MultiplyFields
+
+func Sdiv32T1(instr FetchedInstr) DecodedInstr {
+	fields := decode_multiply(instr.Uint32())
+	fields.Ra = 0
+
+	if bad_multiply(fields, false) {
+		return UnpredictableInstr{}
+	}
+
+	return SdivT1(fields)
+}
+
+func (instr SdivT1) Execute(cpu *CPU) {
+	Divide(cpu, MultiplyFields(instr), true)
+}
+
+func (instr SdivT1) String() string {
+	return fmt.Sprintf("sdiv %s, %s, %s", instr.Rd, instr.Rn, instr.Rm)
+}
+
+/* UDIV - Unsigned Divide
+ * ARM ARM A7.7.192
+ * Encoding T1 */
+type UdivT1 MultiplyFields
+
+func Udiv32T1(instr FetchedInstr) DecodedInstr {
+	fields := decode_multiply(instr.Uint32())
+	fields.Ra = 0
+
+	if bad_multiply(fields, false) {
+		return UnpredictableInstr{}
+	}
+
+	return UdivT1(fields)
+}
+
+func (instr UdivT1) Execute(cpu *CPU) {
+	Divide(cpu, MultiplyFields(instr), false)
+}
+
+func (instr UdivT1) String() string {
+	return fmt.Sprintf("udiv %s, %s, %s", instr.Rd, instr.Rn, instr.Rm)
+}
+
+/* SMULBB, SMULBT, SMULTB, SMULTT - Signed Multiply (halfwords)
+ * ARM ARM A7.7.146
+ * Encoding T1 */
+type SmulxyT1 MultiplyFields
+
+func Smulxy32T1(instr FetchedInstr) DecodedInstr {
+	fields := decode_multiply_halfwords(instr.Uint32(), false)
+	fields.Ra = 0
+
+	if bad_multiply(fields, false) {
+		return UnpredictableInstr{}
+	}
+
+	return SmulxyT1(fields)
+}
+
+func (instr SmulxyT1) Execute(cpu *CPU) {
+	MultiplyHalfwords(cpu, MultiplyFields(instr), false)
+}
+
+func (instr SmulxyT1) String() string {
+	return fmt.Sprintf("smul%s%s %s, %s, %s", halfword_suffix(instr.NHigh), halfword_suffix(instr.MHigh),
+		instr.Rd, instr.Rn, instr.Rm)
+}
+
+/* SMLABB, SMLABT, SMLATB, SMLATT - Signed Multiply Accumulate (halfwords)
+ * ARM ARM A7.7.134
+ * Encoding T1 */
+type SmlaxyT1 MultiplyFields
+
+func Smlaxy32T1(instr FetchedInstr) DecodedInstr {
+	fields := decode_multiply_halfwords(instr.Uint32(), false)
+
+	/* Without an accumulator, this is SMUL<x><y> */
+	if fields.Ra == PC {
+		return Smulxy32T1(instr)
+	}
+
+	if bad_multiply(fields, false) || fields.Ra == SP {
+		return UnpredictableInstr{}
+	}
+
+	return SmlaxyT1(fields)
+}
+
+func (instr SmlaxyT1) Execute(cpu *CPU) {
+	MultiplyHalfwords(cpu, MultiplyFields(instr), true)
+}
+
+func (instr SmlaxyT1) String() string {
+	return fmt.Sprintf("smla%s%s %s, %s, %s, %s", halfword_suffix(instr.NHigh), halfword_suffix(instr.MHigh),
+		instr.Rd, instr.Rn, instr.Rm, instr.Ra)
+}
+
+/* SMULWB, SMULWT - Signed Multiply (word by halfword)
+ * ARM ARM A7.7.148
+ * Encoding T1 */
+type SmulwyT1 MultiplyFields
+
+func Smulwy32T1(instr FetchedInstr) DecodedInstr {
+	fields := decode_multiply_halfwords(instr.Uint32(), false)
+	fields.Ra = 0
+
+	if bad_multiply(fields, false) {
+		return UnpredictableInstr{}
+	}
+
+	return SmulwyT1(fields)
+}
+
+func (instr SmulwyT1) Execute(cpu *CPU) {
+	MultiplyWordHalfword(cpu, MultiplyFields(instr), false)
+}
+
+func (instr SmulwyT1) String() string {
+	return fmt.Sprintf("smulw%s %s, %s, %s", halfword_suffix(instr.MHigh), instr.Rd, instr.Rn, instr.Rm)
+}
+
+/* SMLAWB, SMLAWT - Signed Multiply Accumulate (word by halfword)
+ * ARM ARM A7.7.139
+ * Encoding T1 */
+type SmlawyT1 MultiplyFields
+
+func Smlawy32T1(instr FetchedInstr) DecodedInstr {
+	fields := decode_multiply_halfwords(instr.Uint32(), false)
+
+	/* Without an accumulator, this is SMULW<y> */
+	if fields.Ra == PC {
+		return Smulwy32T1(instr)
+	}
+
+	if bad_multiply(fields, false) || fields.Ra == SP {
+		return UnpredictableInstr{}
+	}
+
+	return SmlawyT1(fields)
+}
+
+func (instr SmlawyT1) Execute(cpu *CPU) {
+	MultiplyWordHalfword(cpu, MultiplyFields(instr), true)
+}
+
+func (instr SmlawyT1) String() string {
+	return fmt.Sprintf("smlaw%s %s, %s, %s, %s", halfword_suffix(instr.MHigh), instr.Rd, instr.Rn, instr.Rm, instr.Ra)
+}
+
+/* SMLALBB, SMLALBT, SMLALTB, SMLALTT - Signed Multiply Accumulate Long
+ * (halfwords)
+ * ARM ARM A7.7.137
+ * Encoding T1 */
+type SmlalxyT1 MultiplyFields
+
+func Smlalxy32T1(instr FetchedInstr) DecodedInstr {
+	fields := decode_multiply_halfwords(instr.Uint32(), true)
+
+	if bad_multiply(fields, true) {
+		return UnpredictableInstr{}
+	}
+
+	return SmlalxyT1(fields)
+}
+
+func (instr SmlalxyT1) Execute(cpu *CPU) {
+	MultiplyHalfwordsLong(cpu, MultiplyFields(instr))
+}
+
+func (instr SmlalxyT1) String() string {
+	return fmt.Sprintf("smlal%s%s %s, %s, %s, %s", halfword_suffix(instr.NHigh), halfword_suffix(instr.MHigh),
+		instr.Rd, instr.RdHi, instr.Rn, instr.Rm)
+}
+
+/* SMUAD - Signed Dual Multiply Add
+ * ARM ARM A7.7.145
+ * Encoding T1 */
+type SmuadT1 MultiplyFields
+
+func Smuad32T1(instr FetchedInstr) DecodedInstr {
+	raw_instr := instr.Uint32()
+	fields := decode_multiply(raw_instr)
+	fields.Ra = 0
+	fields.Exchange = (raw_instr>>4)&0x1 == 1
+
+	if bad_multiply(fields, false) {
+		return UnpredictableInstr{}
+	}
+
+	return SmuadT1(fields)
+}
+
+func (instr SmuadT1) Execute(cpu *CPU) {
+	MultiplyDual(cpu, MultiplyFields(instr), false, false)
+}
+
+func (instr SmuadT1) String() string {
+	return fmt.Sprintf("smuad%s %s, %s, %s", option_suffix(instr.Exchange, "x"), instr.Rd, instr.Rn, instr.Rm)
+}
+
+/* SMLAD - Signed Multiply Accumulate Dual
+ * ARM ARM A7.7.135
+ * Encoding T1 */
+type SmladT1 MultiplyFields
+
+func Smlad32T1(instr FetchedInstr) DecodedInstr {
+	raw_instr := instr.Uint32()
+	fields := decode_multiply(raw_instr)
+	fields.Exchange = (raw_instr>>4)&0x1 == 1
+
+	/* Without an accumulator, this is SMUAD */
+	if fields.Ra == PC {
+		return Smuad32T1(instr)
+	}
+
+	if bad_multiply(fields, false) || fields.Ra == SP {
+		return UnpredictableInstr{}
+	}
+
+	return SmladT1(fields)
+}
+
+func (instr SmladT1) Execute(cpu *CPU) {
+	MultiplyDual(cpu, MultiplyFields(instr), false, true)
+}
+
+func (instr SmladT1) String() string {
+	return fmt.Sprintf("smlad%s %s, %s, %s, %s", option_suffix(instr.Exchange, "x"), instr.Rd, instr.Rn, instr.Rm, instr.Ra)
+}
+
+/* SMUSD - Signed Dual Multiply Subtract
+ * ARM ARM A7.7.149
+ * Encoding T1 */
+type SmusdT1 MultiplyFields
+
+func Smusd32T1(instr FetchedInstr) DecodedInstr {
+	raw_instr := instr.Uint32()
+	fields := decode_multiply(raw_instr)
+	fields.Ra = 0
+	fields.Exchange = (raw_instr>>4)&0x1 == 1
+
+	if bad_multiply(fields, false) {
+		return UnpredictableInstr{}
+	}
+
+	return SmusdT1(fields)
+}
+
+func (instr SmusdT1) Execute(cpu *CPU) {
+	MultiplyDual(cpu, MultiplyFields(instr), true, false)
+}
+
+func (instr SmusdT1) String() string {
+	return fmt.Sprintf("smusd%s %s, %s, %s", option_suffix(instr.Exchange, "x"), instr.Rd, instr.Rn, instr.Rm)
+}
+
+/* SMLSD - Signed Multiply Subtract Dual
+ * ARM ARM A7.7.140
+ * Encoding T1 */
+type SmlsdT1 MultiplyFields
+
+func Smlsd32T1(instr FetchedInstr) DecodedInstr {
+	raw_instr := instr.Uint32()
+	fields := decode_multiply(raw_instr)
+	fields.Exchange = (raw_instr>>4)&0x1 == 1
+
+	/* Without an accumulator, this is SMUSD */
+	if fields.Ra == PC {
+		return Smusd32T1(instr)
+	}
+
+	if bad_multiply(fields, false) || fields.Ra == SP {
+		return UnpredictableInstr{}
+	}
+
+	return SmlsdT1(fields)
+}
+
+func (instr SmlsdT1) Execute(cpu *CPU) {
+	MultiplyDual(cpu, MultiplyFields(instr), true, true)
+}
+
+func (instr SmlsdT1) String() string {
+	return fmt.Sprintf("smlsd%s %s, %s, %s, %s", option_suffix(instr.Exchange, "x"), instr.Rd, instr.Rn, instr.Rm, instr.Ra)
+}
+
+/* SMLALD - Signed Multiply Accumulate Long Dual
+ * ARM ARM A7.7.138
+ * Encoding T1 */
+type SmlaldT1 MultiplyFields
+
+func Smlald32T1(instr FetchedInstr) DecodedInstr {
+	raw_instr := instr.Uint32()
+	fields := decode_multiply_long(raw_instr)
+	fields.Exchange = (raw_instr>>4)&0x1 == 1
+
+	if bad_multiply(fields, true) {
+		return UnpredictableInstr{}
+	}
+
+	return SmlaldT1(fields)
+}
+
+func (instr SmlaldT1) Execute(cpu *CPU) {
+	MultiplyDualLong(cpu, MultiplyFields(instr), false)
+}
+
+func (instr SmlaldT1) String() string {
+	return fmt.Sprintf("smlald%s %s, %s, %s, %s", option_suffix(instr.Exchange, "x"),
+		instr.Rd, instr.RdHi, instr.Rn, instr.Rm)
+}
+
+/* SMLSLD - Signed Multiply Subtract Long Dual
+ * ARM ARM A7.7.141
+ * Encoding T1 */
+type SmlsldT1 MultiplyFields
+
+func Smlsld32T1(instr FetchedInstr) DecodedInstr {
+	raw_instr := instr.Uint32()
+	fields := decode_multiply_long(raw_instr)
+	fields.Exchange = (raw_instr>>4)&0x1 == 1
+
+	if bad_multiply(fields, true) {
+		return UnpredictableInstr{}
+	}
+
+	return SmlsldT1(fields)
+}
+
+func (instr SmlsldT1) Execute(cpu *CPU) {
+	MultiplyDualLong(cpu, MultiplyFields(instr), true)
+}
+
+func (instr SmlsldT1) String() string {
+	return fmt.Sprintf("smlsld%s %s, %s, %s, %s", option_suffix(instr.Exchange, "x"),
+		instr.Rd, instr.RdHi, instr.Rn, instr.Rm)
+}
+
+/* SMMUL - Signed Most Significant Word Multiply
+ * ARM ARM A7.7.144
+ * Encoding T1 */
+type SmmulT1 MultiplyFields
+
+func Smmul32T1(instr FetchedInstr) DecodedInstr {
+	raw_instr := instr.Uint32()
+	fields := decode_multiply(raw_instr)
+	fields.Ra = 0
+	fields.Round = (raw_instr>>4)&0x1 == 1
+
+	if bad_multiply(fields, false) {
+		return UnpredictableInstr{}
+	}
+
+	return SmmulT1(fields)
+}
+
+func (instr SmmulT1) Execute(cpu *CPU) {
+	MultiplyMostSignificant(cpu, MultiplyFields(instr), false, false)
+}
+
+func (instr SmmulT1) String() string {
+	return fmt.Sprintf("smmul%s %s, %s, %s", option_suffix(instr.Round, "r"), instr.Rd, instr.Rn, instr.Rm)
+}
+
+/* SMMLA - Signed Most Significant Word Multiply Accumulate
+ * ARM ARM A7.7.142
+ * Encoding T1 */
+type SmmlaT1 MultiplyFields
+
+func Smmla32T1(instr FetchedInstr) DecodedInstr {
+	raw_instr := instr.Uint32()
+	fields := decode_multiply(raw_instr)
+	fields.Round = (raw_instr>>4)&0x1 == 1
+
+	/* Without an accumulator, this is SMMUL */
+	if fields.Ra == PC {
+		return Smmul32T1(instr)
+	}
+
+	if bad_multiply(fields, false) || fields.Ra == SP {
+		return UnpredictableInstr{}
+	}
+
+	return SmmlaT1(fields)
+}
+
+func (instr SmmlaT1) Execute(cpu *CPU) {
+	MultiplyMostSignificant(cpu, MultiplyFields(instr), true, false)
+}
+
+func (instr SmmlaT1) String() string {
+	return fmt.Sprintf("smmla%s %s, %s, %s, %s", option_suffix(instr.Round, "r"), instr.Rd, instr.Rn, instr.Rm, instr.Ra)
+}
+
+/* SMMLS - Signed Most Significant Word Multiply Subtract
+ * ARM ARM A7.7.143
+ * Encoding T1 */
+type SmmlsT1 MultiplyFields
+
+func Smmls32T1(instr FetchedInstr) DecodedInstr {
+	raw_instr := instr.Uint32()
+	fields := decode_multiply(raw_instr)
+	fields.Round = (raw_instr>>4)&0x1 == 1
+
+	if bad_multiply(fields, false) || BadReg(fields.Ra) {
+		return UnpredictableInstr{}
+	}
+
+	return SmmlsT1(fields)
+}
+
+func (instr SmmlsT1) Execute(cpu *CPU) {
+	MultiplyMostSignificant(cpu, MultiplyFields(instr), true, true)
+}
+
+func (instr SmmlsT1) String() string {
+	return fmt.Sprintf("smmls%s %s, %s, %s, %s", option_suffix(instr.Round, "r"), instr.Rd, instr.Rn, instr.Rm, instr.Ra)
+}
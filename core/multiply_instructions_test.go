@@ -0,0 +1,327 @@
+package core
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestIdentifyMulT1(t *testing.T) {
+	cases := []IdentifyCase{
+		{instr: FetchedInstr16(0x4363), instr_valid: true},  // muls r3, r4, r3
+		{instr: FetchedInstr16(0x4340), instr_valid: true},  // muls r0, r0, r0
+		{instr: FetchedInstr16(0x4311), instr_valid: false}, // orrs r1, r2
+		{instr: FetchedInstr16(0x43b5), instr_valid: false}, // bics r5, r6
+		{instr: FetchedInstr16(0xffff), instr_valid: false},
+	}
+
+	test_identify(t, cases, reflect.TypeOf(MulT1{}))
+}
+
+func TestDecodeMul16T1(t *testing.T) {
+	cases := []DecodeCase{
+		// muls r3, r4, r3
+		{instr: FetchedInstr16(0x4363), decoded: MulT1{Rd: 3, Rm: 3, Rn: 4, Imm: 0, setflags: NOT_IT}},
+	}
+
+	test_decode(t, cases, Mul16T1)
+}
+
+func TestExecuteMulT1(t *testing.T) {
+	cases := []ExecuteCase{
+		// muls r3, r4, r3
+		{instr: MulT1{Rd: 3, Rm: 3, Rn: 4, Imm: 0, setflags: NOT_IT},
+			regs:     Registers{r: GeneralRegs{0, 1, 2, 6, 7, 5, 6, 7, 8, 9, 10, 11, 12}, Apsr: Apsr{C: true, V: true}},
+			expected: Registers{r: GeneralRegs{0, 1, 2, 42, 7, 5, 6, 7, 8, 9, 10, 11, 12}, Apsr: Apsr{C: true, V: true}}},
+		// muls r3, r4, r3 (truncated to 32 bits)
+		{instr: MulT1{Rd: 3, Rm: 3, Rn: 4, Imm: 0, setflags: NOT_IT},
+			regs:     Registers{r: GeneralRegs{0, 1, 2, 0x10000, 0x18000, 5, 6, 7, 8, 9, 10, 11, 12}},
+			expected: Registers{r: GeneralRegs{0, 1, 2, 0x80000000, 0x18000, 5, 6, 7, 8, 9, 10, 11, 12}, Apsr: Apsr{N: true}}},
+		// muls r3, r4, r3
+		{instr: MulT1{Rd: 3, Rm: 3, Rn: 4, Imm: 0, setflags: NOT_IT},
+			regs:     Registers{r: GeneralRegs{0, 1, 2, 0x10000, 0x10000, 5, 6, 7, 8, 9, 10, 11, 12}},
+			expected: Registers{r: GeneralRegs{0, 1, 2, 0, 0x10000, 5, 6, 7, 8, 9, 10, 11, 12}, Apsr: Apsr{Z: true}}},
+	}
+
+	test_execute(t, cases)
+}
+
+func TestIdentifyMlaT1(t *testing.T) {
+	cases := []IdentifyCase{
+		{instr: FetchedInstr32(0xfb013002), instr_valid: true},  // mla r0, r1, r2, r3
+		{instr: FetchedInstr32(0xfb01f002), instr_valid: false}, // mul r0, r1, r2
+		{instr: FetchedInstr32(0xfb013012), instr_valid: false}, // mls r0, r1, r2, r3
+	}
+
+	test_identify(t, cases, reflect.TypeOf(MlaT1{}))
+
+	cases = []IdentifyCase{
+		{instr: FetchedInstr32(0xfb01f002), instr_valid: true},  // mul r0, r1, r2
+		{instr: FetchedInstr32(0xfb013002), instr_valid: false}, // mla r0, r1, r2, r3
+	}
+
+	test_identify(t, cases, reflect.TypeOf(MulT2{}))
+}
+
+func TestDecodeMultiply32(t *testing.T) {
+	cases := []DecodeCase{
+		// mla r0, r1, r2, r3
+		{instr: FetchedInstr32(0xfb013002), decoded: MlaT1{Rd: 0, Rn: 1, Rm: 2, Ra: 3}},
+		// mul r0, r1, r2
+		{instr: FetchedInstr32(0xfb01f002), decoded: MulT2{Rd: 0, Rn: 1, Rm: 2, setflags: NEVER}},
+		// mla r0, r1, r2, sp
+		{instr: FetchedInstr32(0xfb01d002), decoded: UnpredictableInstr{}},
+	}
+
+	test_decode(t, cases, Mla32T1)
+
+	cases = []DecodeCase{
+		// smull r0, r1, r2, r3
+		{instr: FetchedInstr32(0xfb820103), decoded: SmullT1{Rd: 0, RdHi: 1, Rn: 2, Rm: 3}},
+		// smull r0, r0, r2, r3
+		{instr: FetchedInstr32(0xfb820003), decoded: UnpredictableInstr{}},
+	}
+
+	test_decode(t, cases, Smull32T1)
+
+	cases = []DecodeCase{
+		// umlal r0, r1, r2, r3
+		{instr: FetchedInstr32(0xfbe20103), decoded: UmlalT1{Rd: 0, RdHi: 1, Rn: 2, Rm: 3}},
+	}
+
+	test_decode(t, cases, Umlal32T1)
+
+	cases = []DecodeCase{
+		// sdiv r0, r1, r2
+		{instr: FetchedInstr32(0xfb91f0f2), decoded: SdivT1{Rd: 0, Rn: 1, Rm: 2}},
+		// sdiv r0, r1, pc
+		{instr: FetchedInstr32(0xfb91f0ff), decoded: UnpredictableInstr{}},
+	}
+
+	test_decode(t, cases, Sdiv32T1)
+}
+
+func TestExecuteMultiply32(t *testing.T) {
+	cases := []ExecuteCase{
+		// mla r0, r1, r2, r3
+		{instr: MlaT1{Rd: 0, Rn: 1, Rm: 2, Ra: 3},
+			regs:     Registers{r: GeneralRegs{0, 6, 7, 100}},
+			expected: Registers{r: GeneralRegs{142, 6, 7, 100}}},
+		// mls r0, r1, r2, r3
+		{instr: MlsT1{Rd: 0, Rn: 1, Rm: 2, Ra: 3},
+			regs:     Registers{r: GeneralRegs{0, 6, 7, 40}},
+			expected: Registers{r: GeneralRegs{0xfffffffe, 6, 7, 40}}},
+		// umull r0, r1, r2, r3
+		{instr: UmullT1{Rd: 0, RdHi: 1, Rn: 2, Rm: 3},
+			regs:     Registers{r: GeneralRegs{0, 0, 0xffffffff, 0xffffffff}},
+			expected: Registers{r: GeneralRegs{0x1, 0xfffffffe, 0xffffffff, 0xffffffff}}},
+		// smull r0, r1, r2, r3
+		{instr: SmullT1{Rd: 0, RdHi: 1, Rn: 2, Rm: 3},
+			regs:     Registers{r: GeneralRegs{0, 0, 0xffffffff, 0x80000000}},
+			expected: Registers{r: GeneralRegs{0x80000000, 0, 0xffffffff, 0x80000000}}},
+		// smull r0, r1, r2, r3
+		{instr: SmullT1{Rd: 0, RdHi: 1, Rn: 2, Rm: 3},
+			regs:     Registers{r: GeneralRegs{0, 0, 0xfffffffe, 3}},
+			expected: Registers{r: GeneralRegs{0xfffffffa, 0xffffffff, 0xfffffffe, 3}}},
+		// umlal r0, r1, r2, r3
+		{instr: UmlalT1{Rd: 0, RdHi: 1, Rn: 2, Rm: 3},
+			regs:     Registers{r: GeneralRegs{0xffffffff, 1, 2, 3}},
+			expected: Registers{r: GeneralRegs{0x5, 2, 2, 3}}},
+		// smlal r0, r1, r2, r3
+		{instr: SmlalT1{Rd: 0, RdHi: 1, Rn: 2, Rm: 3},
+			regs:     Registers{r: GeneralRegs{0x2, 0, 0xffffffff, 3}},
+			expected: Registers{r: GeneralRegs{0xffffffff, 0xffffffff, 0xffffffff, 3}}},
+	}
+
+	test_execute(t, cases)
+}
+
+func TestExecuteDivide(t *testing.T) {
+	cases := []ExecuteCase{
+		// sdiv r0, r1, r2
+		{instr: SdivT1{Rd: 0, Rn: 1, Rm: 2},
+			regs:     Registers{r: GeneralRegs{0, 0xfffffff9, 2}},
+			expected: Registers{r: GeneralRegs{0xfffffffd, 0xfffffff9, 2}}},
+		// sdiv r0, r1, r2 (INT_MIN / -1)
+		{instr: SdivT1{Rd: 0, Rn: 1, Rm: 2},
+			regs:     Registers{r: GeneralRegs{0, 0x80000000, 0xffffffff}},
+			expected: Registers{r: GeneralRegs{0x80000000, 0x80000000, 0xffffffff}}},
+		// sdiv r0, r1, r2 (divide by zero)
+		{instr: SdivT1{Rd: 0, Rn: 1, Rm: 2},
+			regs:     Registers{r: GeneralRegs{5, 7, 0}},
+			expected: Registers{r: GeneralRegs{0, 7, 0}}},
+		// udiv r0, r1, r2
+		{instr: UdivT1{Rd: 0, Rn: 1, Rm: 2},
+			regs:     Registers{r: GeneralRegs{0, 0xfffffff9, 2}},
+			expected: Registers{r: GeneralRegs{0x7ffffffc, 0xfffffff9, 2}}},
+		// udiv r0, r1, r2 (divide by zero)
+		{instr: UdivT1{Rd: 0, Rn: 1, Rm: 2},
+			regs:     Registers{r: GeneralRegs{5, 7, 0}},
+			expected: Registers{r: GeneralRegs{0, 7, 0}}},
+	}
+
+	test_execute(t, cases)
+}
+
+func TestDivideByZeroTrap(t *testing.T) {
+	regs := Registers{r: GeneralRegs{5, 7, 0}}
+	cpu := CPU{Registers: regs}
+	cpu.Scb.Ccr = CCR_DIV_0_TRP
+
+	UdivT1{Rd: 0, Rn: 1, Rm: 2}.Execute(&cpu)
+
+	if cpu.fault != UFSR_DIVBYZERO {
+		t.Errorf("fault = %v, expected %v", cpu.fault, UFSR_DIVBYZERO)
+	}
+
+	if cpu.Registers != regs {
+		t.Errorf("Registers changed by faulting divide:\n%s", cpu.Pretty())
+	}
+}
+
+func TestIdentifySmlaxyT1(t *testing.T) {
+	cases := []IdentifyCase{
+		{instr: FetchedInstr32(0xfb113012), instr_valid: true},  // smlabt r0, r1, r2, r3
+		{instr: FetchedInstr32(0xfb11f022), instr_valid: false}, // smultb r0, r1, r2
+		{instr: FetchedInstr32(0xfb313012), instr_valid: false}, // smlawt r0, r1, r2, r3
+	}
+
+	test_identify(t, cases, reflect.TypeOf(SmlaxyT1{}))
+
+	cases = []IdentifyCase{
+		{instr: FetchedInstr32(0xfb11f022), instr_valid: true},  // smultb r0, r1, r2
+		{instr: FetchedInstr32(0xfb113012), instr_valid: false}, // smlabt r0, r1, r2, r3
+	}
+
+	test_identify(t, cases, reflect.TypeOf(SmulxyT1{}))
+}
+
+func TestDecodeDSPMultiply(t *testing.T) {
+	cases := []DecodeCase{
+		// smlabt r0, r1, r2, r3
+		{instr: FetchedInstr32(0xfb113012), decoded: SmlaxyT1{Rd: 0, Rn: 1, Rm: 2, Ra: 3, MHigh: true}},
+		// smultb r0, r1, r2
+		{instr: FetchedInstr32(0xfb11f022), decoded: SmulxyT1{Rd: 0, Rn: 1, Rm: 2, NHigh: true}},
+	}
+
+	test_decode(t, cases, Smlaxy32T1)
+
+	cases = []DecodeCase{
+		// smlaltb r0, r1, r2, r3
+		{instr: FetchedInstr32(0xfbc201a3), decoded: SmlalxyT1{Rd: 0, RdHi: 1, Rn: 2, Rm: 3, NHigh: true}},
+		// smlalbb r0, r0, r2, r3
+		{instr: FetchedInstr32(0xfbc20083), decoded: UnpredictableInstr{}},
+	}
+
+	test_decode(t, cases, Smlalxy32T1)
+
+	cases = []DecodeCase{
+		// smladx r0, r1, r2, r3
+		{instr: FetchedInstr32(0xfb213012), decoded: SmladT1{Rd: 0, Rn: 1, Rm: 2, Ra: 3, Exchange: true}},
+		// smuad r0, r1, r2
+		{instr: FetchedInstr32(0xfb21f002), decoded: SmuadT1{Rd: 0, Rn: 1, Rm: 2}},
+	}
+
+	test_decode(t, cases, Smlad32T1)
+
+	cases = []DecodeCase{
+		// smmlar r0, r1, r2, r3
+		{instr: FetchedInstr32(0xfb513012), decoded: SmmlaT1{Rd: 0, Rn: 1, Rm: 2, Ra: 3, Round: true}},
+		// smmul r0, r1, r2
+		{instr: FetchedInstr32(0xfb51f002), decoded: SmmulT1{Rd: 0, Rn: 1, Rm: 2}},
+	}
+
+	test_decode(t, cases, Smmla32T1)
+
+	cases = []DecodeCase{
+		// smmls r0, r1, r2, pc
+		{instr: FetchedInstr32(0xfb61f002), decoded: UnpredictableInstr{}},
+	}
+
+	test_decode(t, cases, Smmls32T1)
+
+	cases = []DecodeCase{
+		// umaal r2, r0, lr, r3
+		{instr: FetchedInstr32(0xfbee2063), decoded: UmaalT1{Rd: 2, RdHi: 0, Rn: LR, Rm: 3}},
+		// umaal r2, r2, lr, r3
+		{instr: FetchedInstr32(0xfbee2263), decoded: UnpredictableInstr{}},
+		// umaal r2, r0, sp, r3
+		{instr: FetchedInstr32(0xfbed2063), decoded: UnpredictableInstr{}},
+	}
+
+	test_decode(t, cases, Umaal32T1)
+}
+
+func TestExecuteDSPMultiply(t *testing.T) {
+	cases := []ExecuteCase{
+		// smultb r0, r1, r2
+		{instr: SmulxyT1{Rd: 0, Rn: 1, Rm: 2, NHigh: true},
+			regs:     Registers{r: GeneralRegs{0, 0x00030005, 0x7fff0004}},
+			expected: Registers{r: GeneralRegs{12, 0x00030005, 0x7fff0004}}},
+		// smlabb r0, r1, r2, r3 (overflow sets Q)
+		{instr: SmlaxyT1{Rd: 0, Rn: 1, Rm: 2, Ra: 3},
+			regs:     Registers{r: GeneralRegs{0, 0x7fff, 0x7fff, 0x7fffffff}},
+			expected: Registers{r: GeneralRegs{0xbfff0000, 0x7fff, 0x7fff, 0x7fffffff}, Apsr: Apsr{Q: true}}},
+		// smulwt r0, r1, r2
+		{instr: SmulwyT1{Rd: 0, Rn: 1, Rm: 2, MHigh: true},
+			regs:     Registers{r: GeneralRegs{0, 0x10000, 0xffff0000}},
+			expected: Registers{r: GeneralRegs{0xffffffff, 0x10000, 0xffff0000}}},
+		// smlawb r0, r1, r2, r3 (overflow sets Q)
+		{instr: SmlawyT1{Rd: 0, Rn: 1, Rm: 2, Ra: 3},
+			regs:     Registers{r: GeneralRegs{0, 0x40000000, 2, 0x7fffffff}},
+			expected: Registers{r: GeneralRegs{0x80007fff, 0x40000000, 2, 0x7fffffff}, Apsr: Apsr{Q: true}}},
+		// smlalbt r0, r1, r2, r3
+		{instr: SmlalxyT1{Rd: 0, RdHi: 1, Rn: 2, Rm: 3, MHigh: true},
+			regs:     Registers{r: GeneralRegs{0xffffffff, 0, 2, 0xfffe0000}},
+			expected: Registers{r: GeneralRegs{0xfffffffb, 0, 2, 0xfffe0000}}},
+		// smuadx r0, r1, r2
+		{instr: SmuadT1{Rd: 0, Rn: 1, Rm: 2, Exchange: true},
+			regs:     Registers{r: GeneralRegs{0, 0x00020003, 0x00050007}},
+			expected: Registers{r: GeneralRegs{29, 0x00020003, 0x00050007}}},
+		// smuad r0, r1, r2 (overflow sets Q)
+		{instr: SmuadT1{Rd: 0, Rn: 1, Rm: 2},
+			regs:     Registers{r: GeneralRegs{0, 0x80008000, 0x80008000}},
+			expected: Registers{r: GeneralRegs{0x80000000, 0x80008000, 0x80008000}, Apsr: Apsr{Q: true}}},
+		// smlsd r0, r1, r2, r3
+		{instr: SmlsdT1{Rd: 0, Rn: 1, Rm: 2, Ra: 3},
+			regs:     Registers{r: GeneralRegs{0, 0x00020003, 0x00050007, 100}},
+			expected: Registers{r: GeneralRegs{111, 0x00020003, 0x00050007, 100}}},
+		// smlald r0, r1, r2, r3
+		{instr: SmlaldT1{Rd: 0, RdHi: 1, Rn: 2, Rm: 3},
+			regs:     Registers{r: GeneralRegs{0xfffffff0, 1, 0x00020003, 0x00050007}},
+			expected: Registers{r: GeneralRegs{0xf, 2, 0x00020003, 0x00050007}}},
+		// smlsldx r0, r1, r2, r3
+		{instr: SmlsldT1{Rd: 0, RdHi: 1, Rn: 2, Rm: 3, Exchange: true},
+			regs:     Registers{r: GeneralRegs{0, 0, 0x00010001, 0x00020003}},
+			expected: Registers{r: GeneralRegs{0xffffffff, 0xffffffff, 0x00010001, 0x00020003}}},
+		// umaal r0, r1, r2, r3 (largest result fits in 64 bits)
+		{instr: UmaalT1{Rd: 0, RdHi: 1, Rn: 2, Rm: 3},
+			regs:     Registers{r: GeneralRegs{0xffffffff, 0xffffffff, 0xffffffff, 0xffffffff}},
+			expected: Registers{r: GeneralRegs{0xffffffff, 0xffffffff, 0xffffffff, 0xffffffff}}},
+		// umaal r0, r1, r2, r3
+		{instr: UmaalT1{Rd: 0, RdHi: 1, Rn: 2, Rm: 3},
+			regs:     Registers{r: GeneralRegs{5, 7, 0x10000, 0x10000}},
+			expected: Registers{r: GeneralRegs{12, 1, 0x10000, 0x10000}}},
+		// smmul r0, r1, r2
+		{instr: SmmulT1{Rd: 0, Rn: 1, Rm: 2},
+			regs:     Registers{r: GeneralRegs{0, 0x40000000, 3}},
+			expected: Registers{r: GeneralRegs{0, 0x40000000, 3}}},
+		// smmulr r0, r1, r2
+		{instr: SmmulT1{Rd: 0, Rn: 1, Rm: 2, Round: true},
+			regs:     Registers{r: GeneralRegs{0, 0x40000000, 3}},
+			expected: Registers{r: GeneralRegs{1, 0x40000000, 3}}},
+		// smmla r0, r1, r2, r3
+		{instr: SmmlaT1{Rd: 0, Rn: 1, Rm: 2, Ra: 3},
+			regs:     Registers{r: GeneralRegs{0, 0xffffffff, 1, 0}},
+			expected: Registers{r: GeneralRegs{0xffffffff, 0xffffffff, 1, 0}}},
+		// smmlar r0, r1, r2, r3
+		{instr: SmmlaT1{Rd: 0, Rn: 1, Rm: 2, Ra: 3, Round: true},
+			regs:     Registers{r: GeneralRegs{0, 0xffffffff, 1, 0}},
+			expected: Registers{r: GeneralRegs{0, 0xffffffff, 1, 0}}},
+		// smmls r0, r1, r2, r3
+		{instr: SmmlsT1{Rd: 0, Rn: 1, Rm: 2, Ra: 3},
+			regs:     Registers{r: GeneralRegs{0, 0x10000, 0x10000, 5}},
+			expected: Registers{r: GeneralRegs{4, 0x10000, 0x10000, 5}}},
+	}
+
+	test_execute(t, cases)
+}
@@ -0,0 +1,266 @@
+package core
+
+/* Perform MUL instruction, updating condition codes */
+func Multiply(cpu *CPU, instr InstrFields) {
+	result := cpu.R(instr.Rn) * cpu.R(instr.Rm)
+
+	cpu.SetR(instr.Rd, result)
+	if instr.setflags.ShouldSetFlags(cpu.Registers) {
+		cpu.Apsr.N = (result & 0x80000000) != 0
+		cpu.Apsr.Z = (result) == 0
+	}
+}
+
+/* Perform MLA instruction, Rd = Ra + Rn * Rm */
+func MultiplyAccumulate(cpu *CPU, instr MultiplyFields) {
+	cpu.SetR(instr.Rd, cpu.R(instr.Ra)+cpu.R(instr.Rn)*cpu.R(instr.Rm))
+}
+
+/* Perform MLS instruction, Rd = Ra - Rn * Rm */
+func MultiplySubtract(cpu *CPU, instr MultiplyFields) {
+	cpu.SetR(instr.Rd, cpu.R(instr.Ra)-cpu.R(instr.Rn)*cpu.R(instr.Rm))
+}
+
+/* Perform long multiply instructions (UMULL, SMULL, UMLAL, SMLAL), with
+ * a 64-bit result in RdHi:RdLo, optionally accumulating onto it */
+func MultiplyLong(cpu *CPU, instr MultiplyFields, signed bool, accumulate bool) {
+	var result uint64
+	if signed {
+		result = uint64(int64(int32(cpu.R(instr.Rn))) * int64(int32(cpu.R(instr.Rm))))
+	} else {
+		result = uint64(cpu.R(instr.Rn)) * uint64(cpu.R(instr.Rm))
+	}
+
+	if accumulate {
+		result += uint64(cpu.R(instr.RdHi))<<32 | uint64(cpu.R(instr.Rd))
+	}
+
+	cpu.SetR(instr.Rd, uint32(result))
+	cpu.SetR(instr.RdHi, uint32(result>>32))
+}
+
+/* Perform UMAAL instruction, RdHi:RdLo = Rn * Rm + RdHi + RdLo, which
+ * can't overflow 64 bits */
+func MultiplyAccumulateLong(cpu *CPU, instr MultiplyFields) {
+	result := uint64(cpu.R(instr.Rn))*uint64(cpu.R(instr.Rm)) +
+		uint64(cpu.R(instr.RdHi)) + uint64(cpu.R(instr.Rd))
+
+	cpu.SetR(instr.Rd, uint32(result))
+	cpu.SetR(instr.RdHi, uint32(result>>32))
+}
+
+/* Perform divide instructions (SDIV, UDIV), rounding towards zero.
+ * Division by zero gives zero, unless CCR.DIV_0_TRP is set. */
+func Divide(cpu *CPU, instr MultiplyFields, signed bool) {
+	n := cpu.R(instr.Rn)
+	m := cpu.R(instr.Rm)
+
+	if m == 0 {
+		if cpu.Scb.Ccr&CCR_DIV_0_TRP != 0 {
+			cpu.raise(UFSR_DIVBYZERO)
+			return
+		}
+		cpu.SetR(instr.Rd, 0)
+		return
+	}
+
+	var result uint32
+	switch {
+	case !signed:
+		result = n / m
+	case n == 0x80000000 && m == 0xffffffff:
+		/* 2^31 doesn't fit, and wraps back to INT_MIN */
+		result = 0x80000000
+	default:
+		result = uint32(int32(n) / int32(m))
+	}
+
+	cpu.SetR(instr.Rd, result)
+}
+
+/* Extract the fields of MLA and MLS, and the register fields of MUL,
+ * SDIV and UDIV, which have Ra fixed at 1111 */
+func decode_multiply(raw_instr uint32) MultiplyFields {
+	Rn := RegIndex((raw_instr >> 16) & 0xf)
+	Ra := RegIndex((raw_instr >> 12) & 0xf)
+	Rd := RegIndex((raw_instr >> 8) & 0xf)
+	Rm := RegIndex(raw_instr & 0xf)
+
+	return MultiplyFields{Rd: Rd, Rn: Rn, Rm: Rm, Ra: Ra}
+}
+
+/* Extract the fields of the long multiplies */
+func decode_multiply_long(raw_instr uint32) MultiplyFields {
+	Rn := RegIndex((raw_instr >> 16) & 0xf)
+	RdLo := RegIndex((raw_instr >> 12) & 0xf)
+	RdHi := RegIndex((raw_instr >> 8) & 0xf)
+	Rm := RegIndex(raw_instr & 0xf)
+
+	return MultiplyFields{Rd: RdLo, RdHi: RdHi, Rn: Rn, Rm: Rm}
+}
+
+/* Only the 16-bit MUL may use SP or PC, and the long multiplies must write
+ * two different registers */
+func bad_multiply(instr MultiplyFields, long bool) bool {
+	if BadReg(instr.Rd) || BadReg(instr.Rn) || BadReg(instr.Rm) {
+		return true
+	}
+
+	if long {
+		return BadReg(instr.RdHi) || instr.RdHi == instr.Rd
+	}
+
+	return false
+}
+
+/* Signed halfword of x, the top one if high */
+func halfword(x uint32, high bool) int64 {
+	if high {
+		x >>= 16
+	}
+
+	return int64(int16(x))
+}
+
+/* Set Q if result doesn't fit in 32 signed bits */
+func multiply_overflow(cpu *CPU, result int64) {
+	if result != int64(int32(result)) {
+		cpu.Apsr.Q = true
+	}
+}
+
+/* Perform signed halfword multiply instructions (SMUL<x><y>,
+ * SMLA<x><y>), setting Q if the accumulation overflows */
+func MultiplyHalfwords(cpu *CPU, instr MultiplyFields, accumulate bool) {
+	result := halfword(cpu.R(instr.Rn), instr.NHigh) * halfword(cpu.R(instr.Rm), instr.MHigh)
+	if accumulate {
+		result += int64(int32(cpu.R(instr.Ra)))
+		multiply_overflow(cpu, result)
+	}
+
+	cpu.SetR(instr.Rd, uint32(result))
+}
+
+/* Perform signed word by halfword multiply instructions (SMULW<y>,
+ * SMLAW<y>), keeping the top 32 bits of the 48-bit product. Q is set if
+ * the accumulation overflows. */
+func MultiplyWordHalfword(cpu *CPU, instr MultiplyFields, accumulate bool) {
+	result := int64(int32(cpu.R(instr.Rn))) * halfword(cpu.R(instr.Rm), instr.MHigh)
+	if accumulate {
+		result += int64(int32(cpu.R(instr.Ra))) << 16
+		multiply_overflow(cpu, result>>16)
+	}
+
+	cpu.SetR(instr.Rd, uint32(result>>16))
+}
+
+/* Perform SMLAL<x><y>, accumulating a halfword product onto RdHi:RdLo */
+func MultiplyHalfwordsLong(cpu *CPU, instr MultiplyFields) {
+	result := halfword(cpu.R(instr.Rn), instr.NHigh) * halfword(cpu.R(instr.Rm), instr.MHigh)
+	result += int64(uint64(cpu.R(instr.RdHi))<<32 | uint64(cpu.R(instr.Rd)))
+
+	cpu.SetR(instr.Rd, uint32(result))
+	cpu.SetR(instr.RdHi, uint32(uint64(result)>>32))
+}
+
+/* Products of the bottom and top halfwords of Rn and Rm, with the
+ * halfwords of Rm swapped if exchanging */
+func dual_products(cpu *CPU, instr MultiplyFields) (int64, int64) {
+	n := cpu.R(instr.Rn)
+	m := cpu.R(instr.Rm)
+	if instr.Exchange {
+		m = m>>16 | m<<16
+	}
+
+	return halfword(n, false) * halfword(m, false), halfword(n, true) * halfword(m, true)
+}
+
+/* Perform dual multiply instructions (SMUAD, SMLAD, SMUSD, SMLSD),
+ * adding or subtracting the products. Q is set on overflow. */
+func MultiplyDual(cpu *CPU, instr MultiplyFields, subtract bool, accumulate bool) {
+	low, high := dual_products(cpu, instr)
+
+	result := low + high
+	if subtract {
+		result = low - high
+	}
+
+	if accumulate {
+		result += int64(int32(cpu.R(instr.Ra)))
+	}
+
+	multiply_overflow(cpu, result)
+	cpu.SetR(instr.Rd, uint32(result))
+}
+
+/* Perform long dual multiply instructions (SMLALD, SMLSLD), accumulating
+ * the sum or difference of the products onto RdHi:RdLo */
+func MultiplyDualLong(cpu *CPU, instr MultiplyFields, subtract bool) {
+	low, high := dual_products(cpu, instr)
+
+	result := low + high
+	if subtract {
+		result = low - high
+	}
+	result += int64(uint64(cpu.R(instr.RdHi))<<32 | uint64(cpu.R(instr.Rd)))
+
+	cpu.SetR(instr.Rd, uint32(result))
+	cpu.SetR(instr.RdHi, uint32(uint64(result)>>32))
+}
+
+/* Perform most significant word multiply instructions (SMMUL, SMMLA,
+ * SMMLS), keeping the top 32 bits of the product added to or subtracted
+ * from Ra:0, optionally rounded */
+func MultiplyMostSignificant(cpu *CPU, instr MultiplyFields, accumulate bool, subtract bool) {
+	product := int64(int32(cpu.R(instr.Rn))) * int64(int32(cpu.R(instr.Rm)))
+
+	/* Only the top word is kept, so the 64-bit arithmetic may wrap */
+	var result uint64
+	if accumulate {
+		result = uint64(cpu.R(instr.Ra)) << 32
+	}
+
+	if subtract {
+		result -= uint64(product)
+	} else {
+		result += uint64(product)
+	}
+
+	if instr.Round {
+		result += 0x80000000
+	}
+
+	cpu.SetR(instr.Rd, uint32(result>>32))
+}
+
+/* Extract the fields of the halfword multiplies, with bits 5:4 selecting
+ * the halfwords of Rn and Rm */
+func decode_multiply_halfwords(raw_instr uint32, long bool) MultiplyFields {
+	fields := decode_multiply(raw_instr)
+	if long {
+		fields = decode_multiply_long(raw_instr)
+	}
+
+	fields.NHigh = (raw_instr>>5)&0x1 == 1
+	fields.MHigh = (raw_instr>>4)&0x1 == 1
+
+	return fields
+}
+
+/* Mnemonic suffix selecting a halfword */
+func halfword_suffix(high bool) string {
+	if high {
+		return "t"
+	}
+
+	return "b"
+}
+
+/* Mnemonic suffix of the exchanging (X) and rounding (R) variants */
+func option_suffix(set bool, suffix string) string {
+	if set {
+		return suffix
+	}
+
+	return ""
+}
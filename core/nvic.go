@@ -0,0 +1,205 @@
+package core
+
+/* Nested Vectored Interrupt Controller, holding the enables and
+ * priorities of the external interrupts. Their pending and active state
+ * is the CPU's, as for every exception.
+ * ARMv7-M ARM B3.4 */
+const (
+	NVIC_ICTR = 0xe000e004
+	NVIC_ISER = 0xe000e100 // Set-enable, a bit for each interrupt
+	NVIC_ICER = 0xe000e180 // Clear-enable
+	NVIC_ISPR = 0xe000e200 // Set-pending
+	NVIC_ICPR = 0xe000e280 // Clear-pending
+	NVIC_IABR = 0xe000e300 // Active
+	NVIC_IPR  = 0xe000e400 // Priority, a byte for each interrupt
+	NVIC_STIR = 0xe000ef00 // Software trigger
+
+	NVIC_BANK_SIZE = 0x40 // ISER-IABR each have room for 16 words
+
+	NVIC_MAX_INTERRUPTS = 240
+	STIR_INTID_MASK     = 0x1ff
+)
+
+type NVIC struct {
+	Interrupts   int   // External interrupts implemented, up to 240
+	PriorityBits uint8 // Implemented, most significant, bits of each priority
+
+	enabled [NVIC_MAX_INTERRUPTS]bool
+	lines   [NVIC_MAX_INTERRUPTS]bool // Interrupt lines asserted by the host
+}
+
+/* Disable every interrupt, keeping the configuration. The host must
+ * reassert lines still raised. */
+func (nvic *NVIC) Reset() {
+	nvic.enabled = [NVIC_MAX_INTERRUPTS]bool{}
+	nvic.lines = [NVIC_MAX_INTERRUPTS]bool{}
+}
+
+/* The implemented bits of a priority */
+func (nvic NVIC) priority_mask() uint8 {
+	if nvic.PriorityBits >= 8 {
+		return 0xff
+	}
+
+	return ^(0xff >> nvic.PriorityBits)
+}
+
+func (nvic NVIC) implemented(irq int) bool {
+	return irq >= 0 && irq < nvic.Interrupts && irq < NVIC_MAX_INTERRUPTS
+}
+
+/* Can excp be taken? External interrupts must be enabled in the NVIC. */
+func (cpu *CPU) interrupt_enabled(excp uint16) bool {
+	if excp < EXCEPTION_IRQ0 {
+		return true
+	}
+
+	irq := int(excp - EXCEPTION_IRQ0)
+	return cpu.Nvic.implemented(irq) && cpu.Nvic.enabled[irq]
+}
+
+/* Assert interrupt line irq, for a peripheral model. The interrupt is
+ * pended, and again on each return from its handler while the line
+ * stays asserted, as for a level-sensitive interrupt. A pulse is a raise
+ * followed by a clear. Returns false if irq isn't implemented. */
+func (cpu *CPU) RaiseIRQ(irq int) bool {
+	if !cpu.Nvic.implemented(irq) {
+		return false
+	}
+
+	cpu.Nvic.lines[irq] = true
+	cpu.SetPending(EXCEPTION_IRQ0 + uint16(irq))
+
+	return true
+}
+
+/* Deassert interrupt line irq. Pending state is kept, until the
+ * interrupt is taken or software clears it. */
+func (cpu *CPU) ClearIRQ(irq int) bool {
+	if !cpu.Nvic.implemented(irq) {
+		return false
+	}
+
+	cpu.Nvic.lines[irq] = false
+
+	return true
+}
+
+func in_nvic_bank(addr uint32, base uint32) bool {
+	return addr >= base && addr-base < NVIC_BANK_SIZE
+}
+
+/* The 32 interrupts of word addr within the bank at base */
+func nvic_first_irq(addr uint32, base uint32) int {
+	return int(addr-base) * 8
+}
+
+/* Read a word of bits, one for each of the 32 interrupts from first */
+func (cpu *CPU) nvic_bits(first int, state func(irq int) bool) uint32 {
+	var value uint32
+	for i := 0; i < 32; i++ {
+		if cpu.Nvic.implemented(first+i) && state(first+i) {
+			value |= 1 << uint(i)
+		}
+	}
+
+	return value
+}
+
+/* Apply action to each implemented interrupt whose bit is set */
+func (cpu *CPU) nvic_set_bits(first int, value uint32, action func(irq int)) {
+	for i := 0; i < 32; i++ {
+		if value&(1<<uint(i)) != 0 && cpu.Nvic.implemented(first+i) {
+			action(first + i)
+		}
+	}
+}
+
+func (cpu *CPU) irq_enabled(irq int) bool {
+	return cpu.Nvic.enabled[irq]
+}
+
+func (cpu *CPU) irq_pending(irq int) bool {
+	return cpu.pending[EXCEPTION_IRQ0+irq]
+}
+
+func (cpu *CPU) irq_active(irq int) bool {
+	return cpu.active[EXCEPTION_IRQ0+irq]
+}
+
+/* Read an aligned NVIC word, returning false if addr isn't an NVIC
+ * register */
+func (cpu *CPU) nvic_read(addr uint32) (uint32, bool) {
+	switch {
+	case addr == NVIC_ICTR:
+		if cpu.Nvic.Interrupts == 0 {
+			return 0, true
+		}
+		return uint32((cpu.Nvic.Interrupts+31)/32-1) & 0xf, true
+	case in_nvic_bank(addr, NVIC_ISER):
+		return cpu.nvic_bits(nvic_first_irq(addr, NVIC_ISER), cpu.irq_enabled), true
+	case in_nvic_bank(addr, NVIC_ICER):
+		return cpu.nvic_bits(nvic_first_irq(addr, NVIC_ICER), cpu.irq_enabled), true
+	case in_nvic_bank(addr, NVIC_ISPR):
+		return cpu.nvic_bits(nvic_first_irq(addr, NVIC_ISPR), cpu.irq_pending), true
+	case in_nvic_bank(addr, NVIC_ICPR):
+		return cpu.nvic_bits(nvic_first_irq(addr, NVIC_ICPR), cpu.irq_pending), true
+	case in_nvic_bank(addr, NVIC_IABR):
+		return cpu.nvic_bits(nvic_first_irq(addr, NVIC_IABR), cpu.irq_active), true
+	case addr >= NVIC_IPR && addr-NVIC_IPR < NVIC_MAX_INTERRUPTS:
+		var value uint32
+		for i := 0; i < 4; i++ {
+			value |= uint32(cpu.priority[EXCEPTION_IRQ0+int(addr-NVIC_IPR)+i]) << uint(8*i)
+		}
+		return value, true
+	case addr == NVIC_STIR:
+		return 0, true
+	}
+
+	return 0, false
+}
+
+/* Write the bytes of an aligned NVIC word selected by mask, returning
+ * false if addr isn't an NVIC register */
+func (cpu *CPU) nvic_write(addr uint32, value uint32, mask uint32) bool {
+	value &= mask
+
+	switch {
+	case addr == NVIC_ICTR:
+	case in_nvic_bank(addr, NVIC_ISER):
+		cpu.nvic_set_bits(nvic_first_irq(addr, NVIC_ISER), value, func(irq int) {
+			cpu.Nvic.enabled[irq] = true
+		})
+	case in_nvic_bank(addr, NVIC_ICER):
+		cpu.nvic_set_bits(nvic_first_irq(addr, NVIC_ICER), value, func(irq int) {
+			cpu.Nvic.enabled[irq] = false
+		})
+	case in_nvic_bank(addr, NVIC_ISPR):
+		cpu.nvic_set_bits(nvic_first_irq(addr, NVIC_ISPR), value, func(irq int) {
+			cpu.SetPending(EXCEPTION_IRQ0 + uint16(irq))
+		})
+	case in_nvic_bank(addr, NVIC_ICPR):
+		cpu.nvic_set_bits(nvic_first_irq(addr, NVIC_ICPR), value, func(irq int) {
+			cpu.ClearPending(EXCEPTION_IRQ0 + uint16(irq))
+		})
+	case in_nvic_bank(addr, NVIC_IABR):
+	case addr >= NVIC_IPR && addr-NVIC_IPR < NVIC_MAX_INTERRUPTS:
+		for i := 0; i < 4; i++ {
+			irq := int(addr-NVIC_IPR) + i
+			if mask&(0xff<<uint(8*i)) != 0 && cpu.Nvic.implemented(irq) {
+				cpu.priority[EXCEPTION_IRQ0+irq] = uint8(value>>uint(8*i)) & cpu.Nvic.priority_mask()
+			}
+		}
+	case addr == NVIC_STIR:
+		if mask&STIR_INTID_MASK == STIR_INTID_MASK {
+			irq := int(value & STIR_INTID_MASK)
+			if cpu.Nvic.implemented(irq) {
+				cpu.SetPending(EXCEPTION_IRQ0 + uint16(irq))
+			}
+		}
+	default:
+		return false
+	}
+
+	return true
+}
@@ -0,0 +1,199 @@
+package core
+
+import "testing"
+
+func TestNVICRegisters(t *testing.T) {
+	cpu := NewCPU(NewDefaultBus())
+	cpu.Nvic.Interrupts = 40
+	cpu.Nvic.PriorityBits = 3
+
+	if ictr, _ := cpu.Read32(NVIC_ICTR); ictr != 1 {
+		t.Errorf("ICTR = %#x, expected 1", ictr)
+	}
+
+	/* Only implemented interrupts can be enabled */
+	cpu.Write32(NVIC_ISER+4, 0xffffffff)
+	cpu.Write32(NVIC_ICER+4, 0x1)
+	if iser, _ := cpu.Read32(NVIC_ISER + 4); iser != 0xfe {
+		t.Errorf("ISER1 = %#x, expected 0xfe", iser)
+	}
+	if icer, _ := cpu.Read32(NVIC_ICER + 4); icer != 0xfe {
+		t.Errorf("ICER1 = %#x, expected 0xfe", icer)
+	}
+
+	cpu.Write32(NVIC_ISPR, 0x30)
+	cpu.Write32(NVIC_ICPR, 0x10)
+	if ispr, _ := cpu.Read32(NVIC_ISPR); ispr != 0x20 || !cpu.IsPending(EXCEPTION_IRQ0+5) {
+		t.Errorf("ISPR0 = %#x, expected 0x20", ispr)
+	}
+
+	/* Software triggered */
+	cpu.Write32(NVIC_STIR, 39)
+	cpu.Write32(NVIC_STIR, 40)
+	if ispr, _ := cpu.Read32(NVIC_ISPR + 4); ispr != 0x80 {
+		t.Errorf("ISPR1 = %#x, expected 0x80", ispr)
+	}
+
+	/* Priorities keep their implemented top bits */
+	cpu.Write8(NVIC_IPR+5, 0xff)
+	cpu.Write32(NVIC_IPR+4*9, 0xffffffff)
+	if ipr, _ := cpu.Read32(NVIC_IPR + 4); ipr != 0xe000 {
+		t.Errorf("IPR1 = %#x, expected 0xe000", ipr)
+	}
+	if ipr, _ := cpu.Read32(NVIC_IPR + 4*9); ipr != 0xe0e0e0e0 {
+		t.Errorf("IPR9 = %#x, expected 0xe0e0e0e0", ipr)
+	}
+	if ipr, _ := cpu.Read32(NVIC_IPR + 4*10); ipr != 0 {
+		t.Errorf("IPR10 = %#x, expected 0", ipr)
+	}
+
+	cpu.Write32(SCB_SHPR3, 0xffffffff)
+	if shpr3, _ := cpu.Read32(SCB_SHPR3); shpr3 != 0xe0e000e0 {
+		t.Errorf("SHPR3 = %#x, expected 0xe0e000e0", shpr3)
+	}
+}
+
+func TestPriorityGrouping(t *testing.T) {
+	cpu := exception_cpu(t)
+
+	/* Writes without VECTKEY are ignored */
+	cpu.Write32(SCB_AIRCR, 5<<AIRCR_PRIGROUP_SHIFT)
+	if aircr, _ := cpu.Read32(SCB_AIRCR); aircr != AIRCR_VECTKEYSTAT {
+		t.Errorf("AIRCR = %#x, expected %#x", aircr, AIRCR_VECTKEYSTAT)
+	}
+
+	/* Group priority in bits 7:6, subpriority in bits 5:0 */
+	cpu.Write32(SCB_AIRCR, AIRCR_VECTKEY|5<<AIRCR_PRIGROUP_SHIFT)
+	if aircr, _ := cpu.Read32(SCB_AIRCR); aircr != AIRCR_VECTKEYSTAT|5<<AIRCR_PRIGROUP_SHIFT {
+		t.Errorf("AIRCR = %#x", aircr)
+	}
+
+	cpu.Write32(NVIC_IPR, 0x40<<24|0x60<<16|0x50<<8)
+	cpu.RaiseIRQ(1)
+	step(t, cpu)
+
+	/* Same group, so IRQ2 doesn't preempt despite its higher subpriority */
+	cpu.RaiseIRQ(2)
+	if _, ok := cpu.PendingException(); ok {
+		t.Errorf("IRQ2 preempts IRQ1")
+	}
+
+	/* Subpriority orders IRQs pending together */
+	cpu.RaiseIRQ(3)
+	if icsr, _ := cpu.Read32(SCB_ICSR); (icsr&ICSR_VECTPENDING_MASK)>>12 != EXCEPTION_IRQ0+3 {
+		t.Errorf("VECTPENDING = %d, expected %d", (icsr&ICSR_VECTPENDING_MASK)>>12, EXCEPTION_IRQ0+3)
+	}
+
+	/* BASEPRI masks by group */
+	cpu.Basepri = 0x7f
+	if priority := cpu.ExecutionPriority(); priority != 0x40 {
+		t.Errorf("execution priority = %#x, expected 0x40", priority)
+	}
+}
+
+func TestInterruptLines(t *testing.T) {
+	cpu := exception_cpu(t)
+
+	if cpu.RaiseIRQ(NVIC_MAX_INTERRUPTS) {
+		t.Errorf("raised unimplemented interrupt")
+	}
+
+	/* Disabled interrupts stay pending */
+	cpu.Write32(NVIC_ICER, 0x1)
+	cpu.RaiseIRQ(0)
+	step(t, cpu)
+	if cpu.Mode != MODE_THREAD || !cpu.IsPending(EXCEPTION_IRQ0) {
+		t.Fatalf("disabled interrupt taken")
+	}
+
+	cpu.Write32(NVIC_ISER, 0x1)
+	step(t, cpu)
+	if cpu.Ipsr.ExcpNum != EXCEPTION_IRQ0 {
+		t.Fatalf("IPSR = %d, expected %d", cpu.Ipsr.ExcpNum, EXCEPTION_IRQ0)
+	}
+
+	/* Still asserted on return, so pended and tail-chained again */
+	if iabr, _ := cpu.Read32(NVIC_IABR); iabr != 0x1 {
+		t.Errorf("IABR0 = %#x, expected 0x1", iabr)
+	}
+
+	step(t, cpu)
+	if cpu.Ipsr.ExcpNum != EXCEPTION_IRQ0 || cpu.IsPending(EXCEPTION_IRQ0) {
+		t.Fatalf("IPSR = %d, expected %d", cpu.Ipsr.ExcpNum, EXCEPTION_IRQ0)
+	}
+
+	cpu.ClearIRQ(0)
+	step(t, cpu)
+	if cpu.Mode != MODE_THREAD || cpu.IsPending(EXCEPTION_IRQ0) {
+		t.Errorf("Returned with:\n%s", cpu.Pretty())
+	}
+}
+
+func TestStepWakeOnInterrupt(t *testing.T) {
+	cpu := exception_cpu(t)
+	LoadBytes(cpu.Mem, 0x200, []byte{0x30, 0xbf}) // wfi
+	cpu.Primask = true
+
+	/* An interrupt raised by a timer, at cycle 100 */
+	cpu.Idle = func(now uint64) (uint64, bool) {
+		cpu.RaiseIRQ(4)
+		return 100, true
+	}
+
+	step(t, cpu)
+	step(t, cpu)
+	if cpu.Sleep != SLEEP_WFI || cpu.Cycles != 100 {
+		t.Fatalf("sleep = %v, cycles = %d", cpu.Sleep, cpu.Cycles)
+	}
+
+	/* Wakes, but PRIMASK holds off the handler */
+	step(t, cpu)
+	if cpu.Sleep != AWAKE || cpu.Mode != MODE_THREAD || cpu.Pc() != 0x204 {
+		t.Errorf("sleep = %v, pc = %#x", cpu.Sleep, cpu.Pc())
+	}
+
+	cpu.Primask = false
+	step(t, cpu)
+	if cpu.Ipsr.ExcpNum != EXCEPTION_IRQ0+4 || cpu.Pc() != handler(EXCEPTION_IRQ0+4) {
+		t.Errorf("IPSR = %d, pc = %#x", cpu.Ipsr.ExcpNum, cpu.Pc())
+	}
+}
+
+func TestUnprivilegedSCS(t *testing.T) {
+	cpu := exception_cpu(t)
+	cpu.Control.Npriv = true
+
+	if _, err := cpu.Read32(SCB_ICSR); err != ErrUnprivilegedAccess {
+		t.Errorf("read ICSR: %v, expected %v", err, ErrUnprivilegedAccess)
+	}
+	if err := cpu.Write32(NVIC_STIR, 5); err != ErrUnprivilegedAccess || cpu.IsPending(EXCEPTION_IRQ0+5) {
+		t.Errorf("write STIR: %v, expected %v", err, ErrUnprivilegedAccess)
+	}
+
+	/* CCR.USERSETMPEND opens up STIR, and only STIR */
+	cpu.Control.Npriv = false
+	cpu.Write32(SCB_CCR, CCR_RESET|CCR_USERSETMPEND)
+	cpu.Control.Npriv = true
+
+	if err := cpu.Write32(NVIC_STIR, 5); err != nil || !cpu.IsPending(EXCEPTION_IRQ0+5) {
+		t.Errorf("write STIR: %v, pending = %v", err, cpu.IsPending(EXCEPTION_IRQ0+5))
+	}
+	if _, err := cpu.Read32(NVIC_STIR); err != ErrUnprivilegedAccess {
+		t.Errorf("read STIR: %v, expected %v", err, ErrUnprivilegedAccess)
+	}
+	if err := cpu.Write32(NVIC_ISPR, 0x40); err != ErrUnprivilegedAccess || cpu.IsPending(EXCEPTION_IRQ0+6) {
+		t.Errorf("write ISPR0: %v, expected %v", err, ErrUnprivilegedAccess)
+	}
+
+	/* A store from unprivileged code is a precise BusFault */
+	cpu.ClearPending(EXCEPTION_IRQ0 + 5)
+	LoadBytes(cpu.Mem, 0x200, []byte{0x01, 0x60}) // str r1, [r0]
+	cpu.SetR(0, NVIC_ISPR)
+	cpu.Fault = func(fault error) bool { return true }
+
+	step(t, cpu)
+	if cpu.Ipsr.ExcpNum != EXCEPTION_HARDFAULT || cpu.Scb.Bfar != NVIC_ISPR ||
+		cpu.Scb.Cfsr&(uint32(BFSR_PRECISERR)<<CFSR_BFSR_SHIFT) == 0 {
+		t.Errorf("IPSR = %d, BFAR = %#x, CFSR = %#x", cpu.Ipsr.ExcpNum, cpu.Scb.Bfar, cpu.Scb.Cfsr)
+	}
+}
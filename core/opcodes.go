@@ -27,6 +27,379 @@ var InstrOpcodes16 = map[Opcode]DecodeFunc{
 	Opcode{mask: 0xfe00, value: 0x1a00}: SubReg16T1,
 	Opcode{mask: 0xfe00, value: 0x1c00}: AddImm16T1,
 	Opcode{mask: 0xf800, value: 0x3000}: AddImm16T2,
+	Opcode{mask: 0xfe00, value: 0x1e00}: SubImm16T1,
+	Opcode{mask: 0xf800, value: 0x3800}: SubImm16T2,
+	Opcode{mask: 0xf800, value: 0xa000}: Adr16T1,
+	Opcode{mask: 0xf800, value: 0xa800}: AddSPImm16T1,
+	Opcode{mask: 0xff80, value: 0xb000}: AddSPImm16T2,
+	Opcode{mask: 0xff80, value: 0xb080}: SubSPImm16T1,
+	Opcode{mask: 0xf800, value: 0x2800}: CmpImm16T1,
+	Opcode{mask: 0xff00, value: 0x4500}: CmpReg16T2,
+	Opcode{mask: 0xffc0, value: 0x4000}: AndReg16T1,
+	Opcode{mask: 0xffc0, value: 0x4040}: EorReg16T1,
+	Opcode{mask: 0xffc0, value: 0x4100}: AsrReg16,
+	Opcode{mask: 0xffc0, value: 0x4140}: AdcReg16T1,
+	Opcode{mask: 0xffc0, value: 0x4180}: SbcReg16T1,
+	Opcode{mask: 0xffc0, value: 0x41c0}: RorReg16,
+	Opcode{mask: 0xffc0, value: 0x4200}: TstReg16T1,
+	Opcode{mask: 0xffc0, value: 0x4240}: RsbImm16T1,
+	Opcode{mask: 0xffc0, value: 0x4280}: CmpReg16T1,
+	Opcode{mask: 0xffc0, value: 0x42c0}: CmnReg16T1,
+	Opcode{mask: 0xffc0, value: 0x4300}: OrrReg16T1,
+	Opcode{mask: 0xffc0, value: 0x4340}: Mul16T1,
+	Opcode{mask: 0xffc0, value: 0x4380}: BicReg16T1,
+	Opcode{mask: 0xffc0, value: 0x43c0}: MvnReg16T1,
+	Opcode{mask: 0xf800, value: 0x4800}: LdrLit16T1,
+	Opcode{mask: 0xfe00, value: 0x5000}: StrReg16T1,
+	Opcode{mask: 0xfe00, value: 0x5200}: StrhReg16T1,
+	Opcode{mask: 0xfe00, value: 0x5400}: StrbReg16T1,
+	Opcode{mask: 0xfe00, value: 0x5600}: LdrsbReg16T1,
+	Opcode{mask: 0xfe00, value: 0x5800}: LdrReg16T1,
+	Opcode{mask: 0xfe00, value: 0x5a00}: LdrhReg16T1,
+	Opcode{mask: 0xfe00, value: 0x5c00}: LdrbReg16T1,
+	Opcode{mask: 0xfe00, value: 0x5e00}: LdrshReg16T1,
+	Opcode{mask: 0xf800, value: 0x6000}: StrImm16T1,
+	Opcode{mask: 0xf800, value: 0x6800}: LdrImm16T1,
+	Opcode{mask: 0xf800, value: 0x7000}: StrbImm16T1,
+	Opcode{mask: 0xf800, value: 0x7800}: LdrbImm16T1,
+	Opcode{mask: 0xf800, value: 0x8000}: StrhImm16T1,
+	Opcode{mask: 0xf800, value: 0x8800}: LdrhImm16T1,
+	Opcode{mask: 0xf800, value: 0x9000}: StrImm16T2,
+	Opcode{mask: 0xf800, value: 0x9800}: LdrImm16T2,
+	Opcode{mask: 0xf800, value: 0xc000}: Stm16T1,
+	Opcode{mask: 0xf800, value: 0xc800}: Ldm16T1,
+	Opcode{mask: 0xfe00, value: 0xb400}: Push16T1,
+	Opcode{mask: 0xfe00, value: 0xbc00}: Pop16T1,
+	Opcode{mask: 0xf800, value: 0xd000}: B16T1, // cond 0xxx
+	Opcode{mask: 0xfc00, value: 0xd800}: B16T1, // cond 10xx
+	Opcode{mask: 0xfe00, value: 0xdc00}: B16T1, // cond 110x
+	Opcode{mask: 0xf800, value: 0xe000}: B16T2,
+	Opcode{mask: 0xff00, value: 0xde00}: Udf16T1,
+	Opcode{mask: 0xff00, value: 0xdf00}: Svc16T1,
+	Opcode{mask: 0xff00, value: 0xbe00}: Bkpt16T1,
+	Opcode{mask: 0xff87, value: 0x4700}: Bx16T1,
+	Opcode{mask: 0xff87, value: 0x4780}: BlxReg16T1,
+	Opcode{mask: 0xfd00, value: 0xb100}: Cbz16T1,
+	Opcode{mask: 0xfd00, value: 0xb900}: Cbnz16T1,
+	Opcode{mask: 0xffec, value: 0xb660}: Cps16T1,
+	Opcode{mask: 0xffff, value: 0xbf00}: Nop16T1,
+	Opcode{mask: 0xffff, value: 0xbf10}: Yield16T1,
+	Opcode{mask: 0xffff, value: 0xbf20}: Wfe16T1,
+	Opcode{mask: 0xffff, value: 0xbf30}: Wfi16T1,
+	Opcode{mask: 0xffff, value: 0xbf40}: Sev16T1,
+	Opcode{mask: 0xffff, value: 0xbf50}: Nop16T1, // unallocated hint
+	Opcode{mask: 0xffef, value: 0xbf60}: Nop16T1, // unallocated hint
+	Opcode{mask: 0xff8f, value: 0xbf80}: Nop16T1, // unallocated hint
+	Opcode{mask: 0xff0f, value: 0xbf08}: It16T1,  // mask 1000
+	Opcode{mask: 0xff07, value: 0xbf04}: It16T1,  // mask x100
+	Opcode{mask: 0xff03, value: 0xbf02}: It16T1,  // mask xx10
+	Opcode{mask: 0xff01, value: 0xbf01}: It16T1,  // mask xxx1
+	Opcode{mask: 0xffc0, value: 0xb200}: Sxth16T1,
+	Opcode{mask: 0xffc0, value: 0xb240}: Sxtb16T1,
+	Opcode{mask: 0xffc0, value: 0xb280}: Uxth16T1,
+	Opcode{mask: 0xffc0, value: 0xb2c0}: Uxtb16T1,
+	Opcode{mask: 0xffc0, value: 0xba00}: Rev16T1,
+	Opcode{mask: 0xffc0, value: 0xba40}: RevPacked16T1,
+	Opcode{mask: 0xffc0, value: 0xbac0}: Revsh16T1,
 }
 
-var InstrOpcodes32 = map[Opcode]DecodeFunc{}
+var InstrOpcodes32 = map[Opcode]DecodeFunc{
+	Opcode{mask: 0xfbe08000, value: 0xf0000000}: AndImm32T1,
+	Opcode{mask: 0xfbf08f00, value: 0xf0100f00}: TstImm32T1,
+	Opcode{mask: 0xfbe08000, value: 0xf0200000}: BicImm32T1,
+	Opcode{mask: 0xfbe08000, value: 0xf0400000}: OrrImm32T1,
+	Opcode{mask: 0xfbef8000, value: 0xf04f0000}: MovImm32T2,
+	Opcode{mask: 0xfbe08000, value: 0xf0600000}: OrnImm32T1,
+	Opcode{mask: 0xfbef8000, value: 0xf06f0000}: MvnImm32T1,
+	Opcode{mask: 0xfbe08000, value: 0xf0800000}: EorImm32T1,
+	Opcode{mask: 0xfbf08f00, value: 0xf0900f00}: TeqImm32T1,
+	Opcode{mask: 0xfbe08000, value: 0xf1000000}: AddImm32T3,
+	Opcode{mask: 0xfbf08f00, value: 0xf1100f00}: CmnImm32T1,
+	Opcode{mask: 0xfbe08000, value: 0xf1400000}: AdcImm32T1,
+	Opcode{mask: 0xfbe08000, value: 0xf1600000}: SbcImm32T1,
+	Opcode{mask: 0xfbe08000, value: 0xf1a00000}: SubImm32T3,
+	Opcode{mask: 0xfbf08f00, value: 0xf1b00f00}: CmpImm32T2,
+	Opcode{mask: 0xfbe08000, value: 0xf1c00000}: RsbImm32T2,
+	Opcode{mask: 0xfbf08000, value: 0xf2000000}: AddImm32T4,
+	Opcode{mask: 0xfbff8000, value: 0xf20f0000}: Adr32T3,
+	Opcode{mask: 0xfbf08000, value: 0xf2400000}: MovImm32T3,
+	Opcode{mask: 0xfbf08000, value: 0xf2a00000}: SubImm32T4,
+	Opcode{mask: 0xfbff8000, value: 0xf2af0000}: Adr32T2,
+	Opcode{mask: 0xfbf08000, value: 0xf2c00000}: Movt32T1,
+	Opcode{mask: 0xfbf08000, value: 0xf3000000}: Ssat32T1,
+	Opcode{mask: 0xfbf09000, value: 0xf3201000}: Ssat32T1, // asr, shift_n != 0
+	Opcode{mask: 0xfbf0a000, value: 0xf3202000}: Ssat32T1, // asr, shift_n != 0
+	Opcode{mask: 0xfbf0c000, value: 0xf3204000}: Ssat32T1, // asr, shift_n != 0
+	Opcode{mask: 0xfbf08040, value: 0xf3200040}: Ssat32T1, // asr, shift_n != 0
+	Opcode{mask: 0xfbf08080, value: 0xf3200080}: Ssat32T1, // asr, shift_n != 0
+	Opcode{mask: 0xfbf08000, value: 0xf3400000}: Sbfx32T1,
+	Opcode{mask: 0xfbf08000, value: 0xf3600000}: Bfi32T1,
+	Opcode{mask: 0xfbff8000, value: 0xf36f0000}: Bfc32T1,
+	Opcode{mask: 0xfbf08000, value: 0xf3800000}: Usat32T1,
+	Opcode{mask: 0xfbf09000, value: 0xf3a01000}: Usat32T1, // asr, shift_n != 0
+	Opcode{mask: 0xfbf0a000, value: 0xf3a02000}: Usat32T1, // asr, shift_n != 0
+	Opcode{mask: 0xfbf0c000, value: 0xf3a04000}: Usat32T1, // asr, shift_n != 0
+	Opcode{mask: 0xfbf08040, value: 0xf3a00040}: Usat32T1, // asr, shift_n != 0
+	Opcode{mask: 0xfbf08080, value: 0xf3a00080}: Usat32T1, // asr, shift_n != 0
+	Opcode{mask: 0xfbf08000, value: 0xf3c00000}: Ubfx32T1,
+	Opcode{mask: 0xffe08000, value: 0xea000000}: AndReg32T2,
+	Opcode{mask: 0xfff08f00, value: 0xea100f00}: TstReg32T2,
+	Opcode{mask: 0xffe08000, value: 0xea200000}: BicReg32T2,
+	Opcode{mask: 0xffe08000, value: 0xea400000}: OrrReg32T2,
+	Opcode{mask: 0xffef8000, value: 0xea4f0000}: MovRegImmShift32,
+	Opcode{mask: 0xffe08000, value: 0xea600000}: OrnReg32T1,
+	Opcode{mask: 0xffef8000, value: 0xea6f0000}: MvnReg32T2,
+	Opcode{mask: 0xffe08000, value: 0xea800000}: EorReg32T2,
+	Opcode{mask: 0xfff08f00, value: 0xea900f00}: TeqReg32T1,
+	Opcode{mask: 0xffe08000, value: 0xeb000000}: AddReg32T3,
+	Opcode{mask: 0xfff08f00, value: 0xeb100f00}: CmnReg32T2,
+	Opcode{mask: 0xffe08000, value: 0xeb400000}: AdcReg32T2,
+	Opcode{mask: 0xffe08000, value: 0xeb600000}: SbcReg32T2,
+	Opcode{mask: 0xffe08000, value: 0xeba00000}: SubReg32T2,
+	Opcode{mask: 0xfff08f00, value: 0xebb00f00}: CmpReg32T3,
+	Opcode{mask: 0xffe08000, value: 0xebc00000}: RsbReg32T1,
+	Opcode{mask: 0xffe0f0f0, value: 0xfa00f000}: LslReg32T2,
+	Opcode{mask: 0xffe0f0f0, value: 0xfa20f000}: LsrReg32T2,
+	Opcode{mask: 0xffe0f0f0, value: 0xfa40f000}: AsrReg32T2,
+	Opcode{mask: 0xffe0f0f0, value: 0xfa60f000}: RorReg32T2,
+	Opcode{mask: 0xfff00000, value: 0xf8800000}: StrbImm32T2,
+	Opcode{mask: 0xfff00800, value: 0xf8000800}: StrbImm32T3,
+	Opcode{mask: 0xfff00f00, value: 0xf8000e00}: Strbt32T1,
+	Opcode{mask: 0xfff00fc0, value: 0xf8000000}: StrbReg32T2,
+	Opcode{mask: 0xfff00000, value: 0xf8a00000}: StrhImm32T2,
+	Opcode{mask: 0xfff00800, value: 0xf8200800}: StrhImm32T3,
+	Opcode{mask: 0xfff00f00, value: 0xf8200e00}: Strht32T1,
+	Opcode{mask: 0xfff00fc0, value: 0xf8200000}: StrhReg32T2,
+	Opcode{mask: 0xfff00000, value: 0xf8c00000}: StrImm32T3,
+	Opcode{mask: 0xfff00800, value: 0xf8400800}: StrImm32T4,
+	Opcode{mask: 0xfff00f00, value: 0xf8400e00}: Strt32T1,
+	Opcode{mask: 0xfff00fc0, value: 0xf8400000}: StrReg32T2,
+	Opcode{mask: 0xfff00000, value: 0xf8900000}: LdrbImm32T2,
+	Opcode{mask: 0xfff00800, value: 0xf8100800}: LdrbImm32T3,
+	Opcode{mask: 0xfff00f00, value: 0xf8100e00}: Ldrbt32T1,
+	Opcode{mask: 0xfff00fc0, value: 0xf8100000}: LdrbReg32T2,
+	Opcode{mask: 0xff7f0000, value: 0xf81f0000}: LdrbLit32T1,
+	Opcode{mask: 0xfff00000, value: 0xf9900000}: LdrsbImm32T1,
+	Opcode{mask: 0xfff00800, value: 0xf9100800}: LdrsbImm32T2,
+	Opcode{mask: 0xfff00f00, value: 0xf9100e00}: Ldrsbt32T1,
+	Opcode{mask: 0xfff00fc0, value: 0xf9100000}: LdrsbReg32T2,
+	Opcode{mask: 0xff7f0000, value: 0xf91f0000}: LdrsbLit32T1,
+	Opcode{mask: 0xfff00000, value: 0xf8b00000}: LdrhImm32T2,
+	Opcode{mask: 0xfff00800, value: 0xf8300800}: LdrhImm32T3,
+	Opcode{mask: 0xfff00f00, value: 0xf8300e00}: Ldrht32T1,
+	Opcode{mask: 0xfff00fc0, value: 0xf8300000}: LdrhReg32T2,
+	Opcode{mask: 0xff7f0000, value: 0xf83f0000}: LdrhLit32T1,
+	Opcode{mask: 0xfff00000, value: 0xf9b00000}: LdrshImm32T1,
+	Opcode{mask: 0xfff00800, value: 0xf9300800}: LdrshImm32T2,
+	Opcode{mask: 0xfff00f00, value: 0xf9300e00}: Ldrsht32T1,
+	Opcode{mask: 0xfff00fc0, value: 0xf9300000}: LdrshReg32T2,
+	Opcode{mask: 0xff7f0000, value: 0xf93f0000}: LdrshLit32T1,
+	Opcode{mask: 0xfff00000, value: 0xf8d00000}: LdrImm32T3,
+	Opcode{mask: 0xfff00800, value: 0xf8500800}: LdrImm32T4,
+	Opcode{mask: 0xfff00f00, value: 0xf8500e00}: Ldrt32T1,
+	Opcode{mask: 0xfff00fc0, value: 0xf8500000}: LdrReg32T2,
+	Opcode{mask: 0xff7f0000, value: 0xf85f0000}: LdrLit32T2,
+	Opcode{mask: 0xff500000, value: 0xe9400000}: StrdImm32T1,
+	Opcode{mask: 0xff700000, value: 0xe8600000}: StrdImm32T1,
+	Opcode{mask: 0xff500000, value: 0xe9500000}: LdrdImm32T1,
+	Opcode{mask: 0xff700000, value: 0xe8700000}: LdrdImm32T1,
+	Opcode{mask: 0xff7f0000, value: 0xe95f0000}: LdrdLit32T1,
+	Opcode{mask: 0xff7f0000, value: 0xe87f0000}: LdrdLit32T1,
+	Opcode{mask: 0xffd00000, value: 0xe8800000}: Stm32T2,
+	Opcode{mask: 0xffd00000, value: 0xe8900000}: Ldm32T2,
+	Opcode{mask: 0xffd00000, value: 0xe9000000}: Stmdb32T1,
+	Opcode{mask: 0xffd00000, value: 0xe9100000}: Ldmdb32T1,
+	Opcode{mask: 0xffff0000, value: 0xe92d0000}: Push32T2,
+	Opcode{mask: 0xffff0000, value: 0xe8bd0000}: Pop32T2,
+	Opcode{mask: 0xffff0fff, value: 0xf84d0d04}: Push32T3,
+	Opcode{mask: 0xffff0fff, value: 0xf85d0b04}: Pop32T3,
+	Opcode{mask: 0xfa00d000, value: 0xf0008000}: B32T3, // cond 0xxx
+	Opcode{mask: 0xfb00d000, value: 0xf2008000}: B32T3, // cond 10xx
+	Opcode{mask: 0xfb80d000, value: 0xf3008000}: B32T3, // cond 110x
+	Opcode{mask: 0xf800d000, value: 0xf0009000}: B32T4,
+	Opcode{mask: 0xf800d000, value: 0xf000d000}: Bl32T1,
+	Opcode{mask: 0xfff0f000, value: 0xf7f0a000}: Udf32T2,
+	Opcode{mask: 0xfff00f00, value: 0xe8500f00}: Ldrex32T1,
+	Opcode{mask: 0xfffff000, value: 0xf3ef8000}: Mrs32T1,
+	Opcode{mask: 0xfff0f700, value: 0xf3808000}: Msr32T1,
+	Opcode{mask: 0xfff00fff, value: 0xe8d00f4f}: Ldrexb32T1,
+	Opcode{mask: 0xfff00fff, value: 0xe8d00f5f}: Ldrexh32T1,
+	Opcode{mask: 0xfff00000, value: 0xe8400000}: Strex32T1,
+	Opcode{mask: 0xfff00ff0, value: 0xe8c00f40}: Strexb32T1,
+	Opcode{mask: 0xfff00ff0, value: 0xe8c00f50}: Strexh32T1,
+	Opcode{mask: 0xffffffff, value: 0xf3bf8f2f}: Clrex32T1,
+	Opcode{mask: 0xffffffff, value: 0xf3af8000}: Nop32T2,
+	Opcode{mask: 0xffffffff, value: 0xf3af8001}: Yield32T2,
+	Opcode{mask: 0xffffffff, value: 0xf3af8002}: Wfe32T2,
+	Opcode{mask: 0xffffffff, value: 0xf3af8003}: Wfi32T2,
+	Opcode{mask: 0xffffffff, value: 0xf3af8004}: Sev32T2,
+	Opcode{mask: 0xffffffff, value: 0xf3af8005}: Nop32T2, // unallocated hint
+	Opcode{mask: 0xfffffffe, value: 0xf3af8006}: Nop32T2, // unallocated hint
+	Opcode{mask: 0xfffffff8, value: 0xf3af8008}: Nop32T2, // unallocated hint
+	Opcode{mask: 0xfffffff0, value: 0xf3af8010}: Nop32T2, // unallocated hint
+	Opcode{mask: 0xffffffe0, value: 0xf3af8020}: Nop32T2, // unallocated hint
+	Opcode{mask: 0xffffffc0, value: 0xf3af8040}: Nop32T2, // unallocated hint
+	Opcode{mask: 0xffffffc0, value: 0xf3af8080}: Nop32T2, // unallocated hint
+	Opcode{mask: 0xffffffe0, value: 0xf3af80c0}: Nop32T2, // unallocated hint
+	Opcode{mask: 0xfffffff0, value: 0xf3af80e0}: Nop32T2, // unallocated hint
+	Opcode{mask: 0xfffffff0, value: 0xf3af80f0}: Dbg32T1,
+	Opcode{mask: 0xfffffff0, value: 0xf3bf8f40}: Dsb32T1,
+	Opcode{mask: 0xfffffff0, value: 0xf3bf8f50}: Dmb32T1,
+	Opcode{mask: 0xfffffff0, value: 0xf3bf8f60}: Isb32T1,
+	Opcode{mask: 0xfff0fff0, value: 0xe8d0f000}: Tbb32T1,
+	Opcode{mask: 0xfff0fff0, value: 0xe8d0f010}: Tbh32T1,
+	Opcode{mask: 0xfff0f0f0, value: 0xfb00f000}: Mul32T2,
+	Opcode{mask: 0xfff000f0, value: 0xfb000000}: Mla32T1,
+	Opcode{mask: 0xfff000f0, value: 0xfb000010}: Mls32T1,
+	Opcode{mask: 0xfff000f0, value: 0xfb800000}: Smull32T1,
+	Opcode{mask: 0xfff0f0f0, value: 0xfb90f0f0}: Sdiv32T1,
+	Opcode{mask: 0xfff000f0, value: 0xfba00000}: Umull32T1,
+	Opcode{mask: 0xfff0f0f0, value: 0xfbb0f0f0}: Udiv32T1,
+	Opcode{mask: 0xfff000f0, value: 0xfbc00000}: Smlal32T1,
+	Opcode{mask: 0xfff000f0, value: 0xfbe00000}: Umlal32T1,
+	Opcode{mask: 0xfffff0c0, value: 0xfa0ff080}: Sxth32T2,
+	Opcode{mask: 0xfffff0c0, value: 0xfa1ff080}: Uxth32T2,
+	Opcode{mask: 0xfffff0c0, value: 0xfa4ff080}: Sxtb32T2,
+	Opcode{mask: 0xfffff0c0, value: 0xfa5ff080}: Uxtb32T2,
+	Opcode{mask: 0xfff0f0f0, value: 0xfa90f080}: Rev32T2,
+	Opcode{mask: 0xfff0f0f0, value: 0xfa90f090}: RevPacked32T2,
+	Opcode{mask: 0xfff0f0f0, value: 0xfa90f0a0}: Rbit32T1,
+	Opcode{mask: 0xfff0f0f0, value: 0xfa90f0b0}: Revsh32T2,
+	Opcode{mask: 0xfff0f0f0, value: 0xfab0f080}: Clz32T1,
+}
+
+/* ARMv7E-M DSP extension instructions, UNDEFINED on ARMv7-M */
+var DSPOpcodes32 = map[Opcode]DecodeFunc{
+	Opcode{mask: 0xfff0f0f0, value: 0xfa90f000}: Sadd1632T1,
+	Opcode{mask: 0xfff0f0f0, value: 0xfaa0f000}: Sasx32T1,
+	Opcode{mask: 0xfff0f0f0, value: 0xfae0f000}: Ssax32T1,
+	Opcode{mask: 0xfff0f0f0, value: 0xfad0f000}: Ssub1632T1,
+	Opcode{mask: 0xfff0f0f0, value: 0xfa80f000}: Sadd832T1,
+	Opcode{mask: 0xfff0f0f0, value: 0xfac0f000}: Ssub832T1,
+	Opcode{mask: 0xfff0f0f0, value: 0xfa90f010}: Qadd1632T1,
+	Opcode{mask: 0xfff0f0f0, value: 0xfaa0f010}: Qasx32T1,
+	Opcode{mask: 0xfff0f0f0, value: 0xfae0f010}: Qsax32T1,
+	Opcode{mask: 0xfff0f0f0, value: 0xfad0f010}: Qsub1632T1,
+	Opcode{mask: 0xfff0f0f0, value: 0xfa80f010}: Qadd832T1,
+	Opcode{mask: 0xfff0f0f0, value: 0xfac0f010}: Qsub832T1,
+	Opcode{mask: 0xfff0f0f0, value: 0xfa90f020}: Shadd1632T1,
+	Opcode{mask: 0xfff0f0f0, value: 0xfaa0f020}: Shasx32T1,
+	Opcode{mask: 0xfff0f0f0, value: 0xfae0f020}: Shsax32T1,
+	Opcode{mask: 0xfff0f0f0, value: 0xfad0f020}: Shsub1632T1,
+	Opcode{mask: 0xfff0f0f0, value: 0xfa80f020}: Shadd832T1,
+	Opcode{mask: 0xfff0f0f0, value: 0xfac0f020}: Shsub832T1,
+	Opcode{mask: 0xfff0f0f0, value: 0xfa90f040}: Uadd1632T1,
+	Opcode{mask: 0xfff0f0f0, value: 0xfaa0f040}: Uasx32T1,
+	Opcode{mask: 0xfff0f0f0, value: 0xfae0f040}: Usax32T1,
+	Opcode{mask: 0xfff0f0f0, value: 0xfad0f040}: Usub1632T1,
+	Opcode{mask: 0xfff0f0f0, value: 0xfa80f040}: Uadd832T1,
+	Opcode{mask: 0xfff0f0f0, value: 0xfac0f040}: Usub832T1,
+	Opcode{mask: 0xfff0f0f0, value: 0xfa90f050}: Uqadd1632T1,
+	Opcode{mask: 0xfff0f0f0, value: 0xfaa0f050}: Uqasx32T1,
+	Opcode{mask: 0xfff0f0f0, value: 0xfae0f050}: Uqsax32T1,
+	Opcode{mask: 0xfff0f0f0, value: 0xfad0f050}: Uqsub1632T1,
+	Opcode{mask: 0xfff0f0f0, value: 0xfa80f050}: Uqadd832T1,
+	Opcode{mask: 0xfff0f0f0, value: 0xfac0f050}: Uqsub832T1,
+	Opcode{mask: 0xfff0f0f0, value: 0xfa90f060}: Uhadd1632T1,
+	Opcode{mask: 0xfff0f0f0, value: 0xfaa0f060}: Uhasx32T1,
+	Opcode{mask: 0xfff0f0f0, value: 0xfae0f060}: Uhsax32T1,
+	Opcode{mask: 0xfff0f0f0, value: 0xfad0f060}: Uhsub1632T1,
+	Opcode{mask: 0xfff0f0f0, value: 0xfa80f060}: Uhadd832T1,
+	Opcode{mask: 0xfff0f0f0, value: 0xfac0f060}: Uhsub832T1,
+	Opcode{mask: 0xfff0f0f0, value: 0xfaa0f080}: Sel32T1,
+	Opcode{mask: 0xfff08010, value: 0xeac00000}: Pkh32T1,
+	Opcode{mask: 0xfff0f0f0, value: 0xfb70f000}: Usad832T1,
+	Opcode{mask: 0xfff000f0, value: 0xfb700000}: Usada832T1,
+	Opcode{mask: 0xfff0f0c0, value: 0xfa00f080}: Sxtah32T1,
+	Opcode{mask: 0xfff0f0c0, value: 0xfa10f080}: Uxtah32T1,
+	Opcode{mask: 0xfffff0c0, value: 0xfa2ff080}: SxtbDual32T1,
+	Opcode{mask: 0xfff0f0c0, value: 0xfa20f080}: SxtabDual32T1,
+	Opcode{mask: 0xfffff0c0, value: 0xfa3ff080}: UxtbDual32T1,
+	Opcode{mask: 0xfff0f0c0, value: 0xfa30f080}: UxtabDual32T1,
+	Opcode{mask: 0xfff0f0c0, value: 0xfa40f080}: Sxtab32T1,
+	Opcode{mask: 0xfff0f0c0, value: 0xfa50f080}: Uxtab32T1,
+	Opcode{mask: 0xfff0f700, value: 0xf3808400}: Msr32T1, // APSR.GE
+	Opcode{mask: 0xfbf0f0d0, value: 0xf3200000}: Ssat1632T1,
+	Opcode{mask: 0xfbf0f0d0, value: 0xf3a00000}: Usat1632T1,
+	Opcode{mask: 0xfff0f0f0, value: 0xfa80f080}: Qadd32T1,
+	Opcode{mask: 0xfff0f0f0, value: 0xfa80f090}: Qdadd32T1,
+	Opcode{mask: 0xfff0f0f0, value: 0xfa80f0a0}: Qsub32T1,
+	Opcode{mask: 0xfff0f0f0, value: 0xfa80f0b0}: Qdsub32T1,
+	Opcode{mask: 0xfff0f0c0, value: 0xfb10f000}: Smulxy32T1,
+	Opcode{mask: 0xfff000c0, value: 0xfb100000}: Smlaxy32T1,
+	Opcode{mask: 0xfff0f0e0, value: 0xfb30f000}: Smulwy32T1,
+	Opcode{mask: 0xfff000e0, value: 0xfb300000}: Smlawy32T1,
+	Opcode{mask: 0xfff000c0, value: 0xfbc00080}: Smlalxy32T1,
+	Opcode{mask: 0xfff0f0e0, value: 0xfb20f000}: Smuad32T1,
+	Opcode{mask: 0xfff000e0, value: 0xfb200000}: Smlad32T1,
+	Opcode{mask: 0xfff0f0e0, value: 0xfb40f000}: Smusd32T1,
+	Opcode{mask: 0xfff000e0, value: 0xfb400000}: Smlsd32T1,
+	Opcode{mask: 0xfff000e0, value: 0xfbc000c0}: Smlald32T1,
+	Opcode{mask: 0xfff000f0, value: 0xfbe00060}: Umaal32T1,
+	Opcode{mask: 0xfff000e0, value: 0xfbd000c0}: Smlsld32T1,
+	Opcode{mask: 0xfff0f0e0, value: 0xfb50f000}: Smmul32T1,
+	Opcode{mask: 0xfff000e0, value: 0xfb500000}: Smmla32T1,
+	Opcode{mask: 0xfff000e0, value: 0xfb600000}: Smmls32T1,
+}
+
+/* Floating-point extension instructions, UNDEFINED without an FPU */
+var FPOpcodes32 = map[Opcode]DecodeFunc{
+	Opcode{mask: 0xffb00f50, value: 0xee000a00}: Vmla32T1,
+	Opcode{mask: 0xffb00f50, value: 0xee000a40}: Vmls32T1,
+	Opcode{mask: 0xffb00f50, value: 0xee100a00}: Vnmls32T1,
+	Opcode{mask: 0xffb00f50, value: 0xee100a40}: Vnmla32T1,
+	Opcode{mask: 0xffb00f50, value: 0xee200a00}: Vmul32T1,
+	Opcode{mask: 0xffb00f50, value: 0xee200a40}: Vnmul32T2,
+	Opcode{mask: 0xffb00f50, value: 0xee300a00}: Vadd32T1,
+	Opcode{mask: 0xffb00f50, value: 0xee300a40}: Vsub32T1,
+	Opcode{mask: 0xffb00f50, value: 0xee800a00}: Vdiv32T1,
+	Opcode{mask: 0xffb00f50, value: 0xee900a00}: Vfnms32T1,
+	Opcode{mask: 0xffb00f50, value: 0xee900a40}: Vfnma32T1,
+	Opcode{mask: 0xffb00f50, value: 0xeea00a00}: Vfma32T1,
+	Opcode{mask: 0xffb00f50, value: 0xeea00a40}: Vfms32T1,
+	Opcode{mask: 0xffb00ff0, value: 0xeeb00a00}: VmovImm32T1,
+	Opcode{mask: 0xffbf0fd0, value: 0xeeb00a40}: VmovReg32T1,
+	Opcode{mask: 0xffbf0fd0, value: 0xeeb00ac0}: Vabs32T1,
+	Opcode{mask: 0xffbf0fd0, value: 0xeeb10a40}: Vneg32T1,
+	Opcode{mask: 0xffbf0fd0, value: 0xeeb10ac0}: Vsqrt32T1,
+	Opcode{mask: 0xffbe0f50, value: 0xeeb20a40}: VcvtHalf32T1,
+	Opcode{mask: 0xffbf0f50, value: 0xeeb40a40}: Vcmp32T1,
+	Opcode{mask: 0xffbf0f7f, value: 0xeeb50a40}: Vcmp32T2,
+	Opcode{mask: 0xffbf0f50, value: 0xeeb80a40}: VcvtInt32T1, // To floating-point
+	Opcode{mask: 0xffbe0f50, value: 0xeebc0a40}: VcvtInt32T1, // To integer
+	Opcode{mask: 0xffba0f50, value: 0xeeba0a40}: VcvtFixed32T1,
+	Opcode{mask: 0xfff00fff, value: 0xeef00a10}: Vmrs32T1,
+	Opcode{mask: 0xfff00fff, value: 0xeee00a10}: Vmsr32T1,
+	Opcode{mask: 0xffe00f7f, value: 0xee000a10}: VmovSingle32T1,
+	Opcode{mask: 0xffd00f7f, value: 0xee000b10}: VmovToScalar32T1,
+	Opcode{mask: 0xffd00f7f, value: 0xee100b10}: VmovFromScalar32T1,
+	Opcode{mask: 0xffe00fd0, value: 0xec400a10}: VmovSinglePair32T1,
+	Opcode{mask: 0xffe00fd0, value: 0xec400b10}: VmovDouble32T1,
+	Opcode{mask: 0xff300f00, value: 0xed100b00}: Vldr32T1,
+	Opcode{mask: 0xff300f00, value: 0xed100a00}: Vldr32T2,
+	Opcode{mask: 0xff300f00, value: 0xed000b00}: Vstr32T1,
+	Opcode{mask: 0xff300f00, value: 0xed000a00}: Vstr32T2,
+	Opcode{mask: 0xff900f00, value: 0xec900b00}: Vldm32T1, // Increment After
+	Opcode{mask: 0xff900f00, value: 0xec900a00}: Vldm32T2,
+	Opcode{mask: 0xff900f00, value: 0xec800b00}: Vstm32T1,
+	Opcode{mask: 0xff900f00, value: 0xec800a00}: Vstm32T2,
+	Opcode{mask: 0xffb00f00, value: 0xed300b00}: Vldm32T1, // Decrement Before
+	Opcode{mask: 0xffb00f00, value: 0xed300a00}: Vldm32T2,
+	Opcode{mask: 0xffb00f00, value: 0xed200b00}: Vstm32T1,
+	Opcode{mask: 0xffb00f00, value: 0xed200a00}: Vstm32T2,
+}
+
+/* Coprocessor instructions, after the floating-point extension's in the
+ * same space */
+var CoprocessorOpcodes32 = map[Opcode]DecodeFunc{
+	Opcode{mask: 0xfe100000, value: 0xec000000}: Stc32T1,
+	Opcode{mask: 0xfe100000, value: 0xfc000000}: Stc32T2,
+	Opcode{mask: 0xfe100000, value: 0xec100000}: LdcImm32T1,
+	Opcode{mask: 0xfe100000, value: 0xfc100000}: LdcImm32T2,
+	Opcode{mask: 0xff000010, value: 0xee000000}: Cdp32T1,
+	Opcode{mask: 0xff000010, value: 0xfe000000}: Cdp32T2,
+	Opcode{mask: 0xff100010, value: 0xee000010}: Mcr32T1,
+	Opcode{mask: 0xff100010, value: 0xfe000010}: Mcr32T2,
+	Opcode{mask: 0xff100010, value: 0xee100010}: Mrc32T1,
+	Opcode{mask: 0xff100010, value: 0xfe100010}: Mrc32T2,
+}
@@ -0,0 +1,902 @@
+package core
+
+import "fmt"
+
+/* SADD16
+ * ARM ARM A7.7.119
+ * Encoding T1 */
+type Sadd16T1 InstrFields
+
+func Sadd1632T1(instr FetchedInstr) DecodedInstr {
+	fields, ok := decode_parallel(instr.Uint32())
+	if !ok {
+		return UnpredictableInstr{}
+	}
+
+	return Sadd16T1(fields)
+}
+
+func (instr Sadd16T1) Execute(cpu *CPU) {
+	ParallelAddSubtract(cpu, InstrFields(instr), PARALLEL_ADD16, true, PARALLEL_MODULAR)
+}
+
+func (instr Sadd16T1) String() string {
+	return fmt.Sprintf("sadd16 %s, %s, %s", instr.Rd, instr.Rn, instr.Rm)
+}
+
+/* SASX
+ * ARM ARM A7.7.121
+ * Encoding T1 */
+type SasxT1 InstrFields
+
+func Sasx32T1(instr FetchedInstr) DecodedInstr {
+	fields, ok := decode_parallel(instr.Uint32())
+	if !ok {
+		return UnpredictableInstr{}
+	}
+
+	return SasxT1(fields)
+}
+
+func (instr SasxT1) Execute(cpu *CPU) {
+	ParallelAddSubtract(cpu, InstrFields(instr), PARALLEL_ASX, true, PARALLEL_MODULAR)
+}
+
+func (instr SasxT1) String() string {
+	return fmt.Sprintf("sasx %s, %s, %s", instr.Rd, instr.Rn, instr.Rm)
+}
+
+/* SSAX
+ * ARM ARM A7.7.152
+ * Encoding T1 */
+type SsaxT1 InstrFields
+
+func Ssax32T1(instr FetchedInstr) DecodedInstr {
+	fields, ok := decode_parallel(instr.Uint32())
+	if !ok {
+		return UnpredictableInstr{}
+	}
+
+	return SsaxT1(fields)
+}
+
+func (instr SsaxT1) Execute(cpu *CPU) {
+	ParallelAddSubtract(cpu, InstrFields(instr), PARALLEL_SAX, true, PARALLEL_MODULAR)
+}
+
+func (instr SsaxT1) String() string {
+	return fmt.Sprintf("ssax %s, %s, %s", instr.Rd, instr.Rn, instr.Rm)
+}
+
+/* SSUB16
+ * ARM ARM A7.7.153
+ * Encoding T1 */
+type Ssub16T1 InstrFields
+
+func Ssub1632T1(instr FetchedInstr) DecodedInstr {
+	fields, ok := decode_parallel(instr.Uint32())
+	if !ok {
+		return UnpredictableInstr{}
+	}
+
+	return Ssub16T1(fields)
+}
+
+func (instr Ssub16T1) Execute(cpu *CPU) {
+	ParallelAddSubtract(cpu, InstrFields(instr), PARALLEL_SUB16, true, PARALLEL_MODULAR)
+}
+
+func (instr Ssub16T1) String() string {
+	return fmt.Sprintf("ssub16 %s, %s, %s", instr.Rd, instr.Rn, instr.Rm)
+}
+
+/* SADD8
+ * ARM ARM A7.7.120
+ * Encoding T1 */
+type Sadd8T1 InstrFields
+
+func Sadd832T1(instr FetchedInstr) DecodedInstr {
+	fields, ok := decode_parallel(instr.Uint32())
+	if !ok {
+		return UnpredictableInstr{}
+	}
+
+	return Sadd8T1(fields)
+}
+
+func (instr Sadd8T1) Execute(cpu *CPU) {
+	ParallelAddSubtract(cpu, InstrFields(instr), PARALLEL_ADD8, true, PARALLEL_MODULAR)
+}
+
+func (instr Sadd8T1) String() string {
+	return fmt.Sprintf("sadd8 %s, %s, %s", instr.Rd, instr.Rn, instr.Rm)
+}
+
+/* SSUB8
+ * ARM ARM A7.7.154
+ * Encoding T1 */
+type Ssub8T1 InstrFields
+
+func Ssub832T1(instr FetchedInstr) DecodedInstr {
+	fields, ok := decode_parallel(instr.Uint32())
+	if !ok {
+		return UnpredictableInstr{}
+	}
+
+	return Ssub8T1(fields)
+}
+
+func (instr Ssub8T1) Execute(cpu *CPU) {
+	ParallelAddSubtract(cpu, InstrFields(instr), PARALLEL_SUB8, true, PARALLEL_MODULAR)
+}
+
+func (instr Ssub8T1) String() string {
+	return fmt.Sprintf("ssub8 %s, %s, %s", instr.Rd, instr.Rn, instr.Rm)
+}
+
+/* QADD16
+ * ARM ARM A7.7.101
+ * Encoding T1 */
+type Qadd16T1 InstrFields
+
+func Qadd1632T1(instr FetchedInstr) DecodedInstr {
+	fields, ok := decode_parallel(instr.Uint32())
+	if !ok {
+		return UnpredictableInstr{}
+	}
+
+	return Qadd16T1(fields)
+}
+
+func (instr Qadd16T1) Execute(cpu *CPU) {
+	ParallelAddSubtract(cpu, InstrFields(instr), PARALLEL_ADD16, true, PARALLEL_SATURATING)
+}
+
+func (instr Qadd16T1) String() string {
+	return fmt.Sprintf("qadd16 %s, %s, %s", instr.Rd, instr.Rn, instr.Rm)
+}
+
+/* QASX
+ * ARM ARM A7.7.103
+ * Encoding T1 */
+type QasxT1 InstrFields
+
+func Qasx32T1(instr FetchedInstr) DecodedInstr {
+	fields, ok := decode_parallel(instr.Uint32())
+	if !ok {
+		return UnpredictableInstr{}
+	}
+
+	return QasxT1(fields)
+}
+
+func (instr QasxT1) Execute(cpu *CPU) {
+	ParallelAddSubtract(cpu, InstrFields(instr), PARALLEL_ASX, true, PARALLEL_SATURATING)
+}
+
+func (instr QasxT1) String() string {
+	return fmt.Sprintf("qasx %s, %s, %s", instr.Rd, instr.Rn, instr.Rm)
+}
+
+/* QSAX
+ * ARM ARM A7.7.106
+ * Encoding T1 */
+type QsaxT1 InstrFields
+
+func Qsax32T1(instr FetchedInstr) DecodedInstr {
+	fields, ok := decode_parallel(instr.Uint32())
+	if !ok {
+		return UnpredictableInstr{}
+	}
+
+	return QsaxT1(fields)
+}
+
+func (instr QsaxT1) Execute(cpu *CPU) {
+	ParallelAddSubtract(cpu, InstrFields(instr), PARALLEL_SAX, true, PARALLEL_SATURATING)
+}
+
+func (instr QsaxT1) String() string {
+	return fmt.Sprintf("qsax %s, %s, %s", instr.Rd, instr.Rn, instr.Rm)
+}
+
+/* QSUB16
+ * ARM ARM A7.7.108
+ * Encoding T1 */
+type Qsub16T1 InstrFields
+
+func Qsub1632T1(instr FetchedInstr) DecodedInstr {
+	fields, ok := decode_parallel(instr.Uint32())
+	if !ok {
+		return UnpredictableInstr{}
+	}
+
+	return Qsub16T1(fields)
+}
+
+func (instr Qsub16T1) Execute(cpu *CPU) {
+	ParallelAddSubtract(cpu, InstrFields(instr), PARALLEL_SUB16, true, PARALLEL_SATURATING)
+}
+
+func (instr Qsub16T1) String() string {
+	return fmt.Sprintf("qsub16 %s, %s, %s", instr.Rd, instr.Rn, instr.Rm)
+}
+
+/* QADD8
+ * ARM ARM A7.7.102
+ * Encoding T1 */
+type Qadd8T1 InstrFields
+
+func Qadd832T1(instr FetchedInstr) DecodedInstr {
+	fields, ok := decode_parallel(instr.Uint32())
+	if !ok {
+		return UnpredictableInstr{}
+	}
+
+	return Qadd8T1(fields)
+}
+
+func (instr Qadd8T1) Execute(cpu *CPU) {
+	ParallelAddSubtract(cpu, InstrFields(instr), PARALLEL_ADD8, true, PARALLEL_SATURATING)
+}
+
+func (instr Qadd8T1) String() string {
+	return fmt.Sprintf("qadd8 %s, %s, %s", instr.Rd, instr.Rn, instr.Rm)
+}
+
+/* QSUB8
+ * ARM ARM A7.7.109
+ * Encoding T1 */
+type Qsub8T1 InstrFields
+
+func Qsub832T1(instr FetchedInstr) DecodedInstr {
+	fields, ok := decode_parallel(instr.Uint32())
+	if !ok {
+		return UnpredictableInstr{}
+	}
+
+	return Qsub8T1(fields)
+}
+
+func (instr Qsub8T1) Execute(cpu *CPU) {
+	ParallelAddSubtract(cpu, InstrFields(instr), PARALLEL_SUB8, true, PARALLEL_SATURATING)
+}
+
+func (instr Qsub8T1) String() string {
+	return fmt.Sprintf("qsub8 %s, %s, %s", instr.Rd, instr.Rn, instr.Rm)
+}
+
+/* SHADD16
+ * ARM ARM A7.7.128
+ * Encoding T1 */
+type Shadd16T1 InstrFields
+
+func Shadd1632T1(instr FetchedInstr) DecodedInstr {
+	fields, ok := decode_parallel(instr.Uint32())
+	if !ok {
+		return UnpredictableInstr{}
+	}
+
+	return Shadd16T1(fields)
+}
+
+func (instr Shadd16T1) Execute(cpu *CPU) {
+	ParallelAddSubtract(cpu, InstrFields(instr), PARALLEL_ADD16, true, PARALLEL_HALVING)
+}
+
+func (instr Shadd16T1) String() string {
+	return fmt.Sprintf("shadd16 %s, %s, %s", instr.Rd, instr.Rn, instr.Rm)
+}
+
+/* SHASX
+ * ARM ARM A7.7.130
+ * Encoding T1 */
+type ShasxT1 InstrFields
+
+func Shasx32T1(instr FetchedInstr) DecodedInstr {
+	fields, ok := decode_parallel(instr.Uint32())
+	if !ok {
+		return UnpredictableInstr{}
+	}
+
+	return ShasxT1(fields)
+}
+
+func (instr ShasxT1) Execute(cpu *CPU) {
+	ParallelAddSubtract(cpu, InstrFields(instr), PARALLEL_ASX, true, PARALLEL_HALVING)
+}
+
+func (instr ShasxT1) String() string {
+	return fmt.Sprintf("shasx %s, %s, %s", instr.Rd, instr.Rn, instr.Rm)
+}
+
+/* SHSAX
+ * ARM ARM A7.7.131
+ * Encoding T1 */
+type ShsaxT1 InstrFields
+
+func Shsax32T1(instr FetchedInstr) DecodedInstr {
+	fields, ok := decode_parallel(instr.Uint32())
+	if !ok {
+		return UnpredictableInstr{}
+	}
+
+	return ShsaxT1(fields)
+}
+
+func (instr ShsaxT1) Execute(cpu *CPU) {
+	ParallelAddSubtract(cpu, InstrFields(instr), PARALLEL_SAX, true, PARALLEL_HALVING)
+}
+
+func (instr ShsaxT1) String() string {
+	return fmt.Sprintf("shsax %s, %s, %s", instr.Rd, instr.Rn, instr.Rm)
+}
+
+/* SHSUB16
+ * ARM ARM A7.7.132
+ * Encoding T1 */
+type Shsub16T1 InstrFields
+
+func Shsub1632T1(instr FetchedInstr) DecodedInstr {
+	fields, ok := decode_parallel(instr.Uint32())
+	if !ok {
+		return UnpredictableInstr{}
+	}
+
+	return Shsub16T1(fields)
+}
+
+func (instr Shsub16T1) Execute(cpu *CPU) {
+	ParallelAddSubtract(cpu, InstrFields(instr), PARALLEL_SUB16, true, PARALLEL_HALVING)
+}
+
+func (instr Shsub16T1) String() string {
+	return fmt.Sprintf("shsub16 %s, %s, %s", instr.Rd, instr.Rn, instr.Rm)
+}
+
+/* SHADD8
+ * ARM ARM A7.7.129
+ * Encoding T1 */
+type Shadd8T1 InstrFields
+
+func Shadd832T1(instr FetchedInstr) DecodedInstr {
+	fields, ok := decode_parallel(instr.Uint32())
+	if !ok {
+		return UnpredictableInstr{}
+	}
+
+	return Shadd8T1(fields)
+}
+
+func (instr Shadd8T1) Execute(cpu *CPU) {
+	ParallelAddSubtract(cpu, InstrFields(instr), PARALLEL_ADD8, true, PARALLEL_HALVING)
+}
+
+func (instr Shadd8T1) String() string {
+	return fmt.Sprintf("shadd8 %s, %s, %s", instr.Rd, instr.Rn, instr.Rm)
+}
+
+/* SHSUB8
+ * ARM ARM A7.7.133
+ * Encoding T1 */
+type Shsub8T1 InstrFields
+
+func Shsub832T1(instr FetchedInstr) DecodedInstr {
+	fields, ok := decode_parallel(instr.Uint32())
+	if !ok {
+		return UnpredictableInstr{}
+	}
+
+	return Shsub8T1(fields)
+}
+
+func (instr Shsub8T1) Execute(cpu *CPU) {
+	ParallelAddSubtract(cpu, InstrFields(instr), PARALLEL_SUB8, true, PARALLEL_HALVING)
+}
+
+func (instr Shsub8T1) String() string {
+	return fmt.Sprintf("shsub8 %s, %s, %s", instr.Rd, instr.Rn, instr.Rm)
+}
+
+/* UADD16
+ * ARM ARM A7.7.187
+ * Encoding T1 */
+type Uadd16T1 InstrFields
+
+func Uadd1632T1(instr FetchedInstr) DecodedInstr {
+	fields, ok := decode_parallel(instr.Uint32())
+	if !ok {
+		return UnpredictableInstr{}
+	}
+
+	return Uadd16T1(fields)
+}
+
+func (instr Uadd16T1) Execute(cpu *CPU) {
+	ParallelAddSubtract(cpu, InstrFields(instr), PARALLEL_ADD16, false, PARALLEL_MODULAR)
+}
+
+func (instr Uadd16T1) String() string {
+	return fmt.Sprintf("uadd16 %s, %s, %s", instr.Rd, instr.Rn, instr.Rm)
+}
+
+/* UASX
+ * ARM ARM A7.7.189
+ * Encoding T1 */
+type UasxT1 InstrFields
+
+func Uasx32T1(instr FetchedInstr) DecodedInstr {
+	fields, ok := decode_parallel(instr.Uint32())
+	if !ok {
+		return UnpredictableInstr{}
+	}
+
+	return UasxT1(fields)
+}
+
+func (instr UasxT1) Execute(cpu *CPU) {
+	ParallelAddSubtract(cpu, InstrFields(instr), PARALLEL_ASX, false, PARALLEL_MODULAR)
+}
+
+func (instr UasxT1) String() string {
+	return fmt.Sprintf("uasx %s, %s, %s", instr.Rd, instr.Rn, instr.Rm)
+}
+
+/* USAX
+ * ARM ARM A7.7.212
+ * Encoding T1 */
+type UsaxT1 InstrFields
+
+func Usax32T1(instr FetchedInstr) DecodedInstr {
+	fields, ok := decode_parallel(instr.Uint32())
+	if !ok {
+		return UnpredictableInstr{}
+	}
+
+	return UsaxT1(fields)
+}
+
+func (instr UsaxT1) Execute(cpu *CPU) {
+	ParallelAddSubtract(cpu, InstrFields(instr), PARALLEL_SAX, false, PARALLEL_MODULAR)
+}
+
+func (instr UsaxT1) String() string {
+	return fmt.Sprintf("usax %s, %s, %s", instr.Rd, instr.Rn, instr.Rm)
+}
+
+/* USUB16
+ * ARM ARM A7.7.213
+ * Encoding T1 */
+type Usub16T1 InstrFields
+
+func Usub1632T1(instr FetchedInstr) DecodedInstr {
+	fields, ok := decode_parallel(instr.Uint32())
+	if !ok {
+		return UnpredictableInstr{}
+	}
+
+	return Usub16T1(fields)
+}
+
+func (instr Usub16T1) Execute(cpu *CPU) {
+	ParallelAddSubtract(cpu, InstrFields(instr), PARALLEL_SUB16, false, PARALLEL_MODULAR)
+}
+
+func (instr Usub16T1) String() string {
+	return fmt.Sprintf("usub16 %s, %s, %s", instr.Rd, instr.Rn, instr.Rm)
+}
+
+/* UADD8
+ * ARM ARM A7.7.188
+ * Encoding T1 */
+type Uadd8T1 InstrFields
+
+func Uadd832T1(instr FetchedInstr) DecodedInstr {
+	fields, ok := decode_parallel(instr.Uint32())
+	if !ok {
+		return UnpredictableInstr{}
+	}
+
+	return Uadd8T1(fields)
+}
+
+func (instr Uadd8T1) Execute(cpu *CPU) {
+	ParallelAddSubtract(cpu, InstrFields(instr), PARALLEL_ADD8, false, PARALLEL_MODULAR)
+}
+
+func (instr Uadd8T1) String() string {
+	return fmt.Sprintf("uadd8 %s, %s, %s", instr.Rd, instr.Rn, instr.Rm)
+}
+
+/* USUB8
+ * ARM ARM A7.7.214
+ * Encoding T1 */
+type Usub8T1 InstrFields
+
+func Usub832T1(instr FetchedInstr) DecodedInstr {
+	fields, ok := decode_parallel(instr.Uint32())
+	if !ok {
+		return UnpredictableInstr{}
+	}
+
+	return Usub8T1(fields)
+}
+
+func (instr Usub8T1) Execute(cpu *CPU) {
+	ParallelAddSubtract(cpu, InstrFields(instr), PARALLEL_SUB8, false, PARALLEL_MODULAR)
+}
+
+func (instr Usub8T1) String() string {
+	return fmt.Sprintf("usub8 %s, %s, %s", instr.Rd, instr.Rn, instr.Rm)
+}
+
+/* UQADD16
+ * ARM ARM A7.7.202
+ * Encoding T1 */
+type Uqadd16T1 InstrFields
+
+func Uqadd1632T1(instr FetchedInstr) DecodedInstr {
+	fields, ok := decode_parallel(instr.Uint32())
+	if !ok {
+		return UnpredictableInstr{}
+	}
+
+	return Uqadd16T1(fields)
+}
+
+func (instr Uqadd16T1) Execute(cpu *CPU) {
+	ParallelAddSubtract(cpu, InstrFields(instr), PARALLEL_ADD16, false, PARALLEL_SATURATING)
+}
+
+func (instr Uqadd16T1) String() string {
+	return fmt.Sprintf("uqadd16 %s, %s, %s", instr.Rd, instr.Rn, instr.Rm)
+}
+
+/* UQASX
+ * ARM ARM A7.7.204
+ * Encoding T1 */
+type UqasxT1 InstrFields
+
+func Uqasx32T1(instr FetchedInstr) DecodedInstr {
+	fields, ok := decode_parallel(instr.Uint32())
+	if !ok {
+		return UnpredictableInstr{}
+	}
+
+	return UqasxT1(fields)
+}
+
+func (instr UqasxT1) Execute(cpu *CPU) {
+	ParallelAddSubtract(cpu, InstrFields(instr), PARALLEL_ASX, false, PARALLEL_SATURATING)
+}
+
+func (instr UqasxT1) String() string {
+	return fmt.Sprintf("uqasx %s, %s, %s", instr.Rd, instr.Rn, instr.Rm)
+}
+
+/* UQSAX
+ * ARM ARM A7.7.205
+ * Encoding T1 */
+type UqsaxT1 InstrFields
+
+func Uqsax32T1(instr FetchedInstr) DecodedInstr {
+	fields, ok := decode_parallel(instr.Uint32())
+	if !ok {
+		return UnpredictableInstr{}
+	}
+
+	return UqsaxT1(fields)
+}
+
+func (instr UqsaxT1) Execute(cpu *CPU) {
+	ParallelAddSubtract(cpu, InstrFields(instr), PARALLEL_SAX, false, PARALLEL_SATURATING)
+}
+
+func (instr UqsaxT1) String() string {
+	return fmt.Sprintf("uqsax %s, %s, %s", instr.Rd, instr.Rn, instr.Rm)
+}
+
+/* UQSUB16
+ * ARM ARM A7.7.206
+ * Encoding T1 */
+type Uqsub16T1 InstrFields
+
+func Uqsub1632T1(instr FetchedInstr) DecodedInstr {
+	fields, ok := decode_parallel(instr.Uint32())
+	if !ok {
+		return UnpredictableInstr{}
+	}
+
+	return Uqsub16T1(fields)
+}
+
+func (instr Uqsub16T1) Execute(cpu *CPU) {
+	ParallelAddSubtract(cpu, InstrFields(instr), PARALLEL_SUB16, false, PARALLEL_SATURATING)
+}
+
+func (instr Uqsub16T1) String() string {
+	return fmt.Sprintf("uqsub16 %s, %s, %s", instr.Rd, instr.Rn, instr.Rm)
+}
+
+/* UQADD8
+ * ARM ARM A7.7.203
+ * Encoding T1 */
+type Uqadd8T1 InstrFields
+
+func Uqadd832T1(instr FetchedInstr) DecodedInstr {
+	fields, ok := decode_parallel(instr.Uint32())
+	if !ok {
+		return UnpredictableInstr{}
+	}
+
+	return Uqadd8T1(fields)
+}
+
+func (instr Uqadd8T1) Execute(cpu *CPU) {
+	ParallelAddSubtract(cpu, InstrFields(instr), PARALLEL_ADD8, false, PARALLEL_SATURATING)
+}
+
+func (instr Uqadd8T1) String() string {
+	return fmt.Sprintf("uqadd8 %s, %s, %s", instr.Rd, instr.Rn, instr.Rm)
+}
+
+/* UQSUB8
+ * ARM ARM A7.7.207
+ * Encoding T1 */
+type Uqsub8T1 InstrFields
+
+func Uqsub832T1(instr FetchedInstr) DecodedInstr {
+	fields, ok := decode_parallel(instr.Uint32())
+	if !ok {
+		return UnpredictableInstr{}
+	}
+
+	return Uqsub8T1(fields)
+}
+
+func (instr Uqsub8T1) Execute(cpu *CPU) {
+	ParallelAddSubtract(cpu, InstrFields(instr), PARALLEL_SUB8, false, PARALLEL_SATURATING)
+}
+
+func (instr Uqsub8T1) String() string {
+	return fmt.Sprintf("uqsub8 %s, %s, %s", instr.Rd, instr.Rn, instr.Rm)
+}
+
+/* UHADD16
+ * ARM ARM A7.7.193
+ * Encoding T1 */
+type Uhadd16T1 InstrFields
+
+func Uhadd1632T1(instr FetchedInstr) DecodedInstr {
+	fields, ok := decode_parallel(instr.Uint32())
+	if !ok {
+		return UnpredictableInstr{}
+	}
+
+	return Uhadd16T1(fields)
+}
+
+func (instr Uhadd16T1) Execute(cpu *CPU) {
+	ParallelAddSubtract(cpu, InstrFields(instr), PARALLEL_ADD16, false, PARALLEL_HALVING)
+}
+
+func (instr Uhadd16T1) String() string {
+	return fmt.Sprintf("uhadd16 %s, %s, %s", instr.Rd, instr.Rn, instr.Rm)
+}
+
+/* UHASX
+ * ARM ARM A7.7.195
+ * Encoding T1 */
+type UhasxT1 InstrFields
+
+func Uhasx32T1(instr FetchedInstr) DecodedInstr {
+	fields, ok := decode_parallel(instr.Uint32())
+	if !ok {
+		return UnpredictableInstr{}
+	}
+
+	return UhasxT1(fields)
+}
+
+func (instr UhasxT1) Execute(cpu *CPU) {
+	ParallelAddSubtract(cpu, InstrFields(instr), PARALLEL_ASX, false, PARALLEL_HALVING)
+}
+
+func (instr UhasxT1) String() string {
+	return fmt.Sprintf("uhasx %s, %s, %s", instr.Rd, instr.Rn, instr.Rm)
+}
+
+/* UHSAX
+ * ARM ARM A7.7.196
+ * Encoding T1 */
+type UhsaxT1 InstrFields
+
+func Uhsax32T1(instr FetchedInstr) DecodedInstr {
+	fields, ok := decode_parallel(instr.Uint32())
+	if !ok {
+		return UnpredictableInstr{}
+	}
+
+	return UhsaxT1(fields)
+}
+
+func (instr UhsaxT1) Execute(cpu *CPU) {
+	ParallelAddSubtract(cpu, InstrFields(instr), PARALLEL_SAX, false, PARALLEL_HALVING)
+}
+
+func (instr UhsaxT1) String() string {
+	return fmt.Sprintf("uhsax %s, %s, %s", instr.Rd, instr.Rn, instr.Rm)
+}
+
+/* UHSUB16
+ * ARM ARM A7.7.197
+ * Encoding T1 */
+type Uhsub16T1 InstrFields
+
+func Uhsub1632T1(instr FetchedInstr) DecodedInstr {
+	fields, ok := decode_parallel(instr.Uint32())
+	if !ok {
+		return UnpredictableInstr{}
+	}
+
+	return Uhsub16T1(fields)
+}
+
+func (instr Uhsub16T1) Execute(cpu *CPU) {
+	ParallelAddSubtract(cpu, InstrFields(instr), PARALLEL_SUB16, false, PARALLEL_HALVING)
+}
+
+func (instr Uhsub16T1) String() string {
+	return fmt.Sprintf("uhsub16 %s, %s, %s", instr.Rd, instr.Rn, instr.Rm)
+}
+
+/* UHADD8
+ * ARM ARM A7.7.194
+ * Encoding T1 */
+type Uhadd8T1 InstrFields
+
+func Uhadd832T1(instr FetchedInstr) DecodedInstr {
+	fields, ok := decode_parallel(instr.Uint32())
+	if !ok {
+		return UnpredictableInstr{}
+	}
+
+	return Uhadd8T1(fields)
+}
+
+func (instr Uhadd8T1) Execute(cpu *CPU) {
+	ParallelAddSubtract(cpu, InstrFields(instr), PARALLEL_ADD8, false, PARALLEL_HALVING)
+}
+
+func (instr Uhadd8T1) String() string {
+	return fmt.Sprintf("uhadd8 %s, %s, %s", instr.Rd, instr.Rn, instr.Rm)
+}
+
+/* UHSUB8
+ * ARM ARM A7.7.198
+ * Encoding T1 */
+type Uhsub8T1 InstrFields
+
+func Uhsub832T1(instr FetchedInstr) DecodedInstr {
+	fields, ok := decode_parallel(instr.Uint32())
+	if !ok {
+		return UnpredictableInstr{}
+	}
+
+	return Uhsub8T1(fields)
+}
+
+func (instr Uhsub8T1) Execute(cpu *CPU) {
+	ParallelAddSubtract(cpu, InstrFields(instr), PARALLEL_SUB8, false, PARALLEL_HALVING)
+}
+
+func (instr Uhsub8T1) String() string {
+	return fmt.Sprintf("uhsub8 %s, %s, %s", instr.Rd, instr.Rn, instr.Rm)
+}
+
+/* SEL
+ * ARM ARM A7.7.126
+ * Encoding T1 */
+type SelT1 InstrFields
+
+func Sel32T1(instr FetchedInstr) DecodedInstr {
+	fields, ok := decode_parallel(instr.Uint32())
+	if !ok {
+		return UnpredictableInstr{}
+	}
+
+	return SelT1(fields)
+}
+
+func (instr SelT1) Execute(cpu *CPU) {
+	Select(cpu, InstrFields(instr))
+}
+
+func (instr SelT1) String() string {
+	return fmt.Sprintf("sel %s, %s, %s", instr.Rd, instr.Rn, instr.Rm)
+}
+
+/* PKHBT, PKHTB
+ * ARM ARM A7.7.92
+ * Encoding T1 */
+type PkhT1 InstrFields
+
+func Pkh32T1(instr FetchedInstr) DecodedInstr {
+	raw_instr := instr.Uint32()
+
+	fields, ok := decode_parallel(raw_instr)
+	if !ok {
+		return UnpredictableInstr{}
+	}
+
+	imm3 := (raw_instr >> 12) & 0x7
+	imm2 := (raw_instr >> 6) & 0x3
+	tb := (raw_instr >> 5) & 0x1
+	fields.Shift = DecodeImmShift(tb<<1, (imm3<<2)|imm2)
+
+	return PkhT1(fields)
+}
+
+func (instr PkhT1) Execute(cpu *CPU) {
+	PackHalfword(cpu, InstrFields(instr))
+}
+
+func (instr PkhT1) String() string {
+	mnemonic := "pkhbt"
+	if instr.Shift.srtype == SRType_ASR {
+		mnemonic = "pkhtb"
+	}
+
+	return fmt.Sprintf("%s %s, %s, %s", mnemonic, instr.Rd, instr.Rn, shifted_operand(instr.Rm, instr.Shift))
+}
+
+/* USAD8
+ * ARM ARM A7.7.208
+ * Encoding T1 */
+type Usad8T1 MultiplyFields
+
+func Usad832T1(instr FetchedInstr) DecodedInstr {
+	fields := decode_multiply(instr.Uint32())
+	fields.Ra = 0
+
+	if bad_multiply(fields, false) {
+		return UnpredictableInstr{}
+	}
+
+	return Usad8T1(fields)
+}
+
+func (instr Usad8T1) Execute(cpu *CPU) {
+	SumAbsoluteDifferences(cpu, MultiplyFields(instr), false)
+}
+
+func (instr Usad8T1) String() string {
+	return fmt.Sprintf("usad8 %s, %s, %s", instr.Rd, instr.Rn, instr.Rm)
+}
+
+/* USADA8
+ * ARM ARM A7.7.209
+ * Encoding T1 */
+type Usada8T1 MultiplyFields
+
+func Usada832T1(instr FetchedInstr) DecodedInstr {
+	fields := decode_multiply(instr.Uint32())
+
+	if fields.Ra == PC {
+		return Usad832T1(instr)
+	}
+
+	if bad_multiply(fields, false) || fields.Ra == SP {
+		return UnpredictableInstr{}
+	}
+
+	return Usada8T1(fields)
+}
+
+func (instr Usada8T1) Execute(cpu *CPU) {
+	SumAbsoluteDifferences(cpu, MultiplyFields(instr), true)
+}
+
+func (instr Usada8T1) String() string {
+	return fmt.Sprintf("usada8 %s, %s, %s, %s", instr.Rd, instr.Rn, instr.Rm, instr.Ra)
+}
@@ -0,0 +1,194 @@
+package core
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestIdentifySadd8T1(t *testing.T) {
+	cases := []IdentifyCase{
+		{instr: FetchedInstr32(0xfa81f002), instr_valid: true},  // sadd8 r0, r1, r2
+		{instr: FetchedInstr32(0xfa81f012), instr_valid: false}, // qadd8 r0, r1, r2
+		{instr: FetchedInstr32(0xfa81f042), instr_valid: false}, // uadd8 r0, r1, r2
+		{instr: FetchedInstr32(0xfa91f002), instr_valid: false}, // sadd16 r0, r1, r2
+	}
+
+	test_identify(t, cases, reflect.TypeOf(Sadd8T1{}))
+}
+
+func TestDecodeParallel(t *testing.T) {
+	cases := []DecodeCase{
+		// uqsub16 r0, r1, r2
+		{instr: FetchedInstr32(0xfad1f052), decoded: Uqsub16T1{Rd: 0, Rn: 1, Rm: 2, setflags: NEVER}},
+		// uqsub16 r0, sp, r2
+		{instr: FetchedInstr32(0xfaddf052), decoded: UnpredictableInstr{}},
+	}
+
+	test_decode(t, cases, Uqsub1632T1)
+
+	cases = []DecodeCase{
+		// sel r0, r1, r2
+		{instr: FetchedInstr32(0xfaa1f082), decoded: SelT1{Rd: 0, Rn: 1, Rm: 2, setflags: NEVER}},
+	}
+
+	test_decode(t, cases, Sel32T1)
+
+	cases = []DecodeCase{
+		// usada8 r0, r1, r2, r3
+		{instr: FetchedInstr32(0xfb713002), decoded: Usada8T1{Rd: 0, Rn: 1, Rm: 2, Ra: 3}},
+		// usad8 r0, r1, r2
+		{instr: FetchedInstr32(0xfb71f002), decoded: Usad8T1{Rd: 0, Rn: 1, Rm: 2}},
+	}
+
+	test_decode(t, cases, Usada832T1)
+
+	cases = []DecodeCase{
+		// pkhbt lr, r3, r8
+		{instr: FetchedInstr32(0xeac30e08), decoded: PkhT1{Rd: LR, Rn: 3, Rm: 8,
+			Shift: Shift{srtype: SRType_LSL, amount: 0}, setflags: NEVER}},
+		// pkhtb r2, r5, lr, asr #20
+		{instr: FetchedInstr32(0xeac5522e), decoded: PkhT1{Rd: 2, Rn: 5, Rm: LR,
+			Shift: Shift{srtype: SRType_ASR, amount: 20}, setflags: NEVER}},
+		// pkhtb r0, r1, r2, asr #32
+		{instr: FetchedInstr32(0xeac10022), decoded: PkhT1{Rd: 0, Rn: 1, Rm: 2,
+			Shift: Shift{srtype: SRType_ASR, amount: 32}, setflags: NEVER}},
+		// pkhbt r0, r1, sp
+		{instr: FetchedInstr32(0xeac1000d), decoded: UnpredictableInstr{}},
+	}
+
+	test_decode(t, cases, Pkh32T1)
+}
+
+func TestDecodeProfile(t *testing.T) {
+	cases := []struct {
+		instr FetchedInstr
+		dsp   bool
+	}{
+		{instr: FetchedInstr32(0xfa81f002), dsp: true},  // sadd8 r0, r1, r2
+		{instr: FetchedInstr32(0xfaa1f082), dsp: true},  // sel r0, r1, r2
+		{instr: FetchedInstr32(0xfa41f0a2), dsp: true},  // sxtab r0, r1, r2, ror #16
+		{instr: FetchedInstr32(0xfa4ff091), dsp: false}, // sxtb.w r0, r1, ror #8
+		{instr: FetchedInstr32(0xfb820103), dsp: false}, // smull r0, r1, r2, r3
+		{instr: FetchedInstr32(0xfb213002), dsp: true},  // smlad r0, r1, r2, r3
+		{instr: FetchedInstr32(0xfbee2063), dsp: true},  // umaal r2, r0, lr, r3
+		{instr: FetchedInstr32(0xfbe20103), dsp: false}, // umlal r0, r1, r2, r3
+		{instr: FetchedInstr32(0xfa82f081), dsp: true},  // qadd r0, r1, r2
+		{instr: FetchedInstr32(0xf3210007), dsp: true},  // ssat16 r0, #8, r1
+		{instr: FetchedInstr32(0xf3210047), dsp: false}, // ssat r0, #8, r1, asr #1
+		{instr: FetchedInstr32(0xeac30e08), dsp: true},  // pkhbt lr, r3, r8
+	}
+
+	for _, test := range cases {
+		if _, err := test.instr.DecodeFor(PROFILE_ARMV7EM); err != nil {
+			t.Errorf("%v: unexpected error on ARMv7E-M: %v", test.instr, err)
+		}
+
+		_, err := test.instr.DecodeFor(PROFILE_ARMV7M)
+		if test.dsp && err != ErrUndefinedInstruction {
+			t.Errorf("%v: expected UNDEFINED on ARMv7-M, got %v", test.instr, err)
+		} else if !test.dsp && err != nil {
+			t.Errorf("%v: unexpected error on ARMv7-M: %v", test.instr, err)
+		}
+	}
+}
+
+func TestExecuteParallel(t *testing.T) {
+	cases := []ExecuteCase{
+		// sadd16 r0, r1, r2
+		{instr: Sadd16T1{Rd: 0, Rn: 1, Rm: 2, setflags: NEVER},
+			regs:     Registers{r: GeneralRegs{0, 0x7fff0001, 0x0001fffe}},
+			expected: Registers{r: GeneralRegs{0x8000ffff, 0x7fff0001, 0x0001fffe}, Apsr: Apsr{GE: 0xc}}},
+		// uadd8 r0, r1, r2
+		{instr: Uadd8T1{Rd: 0, Rn: 1, Rm: 2, setflags: NEVER},
+			regs:     Registers{r: GeneralRegs{0, 0xff80017f, 0x01800101}},
+			expected: Registers{r: GeneralRegs{0x00000280, 0xff80017f, 0x01800101}, Apsr: Apsr{GE: 0xc}}},
+		// qadd8 r0, r1, r2
+		{instr: Qadd8T1{Rd: 0, Rn: 1, Rm: 2, setflags: NEVER},
+			regs:     Registers{r: GeneralRegs{0, 0x7f80107f, 0x01ff1001}, Apsr: Apsr{GE: 0x5}},
+			expected: Registers{r: GeneralRegs{0x7f80207f, 0x7f80107f, 0x01ff1001}, Apsr: Apsr{GE: 0x5}}},
+		// uqsub16 r0, r1, r2
+		{instr: Uqsub16T1{Rd: 0, Rn: 1, Rm: 2, setflags: NEVER},
+			regs:     Registers{r: GeneralRegs{0, 0x00051000, 0x00100001}},
+			expected: Registers{r: GeneralRegs{0x00000fff, 0x00051000, 0x00100001}}},
+		// shadd8 r0, r1, r2
+		{instr: Shadd8T1{Rd: 0, Rn: 1, Rm: 2, setflags: NEVER},
+			regs:     Registers{r: GeneralRegs{0, 0x7f80ff02, 0x7f80ff04}},
+			expected: Registers{r: GeneralRegs{0x7f80ff03, 0x7f80ff02, 0x7f80ff04}}},
+		// uhsub16 r0, r1, r2
+		{instr: Uhsub16T1{Rd: 0, Rn: 1, Rm: 2, setflags: NEVER},
+			regs:     Registers{r: GeneralRegs{0, 0x00000010, 0x00010004}},
+			expected: Registers{r: GeneralRegs{0xffff0006, 0x00000010, 0x00010004}}},
+		// sasx r0, r1, r2
+		{instr: SasxT1{Rd: 0, Rn: 1, Rm: 2, setflags: NEVER},
+			regs:     Registers{r: GeneralRegs{0, 0x00050003, 0x00040002}},
+			expected: Registers{r: GeneralRegs{0x0007ffff, 0x00050003, 0x00040002}, Apsr: Apsr{GE: 0xc}}},
+		// usax r0, r1, r2
+		{instr: UsaxT1{Rd: 0, Rn: 1, Rm: 2, setflags: NEVER},
+			regs:     Registers{r: GeneralRegs{0, 0x0005fffd, 0x00040002}},
+			expected: Registers{r: GeneralRegs{0x00030001, 0x0005fffd, 0x00040002}, Apsr: Apsr{GE: 0xf}}},
+		// usub8 r0, r1, r2
+		{instr: Usub8T1{Rd: 0, Rn: 1, Rm: 2, setflags: NEVER},
+			regs:     Registers{r: GeneralRegs{0, 0x01020304, 0x02020202}, Apsr: Apsr{GE: 0x1}},
+			expected: Registers{r: GeneralRegs{0xff000102, 0x01020304, 0x02020202}, Apsr: Apsr{GE: 0x7}}},
+	}
+
+	test_execute(t, cases)
+}
+
+func TestExecutePkh(t *testing.T) {
+	cases := []ExecuteCase{
+		// pkhbt r0, r1, r2, lsl #16
+		{instr: PkhT1{Rd: 0, Rn: 1, Rm: 2, Shift: Shift{srtype: SRType_LSL, amount: 16}, setflags: NEVER},
+			regs:     Registers{r: GeneralRegs{0, 0x11223344, 0xaabbccdd}},
+			expected: Registers{r: GeneralRegs{0xccdd3344, 0x11223344, 0xaabbccdd}}},
+		// pkhtb r0, r1, r2, asr #16
+		{instr: PkhT1{Rd: 0, Rn: 1, Rm: 2, Shift: Shift{srtype: SRType_ASR, amount: 16}, setflags: NEVER},
+			regs:     Registers{r: GeneralRegs{0, 0x11223344, 0xaabbccdd}},
+			expected: Registers{r: GeneralRegs{0x1122aabb, 0x11223344, 0xaabbccdd}}},
+		// pkhtb r0, r1, r2, asr #32
+		{instr: PkhT1{Rd: 0, Rn: 1, Rm: 2, Shift: Shift{srtype: SRType_ASR, amount: 32}, setflags: NEVER},
+			regs:     Registers{r: GeneralRegs{0, 0x11223344, 0x80000000}},
+			expected: Registers{r: GeneralRegs{0x1122ffff, 0x11223344, 0x80000000}}},
+	}
+
+	test_execute(t, cases)
+}
+
+func TestPkhString(t *testing.T) {
+	cases := []struct {
+		instr    FetchedInstr
+		expected string
+	}{
+		{instr: FetchedInstr32(0xeac30e08), expected: "pkhbt lr, r3, r8"},
+		{instr: FetchedInstr32(0xeac5522e), expected: "pkhtb r2, r5, lr, asr #20"},
+		{instr: FetchedInstr32(0xeac14402), expected: "pkhbt r4, r1, r2, lsl #16"},
+	}
+
+	for _, test := range cases {
+		decoded, err := test.instr.Decode()
+		if err != nil {
+			t.Errorf("%v: %v", test.instr, err)
+		} else if actual := Disassemble(decoded, 0); actual != test.expected {
+			t.Errorf("%v: %q, expected %q", test.instr, actual, test.expected)
+		}
+	}
+}
+
+func TestExecuteSelUsad8(t *testing.T) {
+	cases := []ExecuteCase{
+		// sel r0, r1, r2
+		{instr: SelT1{Rd: 0, Rn: 1, Rm: 2, setflags: NEVER},
+			regs:     Registers{r: GeneralRegs{0, 0x11223344, 0xaabbccdd}, Apsr: Apsr{GE: 0x5}},
+			expected: Registers{r: GeneralRegs{0xaa22cc44, 0x11223344, 0xaabbccdd}, Apsr: Apsr{GE: 0x5}}},
+		// usad8 r0, r1, r2
+		{instr: Usad8T1{Rd: 0, Rn: 1, Rm: 2},
+			regs:     Registers{r: GeneralRegs{0, 0x01020304, 0x04030201}},
+			expected: Registers{r: GeneralRegs{8, 0x01020304, 0x04030201}}},
+		// usada8 r0, r1, r2, r3
+		{instr: Usada8T1{Rd: 0, Rn: 1, Rm: 2, Ra: 3},
+			regs:     Registers{r: GeneralRegs{0, 0x01020304, 0x04030201, 100}},
+			expected: Registers{r: GeneralRegs{108, 0x01020304, 0x04030201, 100}}},
+	}
+
+	test_execute(t, cases)
+}
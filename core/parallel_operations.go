@@ -0,0 +1,164 @@
+package core
+
+/* Lane arrangement of the parallel add and subtract instructions */
+type ParallelOp uint8
+
+const (
+	PARALLEL_ADD16 ParallelOp = iota // Add halfwords
+	PARALLEL_ASX                     // Add high halfwords, subtract low halfwords, exchanging Rm's halfwords
+	PARALLEL_SAX                     // Subtract high halfwords, add low halfwords, exchanging Rm's halfwords
+	PARALLEL_SUB16                   // Subtract halfwords
+	PARALLEL_ADD8                    // Add bytes
+	PARALLEL_SUB8                    // Subtract bytes
+)
+
+/* What the parallel add and subtract instructions do with each result */
+type ParallelMode uint8
+
+const (
+	PARALLEL_MODULAR    ParallelMode = iota // Truncate, setting the GE flags (SADD16, UADD16, ...)
+	PARALLEL_SATURATING                     // Saturate (QADD16, UQADD16, ...)
+	PARALLEL_HALVING                        // Halve (SHADD16, UHADD16, ...)
+)
+
+/* Extract lane i, of size bits, of x */
+func lane(x uint32, i uint8, size uint8, signed bool) int64 {
+	value := (x >> (i * size)) & ((1 << size) - 1)
+	if signed {
+		return int64(int32(SignExtend(value, size)))
+	}
+
+	return int64(value)
+}
+
+/* Perform parallel add and subtract instructions, treating Rn and Rm as
+ * packed bytes or halfwords
+ * ARM ARM A5.3.13, A5.3.14 */
+func ParallelAddSubtract(cpu *CPU, instr InstrFields, op ParallelOp, signed bool, mode ParallelMode) {
+	n := cpu.R(instr.Rn)
+	m := cpu.R(instr.Rm)
+
+	var size uint8 = 16
+	if op == PARALLEL_ADD8 || op == PARALLEL_SUB8 {
+		size = 8
+	}
+	lanes := 32 / size
+
+	var result uint32
+	var ge uint8
+
+	for i := uint8(0); i < lanes; i++ {
+		/* ASX and SAX pair each halfword of Rn with the other of Rm */
+		j := i
+		if op == PARALLEL_ASX || op == PARALLEL_SAX {
+			j = 1 - i
+		}
+
+		subtract := op == PARALLEL_SUB16 || op == PARALLEL_SUB8 ||
+			(op == PARALLEL_ASX && i == 0) || (op == PARALLEL_SAX && i == 1)
+
+		var r int64
+		if subtract {
+			r = lane(n, i, size, signed) - lane(m, j, size, signed)
+		} else {
+			r = lane(n, i, size, signed) + lane(m, j, size, signed)
+		}
+
+		var value uint32
+		switch mode {
+		case PARALLEL_MODULAR:
+			value = uint32(r)
+
+			/* Unsigned sums are GE if they carry out, everything else
+			 * if it isn't negative */
+			is_ge := r >= 0
+			if !signed && !subtract {
+				is_ge = r >= int64(1)<<size
+			}
+
+			if is_ge {
+				ge |= ((1 << (size / 8)) - 1) << (i * size / 8)
+			}
+		case PARALLEL_SATURATING:
+			if signed {
+				value, _ = SignedSatQ(r, size)
+			} else {
+				value, _ = UnsignedSatQ(r, size)
+			}
+		case PARALLEL_HALVING:
+			value = uint32(r >> 1)
+		}
+
+		result |= (value & ((1 << size) - 1)) << (i * size)
+	}
+
+	cpu.SetR(instr.Rd, result)
+	if mode == PARALLEL_MODULAR {
+		cpu.Apsr.GE = ge
+	}
+}
+
+/* Perform SEL instruction, taking each byte from Rn if its GE flag is
+ * set, otherwise from Rm */
+func Select(cpu *CPU, instr InstrFields) {
+	n := cpu.R(instr.Rn)
+	m := cpu.R(instr.Rm)
+
+	var result uint32
+	for i := uint8(0); i < 4; i++ {
+		mask := uint32(0xff) << (8 * i)
+		if cpu.Apsr.GE&(1<<i) != 0 {
+			result |= n & mask
+		} else {
+			result |= m & mask
+		}
+	}
+
+	cpu.SetR(instr.Rd, result)
+}
+
+/* Perform USAD8 and USADA8 instructions, summing the absolute
+ * differences of the unsigned bytes of Rn and Rm, plus Ra if accumulating */
+func SumAbsoluteDifferences(cpu *CPU, instr MultiplyFields, accumulate bool) {
+	n := cpu.R(instr.Rn)
+	m := cpu.R(instr.Rm)
+
+	var result uint32
+	if accumulate {
+		result = cpu.R(instr.Ra)
+	}
+
+	for i := uint8(0); i < 4; i++ {
+		diff := lane(n, i, 8, false) - lane(m, i, 8, false)
+		if diff < 0 {
+			diff = -diff
+		}
+		result += uint32(diff)
+	}
+
+	cpu.SetR(instr.Rd, result)
+}
+
+/* Extract the fields of the parallel add and subtract instructions and SEL */
+func decode_parallel(raw_instr uint32) (InstrFields, bool) {
+	Rd := RegIndex((raw_instr >> 8) & 0xf)
+	Rn := RegIndex((raw_instr >> 16) & 0xf)
+	Rm := RegIndex(raw_instr & 0xf)
+
+	fields := InstrFields{Rd: Rd, Rn: Rn, Rm: Rm, setflags: NEVER}
+
+	return fields, !BadReg(Rd) && !BadReg(Rn) && !BadReg(Rm)
+}
+
+/* Combine a halfword of Rn with one of the shifted Rm. PKHTB, shifting
+ * right, takes the top halfword from Rn; PKHBT the bottom one. */
+func PackHalfword(cpu *CPU, instr InstrFields) {
+	operand, _ := Shift_C(cpu.R(instr.Rm), instr.Shift.srtype, instr.Shift.amount, cpu.Apsr.C)
+	rn := cpu.R(instr.Rn)
+
+	if instr.Shift.srtype == SRType_ASR {
+		cpu.SetR(instr.Rd, rn&0xffff0000|operand&0xffff)
+	} else {
+		cpu.SetR(instr.Rd, operand&0xffff0000|rn&0xffff)
+	}
+}
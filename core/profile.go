@@ -0,0 +1,13 @@
+package core
+
+/* Optional architecture extensions implemented by the CPU */
+type Profile struct {
+	DSP bool // ARMv7E-M DSP instructions
+	FPU bool // FPv4-SP floating-point extension
+}
+
+var (
+	PROFILE_ARMV7M     = Profile{DSP: false, FPU: false} // Cortex-M3
+	PROFILE_ARMV7EM    = Profile{DSP: true, FPU: false}  // Cortex-M4
+	PROFILE_ARMV7EM_FP = Profile{DSP: true, FPU: true}   // Cortex-M4F
+)
@@ -30,9 +30,9 @@ type Ipsr struct {
 }
 
 type Epsr struct {
-	T   bool   // Thumb bit
-	ICI uint16 // Interrupt-continue
-	IT  uint16 // IT block flags
+	T   bool    // Thumb bit
+	ICI uint16  // Interrupt-continue, laid out as IT with a zero mask
+	IT  ITState // IT block flags
 }
 
 type Mode uint8
@@ -49,18 +49,22 @@ type Control struct {
 }
 
 type Registers struct {
-	r         GeneralRegs
-	sp        SPRegs
-	lr        uint32
-	pc        uint32
-	Apsr      Apsr
-	Ipsr      Ipsr
-	Epsr      Epsr
-	Mode      Mode
-	Primask   bool
-	Faultmask bool
-	Basepri   uint8
-	Control   Control
+	r          GeneralRegs
+	sp         SPRegs
+	lr         uint32
+	pc         uint32
+	s          FPRegs // Floating-point extension registers
+	Apsr       Apsr
+	Ipsr       Ipsr
+	Epsr       Epsr
+	Mode       Mode
+	Primask    bool
+	Faultmask  bool
+	Basepri    uint8
+	Control    Control
+	Fpscr      Fpscr
+	branched   bool // PC written by the current instruction
+	exc_return bool // PC written with EXC_RETURN, returning from an exception
 }
 
 /* Special registers in r13-15 */
@@ -126,16 +130,96 @@ func (regs Registers) Pc() uint32 {
 	return regs.R(PC)
 }
 
+/* Privileged in Handler mode, or in Thread mode unless CONTROL.nPRIV
+ * ARM ARM pseudocode CurrentModeIsPrivileged() */
+func (regs Registers) CurrentModeIsPrivileged() bool {
+	return regs.Mode == MODE_HANDLER || !regs.Control.Npriv
+}
+
+/* Bit positions of the combined Program Status Register
+ * ARMv7-M ARM B1.4.2 */
+const (
+	XPSR_N       = 1 << 31
+	XPSR_Z       = 1 << 30
+	XPSR_C       = 1 << 29
+	XPSR_V       = 1 << 28
+	XPSR_Q       = 1 << 27
+	XPSR_T       = 1 << 24
+	XPSR_GE_MASK = 0xf << 16
+
+	XPSR_NZCVQ_MASK   = XPSR_N | XPSR_Z | XPSR_C | XPSR_V | XPSR_Q
+	XPSR_EXCPNUM_MASK = 0x1ff
+
+	/* ICI/IT[1:0] and ICI/IT[7:2] */
+	XPSR_ICI_IT_LOW_MASK  = 0x3 << 25
+	XPSR_ICI_IT_HIGH_MASK = 0x3f << 10
+)
+
+/* APSR, IPSR and EPSR combined into one word */
+func (regs Registers) Xpsr() uint32 {
+	var xpsr uint32
+
+	xpsr |= uint32(booltou(regs.Apsr.N)) << 31
+	xpsr |= uint32(booltou(regs.Apsr.Z)) << 30
+	xpsr |= uint32(booltou(regs.Apsr.C)) << 29
+	xpsr |= uint32(booltou(regs.Apsr.V)) << 28
+	xpsr |= uint32(booltou(regs.Apsr.Q)) << 27
+	xpsr |= uint32(regs.Apsr.GE&0xf) << 16
+
+	xpsr |= uint32(booltou(regs.Epsr.T)) << 24
+
+	/* ICI and IT share bits, IT taking them inside an IT block */
+	ici_it := uint32(regs.Epsr.IT)
+	if !regs.Epsr.IT.InBlock() {
+		ici_it = uint32(regs.Epsr.ICI)
+	}
+	xpsr |= (ici_it & 0x3) << 25
+	xpsr |= ((ici_it >> 2) & 0x3f) << 10
+
+	xpsr |= uint32(regs.Ipsr.ExcpNum) & XPSR_EXCPNUM_MASK
+
+	return xpsr
+}
+
+/* Set APSR, IPSR and EPSR from one word */
+func (regs *Registers) SetXpsr(xpsr uint32) {
+	regs.Apsr.N = xpsr&XPSR_N != 0
+	regs.Apsr.Z = xpsr&XPSR_Z != 0
+	regs.Apsr.C = xpsr&XPSR_C != 0
+	regs.Apsr.V = xpsr&XPSR_V != 0
+	regs.Apsr.Q = xpsr&XPSR_Q != 0
+	regs.Apsr.GE = uint8((xpsr & XPSR_GE_MASK) >> 16)
+
+	regs.Epsr.T = xpsr&XPSR_T != 0
+
+	/* The ICI form leaves the IT mask, ICI/IT[3:0], clear */
+	ici_it := uint8((xpsr>>25)&0x3) | uint8((xpsr>>10)&0x3f)<<2
+	if ITState(ici_it).InBlock() {
+		regs.Epsr.IT = ITState(ici_it)
+		regs.Epsr.ICI = 0
+	} else {
+		regs.Epsr.IT = 0
+		regs.Epsr.ICI = uint16(ici_it)
+	}
+
+	regs.Ipsr.ExcpNum = uint16(xpsr & XPSR_EXCPNUM_MASK)
+}
+
 func (regs Registers) InITBlock() bool {
-	return (regs.Epsr.IT & 0xf) != 0
+	return regs.Epsr.IT.InBlock()
 }
 
 func (regs Registers) LastInITBlock() bool {
-	return (regs.Epsr.IT & 0xf) == 0x8
+	return regs.Epsr.IT.Last()
+}
+
+func (regs *Registers) ITAdvance() {
+	regs.Epsr.IT.Advance()
 }
 
 func (regs *Registers) BranchTo(addr uint32) {
 	regs.SetR(PC, addr)
+	regs.branched = true
 }
 
 func (regs *Registers) BranchWritePC(addr uint32) {
@@ -148,6 +232,24 @@ func (regs *Registers) ALUWritePC(addr uint32) {
 	regs.BranchWritePC(addr)
 }
 
+/* Interworking branch, bit 0 of addr selects the Thumb state. In Handler
+ * mode, addr may instead be EXC_RETURN, returning from the exception once
+ * the instruction completes. */
+func (regs *Registers) BXWritePC(addr uint32) {
+	if regs.Mode == MODE_HANDLER && addr&EXC_RETURN_PREFIX == EXC_RETURN_PREFIX {
+		regs.exc_return = true
+		regs.BranchTo(addr)
+		return
+	}
+
+	regs.Epsr.T = (addr & 0x1) != 0
+	regs.BranchTo(addr &^ 0x1)
+}
+
+func (regs *Registers) LoadWritePC(addr uint32) {
+	regs.BXWritePC(addr)
+}
+
 func (regs Registers) Pretty() string {
 	var b bytes.Buffer
 	var i RegIndex
@@ -0,0 +1,227 @@
+package core
+
+import "fmt"
+
+/* Extract the fields common to SSAT and USAT
+ * ARM ARM A5.3.3 */
+func decode_saturate(raw_instr uint32) (Rd RegIndex, Rn RegIndex, sat_imm uint8, shift_t SRType, shift_n uint8) {
+	Rd = RegIndex((raw_instr >> 8) & 0xf)
+	Rn = RegIndex((raw_instr >> 16) & 0xf)
+	sat_imm = uint8(raw_instr & 0x1f)
+
+	imm3 := uint8((raw_instr >> 12) & 0x7)
+	imm2 := uint8((raw_instr >> 6) & 0x3)
+	shift_n = (imm3 << 2) | imm2
+
+	shift_t = SRType_LSL
+	if (raw_instr>>21)&0x1 == 1 {
+		shift_t = SRType_ASR
+	}
+
+	return Rd, Rn, sat_imm, shift_t, shift_n
+}
+
+func saturate_string(name string, instr SaturateFields) string {
+	if instr.ShiftN == 0 {
+		return fmt.Sprintf("%s %s, #%d, %s", name, instr.Rd, instr.SaturateTo, instr.Rn)
+	}
+
+	return fmt.Sprintf("%s %s, #%d, %s, %s #%d", name, instr.Rd, instr.SaturateTo, instr.Rn,
+		instr.ShiftType, instr.ShiftN)
+}
+
+/* SSAT
+ * ARM ARM A7.7.150
+ * Encoding T1 */
+type SsatT1 SaturateFields
+
+func Ssat32T1(instr FetchedInstr) DecodedInstr {
+	Rd, Rn, sat_imm, shift_t, shift_n := decode_saturate(instr.Uint32())
+
+	if !plain_imm_sbz(instr.Uint32()) {
+		return UnpredictableInstr{}
+	}
+
+	if BadReg(Rd) || BadReg(Rn) {
+		return UnpredictableInstr{Rd: Rd, Rm: 0, Rn: Rn, Imm: 0, setflags: NEVER}
+	}
+
+	return SsatT1{Rd: Rd, Rn: Rn, SaturateTo: sat_imm + 1, ShiftType: shift_t, ShiftN: shift_n}
+}
+
+func (instr SsatT1) Execute(cpu *CPU) {
+	Saturate(cpu, SaturateFields(instr), SignedSatQ)
+}
+
+func (instr SsatT1) String() string {
+	return saturate_string("ssat", SaturateFields(instr))
+}
+
+/* USAT
+ * ARM ARM A7.7.210
+ * Encoding T1 */
+type UsatT1 SaturateFields
+
+func Usat32T1(instr FetchedInstr) DecodedInstr {
+	Rd, Rn, sat_imm, shift_t, shift_n := decode_saturate(instr.Uint32())
+
+	if !plain_imm_sbz(instr.Uint32()) {
+		return UnpredictableInstr{}
+	}
+
+	if BadReg(Rd) || BadReg(Rn) {
+		return UnpredictableInstr{Rd: Rd, Rm: 0, Rn: Rn, Imm: 0, setflags: NEVER}
+	}
+
+	return UsatT1{Rd: Rd, Rn: Rn, SaturateTo: sat_imm, ShiftType: shift_t, ShiftN: shift_n}
+}
+
+func (instr UsatT1) Execute(cpu *CPU) {
+	Saturate(cpu, SaturateFields(instr), UnsignedSatQ)
+}
+
+func (instr UsatT1) String() string {
+	return saturate_string("usat", SaturateFields(instr))
+}
+
+/* SSAT16
+ * ARM ARM A7.7.151
+ * Encoding T1 */
+type Ssat16T1 SaturateFields
+
+func Ssat1632T1(instr FetchedInstr) DecodedInstr {
+	Rd, Rn, sat_imm, _, _ := decode_saturate(instr.Uint32())
+
+	if !plain_imm_sbz(instr.Uint32()) {
+		return UnpredictableInstr{}
+	}
+
+	if BadReg(Rd) || BadReg(Rn) {
+		return UnpredictableInstr{}
+	}
+
+	return Ssat16T1{Rd: Rd, Rn: Rn, SaturateTo: sat_imm + 1}
+}
+
+func (instr Ssat16T1) Execute(cpu *CPU) {
+	SaturateHalfwords(cpu, SaturateFields(instr), SignedSatQ)
+}
+
+func (instr Ssat16T1) String() string {
+	return fmt.Sprintf("ssat16 %s, #%d, %s", instr.Rd, instr.SaturateTo, instr.Rn)
+}
+
+/* USAT16
+ * ARM ARM A7.7.211
+ * Encoding T1 */
+type Usat16T1 SaturateFields
+
+func Usat1632T1(instr FetchedInstr) DecodedInstr {
+	Rd, Rn, sat_imm, _, _ := decode_saturate(instr.Uint32())
+
+	if !plain_imm_sbz(instr.Uint32()) {
+		return UnpredictableInstr{}
+	}
+
+	if BadReg(Rd) || BadReg(Rn) {
+		return UnpredictableInstr{}
+	}
+
+	return Usat16T1{Rd: Rd, Rn: Rn, SaturateTo: sat_imm}
+}
+
+func (instr Usat16T1) Execute(cpu *CPU) {
+	SaturateHalfwords(cpu, SaturateFields(instr), UnsignedSatQ)
+}
+
+func (instr Usat16T1) String() string {
+	return fmt.Sprintf("usat16 %s, #%d, %s", instr.Rd, instr.SaturateTo, instr.Rn)
+}
+
+/* QADD
+ * ARM ARM A7.7.100
+ * Encoding T1 */
+type QaddT1 InstrFields
+
+func Qadd32T1(instr FetchedInstr) DecodedInstr {
+	fields, ok := decode_parallel(instr.Uint32())
+	if !ok {
+		return UnpredictableInstr{}
+	}
+
+	return QaddT1(fields)
+}
+
+func (instr QaddT1) Execute(cpu *CPU) {
+	SaturatingAddSubtract(cpu, InstrFields(instr), false, false)
+}
+
+func (instr QaddT1) String() string {
+	return fmt.Sprintf("qadd %s, %s, %s", instr.Rd, instr.Rm, instr.Rn)
+}
+
+/* QSUB
+ * ARM ARM A7.7.107
+ * Encoding T1 */
+type QsubT1 InstrFields
+
+func Qsub32T1(instr FetchedInstr) DecodedInstr {
+	fields, ok := decode_parallel(instr.Uint32())
+	if !ok {
+		return UnpredictableInstr{}
+	}
+
+	return QsubT1(fields)
+}
+
+func (instr QsubT1) Execute(cpu *CPU) {
+	SaturatingAddSubtract(cpu, InstrFields(instr), true, false)
+}
+
+func (instr QsubT1) String() string {
+	return fmt.Sprintf("qsub %s, %s, %s", instr.Rd, instr.Rm, instr.Rn)
+}
+
+/* QDADD
+ * ARM ARM A7.7.104
+ * Encoding T1 */
+type QdaddT1 InstrFields
+
+func Qdadd32T1(instr FetchedInstr) DecodedInstr {
+	fields, ok := decode_parallel(instr.Uint32())
+	if !ok {
+		return UnpredictableInstr{}
+	}
+
+	return QdaddT1(fields)
+}
+
+func (instr QdaddT1) Execute(cpu *CPU) {
+	SaturatingAddSubtract(cpu, InstrFields(instr), false, true)
+}
+
+func (instr QdaddT1) String() string {
+	return fmt.Sprintf("qdadd %s, %s, %s", instr.Rd, instr.Rm, instr.Rn)
+}
+
+/* QDSUB
+ * ARM ARM A7.7.105
+ * Encoding T1 */
+type QdsubT1 InstrFields
+
+func Qdsub32T1(instr FetchedInstr) DecodedInstr {
+	fields, ok := decode_parallel(instr.Uint32())
+	if !ok {
+		return UnpredictableInstr{}
+	}
+
+	return QdsubT1(fields)
+}
+
+func (instr QdsubT1) Execute(cpu *CPU) {
+	SaturatingAddSubtract(cpu, InstrFields(instr), true, true)
+}
+
+func (instr QdsubT1) String() string {
+	return fmt.Sprintf("qdsub %s, %s, %s", instr.Rd, instr.Rm, instr.Rn)
+}
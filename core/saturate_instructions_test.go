@@ -0,0 +1,191 @@
+package core
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestIdentifySsatT1(t *testing.T) {
+	cases := []IdentifyCase{
+		{instr: FetchedInstr32(0xf3010007), instr_valid: true},  // ssat r0, #8, r1
+		{instr: FetchedInstr32(0xf303120f), instr_valid: true},  // ssat r2, #16, r3, lsl #4
+		{instr: FetchedInstr32(0xf32574c0), instr_valid: true},  // ssat r4, #1, r5, asr #31
+		{instr: FetchedInstr32(0xf3210007), instr_valid: false}, // ssat16 r0, #8, r1
+		{instr: FetchedInstr32(0xf3870608), instr_valid: false}, // usat r6, #8, r7
+	}
+
+	test_identify(t, cases, reflect.TypeOf(SsatT1{}))
+}
+
+func TestDecodeSsat32T1(t *testing.T) {
+	cases := []DecodeCase{
+		// ssat r0, #8, r1
+		{instr: FetchedInstr32(0xf3010007), decoded: SsatT1{Rd: 0, Rn: 1, SaturateTo: 8, ShiftType: SRType_LSL, ShiftN: 0}},
+		// ssat r2, #16, r3, lsl #4
+		{instr: FetchedInstr32(0xf303120f), decoded: SsatT1{Rd: 2, Rn: 3, SaturateTo: 16, ShiftType: SRType_LSL, ShiftN: 4}},
+		// ssat r4, #1, r5, asr #31
+		{instr: FetchedInstr32(0xf32574c0), decoded: SsatT1{Rd: 4, Rn: 5, SaturateTo: 1, ShiftType: SRType_ASR, ShiftN: 31}},
+		// ssat r0, #8, r1, bit 26 set
+		{instr: FetchedInstr32(0xf7010007), decoded: UnpredictableInstr{}},
+	}
+
+	test_decode(t, cases, Ssat32T1)
+}
+
+func TestExecuteSsatT1(t *testing.T) {
+	cases := []ExecuteCase{
+		// ssat r0, #8, r1
+		{instr: SsatT1{Rd: 0, Rn: 1, SaturateTo: 8, ShiftType: SRType_LSL, ShiftN: 0},
+			regs:     Registers{r: GeneralRegs{0, 100, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12}},
+			expected: Registers{r: GeneralRegs{100, 100, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12}}},
+		// ssat r0, #8, r1
+		{instr: SsatT1{Rd: 0, Rn: 1, SaturateTo: 8, ShiftType: SRType_LSL, ShiftN: 0},
+			regs:     Registers{r: GeneralRegs{0, 200, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12}},
+			expected: Registers{r: GeneralRegs{127, 200, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12}, Apsr: Apsr{Q: true}}},
+		// ssat r0, #8, r1
+		{instr: SsatT1{Rd: 0, Rn: 1, SaturateTo: 8, ShiftType: SRType_LSL, ShiftN: 0},
+			regs:     Registers{r: GeneralRegs{0, 0xfffffe00, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12}},
+			expected: Registers{r: GeneralRegs{0xffffff80, 0xfffffe00, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12}, Apsr: Apsr{Q: true}}},
+		// ssat r2, #16, r3, lsl #4 (Q is sticky)
+		{instr: SsatT1{Rd: 2, Rn: 3, SaturateTo: 16, ShiftType: SRType_LSL, ShiftN: 4},
+			regs:     Registers{r: GeneralRegs{0, 1, 2, 0x100, 4, 5, 6, 7, 8, 9, 10, 11, 12}, Apsr: Apsr{Q: true}},
+			expected: Registers{r: GeneralRegs{0, 1, 0x1000, 0x100, 4, 5, 6, 7, 8, 9, 10, 11, 12}, Apsr: Apsr{Q: true}}},
+		// ssat r4, #1, r5, asr #31
+		{instr: SsatT1{Rd: 4, Rn: 5, SaturateTo: 1, ShiftType: SRType_ASR, ShiftN: 31},
+			regs:     Registers{r: GeneralRegs{0, 1, 2, 3, 4, 0x80000000, 6, 7, 8, 9, 10, 11, 12}},
+			expected: Registers{r: GeneralRegs{0, 1, 2, 3, 0xffffffff, 0x80000000, 6, 7, 8, 9, 10, 11, 12}}},
+	}
+
+	test_execute(t, cases)
+}
+
+func TestIdentifyUsatT1(t *testing.T) {
+	cases := []IdentifyCase{
+		{instr: FetchedInstr32(0xf3870608), instr_valid: true},  // usat r6, #8, r7
+		{instr: FetchedInstr32(0xf3a9089f), instr_valid: true},  // usat r8, #31, r9, asr #2
+		{instr: FetchedInstr32(0xf38b0a00), instr_valid: true},  // usat r10, #0, r11
+		{instr: FetchedInstr32(0xf3010007), instr_valid: false}, // ssat r0, #8, r1
+	}
+
+	test_identify(t, cases, reflect.TypeOf(UsatT1{}))
+}
+
+func TestDecodeUsat32T1(t *testing.T) {
+	cases := []DecodeCase{
+		// usat r6, #8, r7
+		{instr: FetchedInstr32(0xf3870608), decoded: UsatT1{Rd: 6, Rn: 7, SaturateTo: 8, ShiftType: SRType_LSL, ShiftN: 0}},
+		// usat r8, #31, r9, asr #2
+		{instr: FetchedInstr32(0xf3a9089f), decoded: UsatT1{Rd: 8, Rn: 9, SaturateTo: 31, ShiftType: SRType_ASR, ShiftN: 2}},
+		// usat r10, #0, r11
+		{instr: FetchedInstr32(0xf38b0a00), decoded: UsatT1{Rd: 10, Rn: 11, SaturateTo: 0, ShiftType: SRType_LSL, ShiftN: 0}},
+		// usat r6, #8, r7, bit 26 set
+		{instr: FetchedInstr32(0xf7870608), decoded: UnpredictableInstr{}},
+	}
+
+	test_decode(t, cases, Usat32T1)
+}
+
+func TestExecuteUsatT1(t *testing.T) {
+	cases := []ExecuteCase{
+		// usat r6, #8, r7
+		{instr: UsatT1{Rd: 6, Rn: 7, SaturateTo: 8, ShiftType: SRType_LSL, ShiftN: 0},
+			regs:     Registers{r: GeneralRegs{0, 1, 2, 3, 4, 5, 6, 0x1ff, 8, 9, 10, 11, 12}},
+			expected: Registers{r: GeneralRegs{0, 1, 2, 3, 4, 5, 0xff, 0x1ff, 8, 9, 10, 11, 12}, Apsr: Apsr{Q: true}}},
+		// usat r6, #8, r7
+		{instr: UsatT1{Rd: 6, Rn: 7, SaturateTo: 8, ShiftType: SRType_LSL, ShiftN: 0},
+			regs:     Registers{r: GeneralRegs{0, 1, 2, 3, 4, 5, 6, 0xffffffff, 8, 9, 10, 11, 12}},
+			expected: Registers{r: GeneralRegs{0, 1, 2, 3, 4, 5, 0, 0xffffffff, 8, 9, 10, 11, 12}, Apsr: Apsr{Q: true}}},
+		// usat r8, #31, r9, asr #2
+		{instr: UsatT1{Rd: 8, Rn: 9, SaturateTo: 31, ShiftType: SRType_ASR, ShiftN: 2},
+			regs:     Registers{r: GeneralRegs{0, 1, 2, 3, 4, 5, 6, 7, 8, 0x7ffffffc, 10, 11, 12}},
+			expected: Registers{r: GeneralRegs{0, 1, 2, 3, 4, 5, 6, 7, 0x1fffffff, 0x7ffffffc, 10, 11, 12}}},
+		// usat r10, #0, r11
+		{instr: UsatT1{Rd: 10, Rn: 11, SaturateTo: 0, ShiftType: SRType_LSL, ShiftN: 0},
+			regs:     Registers{r: GeneralRegs{0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 1, 12}},
+			expected: Registers{r: GeneralRegs{0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 0, 1, 12}, Apsr: Apsr{Q: true}}},
+	}
+
+	test_execute(t, cases)
+}
+
+func TestDecodeSaturate16(t *testing.T) {
+	cases := []DecodeCase{
+		// ssat16 r0, #8, r1
+		{instr: FetchedInstr32(0xf3210007), decoded: Ssat16T1{Rd: 0, Rn: 1, SaturateTo: 8}},
+		// ssat16 r0, #8, sp
+		{instr: FetchedInstr32(0xf32d0007), decoded: UnpredictableInstr{}},
+	}
+
+	test_decode(t, cases, Ssat1632T1)
+
+	cases = []DecodeCase{
+		// usat16 r0, #15, r1
+		{instr: FetchedInstr32(0xf3a1000f), decoded: Usat16T1{Rd: 0, Rn: 1, SaturateTo: 15}},
+	}
+
+	test_decode(t, cases, Usat1632T1)
+}
+
+func TestExecuteSaturate16(t *testing.T) {
+	cases := []ExecuteCase{
+		// ssat16 r0, #8, r1
+		{instr: Ssat16T1{Rd: 0, Rn: 1, SaturateTo: 8},
+			regs:     Registers{r: GeneralRegs{0, 0x00050003}},
+			expected: Registers{r: GeneralRegs{0x00050003, 0x00050003}}},
+		// ssat16 r0, #8, r1
+		{instr: Ssat16T1{Rd: 0, Rn: 1, SaturateTo: 8},
+			regs:     Registers{r: GeneralRegs{0, 0xff000100}},
+			expected: Registers{r: GeneralRegs{0xff80007f, 0xff000100}, Apsr: Apsr{Q: true}}},
+		// usat16 r0, #8, r1
+		{instr: Usat16T1{Rd: 0, Rn: 1, SaturateTo: 8},
+			regs:     Registers{r: GeneralRegs{0, 0xfff00064}},
+			expected: Registers{r: GeneralRegs{0x00000064, 0xfff00064}, Apsr: Apsr{Q: true}}},
+	}
+
+	test_execute(t, cases)
+}
+
+func TestDecodeSaturatingAddSubtract(t *testing.T) {
+	cases := []DecodeCase{
+		// qadd r0, r1, r2
+		{instr: FetchedInstr32(0xfa82f081), decoded: QaddT1{Rd: 0, Rn: 2, Rm: 1, setflags: NEVER}},
+		// qadd r0, pc, r2
+		{instr: FetchedInstr32(0xfa82f08f), decoded: UnpredictableInstr{}},
+	}
+
+	test_decode(t, cases, Qadd32T1)
+
+	cases = []DecodeCase{
+		// qdsub r0, r1, r2
+		{instr: FetchedInstr32(0xfa82f0b1), decoded: QdsubT1{Rd: 0, Rn: 2, Rm: 1, setflags: NEVER}},
+	}
+
+	test_decode(t, cases, Qdsub32T1)
+}
+
+func TestExecuteSaturatingAddSubtract(t *testing.T) {
+	cases := []ExecuteCase{
+		// qadd r0, r1, r2
+		{instr: QaddT1{Rd: 0, Rn: 2, Rm: 1, setflags: NEVER},
+			regs:     Registers{r: GeneralRegs{0, 0x7fffffff, 1}},
+			expected: Registers{r: GeneralRegs{0x7fffffff, 0x7fffffff, 1}, Apsr: Apsr{Q: true}}},
+		// qsub r0, r1, r2
+		{instr: QsubT1{Rd: 0, Rn: 2, Rm: 1, setflags: NEVER},
+			regs:     Registers{r: GeneralRegs{0, 10, 3}},
+			expected: Registers{r: GeneralRegs{7, 10, 3}}},
+		// qsub r0, r1, r2
+		{instr: QsubT1{Rd: 0, Rn: 2, Rm: 1, setflags: NEVER},
+			regs:     Registers{r: GeneralRegs{0, 0x80000000, 1}},
+			expected: Registers{r: GeneralRegs{0x80000000, 0x80000000, 1}, Apsr: Apsr{Q: true}}},
+		// qdadd r0, r1, r2 (doubling saturates)
+		{instr: QdaddT1{Rd: 0, Rn: 2, Rm: 1, setflags: NEVER},
+			regs:     Registers{r: GeneralRegs{0, 0xffffffff, 0x40000000}},
+			expected: Registers{r: GeneralRegs{0x7ffffffe, 0xffffffff, 0x40000000}, Apsr: Apsr{Q: true}}},
+		// qdsub r0, r1, r2
+		{instr: QdsubT1{Rd: 0, Rn: 2, Rm: 1, setflags: NEVER},
+			regs:     Registers{r: GeneralRegs{0, 100, 10}},
+			expected: Registers{r: GeneralRegs{80, 100, 10}}},
+	}
+
+	test_execute(t, cases)
+}
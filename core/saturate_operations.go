@@ -0,0 +1,85 @@
+package core
+
+/* Saturate i to an N-bit signed range, reporting whether it saturated
+ * ARM ARM A2.2.1 SignedSatQ() */
+func SignedSatQ(i int64, N uint8) (uint32, bool) {
+	max := int64(1)<<(N-1) - 1
+	min := -(int64(1) << (N - 1))
+
+	if i > max {
+		return uint32(max), true
+	} else if i < min {
+		return uint32(min), true
+	}
+
+	return uint32(i), false
+}
+
+/* Saturate i to an N-bit unsigned range, reporting whether it saturated
+ * ARM ARM A2.2.1 UnsignedSatQ() */
+func UnsignedSatQ(i int64, N uint8) (uint32, bool) {
+	max := int64(1)<<N - 1
+
+	if i > max {
+		return uint32(max), true
+	} else if i < 0 {
+		return 0, true
+	}
+
+	return uint32(i), false
+}
+
+/* Perform saturate instruction, setting Q on saturation. The shifted
+ * operand is always treated as signed. */
+func Saturate(cpu *CPU, instr SaturateFields, saturate func(int64, uint8) (uint32, bool)) {
+	operand, _ := Shift_C(cpu.R(instr.Rn), instr.ShiftType, instr.ShiftN, cpu.Apsr.C)
+
+	result, sat := saturate(int64(int32(operand)), instr.SaturateTo)
+
+	cpu.SetR(instr.Rd, result)
+
+	if sat {
+		cpu.Apsr.Q = true
+	}
+}
+
+/* Perform saturating add and subtract instructions (QADD, QSUB, QDADD,
+ * QDSUB), on Rm and Rn, optionally doubled first. Q is set if either
+ * step saturates. */
+func SaturatingAddSubtract(cpu *CPU, instr InstrFields, subtract bool, double bool) {
+	operand := int64(int32(cpu.R(instr.Rn)))
+
+	if double {
+		doubled, sat := SignedSatQ(2*operand, 32)
+		if sat {
+			cpu.Apsr.Q = true
+		}
+		operand = int64(int32(doubled))
+	}
+
+	if subtract {
+		operand = -operand
+	}
+
+	result, sat := SignedSatQ(int64(int32(cpu.R(instr.Rm)))+operand, 32)
+	if sat {
+		cpu.Apsr.Q = true
+	}
+
+	cpu.SetR(instr.Rd, result)
+}
+
+/* Perform packed saturate instructions (SSAT16, USAT16), saturating each
+ * signed halfword separately and setting Q if either saturates */
+func SaturateHalfwords(cpu *CPU, instr SaturateFields, saturate func(int64, uint8) (uint32, bool)) {
+	operand := cpu.R(instr.Rn)
+
+	low, low_sat := saturate(int64(int16(operand)), instr.SaturateTo)
+	high, high_sat := saturate(int64(int16(operand>>16)), instr.SaturateTo)
+
+	cpu.SetR(instr.Rd, high<<16|low&0xffff)
+
+	if low_sat || high_sat {
+		cpu.Apsr.Q = true
+	}
+}
@@ -0,0 +1,470 @@
+package core
+
+import "errors"
+
+/* System Control Space. Accesses to the SCS are handled by the CPU
+ * itself rather than forwarded to the bus.
+ * ARMv7-M ARM B3.2 */
+const (
+	SCS_BASE = 0xe000e000
+	SCS_SIZE = 0x1000
+
+	SCB_ICSR  = 0xe000ed04
+	SCB_VTOR  = 0xe000ed08
+	SCB_AIRCR = 0xe000ed0c
+	SCB_CCR   = 0xe000ed14
+	SCB_SHPR1 = 0xe000ed18
+	SCB_SHPR2 = 0xe000ed1c
+	SCB_SHPR3 = 0xe000ed20
+	SCB_SHCSR = 0xe000ed24
+	SCB_CFSR  = 0xe000ed28
+	SCB_HFSR  = 0xe000ed2c
+	SCB_MMFAR = 0xe000ed34
+	SCB_BFAR  = 0xe000ed38
+	SCB_CPACR = 0xe000ed88
+
+	/* Floating-point extension */
+	SCB_FPCCR  = 0xe000ef34
+	SCB_FPCAR  = 0xe000ef38
+	SCB_FPDSCR = 0xe000ef3c
+)
+
+/* Interrupt Control and State Register bits
+ * ARMv7-M ARM B3.2.4 */
+const (
+	ICSR_VECTACTIVE_MASK  = 0x1ff
+	ICSR_RETTOBASE        = 1 << 11 // No other exception active
+	ICSR_VECTPENDING_MASK = 0x1ff << 12
+	ICSR_ISRPENDING       = 1 << 22 // An external interrupt is pending
+	ICSR_PENDSTCLR        = 1 << 25
+	ICSR_PENDSTSET        = 1 << 26
+	ICSR_PENDSVCLR        = 1 << 27
+	ICSR_PENDSVSET        = 1 << 28
+	ICSR_NMIPENDSET       = 1 << 31
+)
+
+const VTOR_TBLOFF_MASK = 0xffffff80
+
+/* Application Interrupt and Reset Control Register. Writes must hold
+ * VECTKEY, and only set PRIGROUP, the bit below which priorities are
+ * subpriorities; the reset requests aren't implemented.
+ * ARMv7-M ARM B3.2.6 */
+const (
+	AIRCR_VECTKEY        = 0x05fa << 16
+	AIRCR_VECTKEYSTAT    = 0xfa05 << 16
+	AIRCR_VECTKEY_MASK   = 0xffff << 16
+	AIRCR_PRIGROUP_SHIFT = 8
+	AIRCR_PRIGROUP_MASK  = 0x7 << AIRCR_PRIGROUP_SHIFT
+)
+
+/* Configuration and Control Register bits
+ * ARMv7-M ARM B3.2.8 */
+const (
+	CCR_NONBASETHRDENA = 1 << 0 // Thread mode may be entered with exceptions active
+	CCR_USERSETMPEND   = 1 << 1 // Unprivileged software may write STIR
+	CCR_UNALIGN_TRP    = 1 << 3 // Trap unaligned halfword and word accesses
+	CCR_DIV_0_TRP      = 1 << 4 // Trap SDIV and UDIV by zero
+	CCR_STKALIGN       = 1 << 9 // Align exception frames to 8 bytes
+
+	CCR_MASK  = CCR_NONBASETHRDENA | CCR_USERSETMPEND | CCR_UNALIGN_TRP | CCR_DIV_0_TRP | CCR_STKALIGN
+	CCR_RESET = CCR_STKALIGN
+)
+
+/* System Handler Control and State Register bits. The enables are held
+ * in SCB.Shcsr, the active and pended bits read from the exception state.
+ * ARMv7-M ARM B3.2.13 */
+const (
+	SHCSR_MEMFAULTENA = 1 << 16
+	SHCSR_BUSFAULTENA = 1 << 17
+	SHCSR_USGFAULTENA = 1 << 18
+
+	SHCSR_ENA_MASK = SHCSR_MEMFAULTENA | SHCSR_BUSFAULTENA | SHCSR_USGFAULTENA
+)
+
+var shcsr_active = map[uint16]uint32{
+	EXCEPTION_MEMMANAGE:    1 << 0,
+	EXCEPTION_BUSFAULT:     1 << 1,
+	EXCEPTION_USAGEFAULT:   1 << 3,
+	EXCEPTION_SVCALL:       1 << 7,
+	EXCEPTION_DEBUGMONITOR: 1 << 8,
+	EXCEPTION_PENDSV:       1 << 10,
+	EXCEPTION_SYSTICK:      1 << 11,
+}
+
+var shcsr_pended = map[uint16]uint32{
+	EXCEPTION_USAGEFAULT: 1 << 12,
+	EXCEPTION_MEMMANAGE:  1 << 13,
+	EXCEPTION_BUSFAULT:   1 << 14,
+	EXCEPTION_SVCALL:     1 << 15,
+}
+
+/* Configurable Fault Status Register, holding the MMFSR, BFSR and UFSR
+ * ARMv7-M ARM B3.2.15 */
+const (
+	CFSR_BFSR_SHIFT = 8
+	CFSR_UFSR_SHIFT = 16
+)
+
+/* HardFault Status Register bits
+ * ARMv7-M ARM B3.2.16 */
+const (
+	HFSR_VECTTBL = 1 << 1  // Vector table read fault
+	HFSR_FORCED  = 1 << 30 // Escalated from a configurable fault
+)
+
+/* Coprocessor Access Control Register. Only the fields of CP10 and CP11,
+ * the floating-point extension, are implemented, each a CoprocAccess.
+ * ARMv7-M ARM B3.2.20 */
+const (
+	CPACR_CP10_SHIFT = 20
+	CPACR_CP11_SHIFT = 22
+
+	CPACR_FP_MASK = 0xf << CPACR_CP10_SHIFT
+)
+
+type CoprocAccess uint8
+
+const (
+	COPROC_ACCESS_DENIED     CoprocAccess = 0
+	COPROC_ACCESS_PRIVILEGED CoprocAccess = 1
+	COPROC_ACCESS_FULL       CoprocAccess = 3
+)
+
+/* Floating-Point Context Control Register bits
+ * ARMv7-M ARM B3.2.21 */
+const (
+	FPCCR_LSPACT = 1 << 0  // Lazy state preservation pending
+	FPCCR_USER   = 1 << 1  // Stacked context was unprivileged
+	FPCCR_THREAD = 1 << 3  // Stacked context was in Thread mode
+	FPCCR_HFRDY  = 1 << 4  // HardFault could be pended by the lazy save
+	FPCCR_MMRDY  = 1 << 5  // MemManage could be pended by the lazy save
+	FPCCR_BFRDY  = 1 << 6  // BusFault could be pended by the lazy save
+	FPCCR_MONRDY = 1 << 8  // DebugMonitor could be pended by the lazy save
+	FPCCR_LSPEN  = 1 << 30 // Lazy state preservation enabled
+	FPCCR_ASPEN  = 1 << 31 // CONTROL.FPCA set by FP instructions
+
+	FPCCR_MASK  = FPCCR_LSPACT | FPCCR_USER | FPCCR_THREAD | FPCCR_HFRDY | FPCCR_MMRDY | FPCCR_BFRDY | FPCCR_MONRDY | FPCCR_LSPEN | FPCCR_ASPEN
+	FPCCR_RESET = FPCCR_ASPEN | FPCCR_LSPEN
+)
+
+/* FPCAR holds the doubleword aligned address of the space reserved for
+ * S0-S15 in the last extended frame, FPDSCR the FPSCR defaults of a new
+ * floating-point context
+ * ARMv7-M ARM B3.2.22, B3.2.23 */
+const (
+	FPCAR_ADDRESS_MASK = 0xfffffff8
+	FPDSCR_MASK        = FPSCR_AHP | FPSCR_DN | FPSCR_FZ | FPSCR_RMODE
+)
+
+/* System Control Block registers */
+type SCB struct {
+	Vtor   uint32
+	Aircr  uint32
+	Ccr    uint32
+	Shcsr  uint32
+	Cfsr   uint32
+	Hfsr   uint32
+	Mmfar  uint32
+	Bfar   uint32
+	Cpacr  uint32
+	Fpccr  uint32
+	Fpcar  uint32
+	Fpdscr uint32
+}
+
+var ErrUnprivilegedAccess = errors.New("Unprivileged access to the System Control Space.")
+
+func in_scs(addr uint32) bool {
+	return addr >= SCS_BASE && addr-SCS_BASE < SCS_SIZE
+}
+
+/* Whether an access to the SCS is refused. Unprivileged software may
+ * only write STIR, and only if CCR.USERSETMPEND is set.
+ * ARMv7-M ARM B3.2.2 */
+func (cpu *CPU) scs_denied(addr uint32, write bool, privileged bool) bool {
+	if privileged {
+		return false
+	}
+
+	return !write || addr&^0x3 != NVIC_STIR || cpu.Scb.Ccr&CCR_USERSETMPEND == 0
+}
+
+/* Read an aligned SCS word */
+func (cpu *CPU) scs_read(addr uint32) (uint32, error) {
+	if value, ok := cpu.nvic_read(addr); ok {
+		return value, nil
+	}
+
+	switch addr {
+	case SCB_ICSR:
+		return cpu.icsr(), nil
+	case SCB_VTOR:
+		return cpu.Scb.Vtor, nil
+	case SCB_AIRCR:
+		return AIRCR_VECTKEYSTAT | cpu.Scb.Aircr, nil
+	case SCB_CCR:
+		return cpu.Scb.Ccr, nil
+	case SCB_SHPR1, SCB_SHPR2, SCB_SHPR3:
+		return cpu.shpr_read(addr), nil
+	case SCB_SHCSR:
+		return cpu.shcsr(), nil
+	case SCB_CFSR:
+		return cpu.Scb.Cfsr, nil
+	case SCB_HFSR:
+		return cpu.Scb.Hfsr, nil
+	case SCB_MMFAR:
+		return cpu.Scb.Mmfar, nil
+	case SCB_BFAR:
+		return cpu.Scb.Bfar, nil
+	case SCB_CPACR:
+		return cpu.Scb.Cpacr, nil
+	}
+
+	if cpu.Profile.FPU {
+		switch addr {
+		case SCB_FPCCR:
+			return cpu.Scb.Fpccr, nil
+		case SCB_FPCAR:
+			return cpu.Scb.Fpcar, nil
+		case SCB_FPDSCR:
+			return cpu.Scb.Fpdscr, nil
+		}
+	}
+
+	return 0, ErrUnmappedAccess
+}
+
+/* Write the bytes of an aligned SCS word selected by mask */
+func (cpu *CPU) scs_write(addr uint32, value uint32, mask uint32) error {
+	if cpu.nvic_write(addr, value, mask) {
+		return nil
+	}
+
+	switch addr {
+	case SCB_ICSR:
+		cpu.icsr_write(value & mask)
+		return nil
+	case SCB_VTOR:
+		cpu.Scb.Vtor = masked(cpu.Scb.Vtor, value, mask&VTOR_TBLOFF_MASK)
+		return nil
+	case SCB_AIRCR:
+		if mask&AIRCR_VECTKEY_MASK == AIRCR_VECTKEY_MASK && value&AIRCR_VECTKEY_MASK == AIRCR_VECTKEY {
+			cpu.Scb.Aircr = masked(cpu.Scb.Aircr, value, mask&AIRCR_PRIGROUP_MASK)
+		}
+		return nil
+	case SCB_CCR:
+		cpu.Scb.Ccr = masked(cpu.Scb.Ccr, value, mask&CCR_MASK)
+		return nil
+	case SCB_SHPR1, SCB_SHPR2, SCB_SHPR3:
+		cpu.shpr_write(addr, value, mask)
+		return nil
+	case SCB_SHCSR:
+		cpu.Scb.Shcsr = masked(cpu.Scb.Shcsr, value, mask&SHCSR_ENA_MASK)
+		return nil
+	case SCB_CFSR:
+		cpu.Scb.Cfsr &^= value & mask // Write one to clear
+		return nil
+	case SCB_HFSR:
+		cpu.Scb.Hfsr &^= value & mask
+		return nil
+	case SCB_MMFAR:
+		cpu.Scb.Mmfar = masked(cpu.Scb.Mmfar, value, mask)
+		return nil
+	case SCB_BFAR:
+		cpu.Scb.Bfar = masked(cpu.Scb.Bfar, value, mask)
+		return nil
+	case SCB_CPACR:
+		if cpu.Profile.FPU {
+			cpu.Scb.Cpacr = masked(cpu.Scb.Cpacr, value, mask&CPACR_FP_MASK)
+		}
+		return nil
+	}
+
+	if cpu.Profile.FPU {
+		switch addr {
+		case SCB_FPCCR:
+			cpu.Scb.Fpccr = masked(cpu.Scb.Fpccr, value, mask&FPCCR_MASK)
+			return nil
+		case SCB_FPCAR:
+			cpu.Scb.Fpcar = masked(cpu.Scb.Fpcar, value, mask&FPCAR_ADDRESS_MASK)
+			return nil
+		case SCB_FPDSCR:
+			cpu.Scb.Fpdscr = masked(cpu.Scb.Fpdscr, value, mask&FPDSCR_MASK)
+			return nil
+		}
+	}
+
+	return ErrUnmappedAccess
+}
+
+/* ICSR, reporting the active and highest priority pending exceptions */
+func (cpu *CPU) icsr() uint32 {
+	icsr := uint32(cpu.Ipsr.ExcpNum) & ICSR_VECTACTIVE_MASK
+
+	if cpu.active_count() <= 1 {
+		icsr |= ICSR_RETTOBASE
+	}
+	if excp, ok := cpu.highest_pending(); ok {
+		icsr |= (uint32(excp) << 12) & ICSR_VECTPENDING_MASK
+	}
+	for excp := uint16(EXCEPTION_IRQ0); excp < EXCEPTION_COUNT; excp++ {
+		if cpu.pending[excp] {
+			icsr |= ICSR_ISRPENDING
+			break
+		}
+	}
+
+	if cpu.pending[EXCEPTION_SYSTICK] {
+		icsr |= ICSR_PENDSTSET
+	}
+	if cpu.pending[EXCEPTION_PENDSV] {
+		icsr |= ICSR_PENDSVSET
+	}
+	if cpu.pending[EXCEPTION_NMI] {
+		icsr |= ICSR_NMIPENDSET
+	}
+
+	return icsr
+}
+
+/* Pend or clear NMI, PendSV and SysTick through ICSR */
+func (cpu *CPU) icsr_write(value uint32) {
+	if value&ICSR_PENDSTCLR != 0 {
+		cpu.ClearPending(EXCEPTION_SYSTICK)
+	} else if value&ICSR_PENDSTSET != 0 {
+		cpu.SetPending(EXCEPTION_SYSTICK)
+	}
+
+	if value&ICSR_PENDSVCLR != 0 {
+		cpu.ClearPending(EXCEPTION_PENDSV)
+	} else if value&ICSR_PENDSVSET != 0 {
+		cpu.SetPending(EXCEPTION_PENDSV)
+	}
+
+	if value&ICSR_NMIPENDSET != 0 {
+		cpu.SetPending(EXCEPTION_NMI)
+	}
+}
+
+/* SHPR1-3 hold a priority byte for each of exceptions 4-15, those of
+ * exceptions without a configurable priority reading as zero */
+func (cpu *CPU) shpr_read(addr uint32) uint32 {
+	first := uint16(addr-SCB_SHPR1) + EXCEPTION_MEMMANAGE
+
+	var value uint32
+	for i := uint16(0); i < 4; i++ {
+		value |= uint32(cpu.priority[first+i]) << (8 * i)
+	}
+
+	return value
+}
+
+func (cpu *CPU) shpr_write(addr uint32, value uint32, mask uint32) {
+	first := uint16(addr-SCB_SHPR1) + EXCEPTION_MEMMANAGE
+
+	for i := uint16(0); i < 4; i++ {
+		if mask&(0xff<<(8*i)) != 0 && priority_configurable(first+i) {
+			cpu.priority[first+i] = uint8(value>>(8*i)) & cpu.Nvic.priority_mask()
+		}
+	}
+}
+
+/* SHCSR, with the system handlers' active and pended state */
+func (cpu *CPU) shcsr() uint32 {
+	shcsr := cpu.Scb.Shcsr
+
+	for excp, bit := range shcsr_active {
+		if cpu.active[excp] {
+			shcsr |= bit
+		}
+	}
+	for excp, bit := range shcsr_pended {
+		if cpu.pending[excp] {
+			shcsr |= bit
+		}
+	}
+
+	return shcsr
+}
+
+func masked(old uint32, value uint32, mask uint32) uint32 {
+	return (old &^ mask) | (value & mask)
+}
+
+/* The CPU's view of memory: the SCS, privileged but for STIR, then the bus */
+
+func (cpu *CPU) Read8(addr uint32) (uint8, error) {
+	if in_scs(addr) {
+		if cpu.scs_denied(addr, false, cpu.CurrentModeIsPrivileged()) {
+			return 0, ErrUnprivilegedAccess
+		}
+
+		word, err := cpu.scs_read(addr &^ 0x3)
+		return uint8(word >> ((addr & 0x3) * 8)), err
+	}
+
+	return cpu.Mem.Read8(addr)
+}
+
+func (cpu *CPU) Read16(addr uint32) (uint16, error) {
+	if in_scs(addr) {
+		if cpu.scs_denied(addr, false, cpu.CurrentModeIsPrivileged()) {
+			return 0, ErrUnprivilegedAccess
+		}
+
+		word, err := cpu.scs_read(addr &^ 0x3)
+		return uint16(word >> ((addr & 0x2) * 8)), err
+	}
+
+	return cpu.Mem.Read16(addr)
+}
+
+func (cpu *CPU) Read32(addr uint32) (uint32, error) {
+	if in_scs(addr) {
+		if cpu.scs_denied(addr, false, cpu.CurrentModeIsPrivileged()) {
+			return 0, ErrUnprivilegedAccess
+		}
+
+		return cpu.scs_read(addr &^ 0x3)
+	}
+
+	return cpu.Mem.Read32(addr)
+}
+
+func (cpu *CPU) Write8(addr uint32, value uint8) error {
+	if in_scs(addr) {
+		if cpu.scs_denied(addr, true, cpu.CurrentModeIsPrivileged()) {
+			return ErrUnprivilegedAccess
+		}
+
+		shift := (addr & 0x3) * 8
+		return cpu.scs_write(addr&^0x3, uint32(value)<<shift, 0xff<<shift)
+	}
+
+	return cpu.Mem.Write8(addr, value)
+}
+
+func (cpu *CPU) Write16(addr uint32, value uint16) error {
+	if in_scs(addr) {
+		if cpu.scs_denied(addr, true, cpu.CurrentModeIsPrivileged()) {
+			return ErrUnprivilegedAccess
+		}
+
+		shift := (addr & 0x2) * 8
+		return cpu.scs_write(addr&^0x3, uint32(value)<<shift, 0xffff<<shift)
+	}
+
+	return cpu.Mem.Write16(addr, value)
+}
+
+func (cpu *CPU) Write32(addr uint32, value uint32) error {
+	if in_scs(addr) {
+		if cpu.scs_denied(addr, true, cpu.CurrentModeIsPrivileged()) {
+			return ErrUnprivilegedAccess
+		}
+
+		return cpu.scs_write(addr&^0x3, value, 0xffffffff)
+	}
+
+	return cpu.Mem.Write32(addr, value)
+}
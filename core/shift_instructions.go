@@ -21,18 +21,22 @@ func LslImm16(instr FetchedInstr) DecodedInstr {
 	return LslImm{Rd: Rd, Rm: Rm, Rn: 0, Imm: Imm, setflags: NOT_IT}
 }
 
-func (instr LslImm) Execute(regs *Registers) {
-	value := regs.R(instr.Rm)
+func (instr LslImm) Execute(cpu *CPU) {
+	value := cpu.R(instr.Rm)
 	shift_n := uint8(instr.Imm)
 
-	result := LSL(regs, value, shift_n, instr.setflags)
-	regs.SetR(instr.Rd, result)
+	result := LSL(cpu, value, shift_n, instr.setflags)
+	cpu.SetR(instr.Rd, result)
 }
 
 func (instr LslImm) String() string {
 	return fmt.Sprintf("lsl%s %s, %s, #%d", instr.setflags, instr.Rd, instr.Rm, instr.Imm)
 }
 
+func (instr LslImm) SetsFlags() SetFlags {
+	return instr.setflags
+}
+
 /* LSL - Logical Shift Left (register)
  * ARM ARM A7.7.68 */
 type LslReg InstrFields
@@ -46,18 +50,22 @@ func LslReg16(instr FetchedInstr) DecodedInstr {
 	return LslReg{Rd: Rdn, Rn: Rdn, Rm: Rm, Imm: 0, setflags: NOT_IT}
 }
 
-func (instr LslReg) Execute(regs *Registers) {
-	value := regs.R(instr.Rn)
-	shift_n := uint8(regs.R(instr.Rm))
+func (instr LslReg) Execute(cpu *CPU) {
+	value := cpu.R(instr.Rn)
+	shift_n := uint8(cpu.R(instr.Rm))
 
-	result := LSL(regs, value, shift_n, instr.setflags)
-	regs.SetR(instr.Rd, result)
+	result := LSL(cpu, value, shift_n, instr.setflags)
+	cpu.SetR(instr.Rd, result)
 }
 
 func (instr LslReg) String() string {
 	return fmt.Sprintf("lsl%s %s, %s", instr.setflags, instr.Rd, instr.Rm)
 }
 
+func (instr LslReg) SetsFlags() SetFlags {
+	return instr.setflags
+}
+
 /* LSR - Logical Shift Right (immediate)
  * ARM ARM A7.7.69 */
 type LsrImm InstrFields
@@ -72,18 +80,22 @@ func LsrImm16(instr FetchedInstr) DecodedInstr {
 	return LsrImm{Rd: Rd, Rm: Rm, Rn: 0, Imm: Imm, setflags: NOT_IT}
 }
 
-func (instr LsrImm) Execute(regs *Registers) {
-	value := regs.R(instr.Rm)
+func (instr LsrImm) Execute(cpu *CPU) {
+	value := cpu.R(instr.Rm)
 	shift_n := uint8(instr.Imm)
 
-	result := LSR(regs, value, shift_n, instr.setflags)
-	regs.SetR(instr.Rd, result)
+	result := LSR(cpu, value, shift_n, instr.setflags)
+	cpu.SetR(instr.Rd, result)
 }
 
 func (instr LsrImm) String() string {
 	return fmt.Sprintf("lsr%s %s, %s, #%d", instr.setflags, instr.Rd, instr.Rm, instr.Imm)
 }
 
+func (instr LsrImm) SetsFlags() SetFlags {
+	return instr.setflags
+}
+
 /* LSR - Logical Shift Right (register)
  * ARM ARM A7.7.70 */
 type LsrReg InstrFields
@@ -97,18 +109,22 @@ func LsrReg16(instr FetchedInstr) DecodedInstr {
 	return LsrReg{Rd: Rdn, Rn: Rdn, Rm: Rm, Imm: 0, setflags: NOT_IT}
 }
 
-func (instr LsrReg) Execute(regs *Registers) {
-	value := regs.R(instr.Rn)
-	shift_n := uint8(regs.R(instr.Rm))
+func (instr LsrReg) Execute(cpu *CPU) {
+	value := cpu.R(instr.Rn)
+	shift_n := uint8(cpu.R(instr.Rm))
 
-	result := LSR(regs, value, shift_n, instr.setflags)
-	regs.SetR(instr.Rd, result)
+	result := LSR(cpu, value, shift_n, instr.setflags)
+	cpu.SetR(instr.Rd, result)
 }
 
 func (instr LsrReg) String() string {
 	return fmt.Sprintf("lsr%s %s, %s", instr.setflags, instr.Rd, instr.Rm)
 }
 
+func (instr LsrReg) SetsFlags() SetFlags {
+	return instr.setflags
+}
+
 /* ASR - Arithmetic Shift Right (immediate)
  * ARM ARM A7.7.10 */
 type AsrImm InstrFields
@@ -127,14 +143,300 @@ func AsrImm16(instr FetchedInstr) DecodedInstr {
 	return AsrImm{Rd: Rd, Rn: 0, Rm: Rm, Imm: Imm, setflags: NOT_IT}
 }
 
-func (instr AsrImm) Execute(regs *Registers) {
-	value := regs.R(instr.Rm)
+func (instr AsrImm) Execute(cpu *CPU) {
+	value := cpu.R(instr.Rm)
 	shift_n := uint8(instr.Imm)
 
-	result := ASR(regs, value, shift_n, instr.setflags)
-	regs.SetR(instr.Rd, result)
+	result := ASR(cpu, value, shift_n, instr.setflags)
+	cpu.SetR(instr.Rd, result)
 }
 
 func (instr AsrImm) String() string {
 	return fmt.Sprintf("asr%s %s, %s, #%d", instr.setflags, instr.Rd, instr.Rm, instr.Imm)
 }
+
+func (instr AsrImm) SetsFlags() SetFlags {
+	return instr.setflags
+}
+
+/* ASR - Arithmetic Shift Right (register)
+ * ARM ARM A7.7.11 */
+type AsrReg InstrFields
+
+func AsrReg16(instr FetchedInstr) DecodedInstr {
+	raw_instr := instr.Uint32()
+
+	Rdn := RegIndex(raw_instr & 0x7)
+	Rm := RegIndex((raw_instr >> 3) & 0x7)
+
+	return AsrReg{Rd: Rdn, Rn: Rdn, Rm: Rm, Imm: 0, setflags: NOT_IT}
+}
+
+func (instr AsrReg) Execute(cpu *CPU) {
+	value := cpu.R(instr.Rn)
+	shift_n := uint8(cpu.R(instr.Rm))
+
+	result := ASR(cpu, value, shift_n, instr.setflags)
+	cpu.SetR(instr.Rd, result)
+}
+
+func (instr AsrReg) String() string {
+	return fmt.Sprintf("asr%s %s, %s", instr.setflags, instr.Rd, instr.Rm)
+}
+
+func (instr AsrReg) SetsFlags() SetFlags {
+	return instr.setflags
+}
+
+/* ROR - Rotate Right (register)
+ * ARM ARM A7.7.115 */
+type RorReg InstrFields
+
+func RorReg16(instr FetchedInstr) DecodedInstr {
+	raw_instr := instr.Uint32()
+
+	Rdn := RegIndex(raw_instr & 0x7)
+	Rm := RegIndex((raw_instr >> 3) & 0x7)
+
+	return RorReg{Rd: Rdn, Rn: Rdn, Rm: Rm, Imm: 0, setflags: NOT_IT}
+}
+
+func (instr RorReg) Execute(cpu *CPU) {
+	value := cpu.R(instr.Rn)
+	shift_n := uint8(cpu.R(instr.Rm))
+
+	result := ROR(cpu, value, shift_n, instr.setflags)
+	cpu.SetR(instr.Rd, result)
+}
+
+func (instr RorReg) String() string {
+	return fmt.Sprintf("ror%s %s, %s", instr.setflags, instr.Rd, instr.Rm)
+}
+
+func (instr RorReg) SetsFlags() SetFlags {
+	return instr.setflags
+}
+
+/* LSL - Logical Shift Left (immediate)
+ * ARM ARM A7.7.67
+ * Encoding T2 */
+type LslImmT2 InstrFields
+
+func LslImm32T2(instr FetchedInstr) DecodedInstr {
+	Rd, _, Rm, shift, setflags := decode_shifted_reg(instr.Uint32())
+
+	if BadReg(Rd) || BadReg(Rm) {
+		return UnpredictableInstr{Rd: Rd, Rm: Rm, Rn: 0, Imm: 0, setflags: setflags, Shift: shift}
+	}
+
+	return LslImmT2{Rd: Rd, Rm: Rm, Rn: 0, Imm: 0, setflags: setflags, Shift: shift}
+}
+
+func (instr LslImmT2) Execute(cpu *CPU) {
+	MovShiftedRegister(cpu, InstrFields(instr), instr.Shift)
+}
+
+func (instr LslImmT2) String() string {
+	return fmt.Sprintf("lsl%s.w %s, %s, #%d", instr.setflags, instr.Rd, instr.Rm, instr.Shift.amount)
+}
+
+/* LSR - Logical Shift Right (immediate)
+ * ARM ARM A7.7.69
+ * Encoding T2 */
+type LsrImmT2 InstrFields
+
+func LsrImm32T2(instr FetchedInstr) DecodedInstr {
+	Rd, _, Rm, shift, setflags := decode_shifted_reg(instr.Uint32())
+
+	if BadReg(Rd) || BadReg(Rm) {
+		return UnpredictableInstr{Rd: Rd, Rm: Rm, Rn: 0, Imm: 0, setflags: setflags, Shift: shift}
+	}
+
+	return LsrImmT2{Rd: Rd, Rm: Rm, Rn: 0, Imm: 0, setflags: setflags, Shift: shift}
+}
+
+func (instr LsrImmT2) Execute(cpu *CPU) {
+	MovShiftedRegister(cpu, InstrFields(instr), instr.Shift)
+}
+
+func (instr LsrImmT2) String() string {
+	return fmt.Sprintf("lsr%s.w %s, %s, #%d", instr.setflags, instr.Rd, instr.Rm, instr.Shift.amount)
+}
+
+/* ASR - Arithmetic Shift Right (immediate)
+ * ARM ARM A7.7.10
+ * Encoding T2 */
+type AsrImmT2 InstrFields
+
+func AsrImm32T2(instr FetchedInstr) DecodedInstr {
+	Rd, _, Rm, shift, setflags := decode_shifted_reg(instr.Uint32())
+
+	if BadReg(Rd) || BadReg(Rm) {
+		return UnpredictableInstr{Rd: Rd, Rm: Rm, Rn: 0, Imm: 0, setflags: setflags, Shift: shift}
+	}
+
+	return AsrImmT2{Rd: Rd, Rm: Rm, Rn: 0, Imm: 0, setflags: setflags, Shift: shift}
+}
+
+func (instr AsrImmT2) Execute(cpu *CPU) {
+	MovShiftedRegister(cpu, InstrFields(instr), instr.Shift)
+}
+
+func (instr AsrImmT2) String() string {
+	return fmt.Sprintf("asr%s.w %s, %s, #%d", instr.setflags, instr.Rd, instr.Rm, instr.Shift.amount)
+}
+
+/* ROR - Rotate Right (immediate)
+ * ARM ARM A7.7.114
+ * Encoding T1 */
+type RorImmT1 InstrFields
+
+func RorImm32T1(instr FetchedInstr) DecodedInstr {
+	Rd, _, Rm, shift, setflags := decode_shifted_reg(instr.Uint32())
+
+	if BadReg(Rd) || BadReg(Rm) {
+		return UnpredictableInstr{Rd: Rd, Rm: Rm, Rn: 0, Imm: 0, setflags: setflags, Shift: shift}
+	}
+
+	return RorImmT1{Rd: Rd, Rm: Rm, Rn: 0, Imm: 0, setflags: setflags, Shift: shift}
+}
+
+func (instr RorImmT1) Execute(cpu *CPU) {
+	MovShiftedRegister(cpu, InstrFields(instr), instr.Shift)
+}
+
+func (instr RorImmT1) String() string {
+	return fmt.Sprintf("ror%s.w %s, %s, #%d", instr.setflags, instr.Rd, instr.Rm, instr.Shift.amount)
+}
+
+/* RRX - Rotate Right with Extend
+ * ARM ARM A7.7.116
+ * Encoding T1 */
+type RrxT1 InstrFields
+
+func Rrx32T1(instr FetchedInstr) DecodedInstr {
+	Rd, _, Rm, shift, setflags := decode_shifted_reg(instr.Uint32())
+
+	if BadReg(Rd) || BadReg(Rm) {
+		return UnpredictableInstr{Rd: Rd, Rm: Rm, Rn: 0, Imm: 0, setflags: setflags, Shift: shift}
+	}
+
+	return RrxT1{Rd: Rd, Rm: Rm, Rn: 0, Imm: 0, setflags: setflags, Shift: shift}
+}
+
+func (instr RrxT1) Execute(cpu *CPU) {
+	MovShiftedRegister(cpu, InstrFields(instr), instr.Shift)
+}
+
+func (instr RrxT1) String() string {
+	return fmt.Sprintf("rrx%s %s, %s", instr.setflags, instr.Rd, instr.Rm)
+}
+
+/* LSL - Logical Shift Left (register)
+ * ARM ARM A7.7.68
+ * Encoding T2 */
+type LslRegT2 InstrFields
+
+func LslReg32T2(instr FetchedInstr) DecodedInstr {
+	Rd, Rn, Rm, _, setflags := decode_shifted_reg(instr.Uint32())
+
+	if BadReg(Rd) || BadReg(Rn) || BadReg(Rm) {
+		return UnpredictableInstr{Rd: Rd, Rm: Rm, Rn: Rn, Imm: 0, setflags: setflags}
+	}
+
+	return LslRegT2{Rd: Rd, Rm: Rm, Rn: Rn, Imm: 0, setflags: setflags}
+}
+
+/* Only the bottom byte of Rm is used, so amounts of 32 to 255 are possible */
+func (instr LslRegT2) Execute(cpu *CPU) {
+	value := cpu.R(instr.Rn)
+	shift_n := uint8(cpu.R(instr.Rm))
+
+	result := LSL(cpu, value, shift_n, instr.setflags)
+	cpu.SetR(instr.Rd, result)
+}
+
+func (instr LslRegT2) String() string {
+	return fmt.Sprintf("lsl%s.w %s, %s, %s", instr.setflags, instr.Rd, instr.Rn, instr.Rm)
+}
+
+/* LSR - Logical Shift Right (register)
+ * ARM ARM A7.7.70
+ * Encoding T2 */
+type LsrRegT2 InstrFields
+
+func LsrReg32T2(instr FetchedInstr) DecodedInstr {
+	Rd, Rn, Rm, _, setflags := decode_shifted_reg(instr.Uint32())
+
+	if BadReg(Rd) || BadReg(Rn) || BadReg(Rm) {
+		return UnpredictableInstr{Rd: Rd, Rm: Rm, Rn: Rn, Imm: 0, setflags: setflags}
+	}
+
+	return LsrRegT2{Rd: Rd, Rm: Rm, Rn: Rn, Imm: 0, setflags: setflags}
+}
+
+func (instr LsrRegT2) Execute(cpu *CPU) {
+	value := cpu.R(instr.Rn)
+	shift_n := uint8(cpu.R(instr.Rm))
+
+	result := LSR(cpu, value, shift_n, instr.setflags)
+	cpu.SetR(instr.Rd, result)
+}
+
+func (instr LsrRegT2) String() string {
+	return fmt.Sprintf("lsr%s.w %s, %s, %s", instr.setflags, instr.Rd, instr.Rn, instr.Rm)
+}
+
+/* ASR - Arithmetic Shift Right (register)
+ * ARM ARM A7.7.11
+ * Encoding T2 */
+type AsrRegT2 InstrFields
+
+func AsrReg32T2(instr FetchedInstr) DecodedInstr {
+	Rd, Rn, Rm, _, setflags := decode_shifted_reg(instr.Uint32())
+
+	if BadReg(Rd) || BadReg(Rn) || BadReg(Rm) {
+		return UnpredictableInstr{Rd: Rd, Rm: Rm, Rn: Rn, Imm: 0, setflags: setflags}
+	}
+
+	return AsrRegT2{Rd: Rd, Rm: Rm, Rn: Rn, Imm: 0, setflags: setflags}
+}
+
+func (instr AsrRegT2) Execute(cpu *CPU) {
+	value := cpu.R(instr.Rn)
+	shift_n := uint8(cpu.R(instr.Rm))
+
+	result := ASR(cpu, value, shift_n, instr.setflags)
+	cpu.SetR(instr.Rd, result)
+}
+
+func (instr AsrRegT2) String() string {
+	return fmt.Sprintf("asr%s.w %s, %s, %s", instr.setflags, instr.Rd, instr.Rn, instr.Rm)
+}
+
+/* ROR - Rotate Right (register)
+ * ARM ARM A7.7.115
+ * Encoding T2 */
+type RorRegT2 InstrFields
+
+func RorReg32T2(instr FetchedInstr) DecodedInstr {
+	Rd, Rn, Rm, _, setflags := decode_shifted_reg(instr.Uint32())
+
+	if BadReg(Rd) || BadReg(Rn) || BadReg(Rm) {
+		return UnpredictableInstr{Rd: Rd, Rm: Rm, Rn: Rn, Imm: 0, setflags: setflags}
+	}
+
+	return RorRegT2{Rd: Rd, Rm: Rm, Rn: Rn, Imm: 0, setflags: setflags}
+}
+
+func (instr RorRegT2) Execute(cpu *CPU) {
+	value := cpu.R(instr.Rn)
+	shift_n := uint8(cpu.R(instr.Rm))
+
+	result := ROR(cpu, value, shift_n, instr.setflags)
+	cpu.SetR(instr.Rd, result)
+}
+
+func (instr RorRegT2) String() string {
+	return fmt.Sprintf("ror%s.w %s, %s, %s", instr.setflags, instr.Rd, instr.Rn, instr.Rm)
+}
@@ -262,3 +262,217 @@ func TestExecuteAsrImm(t *testing.T) {
 
 	test_execute(t, cases)
 }
+
+func TestIdentifyAsrReg(t *testing.T) {
+	cases := []IdentifyCase{
+		{instr: FetchedInstr16(0x4111), instr_valid: true},  // asrs r1, r2
+		{instr: FetchedInstr16(0x40c0), instr_valid: false}, // lsrs r0, r0
+		{instr: FetchedInstr16(0x1000), instr_valid: false}, // asrs r0, r0, #32
+		{instr: FetchedInstr16(0xffff), instr_valid: false},
+	}
+
+	test_identify(t, cases, reflect.TypeOf(AsrReg{}))
+}
+
+func TestDecodeAsrReg16(t *testing.T) {
+	cases := []DecodeCase{
+		// asrs r1, r2
+		{instr: FetchedInstr16(0x4111), decoded: AsrReg{Rd: 1, Rn: 1, Rm: 2, Imm: 0, setflags: NOT_IT}},
+	}
+
+	test_decode(t, cases, AsrReg16)
+}
+
+func TestExecuteAsrReg(t *testing.T) {
+	cases := []ExecuteCase{
+		// asrs r1, r2
+		{instr: AsrReg{Rd: 1, Rn: 1, Rm: 2, Imm: 0, setflags: NOT_IT},
+			regs:     Registers{r: GeneralRegs{0, 0x80000010, 4, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0}},
+			expected: Registers{r: GeneralRegs{0, 0xf8000001, 4, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0}, Apsr: Apsr{N: true}}},
+		// asrs r1, r2 (shift by zero)
+		{instr: AsrReg{Rd: 1, Rn: 1, Rm: 2, Imm: 0, setflags: NOT_IT},
+			regs:     Registers{r: GeneralRegs{0, 0x10, 0x100, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0}, Apsr: Apsr{C: true}},
+			expected: Registers{r: GeneralRegs{0, 0x10, 0x100, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0}, Apsr: Apsr{C: true}}},
+	}
+
+	test_execute(t, cases)
+}
+
+func TestIdentifyRorReg(t *testing.T) {
+	cases := []IdentifyCase{
+		{instr: FetchedInstr16(0x41c7), instr_valid: true},  // rors r7, r0
+		{instr: FetchedInstr16(0x4111), instr_valid: false}, // asrs r1, r2
+		{instr: FetchedInstr16(0xffff), instr_valid: false},
+	}
+
+	test_identify(t, cases, reflect.TypeOf(RorReg{}))
+}
+
+func TestDecodeRorReg16(t *testing.T) {
+	cases := []DecodeCase{
+		// rors r7, r0
+		{instr: FetchedInstr16(0x41c7), decoded: RorReg{Rd: 7, Rn: 7, Rm: 0, Imm: 0, setflags: NOT_IT}},
+	}
+
+	test_decode(t, cases, RorReg16)
+}
+
+func TestExecuteRorReg(t *testing.T) {
+	cases := []ExecuteCase{
+		// rors r7, r0
+		{instr: RorReg{Rd: 7, Rn: 7, Rm: 0, Imm: 0, setflags: NOT_IT},
+			regs:     Registers{r: GeneralRegs{4, 0, 0, 0, 0, 0, 0, 0x12345678, 0, 0, 0, 0, 0}},
+			expected: Registers{r: GeneralRegs{4, 0, 0, 0, 0, 0, 0, 0x81234567, 0, 0, 0, 0, 0}, Apsr: Apsr{N: true, C: true}}},
+		// rors r7, r0 (multiple of 32)
+		{instr: RorReg{Rd: 7, Rn: 7, Rm: 0, Imm: 0, setflags: NOT_IT},
+			regs:     Registers{r: GeneralRegs{32, 0, 0, 0, 0, 0, 0, 0x12345678, 0, 0, 0, 0, 0}, Apsr: Apsr{C: true}},
+			expected: Registers{r: GeneralRegs{32, 0, 0, 0, 0, 0, 0, 0x12345678, 0, 0, 0, 0, 0}}},
+	}
+
+	test_execute(t, cases)
+}
+
+func TestExecuteLslRegLargeShift(t *testing.T) {
+	cases := []ExecuteCase{
+		// lsls r0, r1 (shift by 32)
+		{instr: LslReg{Rd: 0, Rn: 0, Rm: 1, Imm: 0, setflags: NOT_IT},
+			regs:     Registers{r: GeneralRegs{0x80000001, 32, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12}},
+			expected: Registers{r: GeneralRegs{0, 32, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12}, Apsr: Apsr{Z: true, C: true}}},
+		// lsls r0, r1 (shift by 33)
+		{instr: LslReg{Rd: 0, Rn: 0, Rm: 1, Imm: 0, setflags: NOT_IT},
+			regs:     Registers{r: GeneralRegs{0xffffffff, 33, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12}, Apsr: Apsr{C: true}},
+			expected: Registers{r: GeneralRegs{0, 33, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12}, Apsr: Apsr{Z: true}}},
+		// lsls r0, r1 (only the bottom byte of r1 is used)
+		{instr: LslReg{Rd: 0, Rn: 0, Rm: 1, Imm: 0, setflags: NOT_IT},
+			regs:     Registers{r: GeneralRegs{0xffffffff, 0x100, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12}, Apsr: Apsr{C: true}},
+			expected: Registers{r: GeneralRegs{0xffffffff, 0x100, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12}, Apsr: Apsr{N: true, C: true}}},
+	}
+
+	test_execute(t, cases)
+}
+
+func TestIdentifyLslImmT2(t *testing.T) {
+	cases := []IdentifyCase{
+		{instr: FetchedInstr32(0xea4f1041), instr_valid: true},  // lsl.w r0, r1, #5
+		{instr: FetchedInstr32(0xea4f0809), instr_valid: false}, // mov.w r8, r9
+		{instr: FetchedInstr32(0xea5f0213), instr_valid: false}, // lsrs.w r2, r3, #32
+	}
+
+	test_identify(t, cases, reflect.TypeOf(LslImmT2{}))
+}
+
+func TestDecodeMovRegImmShift32(t *testing.T) {
+	cases := []DecodeCase{
+		// lsl.w r0, r1, #5
+		{instr: FetchedInstr32(0xea4f1041), decoded: LslImmT2{Rd: 0, Rm: 1, Rn: 0, Imm: 0, setflags: NEVER, Shift: Shift{srtype: SRType_LSL, amount: 5}}},
+		// lsrs.w r2, r3, #32
+		{instr: FetchedInstr32(0xea5f0213), decoded: LsrImmT2{Rd: 2, Rm: 3, Rn: 0, Imm: 0, setflags: ALWAYS, Shift: Shift{srtype: SRType_LSR, amount: 32}}},
+		// asr.w r4, r5, #1
+		{instr: FetchedInstr32(0xea4f0465), decoded: AsrImmT2{Rd: 4, Rm: 5, Rn: 0, Imm: 0, setflags: NEVER, Shift: Shift{srtype: SRType_ASR, amount: 1}}},
+		// ror.w r6, r7, #12
+		{instr: FetchedInstr32(0xea4f3637), decoded: RorImmT1{Rd: 6, Rm: 7, Rn: 0, Imm: 0, setflags: NEVER, Shift: Shift{srtype: SRType_ROR, amount: 12}}},
+		// rrx r8, r9
+		{instr: FetchedInstr32(0xea4f0839), decoded: RrxT1{Rd: 8, Rm: 9, Rn: 0, Imm: 0, setflags: NEVER, Shift: Shift{srtype: SRType_RRX, amount: 1}}},
+		// rrxs r10, r11
+		{instr: FetchedInstr32(0xea5f0a3b), decoded: RrxT1{Rd: 10, Rm: 11, Rn: 0, Imm: 0, setflags: ALWAYS, Shift: Shift{srtype: SRType_RRX, amount: 1}}},
+		// mov.w r8, r9
+		{instr: FetchedInstr32(0xea4f0809), decoded: MovRegT3{Rd: 8, Rm: 9, Rn: 0, Imm: 0, setflags: NEVER}},
+	}
+
+	test_decode(t, cases, MovRegImmShift32)
+}
+
+func TestExecuteShiftImm32(t *testing.T) {
+	cases := []ExecuteCase{
+		// lsl.w r0, r1, #5
+		{instr: LslImmT2{Rd: 0, Rm: 1, Rn: 0, Imm: 0, setflags: NEVER, Shift: Shift{srtype: SRType_LSL, amount: 5}},
+			regs:     Registers{r: GeneralRegs{0, 0x08000001, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12}},
+			expected: Registers{r: GeneralRegs{0x20, 0x08000001, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12}}},
+		// lsrs.w r2, r3, #32
+		{instr: LsrImmT2{Rd: 2, Rm: 3, Rn: 0, Imm: 0, setflags: ALWAYS, Shift: Shift{srtype: SRType_LSR, amount: 32}},
+			regs:     Registers{r: GeneralRegs{0, 1, 2, 0x80000000, 4, 5, 6, 7, 8, 9, 10, 11, 12}},
+			expected: Registers{r: GeneralRegs{0, 1, 0, 0x80000000, 4, 5, 6, 7, 8, 9, 10, 11, 12}, Apsr: Apsr{Z: true, C: true}}},
+		// asr.w r4, r5, #1
+		{instr: AsrImmT2{Rd: 4, Rm: 5, Rn: 0, Imm: 0, setflags: NEVER, Shift: Shift{srtype: SRType_ASR, amount: 1}},
+			regs:     Registers{r: GeneralRegs{0, 1, 2, 3, 4, 0x80000000, 6, 7, 8, 9, 10, 11, 12}},
+			expected: Registers{r: GeneralRegs{0, 1, 2, 3, 0xc0000000, 0x80000000, 6, 7, 8, 9, 10, 11, 12}}},
+		// ror.w r6, r7, #12
+		{instr: RorImmT1{Rd: 6, Rm: 7, Rn: 0, Imm: 0, setflags: NEVER, Shift: Shift{srtype: SRType_ROR, amount: 12}},
+			regs:     Registers{r: GeneralRegs{0, 1, 2, 3, 4, 5, 6, 0x12345678, 8, 9, 10, 11, 12}},
+			expected: Registers{r: GeneralRegs{0, 1, 2, 3, 4, 5, 0x67812345, 0x12345678, 8, 9, 10, 11, 12}}},
+		// rrxs r10, r11
+		{instr: RrxT1{Rd: 10, Rm: 11, Rn: 0, Imm: 0, setflags: ALWAYS, Shift: Shift{srtype: SRType_RRX, amount: 1}},
+			regs:     Registers{r: GeneralRegs{0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 3, 12}, Apsr: Apsr{C: true}},
+			expected: Registers{r: GeneralRegs{0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 0x80000001, 3, 12}, Apsr: Apsr{N: true, C: true}}},
+		// rrxs r10, r11
+		{instr: RrxT1{Rd: 10, Rm: 11, Rn: 0, Imm: 0, setflags: ALWAYS, Shift: Shift{srtype: SRType_RRX, amount: 1}},
+			regs:     Registers{r: GeneralRegs{0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 2, 12}},
+			expected: Registers{r: GeneralRegs{0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 1, 2, 12}}},
+	}
+
+	test_execute(t, cases)
+}
+
+func TestIdentifyLslRegT2(t *testing.T) {
+	cases := []IdentifyCase{
+		{instr: FetchedInstr32(0xfa01f002), instr_valid: true},  // lsl.w r0, r1, r2
+		{instr: FetchedInstr32(0xfa34f305), instr_valid: false}, // lsrs.w r3, r4, r5
+		{instr: FetchedInstr32(0xfa01fd02), instr_valid: false}, // lsl.w sp, r1, r2 (UNPREDICTABLE)
+	}
+
+	test_identify(t, cases, reflect.TypeOf(LslRegT2{}))
+}
+
+func TestDecodeShiftReg32(t *testing.T) {
+	// lsl.w r0, r1, r2
+	test_decode(t, []DecodeCase{{instr: FetchedInstr32(0xfa01f002),
+		decoded: LslRegT2{Rd: 0, Rm: 2, Rn: 1, Imm: 0, setflags: NEVER}}}, LslReg32T2)
+	// lsrs.w r3, r4, r5
+	test_decode(t, []DecodeCase{{instr: FetchedInstr32(0xfa34f305),
+		decoded: LsrRegT2{Rd: 3, Rm: 5, Rn: 4, Imm: 0, setflags: ALWAYS}}}, LsrReg32T2)
+	// asr.w r6, r7, r8
+	test_decode(t, []DecodeCase{{instr: FetchedInstr32(0xfa47f608),
+		decoded: AsrRegT2{Rd: 6, Rm: 8, Rn: 7, Imm: 0, setflags: NEVER}}}, AsrReg32T2)
+	// rors.w r9, r10, r11
+	test_decode(t, []DecodeCase{{instr: FetchedInstr32(0xfa7af90b),
+		decoded: RorRegT2{Rd: 9, Rm: 11, Rn: 10, Imm: 0, setflags: ALWAYS}}}, RorReg32T2)
+}
+
+func TestExecuteShiftReg32(t *testing.T) {
+	cases := []ExecuteCase{
+		// lsls.w r0, r1, r2 (shift by 32)
+		{instr: LslRegT2{Rd: 0, Rm: 2, Rn: 1, Imm: 0, setflags: ALWAYS},
+			regs:     Registers{r: GeneralRegs{0, 0x80000001, 32, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12}},
+			expected: Registers{r: GeneralRegs{0, 0x80000001, 32, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12}, Apsr: Apsr{Z: true, C: true}}},
+		// lsl.w r0, r1, r2 (shift by 0, flags untouched)
+		{instr: LslRegT2{Rd: 0, Rm: 2, Rn: 1, Imm: 0, setflags: NEVER},
+			regs:     Registers{r: GeneralRegs{0, 0x80000001, 0xff00, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12}},
+			expected: Registers{r: GeneralRegs{0x80000001, 0x80000001, 0xff00, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12}}},
+		// lsrs.w r3, r4, r5 (shift by 32)
+		{instr: LsrRegT2{Rd: 3, Rm: 5, Rn: 4, Imm: 0, setflags: ALWAYS},
+			regs:     Registers{r: GeneralRegs{0, 1, 2, 3, 0x80000001, 32, 6, 7, 8, 9, 10, 11, 12}},
+			expected: Registers{r: GeneralRegs{0, 1, 2, 0, 0x80000001, 32, 6, 7, 8, 9, 10, 11, 12}, Apsr: Apsr{Z: true, C: true}}},
+		// lsrs.w r3, r4, r5 (shift by 255)
+		{instr: LsrRegT2{Rd: 3, Rm: 5, Rn: 4, Imm: 0, setflags: ALWAYS},
+			regs:     Registers{r: GeneralRegs{0, 1, 2, 3, 0xffffffff, 255, 6, 7, 8, 9, 10, 11, 12}, Apsr: Apsr{C: true}},
+			expected: Registers{r: GeneralRegs{0, 1, 2, 0, 0xffffffff, 255, 6, 7, 8, 9, 10, 11, 12}, Apsr: Apsr{Z: true}}},
+		// asrs.w r6, r7, r8 (shift by 40)
+		{instr: AsrRegT2{Rd: 6, Rm: 8, Rn: 7, Imm: 0, setflags: ALWAYS},
+			regs:     Registers{r: GeneralRegs{0, 1, 2, 3, 4, 5, 6, 0x80000000, 40, 9, 10, 11, 12}},
+			expected: Registers{r: GeneralRegs{0, 1, 2, 3, 4, 5, 0xffffffff, 0x80000000, 40, 9, 10, 11, 12}, Apsr: Apsr{N: true, C: true}}},
+		// asrs.w r6, r7, r8 (shift by 200)
+		{instr: AsrRegT2{Rd: 6, Rm: 8, Rn: 7, Imm: 0, setflags: ALWAYS},
+			regs:     Registers{r: GeneralRegs{0, 1, 2, 3, 4, 5, 6, 0x7fffffff, 200, 9, 10, 11, 12}, Apsr: Apsr{C: true}},
+			expected: Registers{r: GeneralRegs{0, 1, 2, 3, 4, 5, 0, 0x7fffffff, 200, 9, 10, 11, 12}, Apsr: Apsr{Z: true}}},
+		// rors.w r9, r10, r11 (rotate by 32)
+		{instr: RorRegT2{Rd: 9, Rm: 11, Rn: 10, Imm: 0, setflags: ALWAYS},
+			regs:     Registers{r: GeneralRegs{0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 0x80000001, 32, 12}},
+			expected: Registers{r: GeneralRegs{0, 1, 2, 3, 4, 5, 6, 7, 8, 0x80000001, 0x80000001, 32, 12}, Apsr: Apsr{N: true, C: true}}},
+		// rors.w r9, r10, r11 (rotate by 33)
+		{instr: RorRegT2{Rd: 9, Rm: 11, Rn: 10, Imm: 0, setflags: ALWAYS},
+			regs:     Registers{r: GeneralRegs{0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 0x80000001, 33, 12}},
+			expected: Registers{r: GeneralRegs{0, 1, 2, 3, 4, 5, 6, 7, 8, 0xc0000000, 0x80000001, 33, 12}, Apsr: Apsr{N: true, C: true}}},
+	}
+
+	test_execute(t, cases)
+}
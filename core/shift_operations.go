@@ -1,5 +1,7 @@
 package core
 
+import "fmt"
+
 /* Generic shifting function
  *
  * @param value uint32		Value to shift
@@ -10,45 +12,145 @@ package core
  */
 type ShiftFunc func(uint32, uint8) (uint32, bool)
 
+/* Shift type, as encoded in instructions
+ * ARM ARM A7.4.2 */
+type SRType uint8
+
+const (
+	SRType_LSL SRType = iota
+	SRType_LSR
+	SRType_ASR
+	SRType_ROR
+	SRType_RRX
+)
+
+func (srtype SRType) String() string {
+	switch srtype {
+	case SRType_LSL:
+		return "lsl"
+	case SRType_LSR:
+		return "lsr"
+	case SRType_ASR:
+		return "asr"
+	case SRType_ROR:
+		return "ror"
+	case SRType_RRX:
+		return "rrx"
+	}
+
+	return "?"
+}
+
+/* Shift applied to a register operand. The zero value is LSL #0, which
+ * leaves the operand unchanged. */
 type Shift struct {
-	function ShiftFunc
-	amount   uint8
+	srtype SRType
+	amount uint8
+}
+
+/* Decode the type and imm5 fields of an immediate shift
+ * ARM ARM A7.4.2 DecodeImmShift() */
+func DecodeImmShift(srtype uint32, imm5 uint32) Shift {
+	amount := uint8(imm5 & 0x1f)
+
+	switch srtype & 0x3 {
+	case 0x0:
+		return Shift{srtype: SRType_LSL, amount: amount}
+	case 0x1:
+		if amount == 0 {
+			amount = 32
+		}
+		return Shift{srtype: SRType_LSR, amount: amount}
+	case 0x2:
+		if amount == 0 {
+			amount = 32
+		}
+		return Shift{srtype: SRType_ASR, amount: amount}
+	}
+
+	if amount == 0 {
+		return Shift{srtype: SRType_RRX, amount: 1}
+	}
+
+	return Shift{srtype: SRType_ROR, amount: amount}
+}
+
+/* Evaluate shift, passing carry_in through if nothing is shifted */
+func (shift Shift) EvaluateC(input uint32, carry_in bool) (uint32, bool) {
+	return Shift_C(input, shift.srtype, shift.amount, carry_in)
+}
+
+func (shift Shift) String() string {
+	if shift.srtype == SRType_RRX {
+		return "rrx"
+	}
+
+	return fmt.Sprintf("%s #%d", shift.srtype, shift.amount)
 }
 
-func (shift Shift) Evaluate(input uint32) (uint32, bool) {
-	return shift.function(input, shift.amount)
+/* Format a register operand with its shift, omitting LSL #0 */
+func shifted_operand(Rm RegIndex, shift Shift) string {
+	if shift.srtype == SRType_LSL && shift.amount == 0 {
+		return Rm.String()
+	}
+
+	return fmt.Sprintf("%s, %s", Rm, shift)
+}
+
+/* Shift value by amount using the encoded shift type, with carry out
+ * ARM ARM A7.4.2 Shift_C() */
+func Shift_C(value uint32, srtype SRType, amount uint8, carry_in bool) (uint32, bool) {
+	if amount == 0 {
+		return value, carry_in
+	}
+
+	switch srtype {
+	case SRType_LSR:
+		return LSR_C(value, amount)
+	case SRType_ASR:
+		return ASR_C(value, amount)
+	case SRType_ROR:
+		return ROR_C(value, amount)
+	case SRType_RRX:
+		return RRX_C(value, carry_in)
+	}
+
+	return LSL_C(value, amount)
 }
 
 /* Perform shift operation, updating condition codes */
-func ShiftOp(regs *Registers, value uint32, shift_n uint8, setflags SetFlags, do_shift ShiftFunc) uint32 {
+func ShiftOp(cpu *CPU, value uint32, shift_n uint8, setflags SetFlags, do_shift ShiftFunc) uint32 {
 	var result uint32
 	var carry_out bool
 
 	if shift_n == 0 {
-		result, carry_out = value, regs.Apsr.C
+		result, carry_out = value, cpu.Apsr.C
 	} else {
 		result, carry_out = do_shift(value, shift_n)
 	}
 
-	if setflags.ShouldSetFlags(*regs) {
-		regs.Apsr.N = (result & 0x80000000) != 0
-		regs.Apsr.Z = (result) == 0
-		regs.Apsr.C = carry_out
+	if setflags.ShouldSetFlags(cpu.Registers) {
+		cpu.Apsr.N = (result & 0x80000000) != 0
+		cpu.Apsr.Z = (result) == 0
+		cpu.Apsr.C = carry_out
 	}
 
 	return result
 }
 
 /* Perform LSL instruction, updating condition codes */
-func LSL(regs *Registers, value uint32, shift_n uint8, setflags SetFlags) uint32 {
-	return ShiftOp(regs, value, shift_n, setflags, LSL_C)
+func LSL(cpu *CPU, value uint32, shift_n uint8, setflags SetFlags) uint32 {
+	return ShiftOp(cpu, value, shift_n, setflags, LSL_C)
 }
 
-/* Left shift value by a positive amount */
+/* Left shift value by a positive amount. Amounts over 32 shift
+ * out every bit, including the carry. */
 func LSL_C(value uint32, amount uint8) (uint32, bool) {
-	extended := uint64(value)
+	if amount > 32 {
+		return 0, false
+	}
 
-	extended = extended << amount
+	extended := uint64(value) << amount
 
 	result := uint32(extended & 0xffffffff)
 	carry_out := (extended & 0x100000000) != 0
@@ -57,33 +159,87 @@ func LSL_C(value uint32, amount uint8) (uint32, bool) {
 }
 
 /* Perform LSR instruction, updating condition codes */
-func LSR(regs *Registers, value uint32, shift_n uint8, setflags SetFlags) uint32 {
-	return ShiftOp(regs, value, shift_n, setflags, LSR_C)
+func LSR(cpu *CPU, value uint32, shift_n uint8, setflags SetFlags) uint32 {
+	return ShiftOp(cpu, value, shift_n, setflags, LSR_C)
 }
 
-/* Right shift value by a positive amount */
+/* Right shift value by a positive amount. Amounts over 32 shift
+ * out every bit, including the carry. */
 func LSR_C(value uint32, amount uint8) (uint32, bool) {
+	if amount > 32 {
+		return 0, false
+	}
+
 	/* The last bit to be carried out determines the carry */
-	carry_out := (value & (1 << (amount - 1))) != 0
+	carry_out := ((uint64(value) >> (amount - 1)) & 0x1) != 0
 
-	result := value >> amount
+	result := uint32(uint64(value) >> amount)
 
 	return result, carry_out
 }
 
 /* Perform ASR instruction, updating condition codes */
-func ASR(regs *Registers, value uint32, shift_n uint8, setflags SetFlags) uint32 {
-	return ShiftOp(regs, value, shift_n, setflags, ASR_C)
+func ASR(cpu *CPU, value uint32, shift_n uint8, setflags SetFlags) uint32 {
+	return ShiftOp(cpu, value, shift_n, setflags, ASR_C)
 }
 
-/* Right shift value by a positive amount, copying the leftmost bit */
+/* Right shift value by a positive amount, copying the leftmost bit.
+ * Amounts of 32 or more fill the result and carry with the sign bit. */
 func ASR_C(value uint32, amount uint8) (uint32, bool) {
-	/* The last bit to be carried out determines the carry */
-	carry_out := (value & (1 << (amount - 1))) != 0
+	if amount > 32 {
+		amount = 32
+	}
 
-	extended := int32(value)
+	extended := int64(int32(value))
+
+	/* The last bit to be carried out determines the carry */
+	carry_out := ((extended >> (amount - 1)) & 0x1) != 0
 
 	result := extended >> amount
 
 	return uint32(result), carry_out
 }
+
+/* Perform ROR instruction, updating condition codes */
+func ROR(cpu *CPU, value uint32, shift_n uint8, setflags SetFlags) uint32 {
+	return ShiftOp(cpu, value, shift_n, setflags, ROR_C)
+}
+
+/* Rotate value right by a positive amount */
+func ROR_C(value uint32, amount uint8) (uint32, bool) {
+	m := amount % 32
+
+	result := (value >> m) | (value << (32 - m))
+	carry_out := (result & 0x80000000) != 0
+
+	return result, carry_out
+}
+
+/* Rotate value right by one bit, shifting carry_in into the top */
+func RRX_C(value uint32, carry_in bool) (uint32, bool) {
+	result := (uint32(booltou(carry_in)) << 31) | (value >> 1)
+	carry_out := (value & 0x1) != 0
+
+	return result, carry_out
+}
+
+/* Extract the fields common to the data processing (shifted register)
+ * encodings
+ * ARM ARM A5.3.11 */
+func decode_shifted_reg(raw_instr uint32) (Rd RegIndex, Rn RegIndex, Rm RegIndex, shift Shift, setflags SetFlags) {
+	Rd = RegIndex((raw_instr >> 8) & 0xf)
+	Rn = RegIndex((raw_instr >> 16) & 0xf)
+	Rm = RegIndex(raw_instr & 0xf)
+
+	imm3 := (raw_instr >> 12) & 0x7
+	imm2 := (raw_instr >> 6) & 0x3
+	srtype := (raw_instr >> 4) & 0x3
+	shift = DecodeImmShift(srtype, (imm3<<2)|imm2)
+
+	setflags = NEVER
+	if (raw_instr>>20)&0x1 == 1 {
+		setflags = ALWAYS
+	}
+
+	return Rd, Rn, Rm, shift, setflags
+}
@@ -0,0 +1,71 @@
+package core
+
+import "errors"
+
+var ErrSleeping = errors.New("Core is sleeping, with nothing scheduled to wake it.")
+
+/* Low power state entered by WFI and WFE
+ * ARMv7-M ARM B1.5.18, B1.5.19 */
+type SleepState uint8
+
+const (
+	AWAKE SleepState = iota
+	SLEEP_WFI
+	SLEEP_WFE
+)
+
+/* Called instead of executing while the core sleeps, with the current
+ * virtual time. The host fast-forwards to its next scheduled wakeup,
+ * raising any interrupt or event due then, and returns the new time.
+ * Returning false means nothing is scheduled, so the core can never wake. */
+type IdleFunc func(now uint64) (uint64, bool)
+
+/* Execute WFI, sleeping until an interrupt */
+func (cpu *CPU) WaitForInterrupt() {
+	cpu.Sleep = SLEEP_WFI
+}
+
+/* Execute WFE, consuming the event register if it is set and otherwise
+ * sleeping until an event */
+func (cpu *CPU) WaitForEvent() {
+	if cpu.Event {
+		cpu.Event = false
+		return
+	}
+
+	cpu.Sleep = SLEEP_WFE
+}
+
+/* Signal an event, waking WFE or setting the event register for the
+ * next. SEV signals the core itself. */
+func (cpu *CPU) SendEvent() {
+	if cpu.Sleep == SLEEP_WFE {
+		cpu.Sleep = AWAKE
+		return
+	}
+
+	cpu.Event = true
+}
+
+/* Wake from WFI or WFE, as for an interrupt becoming pending */
+func (cpu *CPU) Wake() {
+	cpu.Sleep = AWAKE
+}
+
+/* Spend a step asleep, letting the host fast-forward virtual time */
+func (cpu *CPU) idle() error {
+	if cpu.Idle == nil {
+		return ErrSleeping
+	}
+
+	now, ok := cpu.Idle(cpu.Cycles)
+	if !ok {
+		return ErrSleeping
+	}
+
+	if now > cpu.Cycles {
+		cpu.Cycles = now
+	}
+
+	return nil
+}
@@ -0,0 +1,507 @@
+package core
+
+import "fmt"
+
+/* STR (immediate)
+ * ARM ARM A7.7.158
+ * Encoding T1 */
+type StrImmT1 LoadStoreFields
+
+func StrImm16T1(instr FetchedInstr) DecodedInstr {
+	return StrImmT1(decode_ldst_imm5(instr.Uint32(), 4))
+}
+
+func (instr StrImmT1) Execute(cpu *CPU) {
+	StoreImmediate(cpu, LoadStoreFields(instr), 4)
+}
+
+func (instr StrImmT1) String() string {
+	return fmt.Sprintf("str %s, %s", instr.Rt, imm_address(LoadStoreFields(instr)))
+}
+
+/* STR (immediate)
+ * ARM ARM A7.7.158
+ * Encoding T2 */
+type StrImmT2 LoadStoreFields
+
+func StrImm16T2(instr FetchedInstr) DecodedInstr {
+	raw_instr := instr.Uint32()
+
+	Rt := RegIndex((raw_instr >> 8) & 0x7)
+	Imm := (raw_instr & 0xff) << 2
+
+	return StrImmT2{Rt: Rt, Rn: SP, Imm: Imm, Index: true, Add: true, Wback: false}
+}
+
+func (instr StrImmT2) Execute(cpu *CPU) {
+	StoreImmediate(cpu, LoadStoreFields(instr), 4)
+}
+
+func (instr StrImmT2) String() string {
+	return fmt.Sprintf("str %s, %s", instr.Rt, imm_address(LoadStoreFields(instr)))
+}
+
+/* STR (immediate)
+ * ARM ARM A7.7.158
+ * Encoding T3 */
+type StrImmT3 LoadStoreFields
+
+func StrImm32T3(instr FetchedInstr) DecodedInstr {
+	raw_instr := instr.Uint32()
+	fields := decode_ldst_imm12(raw_instr)
+
+	if fields.Rn == PC {
+		return UndefinedInstr{}
+	}
+
+	if fields.Rt == PC {
+		return UnpredictableInstr{}
+	}
+
+	return StrImmT3(fields)
+}
+
+func (instr StrImmT3) Execute(cpu *CPU) {
+	StoreImmediate(cpu, LoadStoreFields(instr), 4)
+}
+
+func (instr StrImmT3) String() string {
+	return fmt.Sprintf("str.w %s, %s", instr.Rt, imm_address(LoadStoreFields(instr)))
+}
+
+/* STR (immediate)
+ * ARM ARM A7.7.158
+ * Encoding T4 */
+type StrImmT4 LoadStoreFields
+
+func StrImm32T4(instr FetchedInstr) DecodedInstr {
+	raw_instr := instr.Uint32()
+	fields := decode_ldst_imm8(raw_instr)
+
+	if ldst_imm8_unpriv(raw_instr) {
+		return Strt32T1(instr)
+	}
+
+	if fields.Rn == SP && fields.Index && !fields.Add && fields.Wback && fields.Imm == 4 {
+		return Push32T3(instr)
+	}
+
+	if fields.Rn == PC || (!fields.Index && !fields.Wback) {
+		return UndefinedInstr{}
+	}
+
+	if fields.Rt == PC || (fields.Wback && fields.Rn == fields.Rt) {
+		return UnpredictableInstr{}
+	}
+
+	return StrImmT4(fields)
+}
+
+func (instr StrImmT4) Execute(cpu *CPU) {
+	StoreImmediate(cpu, LoadStoreFields(instr), 4)
+}
+
+func (instr StrImmT4) String() string {
+	return fmt.Sprintf("str %s, %s", instr.Rt, imm_address(LoadStoreFields(instr)))
+}
+
+/* STR (register)
+ * ARM ARM A7.7.159
+ * Encoding T1 */
+type StrRegT1 LoadStoreFields
+
+func StrReg16T1(instr FetchedInstr) DecodedInstr {
+	return StrRegT1(decode_ldst_reg16(instr.Uint32()))
+}
+
+func (instr StrRegT1) Execute(cpu *CPU) {
+	StoreRegister(cpu, LoadStoreFields(instr), 4)
+}
+
+func (instr StrRegT1) String() string {
+	return fmt.Sprintf("str %s, %s", instr.Rt, reg_address(LoadStoreFields(instr)))
+}
+
+/* STR (register)
+ * ARM ARM A7.7.159
+ * Encoding T2 */
+type StrRegT2 LoadStoreFields
+
+func StrReg32T2(instr FetchedInstr) DecodedInstr {
+	raw_instr := instr.Uint32()
+	fields := decode_ldst_reg32(raw_instr)
+
+	if fields.Rn == PC {
+		return UndefinedInstr{}
+	}
+
+	if fields.Rt == PC || BadReg(fields.Rm) {
+		return UnpredictableInstr{}
+	}
+
+	return StrRegT2(fields)
+}
+
+func (instr StrRegT2) Execute(cpu *CPU) {
+	StoreRegister(cpu, LoadStoreFields(instr), 4)
+}
+
+func (instr StrRegT2) String() string {
+	return fmt.Sprintf("str.w %s, %s", instr.Rt, reg_address(LoadStoreFields(instr)))
+}
+
+/* STRT
+ * ARM ARM A7.7.170
+ * Encoding T1 */
+type StrtT1 LoadStoreFields
+
+func Strt32T1(instr FetchedInstr) DecodedInstr {
+	raw_instr := instr.Uint32()
+	fields := decode_ldst_imm8(raw_instr)
+
+	if fields.Rn == PC {
+		return UndefinedInstr{}
+	}
+
+	if BadReg(fields.Rt) {
+		return UnpredictableInstr{}
+	}
+
+	return StrtT1(fields)
+}
+
+func (instr StrtT1) Execute(cpu *CPU) {
+	StoreUnprivileged(cpu, LoadStoreFields(instr), 4)
+}
+
+func (instr StrtT1) String() string {
+	return fmt.Sprintf("strt %s, %s", instr.Rt, imm_address(LoadStoreFields(instr)))
+}
+
+/* STRB (immediate)
+ * ARM ARM A7.7.160
+ * Encoding T1 */
+type StrbImmT1 LoadStoreFields
+
+func StrbImm16T1(instr FetchedInstr) DecodedInstr {
+	return StrbImmT1(decode_ldst_imm5(instr.Uint32(), 1))
+}
+
+func (instr StrbImmT1) Execute(cpu *CPU) {
+	StoreImmediate(cpu, LoadStoreFields(instr), 1)
+}
+
+func (instr StrbImmT1) String() string {
+	return fmt.Sprintf("strb %s, %s", instr.Rt, imm_address(LoadStoreFields(instr)))
+}
+
+/* STRB (immediate)
+ * ARM ARM A7.7.160
+ * Encoding T2 */
+type StrbImmT2 LoadStoreFields
+
+func StrbImm32T2(instr FetchedInstr) DecodedInstr {
+	raw_instr := instr.Uint32()
+	fields := decode_ldst_imm12(raw_instr)
+
+	if fields.Rn == PC {
+		return UndefinedInstr{}
+	}
+
+	if BadReg(fields.Rt) {
+		return UnpredictableInstr{}
+	}
+
+	return StrbImmT2(fields)
+}
+
+func (instr StrbImmT2) Execute(cpu *CPU) {
+	StoreImmediate(cpu, LoadStoreFields(instr), 1)
+}
+
+func (instr StrbImmT2) String() string {
+	return fmt.Sprintf("strb.w %s, %s", instr.Rt, imm_address(LoadStoreFields(instr)))
+}
+
+/* STRB (immediate)
+ * ARM ARM A7.7.160
+ * Encoding T3 */
+type StrbImmT3 LoadStoreFields
+
+func StrbImm32T3(instr FetchedInstr) DecodedInstr {
+	raw_instr := instr.Uint32()
+	fields := decode_ldst_imm8(raw_instr)
+
+	if ldst_imm8_unpriv(raw_instr) {
+		return Strbt32T1(instr)
+	}
+
+	if fields.Rn == PC || (!fields.Index && !fields.Wback) {
+		return UndefinedInstr{}
+	}
+
+	if BadReg(fields.Rt) || (fields.Wback && fields.Rn == fields.Rt) {
+		return UnpredictableInstr{}
+	}
+
+	return StrbImmT3(fields)
+}
+
+func (instr StrbImmT3) Execute(cpu *CPU) {
+	StoreImmediate(cpu, LoadStoreFields(instr), 1)
+}
+
+func (instr StrbImmT3) String() string {
+	return fmt.Sprintf("strb %s, %s", instr.Rt, imm_address(LoadStoreFields(instr)))
+}
+
+/* STRB (register)
+ * ARM ARM A7.7.161
+ * Encoding T1 */
+type StrbRegT1 LoadStoreFields
+
+func StrbReg16T1(instr FetchedInstr) DecodedInstr {
+	return StrbRegT1(decode_ldst_reg16(instr.Uint32()))
+}
+
+func (instr StrbRegT1) Execute(cpu *CPU) {
+	StoreRegister(cpu, LoadStoreFields(instr), 1)
+}
+
+func (instr StrbRegT1) String() string {
+	return fmt.Sprintf("strb %s, %s", instr.Rt, reg_address(LoadStoreFields(instr)))
+}
+
+/* STRB (register)
+ * ARM ARM A7.7.161
+ * Encoding T2 */
+type StrbRegT2 LoadStoreFields
+
+func StrbReg32T2(instr FetchedInstr) DecodedInstr {
+	raw_instr := instr.Uint32()
+	fields := decode_ldst_reg32(raw_instr)
+
+	if fields.Rn == PC {
+		return UndefinedInstr{}
+	}
+
+	if BadReg(fields.Rt) || BadReg(fields.Rm) {
+		return UnpredictableInstr{}
+	}
+
+	return StrbRegT2(fields)
+}
+
+func (instr StrbRegT2) Execute(cpu *CPU) {
+	StoreRegister(cpu, LoadStoreFields(instr), 1)
+}
+
+func (instr StrbRegT2) String() string {
+	return fmt.Sprintf("strb.w %s, %s", instr.Rt, reg_address(LoadStoreFields(instr)))
+}
+
+/* STRBT
+ * ARM ARM A7.7.162
+ * Encoding T1 */
+type StrbtT1 LoadStoreFields
+
+func Strbt32T1(instr FetchedInstr) DecodedInstr {
+	raw_instr := instr.Uint32()
+	fields := decode_ldst_imm8(raw_instr)
+
+	if fields.Rn == PC {
+		return UndefinedInstr{}
+	}
+
+	if BadReg(fields.Rt) {
+		return UnpredictableInstr{}
+	}
+
+	return StrbtT1(fields)
+}
+
+func (instr StrbtT1) Execute(cpu *CPU) {
+	StoreUnprivileged(cpu, LoadStoreFields(instr), 1)
+}
+
+func (instr StrbtT1) String() string {
+	return fmt.Sprintf("strbt %s, %s", instr.Rt, imm_address(LoadStoreFields(instr)))
+}
+
+/* STRH (immediate)
+ * ARM ARM A7.7.167
+ * Encoding T1 */
+type StrhImmT1 LoadStoreFields
+
+func StrhImm16T1(instr FetchedInstr) DecodedInstr {
+	return StrhImmT1(decode_ldst_imm5(instr.Uint32(), 2))
+}
+
+func (instr StrhImmT1) Execute(cpu *CPU) {
+	StoreImmediate(cpu, LoadStoreFields(instr), 2)
+}
+
+func (instr StrhImmT1) String() string {
+	return fmt.Sprintf("strh %s, %s", instr.Rt, imm_address(LoadStoreFields(instr)))
+}
+
+/* STRH (immediate)
+ * ARM ARM A7.7.167
+ * Encoding T2 */
+type StrhImmT2 LoadStoreFields
+
+func StrhImm32T2(instr FetchedInstr) DecodedInstr {
+	raw_instr := instr.Uint32()
+	fields := decode_ldst_imm12(raw_instr)
+
+	if fields.Rn == PC {
+		return UndefinedInstr{}
+	}
+
+	if BadReg(fields.Rt) {
+		return UnpredictableInstr{}
+	}
+
+	return StrhImmT2(fields)
+}
+
+func (instr StrhImmT2) Execute(cpu *CPU) {
+	StoreImmediate(cpu, LoadStoreFields(instr), 2)
+}
+
+func (instr StrhImmT2) String() string {
+	return fmt.Sprintf("strh.w %s, %s", instr.Rt, imm_address(LoadStoreFields(instr)))
+}
+
+/* STRH (immediate)
+ * ARM ARM A7.7.167
+ * Encoding T3 */
+type StrhImmT3 LoadStoreFields
+
+func StrhImm32T3(instr FetchedInstr) DecodedInstr {
+	raw_instr := instr.Uint32()
+	fields := decode_ldst_imm8(raw_instr)
+
+	if ldst_imm8_unpriv(raw_instr) {
+		return Strht32T1(instr)
+	}
+
+	if fields.Rn == PC || (!fields.Index && !fields.Wback) {
+		return UndefinedInstr{}
+	}
+
+	if BadReg(fields.Rt) || (fields.Wback && fields.Rn == fields.Rt) {
+		return UnpredictableInstr{}
+	}
+
+	return StrhImmT3(fields)
+}
+
+func (instr StrhImmT3) Execute(cpu *CPU) {
+	StoreImmediate(cpu, LoadStoreFields(instr), 2)
+}
+
+func (instr StrhImmT3) String() string {
+	return fmt.Sprintf("strh %s, %s", instr.Rt, imm_address(LoadStoreFields(instr)))
+}
+
+/* STRH (register)
+ * ARM ARM A7.7.168
+ * Encoding T1 */
+type StrhRegT1 LoadStoreFields
+
+func StrhReg16T1(instr FetchedInstr) DecodedInstr {
+	return StrhRegT1(decode_ldst_reg16(instr.Uint32()))
+}
+
+func (instr StrhRegT1) Execute(cpu *CPU) {
+	StoreRegister(cpu, LoadStoreFields(instr), 2)
+}
+
+func (instr StrhRegT1) String() string {
+	return fmt.Sprintf("strh %s, %s", instr.Rt, reg_address(LoadStoreFields(instr)))
+}
+
+/* STRH (register)
+ * ARM ARM A7.7.168
+ * Encoding T2 */
+type StrhRegT2 LoadStoreFields
+
+func StrhReg32T2(instr FetchedInstr) DecodedInstr {
+	raw_instr := instr.Uint32()
+	fields := decode_ldst_reg32(raw_instr)
+
+	if fields.Rn == PC {
+		return UndefinedInstr{}
+	}
+
+	if BadReg(fields.Rt) || BadReg(fields.Rm) {
+		return UnpredictableInstr{}
+	}
+
+	return StrhRegT2(fields)
+}
+
+func (instr StrhRegT2) Execute(cpu *CPU) {
+	StoreRegister(cpu, LoadStoreFields(instr), 2)
+}
+
+func (instr StrhRegT2) String() string {
+	return fmt.Sprintf("strh.w %s, %s", instr.Rt, reg_address(LoadStoreFields(instr)))
+}
+
+/* STRHT
+ * ARM ARM A7.7.169
+ * Encoding T1 */
+type StrhtT1 LoadStoreFields
+
+func Strht32T1(instr FetchedInstr) DecodedInstr {
+	raw_instr := instr.Uint32()
+	fields := decode_ldst_imm8(raw_instr)
+
+	if fields.Rn == PC {
+		return UndefinedInstr{}
+	}
+
+	if BadReg(fields.Rt) {
+		return UnpredictableInstr{}
+	}
+
+	return StrhtT1(fields)
+}
+
+func (instr StrhtT1) Execute(cpu *CPU) {
+	StoreUnprivileged(cpu, LoadStoreFields(instr), 2)
+}
+
+func (instr StrhtT1) String() string {
+	return fmt.Sprintf("strht %s, %s", instr.Rt, imm_address(LoadStoreFields(instr)))
+}
+
+/* STRD (immediate)
+ * ARM ARM A7.7.163
+ * Encoding T1 */
+type StrdImmT1 LoadStoreFields
+
+func StrdImm32T1(instr FetchedInstr) DecodedInstr {
+	raw_instr := instr.Uint32()
+	fields := decode_ldst_dual(raw_instr)
+
+	if fields.Wback && (fields.Rn == fields.Rt || fields.Rn == fields.Rt2) {
+		return UnpredictableInstr{}
+	}
+
+	if fields.Rn == PC || BadReg(fields.Rt) || BadReg(fields.Rt2) {
+		return UnpredictableInstr{}
+	}
+
+	return StrdImmT1(fields)
+}
+
+func (instr StrdImmT1) Execute(cpu *CPU) {
+	StoreDual(cpu, LoadStoreFields(instr))
+}
+
+func (instr StrdImmT1) String() string {
+	return fmt.Sprintf("strd %s, %s, %s", instr.Rt, instr.Rt2, imm_address(LoadStoreFields(instr)))
+}
@@ -0,0 +1,175 @@
+package core
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestIdentifyStrImmT4(t *testing.T) {
+	cases := []IdentifyCase{
+		{instr: FetchedInstr32(0xf8465c04), instr_valid: true},  // str r5, [r6, #-4]
+		{instr: FetchedInstr32(0xf8465f04), instr_valid: true},  // str r5, [r6, #4]!
+		{instr: FetchedInstr32(0xf8465904), instr_valid: true},  // str r5, [r6], #-4
+		{instr: FetchedInstr32(0xf8410e04), instr_valid: false}, // strt r0, [r1, #4]
+		{instr: FetchedInstr32(0xf8c43fff), instr_valid: false}, // str.w r3, [r4, #4095]
+		{instr: FetchedInstr32(0xf84f5c04), instr_valid: false}, // Rn == PC
+		{instr: FetchedInstr32(0xf8466f04), instr_valid: false}, // str r6, [r6, #4]!
+	}
+
+	test_identify(t, cases, reflect.TypeOf(StrImmT4{}))
+}
+
+func TestIdentifyStrdImmT1(t *testing.T) {
+	cases := []IdentifyCase{
+		{instr: FetchedInstr32(0xe9c20102), instr_valid: true},  // strd r0, r1, [r2, #8]
+		{instr: FetchedInstr32(0xe9620102), instr_valid: true},  // strd r0, r1, [r2, #-8]!
+		{instr: FetchedInstr32(0xe8e20102), instr_valid: true},  // strd r0, r1, [r2], #8
+		{instr: FetchedInstr32(0xe9e20202), instr_valid: false}, // strd r0, r2, [r2, #8]!
+		{instr: FetchedInstr32(0xe9d20102), instr_valid: false}, // ldrd r0, r1, [r2, #8]
+	}
+
+	test_identify(t, cases, reflect.TypeOf(StrdImmT1{}))
+}
+
+func TestDecodeStr16(t *testing.T) {
+	cases := []DecodeCase{
+		// str r0, [r1, #4]
+		{instr: FetchedInstr16(0x6048), decoded: StrImmT1{Rt: 0, Rn: 1, Imm: 4, Index: true, Add: true, Wback: false}},
+	}
+
+	test_decode(t, cases, StrImm16T1)
+
+	cases = []DecodeCase{
+		// str r2, [sp, #8]
+		{instr: FetchedInstr16(0x9202), decoded: StrImmT2{Rt: 2, Rn: SP, Imm: 8, Index: true, Add: true, Wback: false}},
+	}
+
+	test_decode(t, cases, StrImm16T2)
+
+	cases = []DecodeCase{
+		// strh r0, [r1, #62]
+		{instr: FetchedInstr16(0x87c8), decoded: StrhImmT1{Rt: 0, Rn: 1, Imm: 62, Index: true, Add: true, Wback: false}},
+	}
+
+	test_decode(t, cases, StrhImm16T1)
+
+	cases = []DecodeCase{
+		// strb r0, [r1, r2]
+		{instr: FetchedInstr16(0x5488), decoded: StrbRegT1{Rt: 0, Rn: 1, Rm: 2, Index: true, Add: true, Wback: false}},
+	}
+
+	test_decode(t, cases, StrbReg16T1)
+}
+
+func TestDecodeStr32(t *testing.T) {
+	cases := []DecodeCase{
+		// str.w r3, [r4, #4095]
+		{instr: FetchedInstr32(0xf8c43fff), decoded: StrImmT3{Rt: 3, Rn: 4, Imm: 4095, Index: true, Add: true, Wback: false}},
+		// Rn == PC
+		{instr: FetchedInstr32(0xf8cf3fff), decoded: UndefinedInstr{}},
+		// str.w pc, [r4, #4095]
+		{instr: FetchedInstr32(0xf8c4ffff), decoded: UnpredictableInstr{}},
+	}
+
+	test_decode(t, cases, StrImm32T3)
+
+	cases = []DecodeCase{
+		// str r5, [r6], #-4
+		{instr: FetchedInstr32(0xf8465904), decoded: StrImmT4{Rt: 5, Rn: 6, Imm: 4, Index: false, Add: false, Wback: true}},
+		// strt r0, [r1, #4]
+		{instr: FetchedInstr32(0xf8410e04), decoded: StrtT1{Rt: 0, Rn: 1, Imm: 4, Index: true, Add: true, Wback: false}},
+		// P == 0 && W == 0
+		{instr: FetchedInstr32(0xf8465804), decoded: UndefinedInstr{}},
+	}
+
+	test_decode(t, cases, StrImm32T4)
+
+	cases = []DecodeCase{
+		// strb r8, [r9, #-1]!
+		{instr: FetchedInstr32(0xf8098d01), decoded: StrbImmT3{Rt: 8, Rn: 9, Imm: 1, Index: true, Add: false, Wback: true}},
+		// strb sp, [r9, #-1]!
+		{instr: FetchedInstr32(0xf809dd01), decoded: UnpredictableInstr{}},
+	}
+
+	test_decode(t, cases, StrbImm32T3)
+
+	cases = []DecodeCase{
+		// strh.w r0, [r1, r2, lsl #3]
+		{instr: FetchedInstr32(0xf8210032), decoded: StrhRegT2{Rt: 0, Rn: 1, Rm: 2,
+			Shift: Shift{srtype: SRType_LSL, amount: 3}, Index: true, Add: true, Wback: false}},
+		// strh.w r0, [r1, pc]
+		{instr: FetchedInstr32(0xf821000f), decoded: UnpredictableInstr{}},
+	}
+
+	test_decode(t, cases, StrhReg32T2)
+
+	cases = []DecodeCase{
+		// strd r0, r1, [r2, #-8]!
+		{instr: FetchedInstr32(0xe9620102), decoded: StrdImmT1{Rt: 0, Rt2: 1, Rn: 2, Imm: 8, Index: true, Add: false, Wback: true}},
+		// strd r0, r1, [r2], #8
+		{instr: FetchedInstr32(0xe8e20102), decoded: StrdImmT1{Rt: 0, Rt2: 1, Rn: 2, Imm: 8, Index: false, Add: true, Wback: true}},
+		// strd r0, r1, [pc, #8]
+		{instr: FetchedInstr32(0xe9cf0102), decoded: UnpredictableInstr{}},
+	}
+
+	test_decode(t, cases, StrdImm32T1)
+}
+
+func TestExecuteStr(t *testing.T) {
+	cases := []MemoryCase{
+		// str r0, [r1, #4]
+		{instr: StrImmT1{Rt: 0, Rn: 1, Imm: 4, Index: true, Add: true, Wback: false},
+			regs:     Registers{r: GeneralRegs{0xdeadbeef, 0xc, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12}},
+			expected: Registers{r: GeneralRegs{0xdeadbeef, 0xc, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12}},
+			addr:     0x10, word: 0xdeadbeef},
+		// str r5, [r6], #-4
+		{instr: StrImmT4{Rt: 5, Rn: 6, Imm: 4, Index: false, Add: false, Wback: true},
+			regs:     Registers{r: GeneralRegs{0, 1, 2, 3, 4, 0x11223344, 0x10, 7, 8, 9, 10, 11, 12}},
+			expected: Registers{r: GeneralRegs{0, 1, 2, 3, 4, 0x11223344, 0xc, 7, 8, 9, 10, 11, 12}},
+			addr:     0x10, word: 0x11223344},
+		// str r5, [r6, #4]! (unmapped)
+		{instr: StrImmT4{Rt: 5, Rn: 6, Imm: 4, Index: true, Add: true, Wback: true},
+			regs:     Registers{r: GeneralRegs{0, 1, 2, 3, 4, 0x11223344, 0x3c, 7, 8, 9, 10, 11, 12}},
+			expected: Registers{r: GeneralRegs{0, 1, 2, 3, 4, 0x11223344, 0x3c, 7, 8, 9, 10, 11, 12}},
+			addr:     0x10, word: 0x93929190, fault: true},
+		// strb r8, [r9, #-1]!
+		{instr: StrbImmT3{Rt: 8, Rn: 9, Imm: 1, Index: true, Add: false, Wback: true},
+			regs:     Registers{r: GeneralRegs{0, 1, 2, 3, 4, 5, 6, 7, 0x12345678, 0x11, 10, 11, 12}},
+			expected: Registers{r: GeneralRegs{0, 1, 2, 3, 4, 5, 6, 7, 0x12345678, 0x10, 10, 11, 12}},
+			addr:     0x10, word: 0x93929178},
+		// strh.w r0, [r1, r2, lsl #3]
+		{instr: StrhRegT2{Rt: 0, Rn: 1, Rm: 2, Shift: Shift{srtype: SRType_LSL, amount: 3}, Index: true, Add: true, Wback: false},
+			regs:     Registers{r: GeneralRegs{0x12345678, 0x8, 1, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12}},
+			expected: Registers{r: GeneralRegs{0x12345678, 0x8, 1, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12}},
+			addr:     0x10, word: 0x93925678},
+		// strht r0, [r1, #1] (unaligned)
+		{instr: StrhtT1{Rt: 0, Rn: 1, Imm: 1, Index: true, Add: true, Wback: false},
+			regs:     Registers{r: GeneralRegs{0x12345678, 0x10, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12}},
+			expected: Registers{r: GeneralRegs{0x12345678, 0x10, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12}},
+			addr:     0x10, word: 0x93567890},
+	}
+
+	test_execute_memory(t, cases)
+}
+
+func TestExecuteStrd(t *testing.T) {
+	cases := []MemoryCase{
+		// strd r0, r1, [r2, #-8]!
+		{instr: StrdImmT1{Rt: 0, Rt2: 1, Rn: 2, Imm: 8, Index: true, Add: false, Wback: true},
+			regs:     Registers{r: GeneralRegs{0x11111111, 0x22222222, 0x18, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12}},
+			expected: Registers{r: GeneralRegs{0x11111111, 0x22222222, 0x10, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12}},
+			addr:     0x14, word: 0x22222222},
+		// strd r0, r1, [r2], #8
+		{instr: StrdImmT1{Rt: 0, Rt2: 1, Rn: 2, Imm: 8, Index: false, Add: true, Wback: true},
+			regs:     Registers{r: GeneralRegs{0x11111111, 0x22222222, 0x10, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12}},
+			expected: Registers{r: GeneralRegs{0x11111111, 0x22222222, 0x18, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12}},
+			addr:     0x10, word: 0x11111111},
+		// strd r0, r1, [r2, #8]! (unaligned)
+		{instr: StrdImmT1{Rt: 0, Rt2: 1, Rn: 2, Imm: 8, Index: true, Add: true, Wback: true},
+			regs:     Registers{r: GeneralRegs{0x11111111, 0x22222222, 0x6, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12}},
+			expected: Registers{r: GeneralRegs{0x11111111, 0x22222222, 0x6, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12}},
+			addr:     0xc, word: 0x8f8e8d8c, fault: true},
+	}
+
+	test_execute_memory(t, cases)
+}
@@ -0,0 +1,121 @@
+package core
+
+import "fmt"
+
+/* MRS
+ * ARM ARM A7.7.81
+ * Encoding T1 */
+type MrsT1 SpecialRegFields
+
+func Mrs32T1(instr FetchedInstr) DecodedInstr {
+	raw_instr := instr.Uint32()
+
+	Rd := RegIndex((raw_instr >> 8) & 0xf)
+	SYSm := SpecialReg(raw_instr & 0xff)
+
+	if BadReg(Rd) || !SYSm.Valid() {
+		return UnpredictableInstr{}
+	}
+
+	return MrsT1{Rd: Rd, SYSm: SYSm}
+}
+
+func (instr MrsT1) Execute(cpu *CPU) {
+	MoveFromSpecial(cpu, SpecialRegFields(instr))
+}
+
+func (instr MrsT1) String() string {
+	return fmt.Sprintf("mrs %s, %s", instr.Rd, instr.SYSm)
+}
+
+/* MSR
+ * ARM ARM A7.7.82
+ * Encoding T1 */
+type MsrT1 SpecialRegFields
+
+func Msr32T1(instr FetchedInstr) DecodedInstr {
+	raw_instr := instr.Uint32()
+
+	Rn := RegIndex((raw_instr >> 16) & 0xf)
+	mask := uint8((raw_instr >> 10) & 0x3)
+	SYSm := SpecialReg(raw_instr & 0xff)
+
+	/* Only the APSR fields may be selected individually */
+	if mask == 0 || (mask != 0x2 && !SYSm.IncludesAPSR()) {
+		return UnpredictableInstr{}
+	}
+
+	if BadReg(Rn) || !SYSm.Valid() {
+		return UnpredictableInstr{}
+	}
+
+	return MsrT1{Rn: Rn, SYSm: SYSm, Mask: mask}
+}
+
+func (instr MsrT1) Execute(cpu *CPU) {
+	MoveToSpecial(cpu, SpecialRegFields(instr))
+}
+
+func (instr MsrT1) String() string {
+	suffix := ""
+	if instr.SYSm.IncludesAPSR() {
+		suffix = "_"
+		if instr.Mask&0x2 != 0 {
+			suffix += "nzcvq"
+		}
+		if instr.Mask&0x1 != 0 {
+			suffix += "g"
+		}
+	}
+
+	return fmt.Sprintf("msr %s%s, %s", instr.SYSm, suffix, instr.Rn)
+}
+
+/* CPS
+ * ARM ARM B5.2.1
+ * Encoding T1 */
+type CpsT1 struct {
+	Disable bool // CPSID, rather than CPSIE
+	I       bool // Affect PRIMASK
+	F       bool // Affect FAULTMASK
+}
+
+func Cps16T1(instr FetchedInstr) DecodedInstr {
+	raw_instr := instr.Uint32()
+
+	Disable := (raw_instr>>4)&0x1 == 1
+	I := (raw_instr>>1)&0x1 == 1
+	F := raw_instr&0x1 == 1
+
+	if !I && !F {
+		return UnpredictableInstr{}
+	}
+
+	return CpsT1{Disable: Disable, I: I, F: F}
+}
+
+func (instr CpsT1) Execute(cpu *CPU) {
+	if cpu.InITBlock() {
+		// UNPREDICTABLE
+		return
+	}
+
+	ChangeProcessorState(cpu, instr.Disable, instr.I, instr.F)
+}
+
+func (instr CpsT1) String() string {
+	effect := "ie"
+	if instr.Disable {
+		effect = "id"
+	}
+
+	flags := ""
+	if instr.I {
+		flags += "i"
+	}
+	if instr.F {
+		flags += "f"
+	}
+
+	return fmt.Sprintf("cps%s %s", effect, flags)
+}
@@ -0,0 +1,242 @@
+package core
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestIdentifyMrsMsr(t *testing.T) {
+	cases := []IdentifyCase{
+		{instr: FetchedInstr32(0xf3ef8003), instr_valid: true},  // mrs r0, xpsr
+		{instr: FetchedInstr32(0xf3ef8014), instr_valid: true},  // mrs r0, control
+		{instr: FetchedInstr32(0xf3808814), instr_valid: false}, // msr control, r0
+	}
+
+	test_identify(t, cases, reflect.TypeOf(MrsT1{}))
+
+	cases = []IdentifyCase{
+		{instr: FetchedInstr32(0xf3808800), instr_valid: true},  // msr apsr_nzcvq, r0
+		{instr: FetchedInstr32(0xf3808c03), instr_valid: true},  // msr xpsr_nzcvqg, r0
+		{instr: FetchedInstr32(0xf3808812), instr_valid: true},  // msr basepri_max, r0
+		{instr: FetchedInstr32(0xf3ef8000), instr_valid: false}, // mrs r0, apsr
+		{instr: FetchedInstr32(0xf3800000), instr_valid: false}, // usat r0, #0, r0
+	}
+
+	test_identify(t, cases, reflect.TypeOf(MsrT1{}))
+
+	cases = []IdentifyCase{
+		{instr: FetchedInstr16(0xb662), instr_valid: true},  // cpsie i
+		{instr: FetchedInstr16(0xb673), instr_valid: true},  // cpsid if
+		{instr: FetchedInstr16(0xb650), instr_valid: false}, // setend le (ARM only)
+	}
+
+	test_identify(t, cases, reflect.TypeOf(CpsT1{}))
+}
+
+func TestDecodeMrsMsr(t *testing.T) {
+	cases := []DecodeCase{
+		// mrs r0, basepri_max
+		{instr: FetchedInstr32(0xf3ef8012), decoded: MrsT1{Rd: 0, SYSm: SYSM_BASEPRI_MAX}},
+		// mrs sp, apsr
+		{instr: FetchedInstr32(0xf3ef8d00), decoded: UnpredictableInstr{}},
+		// mrs r0, #4
+		{instr: FetchedInstr32(0xf3ef8004), decoded: UnpredictableInstr{}},
+	}
+
+	test_decode(t, cases, Mrs32T1)
+
+	cases = []DecodeCase{
+		// msr apsr_g, r0
+		{instr: FetchedInstr32(0xf3808400), decoded: MsrT1{Rn: 0, SYSm: SYSM_APSR, Mask: 0x1}},
+		// msr primask, r1
+		{instr: FetchedInstr32(0xf3818810), decoded: MsrT1{Rn: 1, SYSm: SYSM_PRIMASK, Mask: 0x2}},
+		// msr primask, r1 with mask 11
+		{instr: FetchedInstr32(0xf3818c10), decoded: UnpredictableInstr{}},
+		// msr apsr, r0 with mask 00
+		{instr: FetchedInstr32(0xf3808000), decoded: UnpredictableInstr{}},
+	}
+
+	test_decode(t, cases, Msr32T1)
+
+	cases = []DecodeCase{
+		// cpsid f
+		{instr: FetchedInstr16(0xb671), decoded: CpsT1{Disable: true, F: true}},
+		// cpsie (no flags)
+		{instr: FetchedInstr16(0xb660), decoded: UnpredictableInstr{}},
+	}
+
+	test_decode(t, cases, Cps16T1)
+}
+
+func TestExecuteMrs(t *testing.T) {
+	flags := Apsr{N: true, Z: true, C: true, V: true, Q: true, GE: 0xf}
+
+	cases := []ExecuteCase{
+		// mrs r0, apsr (GE is only read with the DSP extension)
+		{instr: MrsT1{Rd: 0, SYSm: SYSM_APSR},
+			regs:     Registers{Apsr: flags},
+			expected: Registers{r: GeneralRegs{0xf8000000}, Apsr: flags}},
+		// mrs r0, xpsr (EPSR reads as zero)
+		{instr: MrsT1{Rd: 0, SYSm: SYSM_XPSR},
+			regs:     Registers{Apsr: flags, Ipsr: Ipsr{11}, Epsr: Epsr{T: true}, Mode: MODE_HANDLER},
+			expected: Registers{r: GeneralRegs{0xf800000b}, Apsr: flags, Ipsr: Ipsr{11}, Epsr: Epsr{T: true}, Mode: MODE_HANDLER}},
+		// mrs r0, ipsr
+		{instr: MrsT1{Rd: 0, SYSm: SYSM_IPSR},
+			regs:     Registers{Apsr: flags, Ipsr: Ipsr{11}, Mode: MODE_HANDLER},
+			expected: Registers{r: GeneralRegs{11}, Apsr: flags, Ipsr: Ipsr{11}, Mode: MODE_HANDLER}},
+		// mrs r0, msp
+		{instr: MrsT1{Rd: 0, SYSm: SYSM_MSP},
+			regs:     Registers{sp: SPRegs{0x20001000, 0x20002000}},
+			expected: Registers{r: GeneralRegs{0x20001000}, sp: SPRegs{0x20001000, 0x20002000}}},
+		// mrs r0, msp (unprivileged)
+		{instr: MrsT1{Rd: 0, SYSm: SYSM_MSP},
+			regs:     Registers{r: GeneralRegs{1}, sp: SPRegs{0x20001000, 0x20002000}, Control: Control{Npriv: true}},
+			expected: Registers{r: GeneralRegs{0}, sp: SPRegs{0x20001000, 0x20002000}, Control: Control{Npriv: true}}},
+		// mrs r0, basepri
+		{instr: MrsT1{Rd: 0, SYSm: SYSM_BASEPRI},
+			regs:     Registers{Basepri: 0x40},
+			expected: Registers{r: GeneralRegs{0x40}, Basepri: 0x40}},
+		// mrs r0, control (unprivileged)
+		{instr: MrsT1{Rd: 0, SYSm: SYSM_CONTROL},
+			regs:     Registers{Control: Control{Npriv: true, Spsel: PSP}},
+			expected: Registers{r: GeneralRegs{3}, Control: Control{Npriv: true, Spsel: PSP}}},
+	}
+
+	test_execute(t, cases)
+}
+
+func TestExecuteMsr(t *testing.T) {
+	cases := []ExecuteCase{
+		// msr apsr_nzcvq, r0
+		{instr: MsrT1{Rn: 0, SYSm: SYSM_APSR, Mask: 0x2},
+			regs:     Registers{r: GeneralRegs{0xa00f0000}, Apsr: Apsr{Z: true, GE: 0x3}},
+			expected: Registers{r: GeneralRegs{0xa00f0000}, Apsr: Apsr{N: true, C: true, GE: 0x3}}},
+		// msr apsr_g, r0 (unprivileged)
+		{instr: MsrT1{Rn: 0, SYSm: SYSM_APSR, Mask: 0x1},
+			regs:     Registers{r: GeneralRegs{0xf0050000}, Control: Control{Npriv: true}},
+			expected: Registers{r: GeneralRegs{0xf0050000}, Apsr: Apsr{GE: 0x5}, Control: Control{Npriv: true}}},
+		// msr ipsr, r0
+		{instr: MsrT1{Rn: 0, SYSm: SYSM_IPSR, Mask: 0x2},
+			regs:     Registers{r: GeneralRegs{0xf}},
+			expected: Registers{r: GeneralRegs{0xf}}},
+		// msr msp, r0
+		{instr: MsrT1{Rn: 0, SYSm: SYSM_MSP, Mask: 0x2},
+			regs:     Registers{r: GeneralRegs{0x20001003}},
+			expected: Registers{r: GeneralRegs{0x20001003}, sp: SPRegs{0x20001000, 0}}},
+		// msr psp, r0 (unprivileged)
+		{instr: MsrT1{Rn: 0, SYSm: SYSM_PSP, Mask: 0x2},
+			regs:     Registers{r: GeneralRegs{0x20001000}, Control: Control{Npriv: true}},
+			expected: Registers{r: GeneralRegs{0x20001000}, Control: Control{Npriv: true}}},
+		// msr primask, r0
+		{instr: MsrT1{Rn: 0, SYSm: SYSM_PRIMASK, Mask: 0x2},
+			regs:     Registers{r: GeneralRegs{1}},
+			expected: Registers{r: GeneralRegs{1}, Primask: true}},
+		// msr primask, r0 (unprivileged)
+		{instr: MsrT1{Rn: 0, SYSm: SYSM_PRIMASK, Mask: 0x2},
+			regs:     Registers{r: GeneralRegs{1}, Control: Control{Npriv: true}},
+			expected: Registers{r: GeneralRegs{1}, Control: Control{Npriv: true}}},
+		// msr basepri_max, r0 (lower priority is ignored)
+		{instr: MsrT1{Rn: 0, SYSm: SYSM_BASEPRI_MAX, Mask: 0x2},
+			regs:     Registers{r: GeneralRegs{0x80}, Basepri: 0x40},
+			expected: Registers{r: GeneralRegs{0x80}, Basepri: 0x40}},
+		// msr basepri_max, r0
+		{instr: MsrT1{Rn: 0, SYSm: SYSM_BASEPRI_MAX, Mask: 0x2},
+			regs:     Registers{r: GeneralRegs{0x20}, Basepri: 0x40},
+			expected: Registers{r: GeneralRegs{0x20}, Basepri: 0x20}},
+		// msr basepri_max, r0 (zero is ignored)
+		{instr: MsrT1{Rn: 0, SYSm: SYSM_BASEPRI_MAX, Mask: 0x2},
+			regs:     Registers{r: GeneralRegs{0}, Basepri: 0x40},
+			expected: Registers{r: GeneralRegs{0}, Basepri: 0x40}},
+		// msr basepri, r0
+		{instr: MsrT1{Rn: 0, SYSm: SYSM_BASEPRI, Mask: 0x2},
+			regs:     Registers{r: GeneralRegs{0}, Basepri: 0x40},
+			expected: Registers{r: GeneralRegs{0}}},
+		// msr faultmask, r0
+		{instr: MsrT1{Rn: 0, SYSm: SYSM_FAULTMASK, Mask: 0x2},
+			regs:     Registers{r: GeneralRegs{1}},
+			expected: Registers{r: GeneralRegs{1}, Faultmask: true}},
+		// msr faultmask, r0 (in the HardFault handler)
+		{instr: MsrT1{Rn: 0, SYSm: SYSM_FAULTMASK, Mask: 0x2},
+			regs:     Registers{r: GeneralRegs{1}, Mode: MODE_HANDLER, Ipsr: Ipsr{EXCEPTION_HARDFAULT}},
+			expected: Registers{r: GeneralRegs{1}, Mode: MODE_HANDLER, Ipsr: Ipsr{EXCEPTION_HARDFAULT}}},
+		// msr control, r0
+		{instr: MsrT1{Rn: 0, SYSm: SYSM_CONTROL, Mask: 0x2},
+			regs:     Registers{r: GeneralRegs{3}},
+			expected: Registers{r: GeneralRegs{3}, Control: Control{Npriv: true, Spsel: PSP}}},
+		// msr control, r0 (SPSEL is ignored in Handler mode)
+		{instr: MsrT1{Rn: 0, SYSm: SYSM_CONTROL, Mask: 0x2},
+			regs:     Registers{r: GeneralRegs{3}, Mode: MODE_HANDLER},
+			expected: Registers{r: GeneralRegs{3}, Mode: MODE_HANDLER, Control: Control{Npriv: true}}},
+		// msr control, r0 (unprivileged)
+		{instr: MsrT1{Rn: 0, SYSm: SYSM_CONTROL, Mask: 0x2},
+			regs:     Registers{r: GeneralRegs{0}, Control: Control{Npriv: true}},
+			expected: Registers{r: GeneralRegs{0}, Control: Control{Npriv: true}}},
+	}
+
+	test_execute(t, cases)
+}
+
+func TestExecuteControlFpca(t *testing.T) {
+	/* CONTROL.FPCA only exists with the FP extension */
+	for _, profile := range []Profile{PROFILE_ARMV7EM, PROFILE_ARMV7EM_FP} {
+		cpu := CPU{Profile: profile}
+		cpu.SetR(0, 0x4)
+
+		MsrT1{Rn: 0, SYSm: SYSM_CONTROL, Mask: 0x2}.Execute(&cpu)
+		MrsT1{Rd: 1, SYSm: SYSM_CONTROL}.Execute(&cpu)
+
+		if cpu.Control.Fpca != profile.FPU || cpu.R(1) != uint32(booltou(profile.FPU))<<2 {
+			t.Errorf("%+v: FPCA = %v, control = %#x", profile, cpu.Control.Fpca, cpu.R(1))
+		}
+	}
+}
+
+func TestExecuteCps(t *testing.T) {
+	cases := []ExecuteCase{
+		// cpsid i
+		{instr: CpsT1{Disable: true, I: true},
+			regs:     Registers{},
+			expected: Registers{Primask: true}},
+		// cpsie if
+		{instr: CpsT1{Disable: false, I: true, F: true},
+			regs:     Registers{Primask: true, Faultmask: true},
+			expected: Registers{}},
+		// cpsid f (in the NMI handler)
+		{instr: CpsT1{Disable: true, F: true},
+			regs:     Registers{Mode: MODE_HANDLER, Ipsr: Ipsr{EXCEPTION_NMI}},
+			expected: Registers{Mode: MODE_HANDLER, Ipsr: Ipsr{EXCEPTION_NMI}}},
+		// cpsid i (unprivileged)
+		{instr: CpsT1{Disable: true, I: true},
+			regs:     Registers{Control: Control{Npriv: true}},
+			expected: Registers{Control: Control{Npriv: true}}},
+	}
+
+	test_execute(t, cases)
+}
+
+func TestXpsr(t *testing.T) {
+	cases := []struct {
+		regs Registers
+		xpsr uint32
+	}{
+		{regs: Registers{Epsr: Epsr{T: true}}, xpsr: 0x01000000},
+		{regs: Registers{Apsr: Apsr{N: true, V: true, Q: true, GE: 0xa}, Ipsr: Ipsr{0x1ff}},
+			xpsr: 0x980a01ff},
+		// itte eq, first instruction
+		{regs: Registers{Epsr: Epsr{T: true, IT: 0x06}}, xpsr: 0x05000400},
+		// Interrupted LDM, continuing from r4
+		{regs: Registers{Epsr: Epsr{T: true, ICI: 0x40}}, xpsr: 0x01004000},
+	}
+
+	for _, test := range cases {
+		if xpsr := test.regs.Xpsr(); xpsr != test.xpsr {
+			t.Errorf("Xpsr() = %#x, expected %#x\n%s", xpsr, test.xpsr, test.regs.Pretty())
+		}
+
+		var regs Registers
+		regs.SetXpsr(test.xpsr)
+		if regs != test.regs {
+			t.Errorf("SetXpsr(%#x):\n%s\nexpected:\n%s", test.xpsr, regs.Pretty(), test.regs.Pretty())
+		}
+	}
+}
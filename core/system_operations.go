@@ -0,0 +1,187 @@
+package core
+
+/* Special registers accessed by MRS and MSR, numbered by SYSm
+ * ARMv7-M ARM B5.1.1 */
+type SpecialReg uint8
+
+const (
+	SYSM_APSR        SpecialReg = 0
+	SYSM_IAPSR       SpecialReg = 1
+	SYSM_EAPSR       SpecialReg = 2
+	SYSM_XPSR        SpecialReg = 3
+	SYSM_IPSR        SpecialReg = 5
+	SYSM_EPSR        SpecialReg = 6
+	SYSM_IEPSR       SpecialReg = 7
+	SYSM_MSP         SpecialReg = 8
+	SYSM_PSP         SpecialReg = 9
+	SYSM_PRIMASK     SpecialReg = 16
+	SYSM_BASEPRI     SpecialReg = 17
+	SYSM_BASEPRI_MAX SpecialReg = 18
+	SYSM_FAULTMASK   SpecialReg = 19
+	SYSM_CONTROL     SpecialReg = 20
+)
+
+var special_reg_names = map[SpecialReg]string{
+	SYSM_APSR:        "apsr",
+	SYSM_IAPSR:       "iapsr",
+	SYSM_EAPSR:       "eapsr",
+	SYSM_XPSR:        "xpsr",
+	SYSM_IPSR:        "ipsr",
+	SYSM_EPSR:        "epsr",
+	SYSM_IEPSR:       "iepsr",
+	SYSM_MSP:         "msp",
+	SYSM_PSP:         "psp",
+	SYSM_PRIMASK:     "primask",
+	SYSM_BASEPRI:     "basepri",
+	SYSM_BASEPRI_MAX: "basepri_max",
+	SYSM_FAULTMASK:   "faultmask",
+	SYSM_CONTROL:     "control",
+}
+
+func (reg SpecialReg) Valid() bool {
+	_, ok := special_reg_names[reg]
+	return ok
+}
+
+/* One of the xPSR views, rather than a stack pointer or mask register */
+func (reg SpecialReg) IsPSR() bool {
+	return reg <= SYSM_IEPSR
+}
+
+/* The xPSR views with SYSm<2> clear include the APSR */
+func (reg SpecialReg) IncludesAPSR() bool {
+	return reg.IsPSR() && reg&0x4 == 0
+}
+
+func (reg SpecialReg) String() string {
+	return special_reg_names[reg]
+}
+
+/* FAULTMASK can only be set below priority -1, so not by the NMI or
+ * HardFault handlers, nor when already set */
+func faultmask_settable(cpu *CPU) bool {
+	if cpu.Faultmask {
+		return false
+	}
+
+	return cpu.Mode != MODE_HANDLER ||
+		(cpu.Ipsr.ExcpNum != EXCEPTION_NMI && cpu.Ipsr.ExcpNum != EXCEPTION_HARDFAULT)
+}
+
+/* Perform MRS. Unprivileged reads of the stack pointers and mask
+ * registers return zero, and EPSR always reads as zero.
+ * ARMv7-M ARM B5.2.2 */
+func MoveFromSpecial(cpu *CPU, instr SpecialRegFields) {
+	var value uint32
+	privileged := cpu.CurrentModeIsPrivileged()
+
+	switch {
+	case instr.SYSm.IsPSR():
+		xpsr := cpu.Xpsr()
+		if instr.SYSm&0x1 != 0 {
+			value |= xpsr & XPSR_EXCPNUM_MASK
+		}
+		if instr.SYSm.IncludesAPSR() {
+			value |= xpsr & XPSR_NZCVQ_MASK
+			if cpu.Profile.DSP {
+				value |= xpsr & XPSR_GE_MASK
+			}
+		}
+	case !privileged && instr.SYSm != SYSM_CONTROL:
+		value = 0
+	case instr.SYSm == SYSM_MSP:
+		value = cpu.Msp()
+	case instr.SYSm == SYSM_PSP:
+		value = cpu.Psp()
+	case instr.SYSm == SYSM_PRIMASK:
+		value = uint32(booltou(cpu.Primask))
+	case instr.SYSm == SYSM_BASEPRI, instr.SYSm == SYSM_BASEPRI_MAX:
+		value = uint32(cpu.Basepri)
+	case instr.SYSm == SYSM_FAULTMASK:
+		value = uint32(booltou(cpu.Faultmask))
+	case instr.SYSm == SYSM_CONTROL:
+		value = uint32(booltou(cpu.Control.Npriv)) | uint32(cpu.Control.Spsel)<<1
+		if cpu.Profile.FPU {
+			value |= uint32(booltou(cpu.Control.Fpca)) << 2
+		}
+	}
+
+	cpu.SetR(instr.Rd, value)
+}
+
+/* Perform MSR. Only the APSR may be written unprivileged, and IPSR and
+ * EPSR are never written. CONTROL.SPSEL is only written in Thread mode,
+ * and CONTROL.FPCA only exists with the FP extension.
+ * ARMv7-M ARM B5.2.3 */
+func MoveToSpecial(cpu *CPU, instr SpecialRegFields) {
+	value := cpu.R(instr.Rn)
+
+	if instr.SYSm.IsPSR() {
+		if !instr.SYSm.IncludesAPSR() {
+			return
+		}
+
+		var mask uint32
+		if instr.Mask&0x2 != 0 {
+			mask |= XPSR_NZCVQ_MASK
+		}
+		if instr.Mask&0x1 != 0 {
+			mask |= XPSR_GE_MASK
+		}
+
+		cpu.SetXpsr(masked(cpu.Xpsr(), value, mask))
+		return
+	}
+
+	if !cpu.CurrentModeIsPrivileged() {
+		return
+	}
+
+	switch instr.SYSm {
+	case SYSM_MSP:
+		cpu.sp[MSP] = value &^ 0x3
+	case SYSM_PSP:
+		cpu.sp[PSP] = value &^ 0x3
+	case SYSM_PRIMASK:
+		cpu.Primask = value&0x1 != 0
+	case SYSM_BASEPRI:
+		cpu.Basepri = uint8(value)
+	case SYSM_BASEPRI_MAX:
+		/* Can only raise the priority boost */
+		if basepri := uint8(value); basepri != 0 && (basepri < cpu.Basepri || cpu.Basepri == 0) {
+			cpu.Basepri = basepri
+		}
+	case SYSM_FAULTMASK:
+		if faultmask_settable(cpu) {
+			cpu.Faultmask = value&0x1 != 0
+		}
+	case SYSM_CONTROL:
+		cpu.Control.Npriv = value&0x1 != 0
+		if cpu.Mode == MODE_THREAD {
+			cpu.Control.Spsel = SPType((value >> 1) & 0x1)
+		}
+		if cpu.Profile.FPU {
+			cpu.Control.Fpca = value&0x4 != 0
+		}
+	}
+}
+
+/* Perform CPS, ignored when unprivileged
+ * ARMv7-M ARM B5.2.1 */
+func ChangeProcessorState(cpu *CPU, disable bool, affect_i bool, affect_f bool) {
+	if !cpu.CurrentModeIsPrivileged() {
+		return
+	}
+
+	if affect_i {
+		cpu.Primask = disable
+	}
+
+	if affect_f {
+		if !disable {
+			cpu.Faultmask = false
+		} else if faultmask_settable(cpu) {
+			cpu.Faultmask = true
+		}
+	}
+}
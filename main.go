@@ -2,87 +2,184 @@ package main
 
 import (
 	"./core"
+	"bytes"
+	"debug/elf"
 	"flag"
 	"fmt"
-	"io"
+	"io/ioutil"
 	"os"
 )
 
 var execute = flag.Bool("execute", false, "Execute instructions in addition to decoding")
+var reset = flag.Bool("reset", false, "Boot from the vector table instead of the entry point")
+var cpu_name = flag.String("cpu", "cortex-m4", "CPU to emulate (cortex-m3, cortex-m4, cortex-m4f)")
+
+var profiles = map[string]core.Profile{
+	"cortex-m3":  core.PROFILE_ARMV7M,
+	"cortex-m4":  core.PROFILE_ARMV7EM,
+	"cortex-m4f": core.PROFILE_ARMV7EM_FP,
+}
+
+/* Architecture profile of the selected CPU */
+var profile core.Profile
+
+/* Symbols from an ELF image, used to label instructions */
+var symbols core.SymbolTable
 
 func main() {
 	flag.Parse()
 
 	if flag.NArg() != 1 {
 		fmt.Printf("ARMv7-M Emulator\n")
-		fmt.Printf("usage: %s binary\n", os.Args[0])
+		fmt.Printf("usage: %s binary|elf\n", os.Args[0])
 		flag.PrintDefaults()
 		os.Exit(1)
 	}
 
+	var ok bool
+	if profile, ok = profiles[*cpu_name]; !ok {
+		fmt.Printf("Unknown CPU %s\n", *cpu_name)
+		os.Exit(1)
+	}
+
 	binary := flag.Arg(0)
-	file, err := os.Open(binary)
+	data, err := ioutil.ReadFile(binary)
+	if err != nil {
+		fmt.Printf("%s\n", err)
+		os.Exit(1)
+	}
+
+	bus := core.NewDefaultBus()
+	image, err := load(bus, data)
 	if err != nil {
 		fmt.Printf("%s\n", err)
 		os.Exit(1)
 	}
 
-	regs := new(core.Registers)
-	b := make([]byte, 2, 2)
-	addr := 0
-	var upper *core.FetchedInstr16 = nil
+	symbols = image.Symbols
 
 	if *execute {
-		fmt.Printf("Register state:\n")
-		regs.Print()
-		fmt.Printf("\n")
+		run(bus, image.Entry)
+	} else {
+		for _, segment := range image.Segments {
+			if segment.Executable {
+				disassemble(bus, segment.Addr, segment.Addr+segment.Size)
+			}
+		}
 	}
+}
 
-	for {
-		n, err := file.Read(b)
-		if err == io.EOF {
-			break
-		} else if err != nil {
-			fmt.Printf("%s\n", err)
-			os.Exit(1)
-		} else if n != len(b) {
-			fmt.Printf("not enough bytes read\n")
-			os.Exit(1)
-		}
+/* Load an ELF executable, or a raw binary at the start of flash */
+func load(mem core.Memory, data []byte) (*core.Image, error) {
+	if bytes.HasPrefix(data, []byte(elf.ELFMAG)) {
+		return core.LoadELF(bytes.NewReader(data), mem)
+	}
 
-		var fetched core.FetchedInstr
+	if err := core.LoadBytes(mem, core.FLASH_BASE, data); err != nil {
+		return nil, err
+	}
 
-		fetched16 := core.FetchedInstr16((uint16(b[1]) << 8) | uint16(b[0]))
+	segment := core.Segment{Addr: core.FLASH_BASE, Size: uint32(len(data)), Executable: true}
 
-		if upper != nil {
-			fmt.Printf(" %v", fetched16)
-			fetched = upper.Extend(fetched16)
-			upper = nil
-		} else {
-			fetched = fetched16
-			fmt.Printf("%x:\t%v", addr, fetched)
+	/* Raw binaries start at the beginning of flash, in Thumb state */
+	return &core.Image{Entry: core.FLASH_BASE | 0x1, Segments: []core.Segment{segment}}, nil
+}
+
+func print_instr(addr uint32, fetched core.FetchedInstr, instr core.DecodedInstr, err error) {
+	if sym, ok := symbols.Lookup(addr); ok && sym.Addr == addr {
+		fmt.Printf("<%s>:\n", sym.Name)
+	}
+
+	if fetched32, ok := fetched.(core.FetchedInstr32); ok {
+		fmt.Printf("%x:\t%v %v", addr, core.FetchedInstr16(fetched32>>16),
+			core.FetchedInstr16(fetched32&0xffff))
+	} else {
+		fmt.Printf("%x:\t%v", addr, fetched)
+	}
+
+	if err != nil {
+		fmt.Printf("\t%s\n", err)
+	} else {
+		fmt.Printf("\t%s\t%#v\n", core.Disassemble(instr, addr), instr)
+	}
+}
+
+/* Decode every instruction in [start, end), in order */
+func disassemble(mem core.Memory, start uint32, end uint32) {
+	addr := start
+	var itstate core.ITState
+
+	for addr < end {
+		fetched, err := core.Fetch(mem, addr)
+		if err != nil {
+			fmt.Printf("%x:\t%s\n", addr, err)
+			return
 		}
 
-		addr += len(b)
+		/* Follow IT blocks, to show the condition of each instruction */
+		instr, err := fetched.DecodeFor(profile)
+		if err == nil {
+			instr = itstate.Conditional(instr)
+			if it, ok := instr.(core.ItT1); ok {
+				itstate = it.State()
+			} else {
+				itstate.Advance()
+			}
+		} else {
+			itstate = 0
+		}
+		print_instr(addr, fetched, instr, err)
 
-		instr, err := fetched.Decode()
-		if err == core.ErrIncompleteInstruction {
-			upper = &fetched16
-			continue
-		} else if err != nil {
-			fmt.Printf("\t%s\n", err)
-			continue
+		if _, ok := fetched.(core.FetchedInstr32); ok {
+			addr += 4
+		} else {
+			addr += 2
 		}
+	}
+}
 
-		fmt.Printf("\t%s\t%#v\n", instr, instr)
+/* Print the core registers, and the FP registers of CPUs with an FPU */
+func print_regs(cpu *core.CPU) {
+	fmt.Printf("Register state:\n")
+	cpu.Print()
+	if profile.FPU {
+		fmt.Print(cpu.FPPretty())
+	}
+	fmt.Printf("\n")
+}
+
+/* Execute from entry (or the reset vector), following the program counter */
+func run(mem core.Memory, entry uint32) {
+	cpu := core.NewCPU(mem)
+	cpu.Profile = profile
 
-		if *execute {
-			instr.Execute(regs)
-			fmt.Printf("Register state:\n")
-			regs.Print()
-			fmt.Printf("\n")
+	if *reset {
+		if err := cpu.Reset(); err != nil {
+			fmt.Printf("reset: %s\n", err)
+			return
 		}
+
+		/* Firmware booted from its vector table handles its own faults */
+		cpu.Fault = func(fault error) bool {
+			fmt.Printf("%x:\t%s\n", cpu.Pc(), fault)
+			return true
+		}
+	} else {
+		cpu.Epsr.T = (entry & 0x1) != 0
+		cpu.SetR(core.PC, entry&^0x1)
+	}
+	cpu.Trace = func(addr uint32, fetched core.FetchedInstr, instr core.DecodedInstr) {
+		print_instr(addr, fetched, instr, nil)
 	}
 
-	file.Close()
+	print_regs(cpu)
+
+	for {
+		if err := cpu.Step(); err != nil {
+			fmt.Printf("%x:\t%s\n", cpu.Pc(), err)
+			return
+		}
+
+		print_regs(cpu)
+	}
 }